@@ -0,0 +1,60 @@
+// This file contains the bounds of the values of a bundle, read from the embedded api.yml.
+package bundle
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// bounds of the values a bundle may hold, the same the API accepts. database.sql checks the same ones,
+// which TestBoundsMatchDatabaseSchema keeps in line
+type bounds struct {
+	estateSide  int
+	treeHeight  int
+	labelLength int // of the species & the labels, in characters
+}
+
+// specBounds read the bounds once from the spec, so they can't drift from the API
+var specBounds = sync.OnceValues(func() (bounds, error) {
+	spec, err := generated.GetSwagger()
+	if err != nil {
+		return bounds{}, fmt.Errorf("failed to load openapi spec: %w", err)
+	}
+
+	var b bounds
+	for _, bound := range []struct {
+		schema, property string
+		value            *int
+		maxLength        bool
+	}{
+		{"CreateEstateRequest", "width", &b.estateSide, false},
+		{"AddTreeRequest", "height", &b.treeHeight, false},
+		{"AddTreeRequest", "species", &b.labelLength, true},
+	} {
+		if *bound.value, err = schemaMax(spec, bound.schema, bound.property, bound.maxLength); err != nil {
+			return bounds{}, err
+		}
+	}
+
+	return b, nil
+})
+
+// schemaMax return the maximum, or the maxLength, of a property of a schema of the spec
+func schemaMax(spec *openapi3.T, schema, property string, maxLength bool) (int, error) {
+	if spec.Components == nil || spec.Components.Schemas[schema] == nil || spec.Components.Schemas[schema].Value == nil {
+		return 0, fmt.Errorf("openapi spec has no %s schema", schema)
+	}
+	prop := spec.Components.Schemas[schema].Value.Properties[property]
+	switch {
+	case prop == nil || prop.Value == nil:
+		return 0, fmt.Errorf("openapi spec has no %s.%s property", schema, property)
+	case maxLength && prop.Value.MaxLength != nil:
+		return int(*prop.Value.MaxLength), nil
+	case !maxLength && prop.Value.Max != nil:
+		return int(*prop.Value.Max), nil
+	}
+	return 0, fmt.Errorf("openapi spec has no bound for %s.%s", schema, property)
+}
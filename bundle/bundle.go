@@ -0,0 +1,353 @@
+// This file contains the estate bundle archive, moving an estate & its history between environments.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+)
+
+const (
+	Format = "estate-bundle"
+	// SchemaVersion is bumped by every change of the files of the archive, a bundle of another version is rejected
+	SchemaVersion = 1
+	ContentType   = "application/gzip"
+)
+
+const (
+	manifestFile       = "manifest.json"
+	estateFile         = "estate.json"
+	treesFile          = "trees.json"
+	statsFile          = "stats.json"
+	exclusionZonesFile = "exclusion_zones.json"
+	obstaclesFile      = "obstacles.json"
+	harvestsFile       = "harvests.json"
+	auditEntriesFile   = "audit_entries.json"
+)
+
+// dataFiles is the order of the files following the manifest in the archive
+var dataFiles = []string{estateFile, treesFile, statsFile, exclusionZonesFile, obstaclesFile, harvestsFile, auditEntriesFile}
+
+// Manifest is the first file of the archive, describing the files following it
+type Manifest struct {
+	Format        string    `json:"format"`
+	SchemaVersion int       `json:"schema_version"`
+	EstateId      uuid.UUID `json:"estate_id"`
+	ExportedAt    time.Time `json:"exported_at"`
+	Files         []File    `json:"files"`
+}
+
+type File struct {
+	Name    string `json:"name"`
+	Sha256  string `json:"sha256"` // hex encoded
+	Size    int64  `json:"size"`
+	Records int    `json:"records"` // 0 or 1 for the files holding a single object
+}
+
+// Write the bundle as a tar.gz archive: the manifest, then a JSON file per table
+func Write(w io.Writer, b *repository.EstateBundle, exportedAt time.Time) error {
+	exportedAt = exportedAt.UTC()
+	files, err := encodeFiles(b)
+	if err != nil {
+		return err
+	}
+
+	manifest := Manifest{
+		Format:        Format,
+		SchemaVersion: SchemaVersion,
+		EstateId:      b.Estate.Id,
+		ExportedAt:    exportedAt,
+		Files:         make([]File, 0, len(dataFiles)),
+	}
+	for _, name := range dataFiles {
+		sum := sha256.Sum256(files[name].data)
+		manifest.Files = append(manifest.Files, File{
+			Name:    name,
+			Sha256:  hex.EncodeToString(sum[:]),
+			Size:    int64(len(files[name].data)),
+			Records: files[name].records,
+		})
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", manifestFile, err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeFile(tw, manifestFile, manifestData, exportedAt); err != nil {
+		return err
+	}
+	for _, name := range dataFiles {
+		if err := writeFile(tw, name, files[name].data, exportedAt); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to close bundle archive: %w", err)
+	}
+	return gz.Close()
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+	})
+	if err == nil {
+		_, err = tw.Write(data)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// Read an archive written by Write, checking every file against the checksum of the manifest.
+// maxSize bounds the decompressed archive. The errors are AppError: 413 for a larger archive,
+// 422 for another schema version and 400 for an invalid or inconsistent bundle
+func Read(r io.Reader, maxSize int64) (*repository.EstateBundle, *Manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, readError(fmt.Errorf("not a gzip archive: %w", err), maxSize)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(&limitedReader{r: gz, n: maxSize})
+
+	header, err := tr.Next()
+	if err != nil {
+		return nil, nil, readError(fmt.Errorf("not a tar archive: %w", err), maxSize)
+	}
+	if header.Name != manifestFile {
+		return nil, nil, invalid("the first file of the bundle must be %s, got %s", manifestFile, header.Name)
+	}
+	manifestData, err := io.ReadAll(tr)
+	if err != nil {
+		return nil, nil, readError(fmt.Errorf("failed to read %s: %w", manifestFile, err), maxSize)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, nil, invalid("failed to decode %s: %s", manifestFile, err)
+	}
+	if manifest.Format != Format {
+		return nil, nil, invalid("not an estate bundle, format is %q", manifest.Format)
+	}
+	if manifest.SchemaVersion != SchemaVersion {
+		return nil, nil, apperror.WrapWithCode(
+			fmt.Errorf("bundle schema version %d is not supported, expected %d", manifest.SchemaVersion, SchemaVersion),
+			http.StatusUnprocessableEntity)
+	}
+
+	listed, err := listedFiles(manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contents := make(map[string][]byte, len(listed))
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, readError(fmt.Errorf("failed to read bundle archive: %w", err), maxSize)
+		}
+
+		file, ok := listed[header.Name]
+		if !ok {
+			return nil, nil, invalid("%s is not listed in the manifest", header.Name)
+		}
+		if _, ok := contents[header.Name]; ok {
+			return nil, nil, invalid("%s is in the bundle twice", header.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, readError(fmt.Errorf("failed to read %s: %w", header.Name, err), maxSize)
+		}
+		sum := sha256.Sum256(data)
+		if int64(len(data)) != file.Size || hex.EncodeToString(sum[:]) != file.Sha256 {
+			return nil, nil, invalid("checksum mismatch for %s", header.Name)
+		}
+		contents[header.Name] = data
+	}
+
+	for _, name := range dataFiles {
+		if _, ok := contents[name]; !ok {
+			return nil, nil, invalid("%s is missing from the bundle", name)
+		}
+	}
+
+	b, err := decodeFiles(contents, listed)
+	if err != nil {
+		return nil, nil, err
+	}
+	if b.Estate.Id != manifest.EstateId {
+		return nil, nil, invalid("%s is estate %s, the manifest is estate %s", estateFile, b.Estate.Id, manifest.EstateId)
+	}
+	if err := validate(b); err != nil {
+		return nil, nil, err
+	}
+
+	return b, &manifest, nil
+}
+
+// listedFiles index the files of the manifest by name, which must be the files of the schema version
+func listedFiles(manifest Manifest) (map[string]File, error) {
+	listed := make(map[string]File, len(manifest.Files))
+	for _, file := range manifest.Files {
+		if _, ok := listed[file.Name]; ok {
+			return nil, invalid("%s is listed twice in the manifest", file.Name)
+		}
+		listed[file.Name] = file
+	}
+
+	for _, name := range dataFiles {
+		if _, ok := listed[name]; !ok {
+			return nil, invalid("%s is missing from the manifest", name)
+		}
+	}
+	if len(listed) != len(dataFiles) {
+		return nil, invalid("the manifest lists %d files, expected %d", len(listed), len(dataFiles))
+	}
+
+	return listed, nil
+}
+
+// validate the values & the references between the files, the database would reject the bundle otherwise
+func validate(b *repository.EstateBundle) error {
+	bounds, err := specBounds()
+	if err != nil {
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+	maxEstateSide, maxTreeHeight, maxLabelLength := bounds.estateSide, bounds.treeHeight, bounds.labelLength
+
+	estate := b.Estate
+	if estate.Width < 1 || estate.Length < 1 || estate.Width > maxEstateSide || estate.Length > maxEstateSide {
+		return invalid("estate size %dx%d must be between 1 and %d", estate.Length, estate.Width, maxEstateSide)
+	}
+	if geo := estate.Geo; geo != nil {
+		if geo.OriginLat < -90 || geo.OriginLat > 90 || geo.OriginLon < -180 || geo.OriginLon > 180 || geo.Rotation < 0 || geo.Rotation >= 360 {
+			return invalid("estate origin (%g,%g) rotated %g is not a geo-reference", geo.OriginLat, geo.OriginLon, geo.Rotation)
+		}
+	}
+
+	trees := make(map[uuid.UUID]bool, len(estate.Trees))
+	plots := make(map[repository.Point]bool, len(estate.Trees))
+	for _, tree := range estate.Trees {
+		p := repository.Point{X: tree.X, Y: tree.Y}
+		if p.X < 1 || p.Y < 1 || p.X > estate.Length || p.Y > estate.Width {
+			return invalid("tree (%d,%d) is outside the %dx%d estate", p.X, p.Y, estate.Length, estate.Width)
+		}
+		if tree.Height < 1 || tree.Height > maxTreeHeight {
+			return invalid("tree (%d,%d) height %d must be between 1 and %d", p.X, p.Y, tree.Height, maxTreeHeight)
+		}
+		switch tree.Health {
+		case repository.TREE_HEALTHY, repository.TREE_DISEASED, repository.TREE_DEAD:
+		default:
+			return invalid("tree (%d,%d) health %q is unknown", p.X, p.Y, tree.Health)
+		}
+		if utf8.RuneCountInString(tree.Species) > maxLabelLength {
+			return invalid("tree (%d,%d) species is longer than %d characters", p.X, p.Y, maxLabelLength)
+		}
+		if plots[p] {
+			return invalid("plot (%d,%d) has more than one tree", p.X, p.Y)
+		}
+		if trees[tree.Id] {
+			return invalid("tree %s is in %s twice", tree.Id, treesFile)
+		}
+		plots[p], trees[tree.Id] = true, true
+	}
+
+	for _, zone := range estate.ExclusionZones {
+		if zone.Kind != repository.EXCLUSION_KIND_PLOTS && zone.Kind != repository.EXCLUSION_KIND_POLYGON {
+			return invalid("exclusion zone %s kind %q is unknown", zone.Id, zone.Kind)
+		}
+		if len(zone.Points) == 0 {
+			return invalid("exclusion zone %s has no points", zone.Id)
+		}
+		if utf8.RuneCountInString(zone.Label) > maxLabelLength {
+			return invalid("exclusion zone %s label is longer than %d characters", zone.Id, maxLabelLength)
+		}
+	}
+
+	obstacles := make(map[repository.Point]bool, len(estate.Obstacles))
+	for _, obstacle := range estate.Obstacles {
+		p := repository.Point{X: obstacle.X, Y: obstacle.Y}
+		if p.X < 1 || p.Y < 1 || p.X > estate.Length || p.Y > estate.Width {
+			return invalid("obstacle (%d,%d) is outside the %dx%d estate", p.X, p.Y, estate.Length, estate.Width)
+		}
+		if obstacle.Height != nil && *obstacle.Height < 1 {
+			return invalid("obstacle (%d,%d) height %d must be positive", p.X, p.Y, *obstacle.Height)
+		}
+		if utf8.RuneCountInString(obstacle.Label) > maxLabelLength {
+			return invalid("obstacle (%d,%d) label is longer than %d characters", p.X, p.Y, maxLabelLength)
+		}
+		if obstacles[p] {
+			return invalid("plot (%d,%d) has more than one obstacle", p.X, p.Y)
+		}
+		obstacles[p] = true
+	}
+
+	for _, harvest := range b.Harvests {
+		if harvest.WeightKg <= 0 {
+			return invalid("harvest %s weight %g kg must be positive", harvest.Id, harvest.WeightKg)
+		}
+		if harvest.BunchCount < 0 {
+			return invalid("harvest %s bunch count %d must not be negative", harvest.Id, harvest.BunchCount)
+		}
+	}
+
+	return nil
+}
+
+func invalid(format string, args ...any) error {
+	return apperror.WrapWithCode(fmt.Errorf("invalid bundle: "+format, args...), http.StatusBadRequest)
+}
+
+var errTooLarge = errors.New("bundle is too large")
+
+// readError is a 413 when the archive is larger than maxSize, a 400 otherwise
+func readError(err error, maxSize int64) error {
+	if errors.Is(err, errTooLarge) {
+		return apperror.WrapWithCode(fmt.Errorf("bundle is larger than %d bytes once decompressed", maxSize), http.StatusRequestEntityTooLarge)
+	}
+	return invalid("%s", err)
+}
+
+// limitedReader fail with errTooLarge past n bytes, where io.LimitReader would end the archive silently
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		// an archive of exactly n bytes is not too large
+		if n, _ := l.r.Read(make([]byte, 1)); n > 0 {
+			return 0, errTooLarge
+		}
+		return 0, io.EOF
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
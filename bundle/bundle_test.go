@@ -0,0 +1,370 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBundle() *repository.EstateBundle {
+	estateID, treeID := uuid.New(), uuid.New()
+	createdAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	plantedAt := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	return &repository.EstateBundle{
+		Estate: repository.Estate{
+			Id:        estateID,
+			Width:     10,
+			Length:    20,
+			Version:   7,
+			Geo:       &repository.GeoReference{OriginLat: 1.5, OriginLon: 103.2, Rotation: 15},
+			CreatedAt: createdAt,
+			UpdatedAt: &updatedAt,
+			Trees: []repository.Tree{{
+				Id: treeID, EstateId: estateID, X: 3, Y: 4, Height: 12, Species: "tenera", PlantedAt: &plantedAt,
+				Health: repository.TREE_DISEASED, Tags: []string{"north"}, CreatedAt: createdAt,
+			}},
+			Stats: &repository.EstateStats{
+				Id: uuid.New(), EstateID: estateID.String(), TreeCount: 1, MaxHeight: 12, MinHeight: 12, MedianHeight: 12,
+				DroneDistance: 26, DroneProfile: "plot=10", DroneDuration: 3, DroneEnergy: 0.2, CreatedAt: createdAt,
+			},
+			ExclusionZones: []repository.ExclusionZone{{
+				Id: uuid.New(), EstateId: estateID, Label: "river", Kind: repository.EXCLUSION_KIND_PLOTS,
+				Points: []repository.Point{{X: 1, Y: 1}}, CreatedAt: createdAt,
+			}},
+			Obstacles: []repository.Obstacle{{Id: uuid.New(), EstateId: estateID, X: 5, Y: 5, Label: "tower", CreatedAt: createdAt}},
+		},
+		Harvests: []repository.Harvest{{
+			Id: uuid.New(), EstateId: estateID, TreeId: &treeID, Plots: []repository.Point{{X: 3, Y: 4}},
+			HarvestedAt: plantedAt, WeightKg: 21.5, BunchCount: 2, CreatedAt: createdAt,
+		}},
+		AuditEntries: []repository.AuditEntry{
+			{
+				Id: uuid.New(), EstateId: estateID, Action: repository.AUDIT_ESTATE_CREATED, EntityId: estateID, Actor: "alice",
+				RequestId: "req-1", SourceIp: "10.0.0.7", After: json.RawMessage(`{"width":10,"length":20}`), CreatedAt: createdAt,
+			},
+			{
+				Id: uuid.New(), EstateId: estateID, Action: repository.AUDIT_TREE_CREATED, EntityId: treeID, Actor: repository.ACTOR_SYSTEM,
+				After: json.RawMessage(`{"x":3,"y":4}`), CreatedAt: createdAt,
+			},
+		},
+	}
+}
+
+// archive rewrite the files of a bundle written by Write, edit may change the file contents
+func archive(t *testing.T, b *repository.EstateBundle, edit func(name string, data []byte) []byte) []byte {
+	var written bytes.Buffer
+	assert.NoError(t, Write(&written, b, time.Now()))
+
+	gz, err := gzip.NewReader(&written)
+	assert.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	var out bytes.Buffer
+	gzOut := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gzOut)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		assert.NoError(t, err)
+		if data = edit(header.Name, data); data == nil {
+			continue
+		}
+		header.Size = int64(len(data))
+		assert.NoError(t, tw.WriteHeader(header))
+		_, err = tw.Write(data)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gzOut.Close())
+	return out.Bytes()
+}
+
+func TestWriteRead(t *testing.T) {
+	t.Run("Round Trip", func(t *testing.T) {
+		b := testBundle()
+		exportedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		var buf bytes.Buffer
+		assert.NoError(t, Write(&buf, b, exportedAt))
+
+		read, manifest, err := Read(&buf, 1<<20)
+		assert.NoError(t, err)
+		assert.Equal(t, b, read)
+		assert.Equal(t, Format, manifest.Format)
+		assert.Equal(t, SchemaVersion, manifest.SchemaVersion)
+		assert.Equal(t, b.Estate.Id, manifest.EstateId)
+		assert.Equal(t, exportedAt, manifest.ExportedAt)
+		assert.Len(t, manifest.Files, len(dataFiles))
+		assert.Equal(t, File{Name: treesFile, Sha256: manifest.Files[1].Sha256, Size: manifest.Files[1].Size, Records: 1}, manifest.Files[1])
+	})
+
+	t.Run("Harvest Of A Removed Tree", func(t *testing.T) {
+		b := testBundle()
+		removedID := uuid.New()
+		b.Harvests[0].TreeId = &removedID
+		var buf bytes.Buffer
+		assert.NoError(t, Write(&buf, b, time.Now()))
+
+		read, _, err := Read(&buf, 1<<20)
+		assert.NoError(t, err)
+		assert.Equal(t, b, read)
+	})
+
+	t.Run("Empty Estate", func(t *testing.T) {
+		b := &repository.EstateBundle{Estate: repository.Estate{Id: uuid.New(), Width: 1, Length: 1, Version: 1, CreatedAt: time.Now().UTC()}}
+		var buf bytes.Buffer
+		assert.NoError(t, Write(&buf, b, time.Now()))
+
+		read, _, err := Read(&buf, 1<<20)
+		assert.NoError(t, err)
+		assert.Equal(t, b, read)
+	})
+}
+
+func TestRead(t *testing.T) {
+	setManifest := func(edit func(*Manifest)) func(string, []byte) []byte {
+		return func(name string, data []byte) []byte {
+			if name != manifestFile {
+				return data
+			}
+			var manifest Manifest
+			_ = json.Unmarshal(data, &manifest)
+			edit(&manifest)
+			data, _ = json.Marshal(manifest)
+			return data
+		}
+	}
+
+	tests := []struct {
+		name          string
+		bundle        func() *repository.EstateBundle
+		edit          func(name string, data []byte) []byte
+		raw           []byte
+		maxSize       int64
+		expectedCode  int
+		expectedError string
+	}{
+		{
+			name:          "Not Gzip",
+			raw:           []byte("this is not an estate bundle"),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bundle: not a gzip archive: gzip: invalid header",
+		},
+		{
+			name: "Tampered File",
+			edit: func(name string, data []byte) []byte {
+				if name == treesFile {
+					return bytes.Replace(data, []byte(`"height":12`), []byte(`"height":13`), 1)
+				}
+				return data
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bundle: checksum mismatch for trees.json",
+		},
+		{
+			name:          "Unsupported Schema Version",
+			edit:          setManifest(func(m *Manifest) { m.SchemaVersion = 2 }),
+			expectedCode:  http.StatusUnprocessableEntity,
+			expectedError: "bundle schema version 2 is not supported, expected 1",
+		},
+		{
+			name:          "Not An Estate Bundle",
+			edit:          setManifest(func(m *Manifest) { m.Format = "other" }),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: `invalid bundle: not an estate bundle, format is "other"`,
+		},
+		{
+			name: "Manifest Not First",
+			edit: func(name string, data []byte) []byte {
+				if name == manifestFile {
+					return nil
+				}
+				return data
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bundle: the first file of the bundle must be manifest.json, got estate.json",
+		},
+		{
+			name: "Missing File",
+			edit: func(name string, data []byte) []byte {
+				if name == harvestsFile {
+					return nil
+				}
+				return data
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bundle: harvests.json is missing from the bundle",
+		},
+		{
+			name:          "File Missing From Manifest",
+			edit:          setManifest(func(m *Manifest) { m.Files = m.Files[1:] }),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bundle: estate.json is missing from the manifest",
+		},
+		{
+			name:          "Record Count Mismatch",
+			edit:          setManifest(func(m *Manifest) { m.Files[1].Records = 2 }),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bundle: trees.json holds 1 records, the manifest 2",
+		},
+		{
+			name:          "Manifest Of Another Estate",
+			edit:          setManifest(func(m *Manifest) { m.EstateId = uuid.Nil }),
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "is estate",
+		},
+		{
+			name: "Tree Outside The Estate",
+			bundle: func() *repository.EstateBundle {
+				b := testBundle()
+				b.Estate.Trees[0].X = 21
+				return b
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bundle: tree (21,4) is outside the 20x10 estate",
+		},
+		{
+			name: "Estate Too Large",
+			bundle: func() *repository.EstateBundle {
+				b := testBundle()
+				b.Estate.Length = 50001
+				return b
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bundle: estate size 50001x10 must be between 1 and 50000",
+		},
+		{
+			name: "Tree Too High",
+			bundle: func() *repository.EstateBundle {
+				b := testBundle()
+				b.Estate.Trees[0].Height = 31
+				return b
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bundle: tree (3,4) height 31 must be between 1 and 30",
+		},
+		{
+			name: "Unknown Tree Health",
+			bundle: func() *repository.EstateBundle {
+				b := testBundle()
+				b.Estate.Trees[0].Health = "sick"
+				return b
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: `invalid bundle: tree (3,4) health "sick" is unknown`,
+		},
+		{
+			name: "Unknown Exclusion Zone Kind",
+			bundle: func() *repository.EstateBundle {
+				b := testBundle()
+				b.Estate.ExclusionZones[0].Kind = "circle"
+				return b
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: `kind "circle" is unknown`,
+		},
+		{
+			name: "Obstacle Without Height",
+			bundle: func() *repository.EstateBundle {
+				b := testBundle()
+				b.Estate.Obstacles[0].Height = ptr.ToPointer(0)
+				return b
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "invalid bundle: obstacle (5,5) height 0 must be positive",
+		},
+		{
+			name: "Harvest Without Weight",
+			bundle: func() *repository.EstateBundle {
+				b := testBundle()
+				b.Harvests[0].WeightKg = 0
+				return b
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "weight 0 kg must be positive",
+		},
+		{
+			name: "Negative Bunch Count",
+			bundle: func() *repository.EstateBundle {
+				b := testBundle()
+				b.Harvests[0].BunchCount = -1
+				return b
+			},
+			expectedCode:  http.StatusBadRequest,
+			expectedError: "bunch count -1 must not be negative",
+		},
+		{
+			name:          "Too Large",
+			maxSize:       1024,
+			expectedCode:  http.StatusRequestEntityTooLarge,
+			expectedError: "bundle is larger than 1024 bytes once decompressed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := testBundle()
+			if tc.bundle != nil {
+				b = tc.bundle()
+			}
+			edit := tc.edit
+			if edit == nil {
+				edit = func(_ string, data []byte) []byte { return data }
+			}
+			raw := tc.raw
+			if raw == nil {
+				raw = archive(t, b, edit)
+			}
+			maxSize := tc.maxSize
+			if maxSize == 0 {
+				maxSize = 1 << 20
+			}
+
+			read, manifest, err := Read(bytes.NewReader(raw), maxSize)
+
+			assert.Nil(t, read)
+			assert.Nil(t, manifest)
+			var appErr *apperror.AppError
+			if assert.ErrorAs(t, err, &appErr) {
+				assert.Equal(t, tc.expectedCode, appErr.Code)
+			}
+			assert.ErrorContains(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestBoundsMatchDatabaseSchema(t *testing.T) {
+	bounds, err := specBounds()
+	assert.NoError(t, err)
+
+	schema, err := os.ReadFile("../database.sql")
+	assert.NoError(t, err)
+	for _, check := range []string{
+		fmt.Sprintf("width <= %d", bounds.estateSide),
+		fmt.Sprintf("length <= %d", bounds.estateSide),
+		fmt.Sprintf("height <= %d", bounds.treeHeight),
+		fmt.Sprintf("species VARCHAR(%d)", bounds.labelLength),
+		fmt.Sprintf("label VARCHAR(%d)", bounds.labelLength),
+	} {
+		assert.Contains(t, string(schema), check)
+	}
+}
@@ -0,0 +1,401 @@
+// This file contains the JSON records of the bundle files, decoupled from the repository models
+// so a change of the database does not silently change the bundle schema.
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/google/uuid"
+)
+
+// the records of the estate children have no estate ID, they belong to the estate of estate.json
+
+type estateRecord struct {
+	Id        uuid.UUID                `json:"id"`
+	Width     int                      `json:"width"`
+	Length    int                      `json:"length"`
+	Version   int64                    `json:"version"`
+	Geo       *repository.GeoReference `json:"geo,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+	UpdatedAt *time.Time               `json:"updated_at,omitempty"`
+}
+
+type treeRecord struct {
+	Id        uuid.UUID  `json:"id"`
+	X         int        `json:"x"`
+	Y         int        `json:"y"`
+	Height    int        `json:"height"`
+	Species   string     `json:"species"`
+	PlantedAt *time.Time `json:"planted_at,omitempty"`
+	Health    string     `json:"health"`
+	Tags      []string   `json:"tags"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type statsRecord struct {
+	Id            uuid.UUID  `json:"id"`
+	TreeCount     int64      `json:"tree_count"`
+	MaxHeight     int        `json:"max_height"`
+	MinHeight     int        `json:"min_height"`
+	MedianHeight  int        `json:"median_height"`
+	DroneDistance int64      `json:"drone_distance"`
+	DroneProfile  string     `json:"drone_profile"`
+	DroneDuration int64      `json:"drone_duration"`
+	DroneEnergy   float64    `json:"drone_energy"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+type exclusionZoneRecord struct {
+	Id        uuid.UUID          `json:"id"`
+	Label     string             `json:"label"`
+	Kind      string             `json:"kind"`
+	Points    []repository.Point `json:"points"`
+	CreatedAt time.Time          `json:"created_at"`
+}
+
+type obstacleRecord struct {
+	Id        uuid.UUID `json:"id"`
+	X         int       `json:"x"`
+	Y         int       `json:"y"`
+	Height    *int      `json:"height,omitempty"`
+	Label     string    `json:"label"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type harvestRecord struct {
+	Id          uuid.UUID          `json:"id"`
+	TreeId      *uuid.UUID         `json:"tree_id,omitempty"`
+	Plots       []repository.Point `json:"plots"`
+	HarvestedAt time.Time          `json:"harvested_at"`
+	WeightKg    float64            `json:"weight_kg"`
+	BunchCount  int                `json:"bunch_count"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+type auditEntryRecord struct {
+	Id        uuid.UUID       `json:"id"`
+	Action    string          `json:"action"`
+	EntityId  uuid.UUID       `json:"entity_id"`
+	Actor     string          `json:"actor"`
+	RequestId string          `json:"request_id,omitempty"`
+	SourceIp  string          `json:"source_ip,omitempty"`
+	Before    json.RawMessage `json:"before,omitempty"`
+	After     json.RawMessage `json:"after,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type encodedFile struct {
+	data    []byte
+	records int
+}
+
+// encodeFiles marshal every file of the bundle, by file name
+func encodeFiles(b *repository.EstateBundle) (map[string]encodedFile, error) {
+	estate := b.Estate
+	statsRecords := 0
+	if estate.Stats != nil {
+		statsRecords = 1
+	}
+	values := map[string]struct {
+		value   any
+		records int
+	}{
+		estateFile:         {toEstateRecord(estate), 1},
+		treesFile:          {mapRecords(estate.Trees, toTreeRecord), len(estate.Trees)},
+		statsFile:          {toStatsRecord(estate.Stats), statsRecords},
+		exclusionZonesFile: {mapRecords(estate.ExclusionZones, toExclusionZoneRecord), len(estate.ExclusionZones)},
+		obstaclesFile:      {mapRecords(estate.Obstacles, toObstacleRecord), len(estate.Obstacles)},
+		harvestsFile:       {mapRecords(b.Harvests, toHarvestRecord), len(b.Harvests)},
+		auditEntriesFile:   {mapRecords(b.AuditEntries, toAuditEntryRecord), len(b.AuditEntries)},
+	}
+
+	files := make(map[string]encodedFile, len(values))
+	for name, v := range values {
+		data, err := json.Marshal(v.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		files[name] = encodedFile{data: data, records: v.records}
+	}
+	return files, nil
+}
+
+// decodeFiles unmarshal the files read from the archive, whose record counts must match the manifest
+func decodeFiles(contents map[string][]byte, listed map[string]File) (*repository.EstateBundle, error) {
+	var (
+		estate    estateRecord
+		trees     []treeRecord
+		stats     *statsRecord
+		zones     []exclusionZoneRecord
+		obstacles []obstacleRecord
+		harvests  []harvestRecord
+		entries   []auditEntryRecord
+	)
+	targets := map[string]any{
+		estateFile:         &estate,
+		treesFile:          &trees,
+		statsFile:          &stats,
+		exclusionZonesFile: &zones,
+		obstaclesFile:      &obstacles,
+		harvestsFile:       &harvests,
+		auditEntriesFile:   &entries,
+	}
+	for _, name := range dataFiles {
+		if err := json.Unmarshal(contents[name], targets[name]); err != nil {
+			return nil, invalid("failed to decode %s: %s", name, err)
+		}
+	}
+
+	statsCount := 0
+	if stats != nil {
+		statsCount = 1
+	}
+	counts := map[string]int{
+		estateFile:         1,
+		treesFile:          len(trees),
+		statsFile:          statsCount,
+		exclusionZonesFile: len(zones),
+		obstaclesFile:      len(obstacles),
+		harvestsFile:       len(harvests),
+		auditEntriesFile:   len(entries),
+	}
+	for _, name := range dataFiles {
+		if counts[name] != listed[name].Records {
+			return nil, invalid("%s holds %d records, the manifest %d", name, counts[name], listed[name].Records)
+		}
+	}
+
+	b := &repository.EstateBundle{Estate: estate.toModel()}
+	id := b.Estate.Id
+	for _, tree := range trees {
+		b.Estate.Trees = append(b.Estate.Trees, tree.toModel(id))
+	}
+	if stats != nil {
+		b.Estate.Stats = stats.toModel(id)
+	}
+	for _, zone := range zones {
+		b.Estate.ExclusionZones = append(b.Estate.ExclusionZones, zone.toModel(id))
+	}
+	for _, obstacle := range obstacles {
+		b.Estate.Obstacles = append(b.Estate.Obstacles, obstacle.toModel(id))
+	}
+	for _, harvest := range harvests {
+		b.Harvests = append(b.Harvests, harvest.toModel(id))
+	}
+	for _, entry := range entries {
+		b.AuditEntries = append(b.AuditEntries, entry.toModel(id))
+	}
+	return b, nil
+}
+
+// mapRecords is never nil, so an empty table is written as [] rather than null
+func mapRecords[M, R any](models []M, toRecord func(M) R) []R {
+	records := make([]R, 0, len(models))
+	for _, m := range models {
+		records = append(records, toRecord(m))
+	}
+	return records
+}
+
+func toEstateRecord(estate repository.Estate) estateRecord {
+	return estateRecord{
+		Id:        estate.Id,
+		Width:     estate.Width,
+		Length:    estate.Length,
+		Version:   estate.Version,
+		Geo:       estate.Geo,
+		CreatedAt: estate.CreatedAt,
+		UpdatedAt: estate.UpdatedAt,
+	}
+}
+
+func (r estateRecord) toModel() repository.Estate {
+	return repository.Estate{
+		Id:        r.Id,
+		Width:     r.Width,
+		Length:    r.Length,
+		Version:   r.Version,
+		Geo:       r.Geo,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func toTreeRecord(tree repository.Tree) treeRecord {
+	return treeRecord{
+		Id:        tree.Id,
+		X:         tree.X,
+		Y:         tree.Y,
+		Height:    tree.Height,
+		Species:   tree.Species,
+		PlantedAt: tree.PlantedAt,
+		Health:    string(tree.Health),
+		Tags:      tree.Tags,
+		CreatedAt: tree.CreatedAt,
+		UpdatedAt: tree.UpdatedAt,
+	}
+}
+
+func (r treeRecord) toModel(estateID uuid.UUID) repository.Tree {
+	return repository.Tree{
+		Id:        r.Id,
+		EstateId:  estateID,
+		X:         r.X,
+		Y:         r.Y,
+		Height:    r.Height,
+		Species:   r.Species,
+		PlantedAt: r.PlantedAt,
+		Health:    repository.TreeHealth(r.Health),
+		Tags:      r.Tags,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// toStatsRecord is nil for an estate never calculated
+func toStatsRecord(stats *repository.EstateStats) *statsRecord {
+	if stats == nil {
+		return nil
+	}
+	return &statsRecord{
+		Id:            stats.Id,
+		TreeCount:     stats.TreeCount,
+		MaxHeight:     stats.MaxHeight,
+		MinHeight:     stats.MinHeight,
+		MedianHeight:  stats.MedianHeight,
+		DroneDistance: stats.DroneDistance,
+		DroneProfile:  stats.DroneProfile,
+		DroneDuration: stats.DroneDuration,
+		DroneEnergy:   stats.DroneEnergy,
+		CreatedAt:     stats.CreatedAt,
+		UpdatedAt:     stats.UpdatedAt,
+	}
+}
+
+func (r statsRecord) toModel(estateID uuid.UUID) *repository.EstateStats {
+	return &repository.EstateStats{
+		Id:            r.Id,
+		EstateID:      estateID.String(),
+		TreeCount:     r.TreeCount,
+		MaxHeight:     r.MaxHeight,
+		MinHeight:     r.MinHeight,
+		MedianHeight:  r.MedianHeight,
+		DroneDistance: r.DroneDistance,
+		DroneProfile:  r.DroneProfile,
+		DroneDuration: r.DroneDuration,
+		DroneEnergy:   r.DroneEnergy,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+}
+
+func toExclusionZoneRecord(zone repository.ExclusionZone) exclusionZoneRecord {
+	return exclusionZoneRecord{
+		Id:        zone.Id,
+		Label:     zone.Label,
+		Kind:      string(zone.Kind),
+		Points:    zone.Points,
+		CreatedAt: zone.CreatedAt,
+	}
+}
+
+func (r exclusionZoneRecord) toModel(estateID uuid.UUID) repository.ExclusionZone {
+	return repository.ExclusionZone{
+		Id:        r.Id,
+		EstateId:  estateID,
+		Label:     r.Label,
+		Kind:      repository.ExclusionKind(r.Kind),
+		Points:    r.Points,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func toObstacleRecord(obstacle repository.Obstacle) obstacleRecord {
+	return obstacleRecord{
+		Id:        obstacle.Id,
+		X:         obstacle.X,
+		Y:         obstacle.Y,
+		Height:    obstacle.Height,
+		Label:     obstacle.Label,
+		CreatedAt: obstacle.CreatedAt,
+	}
+}
+
+func (r obstacleRecord) toModel(estateID uuid.UUID) repository.Obstacle {
+	return repository.Obstacle{
+		Id:        r.Id,
+		EstateId:  estateID,
+		X:         r.X,
+		Y:         r.Y,
+		Height:    r.Height,
+		Label:     r.Label,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func toHarvestRecord(harvest repository.Harvest) harvestRecord {
+	return harvestRecord{
+		Id:          harvest.Id,
+		TreeId:      harvest.TreeId,
+		Plots:       harvest.Plots,
+		HarvestedAt: harvest.HarvestedAt,
+		WeightKg:    harvest.WeightKg,
+		BunchCount:  harvest.BunchCount,
+		CreatedAt:   harvest.CreatedAt,
+	}
+}
+
+func (r harvestRecord) toModel(estateID uuid.UUID) repository.Harvest {
+	return repository.Harvest{
+		Id:          r.Id,
+		EstateId:    estateID,
+		TreeId:      r.TreeId,
+		Plots:       r.Plots,
+		HarvestedAt: r.HarvestedAt,
+		WeightKg:    r.WeightKg,
+		BunchCount:  r.BunchCount,
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+func toAuditEntryRecord(entry repository.AuditEntry) auditEntryRecord {
+	return auditEntryRecord{
+		Id:        entry.Id,
+		Action:    string(entry.Action),
+		EntityId:  entry.EntityId,
+		Actor:     entry.Actor,
+		RequestId: entry.RequestId,
+		SourceIp:  entry.SourceIp,
+		Before:    entry.Before,
+		After:     entry.After,
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+func (r auditEntryRecord) toModel(estateID uuid.UUID) repository.AuditEntry {
+	return repository.AuditEntry{
+		Id:        r.Id,
+		EstateId:  estateID,
+		Action:    repository.AuditAction(r.Action),
+		EntityId:  r.EntityId,
+		Actor:     r.Actor,
+		RequestId: r.RequestId,
+		SourceIp:  r.SourceIp,
+		Before:    nullableJSON(r.Before),
+		After:     nullableJSON(r.After),
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// nullableJSON is nil for a JSON null, stored as NULL like the entries the repository writes
+func nullableJSON(data json.RawMessage) json.RawMessage {
+	if string(data) == "null" {
+		return nil
+	}
+	return data
+}
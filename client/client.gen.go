@@ -0,0 +1,5908 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// AddTreeRequest Exactly one of x & y or lat & lon must be provided, estate documents only accept x & y
+type AddTreeRequest struct {
+	// Health Health status of the tree, `healthy` when omitted
+	Health *string `json:"health,omitempty" validate:"omitempty,oneof=healthy diseased dead"`
+
+	// Height Height of the tree in meters
+	Height int `json:"height" validate:"required,min=1,max=30"`
+
+	// Lat WGS84 latitude of the tree, snapped to the nearest plot of a geo-referenced estate
+	Lat *float64 `json:"lat,omitempty" validate:"omitempty,min=-90,max=90"`
+
+	// Lon WGS84 longitude of the tree, snapped to the nearest plot of a geo-referenced estate
+	Lon *float64 `json:"lon,omitempty" validate:"omitempty,min=-180,max=180"`
+
+	// PlantedAt Date the tree was planted, not in the future
+	PlantedAt *openapi_types.Date `json:"planted_at,omitempty"`
+
+	// Species Oil palm variety, e.g. Tenera or Dura
+	Species *string `json:"species,omitempty" validate:"omitempty,max=100"`
+
+	// Tags Free-form labels, e.g. a block or a treatment
+	Tags *[]string `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+
+	// X X coordinate of the tree (West-East axis)
+	X int `json:"x,omitempty" validate:"omitempty,min=1,max=50000"`
+
+	// Y Y coordinate of the tree (South-North axis)
+	Y int `json:"y,omitempty" validate:"omitempty,min=1,max=50000"`
+}
+
+// AddTreeResponse defines model for AddTreeResponse.
+type AddTreeResponse struct {
+	// Id UUID of the added tree
+	Id *openapi_types.UUID `json:"id,omitempty"`
+}
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// Action The change, e.g. tree.created or estate.resized
+	Action string `json:"action"`
+
+	// Actor The X-Actor header of the request, job:<id> for the background jobs, system otherwise
+	Actor string `json:"actor"`
+
+	// After The entity after the change, absent for a deletion
+	After *map[string]interface{} `json:"after,omitempty"`
+
+	// Before The entity before the change, absent for a creation
+	Before    *map[string]interface{} `json:"before,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+
+	// EntityId UUID of the changed entity, the estate itself or its tree, exclusion zone, obstacle, harvest or stats
+	EntityId openapi_types.UUID `json:"entity_id"`
+	EstateId openapi_types.UUID `json:"estate_id"`
+
+	// Id UUID of the entry
+	Id openapi_types.UUID `json:"id"`
+
+	// RequestId X-Request-ID of the request that made the change
+	RequestId *string `json:"request_id,omitempty"`
+
+	// SourceIp IP address the request came from
+	SourceIp *string `json:"source_ip,omitempty"`
+}
+
+// AuditEntryList defines model for AuditEntryList.
+type AuditEntryList struct {
+	Data []AuditEntry `json:"data"`
+}
+
+// CreateEstateRequest defines model for CreateEstateRequest.
+type CreateEstateRequest struct {
+	// Geo Place of the plot grid on the earth
+	Geo *GeoReference `json:"geo,omitempty"`
+
+	// Length Length of the estate in 10-meter plots
+	Length int `json:"length" validate:"required,min=1,max=50000"`
+
+	// Width Width of the estate in 10-meter plots
+	Width int `json:"width" validate:"required,min=1,max=50000"`
+}
+
+// CreateEstateResponse defines model for CreateEstateResponse.
+type CreateEstateResponse struct {
+	// Id UUID of the created estate
+	Id *openapi_types.UUID `json:"id,omitempty"`
+}
+
+// CreateExclusionZoneRequest Exactly one of plots or polygon must be provided
+type CreateExclusionZoneRequest struct {
+	// Label What the zone is, e.g. river or road
+	Label *string `json:"label,omitempty" validate:"omitempty,max=100"`
+
+	// Plots Excluded plots
+	Plots *[]PlotPoint `json:"plots,omitempty" validate:"omitempty,min=1,max=1000,dive"`
+
+	// Polygon Vertices of the excluded area, in order
+	Polygon *[]PlotPoint `json:"polygon,omitempty" validate:"omitempty,min=3,max=1000,dive"`
+}
+
+// CreateExclusionZoneResponse defines model for CreateExclusionZoneResponse.
+type CreateExclusionZoneResponse struct {
+	// Id UUID of the exclusion zone
+	Id *openapi_types.UUID `json:"id,omitempty"`
+}
+
+// CreateHarvestRequest Exactly one of tree_id or plots must be provided
+type CreateHarvestRequest struct {
+	// BunchCount Number of fresh fruit bunches
+	BunchCount int `json:"bunch_count" validate:"min=0,max=100000"`
+
+	// HarvestedAt Day of the harvest, not in the future
+	HarvestedAt openapi_types.Date `json:"harvested_at"`
+
+	// Plots The harvested region
+	Plots *[]PlotPoint `json:"plots,omitempty" validate:"omitempty,min=1,max=2500,dive"`
+
+	// TreeId The harvested tree
+	TreeId *openapi_types.UUID `json:"tree_id,omitempty"`
+
+	// WeightKg Weight of the fresh fruit bunches in kilograms
+	WeightKg float64 `json:"weight_kg" validate:"required,gt=0,max=1000000"`
+}
+
+// CreateHarvestResponse defines model for CreateHarvestResponse.
+type CreateHarvestResponse struct {
+	// Id UUID of the harvest
+	Id *openapi_types.UUID `json:"id,omitempty"`
+}
+
+// CreateJobRequest defines model for CreateJobRequest.
+type CreateJobRequest struct {
+	// DronePlan Query parameters of GET /estate/{id}/drone-plan, drone_plan jobs only
+	DronePlan *map[string]interface{} `json:"drone_plan,omitempty"`
+
+	// Estate An estate & its trees, the input of import jobs and the result of export jobs
+	Estate *EstateDocument `json:"estate,omitempty"`
+
+	// EstateId Estate of the job, required for every type but import
+	EstateId *openapi_types.UUID `json:"estate_id,omitempty"`
+
+	// Type `drone_plan` calculate the drone plan, `recompute_stats` recalculate the estate stats,
+	// `export` return the estate & its trees as an EstateDocument and `import` create a new estate from one,
+	// `rebuild_tiles` aggregate the map tiles of the estate again
+	Type string `json:"type" validate:"required,oneof=drone_plan recompute_stats import export rebuild_tiles"`
+}
+
+// CreateObstacleRequest Exactly one of height or no_fly must be provided
+type CreateObstacleRequest struct {
+	// Height Height of the structure in meters
+	Height *int `json:"height,omitempty" validate:"omitempty,min=1,max=500"`
+
+	// Label What the obstacle is, e.g. tower or power line
+	Label *string `json:"label,omitempty" validate:"omitempty,max=100"`
+
+	// NoFly The drone must not fly over the plot
+	NoFly *bool `json:"no_fly,omitempty"`
+	X     int   `json:"x" validate:"required,min=1,max=50000"`
+	Y     int   `json:"y" validate:"required,min=1,max=50000"`
+}
+
+// CreateObstacleResponse defines model for CreateObstacleResponse.
+type CreateObstacleResponse struct {
+	// Id UUID of the obstacle
+	Id *openapi_types.UUID `json:"id,omitempty"`
+}
+
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	// Secret Secret used to sign the events, generated when empty
+	Secret *string `json:"secret,omitempty" validate:"omitempty,min=16"`
+
+	// Url URL that receives the events with HTTP POST
+	Url string `json:"url" validate:"required,url"`
+}
+
+// CreateWebhookResponse defines model for CreateWebhookResponse.
+type CreateWebhookResponse struct {
+	// Id UUID of the webhook subscriber
+	Id *openapi_types.UUID `json:"id,omitempty"`
+
+	// Secret Secret used to compute the X-Webhook-Signature header (HMAC SHA-256)
+	Secret *string `json:"secret,omitempty"`
+
+	// Url URL that receives the events
+	Url *string `json:"url,omitempty"`
+}
+
+// DroneCoverage The part of the estate the drone can't monitor because of the no-fly plots
+type DroneCoverage struct {
+	FullyCovered bool `json:"fully_covered"`
+
+	// UncoveredPlots No-fly plots and the plots they wall in
+	UncoveredPlots int64 `json:"uncovered_plots"`
+
+	// UncoveredTrees Trees planted on the uncovered plots
+	UncoveredTrees []Tree `json:"uncovered_trees"`
+}
+
+// DronePlanResponse defines model for DronePlanResponse.
+type DronePlanResponse struct {
+	// Coverage The part of the estate the drone can't monitor because of the no-fly plots
+	Coverage *DroneCoverage `json:"coverage,omitempty"`
+
+	// Distance Total distance the drone will travel in meters
+	Distance *int64 `json:"distance,omitempty"`
+
+	// Duration Estimated flight time in seconds
+	Duration *int64 `json:"duration,omitempty"`
+
+	// Energy Estimated energy drawn in watt-hours
+	Energy *float64 `json:"energy,omitempty"`
+
+	// Pattern Coverage pattern of the plan, rows, columns or spiral
+	Pattern *string `json:"pattern,omitempty"`
+
+	// Rest Plot of the drone path, the landing point when max_distance, max_energy or max_duration is provided
+	Rest *DronePoint `json:"rest,omitempty"`
+
+	// Waypoints Plots where the path turns, from take-off to the last plot, only with waypoints=true.
+	// The drone flies straight between two waypoints, climbing over what is in the way
+	Waypoints *[]DronePoint `json:"waypoints,omitempty"`
+}
+
+// DronePoint Plot of the drone path, the landing point when max_distance, max_energy or max_duration is provided
+type DronePoint struct {
+	// Lat WGS84 latitude of the center of the plot, only when the estate is geo-referenced
+	Lat *float64 `json:"lat,omitempty"`
+
+	// Lon WGS84 longitude of the center of the plot, only when the estate is geo-referenced
+	Lon *float64 `json:"lon,omitempty"`
+
+	// X X coordinate of the plot
+	X *int `json:"x,omitempty"`
+
+	// Y Y coordinate of the plot
+	Y *int `json:"y,omitempty"`
+}
+
+// Estate defines model for Estate.
+type Estate struct {
+	// Geo Place of the plot grid on the earth
+	Geo *GeoReference      `json:"geo,omitempty"`
+	Id  openapi_types.UUID `json:"id"`
+
+	// Length Length of the estate in 10-meter plots (West-East axis)
+	Length int    `json:"length"`
+	Trees  []Tree `json:"trees"`
+
+	// Width Width of the estate in 10-meter plots (South-North axis)
+	Width int `json:"width"`
+}
+
+// EstateDocument An estate & its trees, the input of import jobs and the result of export jobs
+type EstateDocument struct {
+	Length int              `json:"length" validate:"required,min=1,max=50000"`
+	Trees  []AddTreeRequest `json:"trees" validate:"dive"`
+	Width  int              `json:"width" validate:"required,min=1,max=50000"`
+}
+
+// EstateEvent defines model for EstateEvent.
+type EstateEvent struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Data Event payload, depends on the event type
+	Data *map[string]interface{} `json:"data,omitempty"`
+
+	// EstateId UUID of the estate
+	EstateId *openapi_types.UUID `json:"estate_id,omitempty"`
+
+	// Id UUID of the event
+	Id *openapi_types.UUID `json:"id,omitempty"`
+
+	// Type Type of the event, e.g. tree.added or estate.stats_changed
+	Type *string `json:"type,omitempty"`
+}
+
+// EstateResizeConflict defines model for EstateResizeConflict.
+type EstateResizeConflict struct {
+	Message string `json:"message"`
+
+	// Trees Trees that would be outside the new boundary
+	Trees []Tree `json:"trees"`
+}
+
+// EstateStats defines model for EstateStats.
+type EstateStats struct {
+	// Count Total number of trees in the estate
+	Count *int64 `json:"count,omitempty"`
+
+	// Density Trees per plantable plot, from 0 to 1
+	Density *float64 `json:"density,omitempty"`
+
+	// Groups Statistics per species or per health status, only with group_by
+	Groups *[]TreeGroupStats `json:"groups,omitempty"`
+
+	// Max Maximum height of the trees
+	Max *int `json:"max,omitempty"`
+
+	// Median Median height of the trees
+	Median *int `json:"median,omitempty"`
+
+	// Min Minimum height of the trees
+	Min *int `json:"min,omitempty"`
+
+	// PlantablePlots Number of plots outside the exclusion zones
+	PlantablePlots *int64 `json:"plantable_plots,omitempty"`
+}
+
+// ExclusionZone defines model for ExclusionZone.
+type ExclusionZone struct {
+	Id      openapi_types.UUID `json:"id"`
+	Label   string             `json:"label"`
+	Plots   *[]PlotPoint       `json:"plots,omitempty"`
+	Polygon *[]PlotPoint       `json:"polygon,omitempty"`
+}
+
+// ExclusionZoneConflict defines model for ExclusionZoneConflict.
+type ExclusionZoneConflict struct {
+	Message string `json:"message"`
+
+	// Trees Trees planted inside the zone
+	Trees []Tree `json:"trees"`
+}
+
+// ExclusionZoneList defines model for ExclusionZoneList.
+type ExclusionZoneList struct {
+	Data []ExclusionZone `json:"data"`
+
+	// ExcludedPlots Number of estate plots covered by at least one zone
+	ExcludedPlots int64 `json:"excluded_plots"`
+}
+
+// GeoReference Place of the plot grid on the earth
+type GeoReference struct {
+	// OriginLat WGS84 latitude of the South-West corner of the plot (1,1)
+	OriginLat float64 `json:"origin_lat" validate:"min=-90,max=90"`
+
+	// OriginLon WGS84 longitude of the South-West corner of the plot (1,1)
+	OriginLon float64 `json:"origin_lon" validate:"min=-180,max=180"`
+
+	// Rotation Bearing of the South-North axis in degrees clockwise from true North, 0 when omitted
+	Rotation *float64 `json:"rotation,omitempty" validate:"omitempty,min=0,lt=360"`
+}
+
+// Harvest defines model for Harvest.
+type Harvest struct {
+	BunchCount  int                `json:"bunch_count"`
+	HarvestedAt openapi_types.Date `json:"harvested_at"`
+	Id          openapi_types.UUID `json:"id"`
+
+	// Plots The harvested plots, the plot of the tree for a tree harvest
+	Plots []PlotPoint `json:"plots"`
+
+	// TreeId The harvested tree, absent for a region harvest or once the tree is removed
+	TreeId   *openapi_types.UUID `json:"tree_id,omitempty"`
+	WeightKg float64             `json:"weight_kg"`
+}
+
+// HarvestList defines model for HarvestList.
+type HarvestList struct {
+	Data []Harvest `json:"data"`
+}
+
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	// Checks Result of every check, "ok" or the error message
+	Checks *map[string]string `json:"checks,omitempty"`
+
+	// Status ok or unavailable
+	Status string `json:"status"`
+}
+
+// Job defines model for Job.
+type Job struct {
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// EstateId Estate of the job, the estate created by an import job
+	EstateId   openapi_types.UUID `json:"estate_id"`
+	FinishedAt *time.Time         `json:"finished_at,omitempty"`
+	Id         openapi_types.UUID `json:"id"`
+
+	// LastError Error of the last failed attempt
+	LastError   *string `json:"last_error,omitempty"`
+	MaxAttempts int     `json:"max_attempts"`
+
+	// Progress Done fraction of the job, from 0 to 1
+	Progress float64 `json:"progress"`
+
+	// Result Result of a succeeded job, a DronePlanResponse, an EstateStats, an EstateDocument for export
+	// or the estate_id & imported trees for import
+	Result    *map[string]interface{} `json:"result,omitempty"`
+	StartedAt *time.Time              `json:"started_at,omitempty"`
+
+	// Status queued, running, succeeded, failed or cancelled
+	Status string `json:"status"`
+
+	// Type drone_plan, recompute_stats, import, export or rebuild_tiles
+	Type string `json:"type"`
+}
+
+// Obstacle defines model for Obstacle.
+type Obstacle struct {
+	// Height Height of the structure in meters, absent on no-fly plots
+	Height *int               `json:"height,omitempty"`
+	Id     openapi_types.UUID `json:"id"`
+	Label  string             `json:"label"`
+	NoFly  bool               `json:"no_fly"`
+	X      int                `json:"x"`
+	Y      int                `json:"y"`
+}
+
+// ObstacleList defines model for ObstacleList.
+type ObstacleList struct {
+	Data []Obstacle `json:"data"`
+}
+
+// PlantingPlanRequest defines model for PlantingPlanRequest.
+type PlantingPlanRequest struct {
+	// Count Number of new trees
+	Count int `json:"count" validate:"required,min=1,max=1000"`
+
+	// DroneImpact Report the drone distance change of every suggestion, up to 100 trees
+	DroneImpact *bool `json:"drone_impact,omitempty"`
+
+	// Height Height of the new trees in meters, 1 when omitted
+	Height *int `json:"height,omitempty" validate:"omitempty,min=1,max=30"`
+
+	// MinDistance Minimum distance in plots between a new tree and any other tree, 1 when omitted
+	MinDistance *int `json:"min_distance,omitempty" validate:"omitempty,min=1,max=50"`
+
+	// Pattern Layout of the new trees, fill when omitted. fill take the free plots row by row,
+	// square a grid of min_distance plots and triangular staggered rows, denser than square
+	Pattern *string `json:"pattern,omitempty" validate:"omitempty,oneof=fill square triangular"`
+
+	// Species Oil palm variety of the new trees
+	Species *string `json:"species,omitempty" validate:"omitempty,max=100"`
+}
+
+// PlantingPlanResponse defines model for PlantingPlanResponse.
+type PlantingPlanResponse struct {
+	// Applied The suggested trees were planted
+	Applied bool `json:"applied"`
+
+	// DroneDistance Current drone distance in meters, only with drone_impact
+	DroneDistance *int64 `json:"drone_distance,omitempty"`
+	MinDistance   int    `json:"min_distance"`
+	Pattern       string `json:"pattern"`
+
+	// PlannedDroneDistance Drone distance in meters once every suggestion is planted, only with drone_impact
+	PlannedDroneDistance *int64 `json:"planned_drone_distance,omitempty"`
+
+	// Requested Number of trees requested, more than the suggestions when the estate is full
+	Requested int `json:"requested"`
+
+	// Suggestions Suggested plots, row by row from the South-West corner
+	Suggestions []PlantingSuggestion `json:"suggestions"`
+}
+
+// PlantingSuggestion defines model for PlantingSuggestion.
+type PlantingSuggestion struct {
+	// DroneDistanceDelta Change of the drone distance in meters if only this tree is planted, only with drone_impact
+	DroneDistanceDelta *int64 `json:"drone_distance_delta,omitempty"`
+
+	// TreeId The planted tree, only when applied
+	TreeId *openapi_types.UUID `json:"tree_id,omitempty"`
+	X      int                 `json:"x"`
+	Y      int                 `json:"y"`
+}
+
+// PlotPoint defines model for PlotPoint.
+type PlotPoint struct {
+	X int `json:"x" validate:"required,min=1,max=50000"`
+	Y int `json:"y" validate:"required,min=1,max=50000"`
+}
+
+// ResizeEstateResponse defines model for ResizeEstateResponse.
+type ResizeEstateResponse struct {
+	Id     openapi_types.UUID `json:"id"`
+	Length int                `json:"length"`
+
+	// RemovedTrees Trees removed because they were outside the new boundary, only with force
+	RemovedTrees []Tree `json:"removed_trees"`
+	Width        int    `json:"width"`
+}
+
+// Tile defines model for Tile.
+type Tile struct {
+	// CellSize Plots a side of a cell
+	CellSize int `json:"cell_size"`
+
+	// Cells Cells holding trees, by row then column
+	Cells []TileCell `json:"cells"`
+
+	// MaxZoom Zoom where a cell is a single plot
+	MaxZoom int `json:"max_zoom"`
+
+	// TileSize Cells a side of a tile
+	TileSize int `json:"tile_size"`
+	X        int `json:"x"`
+	Y        int `json:"y"`
+	Z        int `json:"z"`
+}
+
+// TileCell defines model for TileCell.
+type TileCell struct {
+	AvgHeight float64 `json:"avg_height"`
+	MaxHeight int     `json:"max_height"`
+	TreeCount int     `json:"tree_count"`
+
+	// X Plot column of the South-West plot of the cell
+	X int `json:"x"`
+
+	// Y Plot row of the South-West plot of the cell
+	Y int `json:"y"`
+}
+
+// Tree defines model for Tree.
+type Tree struct {
+	// Health healthy, diseased or dead
+	Health string `json:"health"`
+
+	// Height Height of the tree in meters
+	Height int                `json:"height"`
+	Id     openapi_types.UUID `json:"id"`
+
+	// Lat WGS84 latitude of the center of the plot, only when the estate is geo-referenced
+	Lat *float64 `json:"lat,omitempty"`
+
+	// Lon WGS84 longitude of the center of the plot, only when the estate is geo-referenced
+	Lon *float64 `json:"lon,omitempty"`
+
+	// PlantedAt Date the tree was planted, omitted when unknown
+	PlantedAt *openapi_types.Date `json:"planted_at,omitempty"`
+
+	// Species Oil palm variety, omitted when unknown
+	Species *string  `json:"species,omitempty"`
+	Tags    []string `json:"tags"`
+	X       int      `json:"x"`
+	Y       int      `json:"y"`
+}
+
+// TreeGroupStats defines model for TreeGroupStats.
+type TreeGroupStats struct {
+	Count  int64 `json:"count"`
+	Max    int   `json:"max"`
+	Median int   `json:"median"`
+	Min    int   `json:"min"`
+
+	// Value The species or the health status, an empty species groups the trees without one
+	Value string `json:"value"`
+}
+
+// TreeList defines model for TreeList.
+type TreeList struct {
+	Data []Tree `json:"data"`
+}
+
+// UpdateEstateRequest At least one of width or length must be provided
+type UpdateEstateRequest struct {
+	// Length New length of the estate in 10-meter plots
+	Length *int `json:"length,omitempty" validate:"omitempty,min=1,max=50000"`
+
+	// Width New width of the estate in 10-meter plots
+	Width *int `json:"width,omitempty" validate:"omitempty,min=1,max=50000"`
+}
+
+// UpdateTreeRequest At least one field must be provided
+type UpdateTreeRequest struct {
+	// Health Health status of the tree
+	Health *string `json:"health,omitempty" validate:"omitempty,oneof=healthy diseased dead"`
+
+	// Species Oil palm variety, empty to clear it
+	Species *string `json:"species,omitempty" validate:"omitempty,max=100"`
+
+	// Tags Free-form labels replacing the tags of the tree, empty to clear them
+	Tags *[]string `json:"tags,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	// Attempts Number of delivery attempts
+	Attempts  *int       `json:"attempts,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// EstateId UUID of the estate the event belongs to
+	EstateId *openapi_types.UUID `json:"estate_id,omitempty"`
+
+	// EventId UUID of the event, sent as X-Webhook-Id header
+	EventId *openapi_types.UUID `json:"event_id,omitempty"`
+
+	// EventType Type of the event, e.g. tree.added or estate.stats_changed
+	EventType *string `json:"event_type,omitempty"`
+
+	// Id UUID of the delivery
+	Id *openapi_types.UUID `json:"id,omitempty"`
+
+	// LastError Error of the last delivery attempt
+	LastError *string `json:"last_error,omitempty"`
+
+	// SubscriberId UUID of the webhook subscriber
+	SubscriberId *openapi_types.UUID `json:"subscriber_id,omitempty"`
+	UpdatedAt    *time.Time          `json:"updated_at,omitempty"`
+
+	// Url URL of the webhook subscriber
+	Url *string `json:"url,omitempty"`
+}
+
+// WebhookDeliveryList defines model for WebhookDeliveryList.
+type WebhookDeliveryList struct {
+	Data *[]WebhookDelivery `json:"data,omitempty"`
+}
+
+// YieldBlock defines model for YieldBlock.
+type YieldBlock struct {
+	// Hectares Plantable area of the block
+	Hectares float64 `json:"hectares"`
+
+	// Length Plots of the block on the West-East axis, lower on the estate edge
+	Length             int     `json:"length"`
+	TreeCount          int64   `json:"tree_count"`
+	WeightKg           float64 `json:"weight_kg"`
+	WeightKgPerHectare float64 `json:"weight_kg_per_hectare"`
+
+	// Width Plots of the block on the South-North axis, lower on the estate edge
+	Width int `json:"width"`
+
+	// X X of the South-West plot of the block
+	X int `json:"x"`
+
+	// Y Y of the South-West plot of the block
+	Y int `json:"y"`
+}
+
+// YieldHeightBand defines model for YieldHeightBand.
+type YieldHeightBand struct {
+	// BunchCount Bunches of the band, fractional when a region harvest is shared
+	BunchCount float64 `json:"bunch_count"`
+	MaxHeight  int     `json:"max_height"`
+	MinHeight  int     `json:"min_height"`
+
+	// TreeCount Trees currently in the band, harvested or not
+	TreeCount       int64   `json:"tree_count"`
+	WeightKg        float64 `json:"weight_kg"`
+	WeightKgPerTree float64 `json:"weight_kg_per_tree"`
+}
+
+// YieldPeriod defines model for YieldPeriod.
+type YieldPeriod struct {
+	BunchCount int64 `json:"bunch_count"`
+	Harvests   int64 `json:"harvests"`
+
+	// Start First day of the period
+	Start              openapi_types.Date `json:"start"`
+	WeightKg           float64            `json:"weight_kg"`
+	WeightKgPerHectare float64            `json:"weight_kg_per_hectare"`
+}
+
+// YieldReport defines model for YieldReport.
+type YieldReport struct {
+	From *openapi_types.Date `json:"from,omitempty"`
+
+	// Hectares Plantable area of the estate
+	Hectares float64 `json:"hectares"`
+
+	// HeightBands Yield of the trees per height band, the bands without trees are omitted
+	HeightBands []YieldHeightBand `json:"height_bands"`
+
+	// LeastProductiveBlocks Blocks holding trees, the lowest weight per hectare first
+	LeastProductiveBlocks []YieldBlock `json:"least_productive_blocks"`
+
+	// Period day, week, month or year
+	Period string `json:"period"`
+
+	// Periods Totals of the periods with at least one harvest, oldest first
+	Periods []YieldPeriod       `json:"periods"`
+	To      *openapi_types.Date `json:"to,omitempty"`
+	Total   YieldTotals         `json:"total"`
+}
+
+// YieldTotals defines model for YieldTotals.
+type YieldTotals struct {
+	BunchCount int64 `json:"bunch_count"`
+
+	// Harvests Number of harvests
+	Harvests int64   `json:"harvests"`
+	WeightKg float64 `json:"weight_kg"`
+
+	// WeightKgPerHectare Weight over the plantable area of the estate
+	WeightKgPerHectare float64 `json:"weight_kg_per_hectare"`
+}
+
+// EstateId defines model for EstateId.
+type EstateId = openapi_types.UUID
+
+// MapDronePath Draw the path of the drone plan over the estate
+type MapDronePath = bool
+
+// MapExclusionMode What the drawn drone path does with the excluded plots. Default to the server DRONE_EXCLUSION_MODE
+type MapExclusionMode = string
+
+// MapMaxCells Most cells drawn along a side of the estate, larger estates are downsampled. Default to 200
+type MapMaxCells = int
+
+// MapMaxDistance Maximum distance the drone can travel before landing (in meters), the rest point is drawn
+type MapMaxDistance = int
+
+// MapPattern Coverage pattern of the drawn drone path, `rows` by default
+type MapPattern = string
+
+// PostEstateImportBundleParams defines parameters for PostEstateImportBundle.
+type PostEstateImportBundleParams struct {
+	EstateId *openapi_types.UUID `form:"estate_id,omitempty" json:"estate_id,omitempty"`
+}
+
+// PatchEstateIdParams defines parameters for PatchEstateId.
+type PatchEstateIdParams struct {
+	Force *bool `form:"force,omitempty" json:"force,omitempty"`
+}
+
+// GetEstateIdAuditParams defines parameters for GetEstateIdAudit.
+type GetEstateIdAuditParams struct {
+	Action *string    `form:"action,omitempty" json:"action,omitempty" validate:"omitempty,oneof=estate.created estate.resized estate.deleted estate.restored estate.geo_updated estate.stats_upserted estate.imported tree.created tree.updated tree.deleted exclusion_zone.created exclusion_zone.deleted obstacle.created obstacle.deleted harvest.created"`
+	Actor  *string    `form:"actor,omitempty" json:"actor,omitempty"`
+	From   *time.Time `form:"from,omitempty" json:"from,omitempty"`
+	To     *time.Time `form:"to,omitempty" json:"to,omitempty"`
+	Limit  *int       `form:"limit,omitempty" json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+	Offset *int       `form:"offset,omitempty" json:"offset,omitempty" validate:"omitempty,min=0"`
+}
+
+// GetEstateIdDronePlanParams defines parameters for GetEstateIdDronePlan.
+type GetEstateIdDronePlanParams struct {
+	MaxDistance       *int     `form:"max_distance,omitempty" json:"max_distance,omitempty"`
+	MaxEnergy         *float64 `form:"max_energy,omitempty" json:"max_energy,omitempty" validate:"omitempty,gt=0"`
+	MaxDuration       *int     `form:"max_duration,omitempty" json:"max_duration,omitempty" validate:"omitempty,min=1"`
+	ExclusionMode     *string  `form:"exclusion_mode,omitempty" json:"exclusion_mode,omitempty" validate:"omitempty,oneof=fly_over skip"`
+	Pattern           *string  `form:"pattern,omitempty" json:"pattern,omitempty" validate:"omitempty,oneof=rows columns spiral auto"`
+	PlotSize          *int     `form:"plot_size,omitempty" json:"plot_size,omitempty" validate:"omitempty,min=1,max=100"`
+	Clearance         *int     `form:"clearance,omitempty" json:"clearance,omitempty" validate:"omitempty,min=0,max=50"`
+	TakeoffAltitude   *int     `form:"takeoff_altitude,omitempty" json:"takeoff_altitude,omitempty" validate:"omitempty,min=1,max=500"`
+	CruiseMinAltitude *int     `form:"cruise_min_altitude,omitempty" json:"cruise_min_altitude,omitempty" validate:"omitempty,min=1,max=500"`
+	AscentCost        *float64 `form:"ascent_cost,omitempty" json:"ascent_cost,omitempty" validate:"omitempty,gt=0,max=100"`
+	DescentCost       *float64 `form:"descent_cost,omitempty" json:"descent_cost,omitempty" validate:"omitempty,gt=0,max=100"`
+	Waypoints         *bool    `form:"waypoints,omitempty" json:"waypoints,omitempty"`
+}
+
+// GetEstateIdHarvestsParams defines parameters for GetEstateIdHarvests.
+type GetEstateIdHarvestsParams struct {
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+	To   *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetEstateIdMapPngParams defines parameters for GetEstateIdMapPng.
+type GetEstateIdMapPngParams struct {
+	MaxCells      *MapMaxCells      `form:"max_cells,omitempty" json:"max_cells,omitempty" validate:"omitempty,min=10,max=1000"`
+	DronePath     *MapDronePath     `form:"drone_path,omitempty" json:"drone_path,omitempty"`
+	Pattern       *MapPattern       `form:"pattern,omitempty" json:"pattern,omitempty" validate:"omitempty,oneof=rows columns spiral auto"`
+	ExclusionMode *MapExclusionMode `form:"exclusion_mode,omitempty" json:"exclusion_mode,omitempty" validate:"omitempty,oneof=fly_over skip"`
+	MaxDistance   *MapMaxDistance   `form:"max_distance,omitempty" json:"max_distance,omitempty" validate:"omitempty,min=1"`
+}
+
+// GetEstateIdMapSvgParams defines parameters for GetEstateIdMapSvg.
+type GetEstateIdMapSvgParams struct {
+	MaxCells      *MapMaxCells      `form:"max_cells,omitempty" json:"max_cells,omitempty" validate:"omitempty,min=10,max=1000"`
+	DronePath     *MapDronePath     `form:"drone_path,omitempty" json:"drone_path,omitempty"`
+	Pattern       *MapPattern       `form:"pattern,omitempty" json:"pattern,omitempty" validate:"omitempty,oneof=rows columns spiral auto"`
+	ExclusionMode *MapExclusionMode `form:"exclusion_mode,omitempty" json:"exclusion_mode,omitempty" validate:"omitempty,oneof=fly_over skip"`
+	MaxDistance   *MapMaxDistance   `form:"max_distance,omitempty" json:"max_distance,omitempty" validate:"omitempty,min=1"`
+}
+
+// PostEstateIdPlantingPlanParams defines parameters for PostEstateIdPlantingPlan.
+type PostEstateIdPlantingPlanParams struct {
+	Apply *bool `form:"apply,omitempty" json:"apply,omitempty"`
+}
+
+// GetEstateIdStatsParams defines parameters for GetEstateIdStats.
+type GetEstateIdStatsParams struct {
+	GroupBy *string `form:"group_by,omitempty" json:"group_by,omitempty" validate:"omitempty,oneof=species health"`
+}
+
+// GetEstateIdTreeParams defines parameters for GetEstateIdTree.
+type GetEstateIdTreeParams struct {
+	Species *string `form:"species,omitempty" json:"species,omitempty"`
+	Health  *string `form:"health,omitempty" json:"health,omitempty" validate:"omitempty,oneof=healthy diseased dead"`
+	Tag     *string `form:"tag,omitempty" json:"tag,omitempty"`
+}
+
+// GetEstateIdYieldParams defines parameters for GetEstateIdYield.
+type GetEstateIdYieldParams struct {
+	From      *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+	To        *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+	Period    *string             `form:"period,omitempty" json:"period,omitempty" validate:"omitempty,oneof=day week month year"`
+	Band      *int                `form:"band,omitempty" json:"band,omitempty" validate:"omitempty,min=1,max=30"`
+	BlockSize *int                `form:"block_size,omitempty" json:"block_size,omitempty" validate:"omitempty,min=1,max=100"`
+	Least     *int                `form:"least,omitempty" json:"least,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// GetWebhooksDeadLettersParams defines parameters for GetWebhooksDeadLetters.
+type GetWebhooksDeadLettersParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty" validate:"omitempty,min=0"`
+}
+
+// PostEstateJSONRequestBody defines body for PostEstate for application/json ContentType.
+type PostEstateJSONRequestBody = CreateEstateRequest
+
+// PatchEstateIdJSONRequestBody defines body for PatchEstateId for application/json ContentType.
+type PatchEstateIdJSONRequestBody = UpdateEstateRequest
+
+// PostEstateIdExclusionsJSONRequestBody defines body for PostEstateIdExclusions for application/json ContentType.
+type PostEstateIdExclusionsJSONRequestBody = CreateExclusionZoneRequest
+
+// PutEstateIdGeoJSONRequestBody defines body for PutEstateIdGeo for application/json ContentType.
+type PutEstateIdGeoJSONRequestBody = GeoReference
+
+// PostEstateIdHarvestsJSONRequestBody defines body for PostEstateIdHarvests for application/json ContentType.
+type PostEstateIdHarvestsJSONRequestBody = CreateHarvestRequest
+
+// PostEstateIdObstaclesJSONRequestBody defines body for PostEstateIdObstacles for application/json ContentType.
+type PostEstateIdObstaclesJSONRequestBody = CreateObstacleRequest
+
+// PostEstateIdPlantingPlanJSONRequestBody defines body for PostEstateIdPlantingPlan for application/json ContentType.
+type PostEstateIdPlantingPlanJSONRequestBody = PlantingPlanRequest
+
+// PostEstateIdTreeJSONRequestBody defines body for PostEstateIdTree for application/json ContentType.
+type PostEstateIdTreeJSONRequestBody = AddTreeRequest
+
+// PatchEstateIdTreeTreeIdJSONRequestBody defines body for PatchEstateIdTreeTreeId for application/json ContentType.
+type PatchEstateIdTreeTreeIdJSONRequestBody = UpdateTreeRequest
+
+// PostJobsJSONRequestBody defines body for PostJobs for application/json ContentType.
+type PostJobsJSONRequestBody = CreateJobRequest
+
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody = CreateWebhookRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// PostEstateWithBody request with any body
+	PostEstateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostEstate(ctx context.Context, body PostEstateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostEstateImportBundleWithBody request with any body
+	PostEstateImportBundleWithBody(ctx context.Context, params *PostEstateImportBundleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteEstateId request
+	DeleteEstateId(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateId request
+	GetEstateId(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchEstateIdWithBody request with any body
+	PatchEstateIdWithBody(ctx context.Context, id openapi_types.UUID, params *PatchEstateIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchEstateId(ctx context.Context, id openapi_types.UUID, params *PatchEstateIdParams, body PatchEstateIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdAudit request
+	GetEstateIdAudit(ctx context.Context, id EstateId, params *GetEstateIdAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdBundle request
+	GetEstateIdBundle(ctx context.Context, id EstateId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdDronePlan request
+	GetEstateIdDronePlan(ctx context.Context, id openapi_types.UUID, params *GetEstateIdDronePlanParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdEvents request
+	GetEstateIdEvents(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdExclusions request
+	GetEstateIdExclusions(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostEstateIdExclusionsWithBody request with any body
+	PostEstateIdExclusionsWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostEstateIdExclusions(ctx context.Context, id openapi_types.UUID, body PostEstateIdExclusionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteEstateIdExclusionsZoneId request
+	DeleteEstateIdExclusionsZoneId(ctx context.Context, id openapi_types.UUID, zoneId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutEstateIdGeoWithBody request with any body
+	PutEstateIdGeoWithBody(ctx context.Context, id EstateId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutEstateIdGeo(ctx context.Context, id EstateId, body PutEstateIdGeoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdHarvests request
+	GetEstateIdHarvests(ctx context.Context, id openapi_types.UUID, params *GetEstateIdHarvestsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostEstateIdHarvestsWithBody request with any body
+	PostEstateIdHarvestsWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostEstateIdHarvests(ctx context.Context, id openapi_types.UUID, body PostEstateIdHarvestsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdMapPng request
+	GetEstateIdMapPng(ctx context.Context, id EstateId, params *GetEstateIdMapPngParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdMapSvg request
+	GetEstateIdMapSvg(ctx context.Context, id EstateId, params *GetEstateIdMapSvgParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdObstacles request
+	GetEstateIdObstacles(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostEstateIdObstaclesWithBody request with any body
+	PostEstateIdObstaclesWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostEstateIdObstacles(ctx context.Context, id openapi_types.UUID, body PostEstateIdObstaclesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteEstateIdObstaclesObstacleId request
+	DeleteEstateIdObstaclesObstacleId(ctx context.Context, id openapi_types.UUID, obstacleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostEstateIdPlantingPlanWithBody request with any body
+	PostEstateIdPlantingPlanWithBody(ctx context.Context, id openapi_types.UUID, params *PostEstateIdPlantingPlanParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostEstateIdPlantingPlan(ctx context.Context, id openapi_types.UUID, params *PostEstateIdPlantingPlanParams, body PostEstateIdPlantingPlanJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostEstateIdRestore request
+	PostEstateIdRestore(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdStats request
+	GetEstateIdStats(ctx context.Context, id openapi_types.UUID, params *GetEstateIdStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdTilesZXTile request
+	GetEstateIdTilesZXTile(ctx context.Context, id EstateId, z int, x int, tile string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdTree request
+	GetEstateIdTree(ctx context.Context, id openapi_types.UUID, params *GetEstateIdTreeParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostEstateIdTreeWithBody request with any body
+	PostEstateIdTreeWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostEstateIdTree(ctx context.Context, id openapi_types.UUID, body PostEstateIdTreeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchEstateIdTreeTreeIdWithBody request with any body
+	PatchEstateIdTreeTreeIdWithBody(ctx context.Context, id EstateId, treeId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchEstateIdTreeTreeId(ctx context.Context, id EstateId, treeId openapi_types.UUID, body PatchEstateIdTreeTreeIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetEstateIdYield request
+	GetEstateIdYield(ctx context.Context, id openapi_types.UUID, params *GetEstateIdYieldParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealthz request
+	GetHealthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostJobsWithBody request with any body
+	PostJobsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostJobs(ctx context.Context, body PostJobsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetJobsId request
+	GetJobsId(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostJobsIdCancel request
+	PostJobsIdCancel(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReadyz request
+	GetReadyz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostWebhooksWithBody request with any body
+	PostWebhooksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostWebhooks(ctx context.Context, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWebhooksDeadLetters request
+	GetWebhooksDeadLetters(ctx context.Context, params *GetWebhooksDeadLettersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) PostEstateWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstate(ctx context.Context, body PostEstateJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateImportBundleWithBody(ctx context.Context, params *PostEstateImportBundleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateImportBundleRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteEstateId(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteEstateIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateId(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchEstateIdWithBody(ctx context.Context, id openapi_types.UUID, params *PatchEstateIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchEstateIdRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchEstateId(ctx context.Context, id openapi_types.UUID, params *PatchEstateIdParams, body PatchEstateIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchEstateIdRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdAudit(ctx context.Context, id EstateId, params *GetEstateIdAuditParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdAuditRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdBundle(ctx context.Context, id EstateId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdBundleRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdDronePlan(ctx context.Context, id openapi_types.UUID, params *GetEstateIdDronePlanParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdDronePlanRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdEvents(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdEventsRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdExclusions(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdExclusionsRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdExclusionsWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdExclusionsRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdExclusions(ctx context.Context, id openapi_types.UUID, body PostEstateIdExclusionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdExclusionsRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteEstateIdExclusionsZoneId(ctx context.Context, id openapi_types.UUID, zoneId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteEstateIdExclusionsZoneIdRequest(c.Server, id, zoneId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutEstateIdGeoWithBody(ctx context.Context, id EstateId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutEstateIdGeoRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutEstateIdGeo(ctx context.Context, id EstateId, body PutEstateIdGeoJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutEstateIdGeoRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdHarvests(ctx context.Context, id openapi_types.UUID, params *GetEstateIdHarvestsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdHarvestsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdHarvestsWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdHarvestsRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdHarvests(ctx context.Context, id openapi_types.UUID, body PostEstateIdHarvestsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdHarvestsRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdMapPng(ctx context.Context, id EstateId, params *GetEstateIdMapPngParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdMapPngRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdMapSvg(ctx context.Context, id EstateId, params *GetEstateIdMapSvgParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdMapSvgRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdObstacles(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdObstaclesRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdObstaclesWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdObstaclesRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdObstacles(ctx context.Context, id openapi_types.UUID, body PostEstateIdObstaclesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdObstaclesRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteEstateIdObstaclesObstacleId(ctx context.Context, id openapi_types.UUID, obstacleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteEstateIdObstaclesObstacleIdRequest(c.Server, id, obstacleId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdPlantingPlanWithBody(ctx context.Context, id openapi_types.UUID, params *PostEstateIdPlantingPlanParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdPlantingPlanRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdPlantingPlan(ctx context.Context, id openapi_types.UUID, params *PostEstateIdPlantingPlanParams, body PostEstateIdPlantingPlanJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdPlantingPlanRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdRestore(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdRestoreRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdStats(ctx context.Context, id openapi_types.UUID, params *GetEstateIdStatsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdStatsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdTilesZXTile(ctx context.Context, id EstateId, z int, x int, tile string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdTilesZXTileRequest(c.Server, id, z, x, tile)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdTree(ctx context.Context, id openapi_types.UUID, params *GetEstateIdTreeParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdTreeRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdTreeWithBody(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdTreeRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostEstateIdTree(ctx context.Context, id openapi_types.UUID, body PostEstateIdTreeJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostEstateIdTreeRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchEstateIdTreeTreeIdWithBody(ctx context.Context, id EstateId, treeId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchEstateIdTreeTreeIdRequestWithBody(c.Server, id, treeId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchEstateIdTreeTreeId(ctx context.Context, id EstateId, treeId openapi_types.UUID, body PatchEstateIdTreeTreeIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchEstateIdTreeTreeIdRequest(c.Server, id, treeId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetEstateIdYield(ctx context.Context, id openapi_types.UUID, params *GetEstateIdYieldParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetEstateIdYieldRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHealthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthzRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostJobsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostJobsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostJobs(ctx context.Context, body PostJobsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostJobsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetJobsId(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetJobsIdRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostJobsIdCancel(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostJobsIdCancelRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReadyz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReadyzRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostWebhooksWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostWebhooksRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostWebhooks(ctx context.Context, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostWebhooksRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWebhooksDeadLetters(ctx context.Context, params *GetWebhooksDeadLettersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWebhooksDeadLettersRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewPostEstateRequest calls the generic PostEstate builder with application/json body
+func NewPostEstateRequest(server string, body PostEstateJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostEstateRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostEstateRequestWithBody generates requests for PostEstate with any type of body
+func NewPostEstateRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostEstateImportBundleRequestWithBody generates requests for PostEstateImportBundle with any type of body
+func NewPostEstateImportBundleRequestWithBody(server string, params *PostEstateImportBundleParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/import-bundle")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.EstateId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "estate_id", runtime.ParamLocationQuery, *params.EstateId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteEstateIdRequest generates requests for DeleteEstateId
+func NewDeleteEstateIdRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEstateIdRequest generates requests for GetEstateId
+func NewGetEstateIdRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPatchEstateIdRequest calls the generic PatchEstateId builder with application/json body
+func NewPatchEstateIdRequest(server string, id openapi_types.UUID, params *PatchEstateIdParams, body PatchEstateIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchEstateIdRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPatchEstateIdRequestWithBody generates requests for PatchEstateId with any type of body
+func NewPatchEstateIdRequestWithBody(server string, id openapi_types.UUID, params *PatchEstateIdParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Force != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "force", runtime.ParamLocationQuery, *params.Force); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetEstateIdAuditRequest generates requests for GetEstateIdAudit
+func NewGetEstateIdAuditRequest(server string, id EstateId, params *GetEstateIdAuditParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/audit", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Action != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "action", runtime.ParamLocationQuery, *params.Action); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Actor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "actor", runtime.ParamLocationQuery, *params.Actor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEstateIdBundleRequest generates requests for GetEstateIdBundle
+func NewGetEstateIdBundleRequest(server string, id EstateId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/bundle", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEstateIdDronePlanRequest generates requests for GetEstateIdDronePlan
+func NewGetEstateIdDronePlanRequest(server string, id openapi_types.UUID, params *GetEstateIdDronePlanParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/drone-plan", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.MaxDistance != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_distance", runtime.ParamLocationQuery, *params.MaxDistance); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MaxEnergy != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_energy", runtime.ParamLocationQuery, *params.MaxEnergy); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MaxDuration != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_duration", runtime.ParamLocationQuery, *params.MaxDuration); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ExclusionMode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "exclusion_mode", runtime.ParamLocationQuery, *params.ExclusionMode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Pattern != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pattern", runtime.ParamLocationQuery, *params.Pattern); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PlotSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "plot_size", runtime.ParamLocationQuery, *params.PlotSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Clearance != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "clearance", runtime.ParamLocationQuery, *params.Clearance); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.TakeoffAltitude != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "takeoff_altitude", runtime.ParamLocationQuery, *params.TakeoffAltitude); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.CruiseMinAltitude != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cruise_min_altitude", runtime.ParamLocationQuery, *params.CruiseMinAltitude); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.AscentCost != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ascent_cost", runtime.ParamLocationQuery, *params.AscentCost); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.DescentCost != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "descent_cost", runtime.ParamLocationQuery, *params.DescentCost); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Waypoints != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "waypoints", runtime.ParamLocationQuery, *params.Waypoints); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEstateIdEventsRequest generates requests for GetEstateIdEvents
+func NewGetEstateIdEventsRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/events", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEstateIdExclusionsRequest generates requests for GetEstateIdExclusions
+func NewGetEstateIdExclusionsRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/exclusions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostEstateIdExclusionsRequest calls the generic PostEstateIdExclusions builder with application/json body
+func NewPostEstateIdExclusionsRequest(server string, id openapi_types.UUID, body PostEstateIdExclusionsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostEstateIdExclusionsRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostEstateIdExclusionsRequestWithBody generates requests for PostEstateIdExclusions with any type of body
+func NewPostEstateIdExclusionsRequestWithBody(server string, id openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/exclusions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteEstateIdExclusionsZoneIdRequest generates requests for DeleteEstateIdExclusionsZoneId
+func NewDeleteEstateIdExclusionsZoneIdRequest(server string, id openapi_types.UUID, zoneId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "zoneId", runtime.ParamLocationPath, zoneId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/exclusions/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPutEstateIdGeoRequest calls the generic PutEstateIdGeo builder with application/json body
+func NewPutEstateIdGeoRequest(server string, id EstateId, body PutEstateIdGeoJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutEstateIdGeoRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPutEstateIdGeoRequestWithBody generates requests for PutEstateIdGeo with any type of body
+func NewPutEstateIdGeoRequestWithBody(server string, id EstateId, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/geo", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetEstateIdHarvestsRequest generates requests for GetEstateIdHarvests
+func NewGetEstateIdHarvestsRequest(server string, id openapi_types.UUID, params *GetEstateIdHarvestsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/harvests", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostEstateIdHarvestsRequest calls the generic PostEstateIdHarvests builder with application/json body
+func NewPostEstateIdHarvestsRequest(server string, id openapi_types.UUID, body PostEstateIdHarvestsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostEstateIdHarvestsRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostEstateIdHarvestsRequestWithBody generates requests for PostEstateIdHarvests with any type of body
+func NewPostEstateIdHarvestsRequestWithBody(server string, id openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/harvests", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetEstateIdMapPngRequest generates requests for GetEstateIdMapPng
+func NewGetEstateIdMapPngRequest(server string, id EstateId, params *GetEstateIdMapPngParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/map.png", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.MaxCells != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_cells", runtime.ParamLocationQuery, *params.MaxCells); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.DronePath != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "drone_path", runtime.ParamLocationQuery, *params.DronePath); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Pattern != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pattern", runtime.ParamLocationQuery, *params.Pattern); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ExclusionMode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "exclusion_mode", runtime.ParamLocationQuery, *params.ExclusionMode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MaxDistance != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_distance", runtime.ParamLocationQuery, *params.MaxDistance); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEstateIdMapSvgRequest generates requests for GetEstateIdMapSvg
+func NewGetEstateIdMapSvgRequest(server string, id EstateId, params *GetEstateIdMapSvgParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/map.svg", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.MaxCells != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_cells", runtime.ParamLocationQuery, *params.MaxCells); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.DronePath != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "drone_path", runtime.ParamLocationQuery, *params.DronePath); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Pattern != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "pattern", runtime.ParamLocationQuery, *params.Pattern); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ExclusionMode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "exclusion_mode", runtime.ParamLocationQuery, *params.ExclusionMode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.MaxDistance != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "max_distance", runtime.ParamLocationQuery, *params.MaxDistance); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEstateIdObstaclesRequest generates requests for GetEstateIdObstacles
+func NewGetEstateIdObstaclesRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/obstacles", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostEstateIdObstaclesRequest calls the generic PostEstateIdObstacles builder with application/json body
+func NewPostEstateIdObstaclesRequest(server string, id openapi_types.UUID, body PostEstateIdObstaclesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostEstateIdObstaclesRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostEstateIdObstaclesRequestWithBody generates requests for PostEstateIdObstacles with any type of body
+func NewPostEstateIdObstaclesRequestWithBody(server string, id openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/obstacles", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteEstateIdObstaclesObstacleIdRequest generates requests for DeleteEstateIdObstaclesObstacleId
+func NewDeleteEstateIdObstaclesObstacleIdRequest(server string, id openapi_types.UUID, obstacleId openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "obstacleId", runtime.ParamLocationPath, obstacleId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/obstacles/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostEstateIdPlantingPlanRequest calls the generic PostEstateIdPlantingPlan builder with application/json body
+func NewPostEstateIdPlantingPlanRequest(server string, id openapi_types.UUID, params *PostEstateIdPlantingPlanParams, body PostEstateIdPlantingPlanJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostEstateIdPlantingPlanRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPostEstateIdPlantingPlanRequestWithBody generates requests for PostEstateIdPlantingPlan with any type of body
+func NewPostEstateIdPlantingPlanRequestWithBody(server string, id openapi_types.UUID, params *PostEstateIdPlantingPlanParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/planting-plan", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Apply != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "apply", runtime.ParamLocationQuery, *params.Apply); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostEstateIdRestoreRequest generates requests for PostEstateIdRestore
+func NewPostEstateIdRestoreRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/restore", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEstateIdStatsRequest generates requests for GetEstateIdStats
+func NewGetEstateIdStatsRequest(server string, id openapi_types.UUID, params *GetEstateIdStatsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/stats", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.GroupBy != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "group_by", runtime.ParamLocationQuery, *params.GroupBy); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEstateIdTilesZXTileRequest generates requests for GetEstateIdTilesZXTile
+func NewGetEstateIdTilesZXTileRequest(server string, id EstateId, z int, x int, tile string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "z", runtime.ParamLocationPath, z)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "x", runtime.ParamLocationPath, x)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam3 string
+
+	pathParam3, err = runtime.StyleParamWithLocation("simple", false, "tile", runtime.ParamLocationPath, tile)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/tiles/%s/%s/%s", pathParam0, pathParam1, pathParam2, pathParam3)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetEstateIdTreeRequest generates requests for GetEstateIdTree
+func NewGetEstateIdTreeRequest(server string, id openapi_types.UUID, params *GetEstateIdTreeParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/tree", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Species != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "species", runtime.ParamLocationQuery, *params.Species); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Health != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "health", runtime.ParamLocationQuery, *params.Health); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Tag != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "tag", runtime.ParamLocationQuery, *params.Tag); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostEstateIdTreeRequest calls the generic PostEstateIdTree builder with application/json body
+func NewPostEstateIdTreeRequest(server string, id openapi_types.UUID, body PostEstateIdTreeJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostEstateIdTreeRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewPostEstateIdTreeRequestWithBody generates requests for PostEstateIdTree with any type of body
+func NewPostEstateIdTreeRequestWithBody(server string, id openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/tree", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPatchEstateIdTreeTreeIdRequest calls the generic PatchEstateIdTreeTreeId builder with application/json body
+func NewPatchEstateIdTreeTreeIdRequest(server string, id EstateId, treeId openapi_types.UUID, body PatchEstateIdTreeTreeIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchEstateIdTreeTreeIdRequestWithBody(server, id, treeId, "application/json", bodyReader)
+}
+
+// NewPatchEstateIdTreeTreeIdRequestWithBody generates requests for PatchEstateIdTreeTreeId with any type of body
+func NewPatchEstateIdTreeTreeIdRequestWithBody(server string, id EstateId, treeId openapi_types.UUID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "treeId", runtime.ParamLocationPath, treeId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/tree/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetEstateIdYieldRequest generates requests for GetEstateIdYield
+func NewGetEstateIdYieldRequest(server string, id openapi_types.UUID, params *GetEstateIdYieldParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/estate/%s/yield", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Period != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "period", runtime.ParamLocationQuery, *params.Period); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Band != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "band", runtime.ParamLocationQuery, *params.Band); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.BlockSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "block_size", runtime.ParamLocationQuery, *params.BlockSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Least != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "least", runtime.ParamLocationQuery, *params.Least); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHealthzRequest generates requests for GetHealthz
+func NewGetHealthzRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostJobsRequest calls the generic PostJobs builder with application/json body
+func NewPostJobsRequest(server string, body PostJobsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostJobsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostJobsRequestWithBody generates requests for PostJobs with any type of body
+func NewPostJobsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/jobs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetJobsIdRequest generates requests for GetJobsId
+func NewGetJobsIdRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/jobs/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostJobsIdCancelRequest generates requests for PostJobsIdCancel
+func NewPostJobsIdCancelRequest(server string, id openapi_types.UUID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/jobs/%s/cancel", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetReadyzRequest generates requests for GetReadyz
+func NewGetReadyzRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostWebhooksRequest calls the generic PostWebhooks builder with application/json body
+func NewPostWebhooksRequest(server string, body PostWebhooksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostWebhooksRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostWebhooksRequestWithBody generates requests for PostWebhooks with any type of body
+func NewPostWebhooksRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetWebhooksDeadLettersRequest generates requests for GetWebhooksDeadLetters
+func NewGetWebhooksDeadLettersRequest(server string, params *GetWebhooksDeadLettersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/webhooks/dead-letters")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// PostEstateWithBodyWithResponse request with any body
+	PostEstateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateResponse, error)
+
+	PostEstateWithResponse(ctx context.Context, body PostEstateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateResponse, error)
+
+	// PostEstateImportBundleWithBodyWithResponse request with any body
+	PostEstateImportBundleWithBodyWithResponse(ctx context.Context, params *PostEstateImportBundleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateImportBundleResponse, error)
+
+	// DeleteEstateIdWithResponse request
+	DeleteEstateIdWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteEstateIdResponse, error)
+
+	// GetEstateIdWithResponse request
+	GetEstateIdWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetEstateIdResponse, error)
+
+	// PatchEstateIdWithBodyWithResponse request with any body
+	PatchEstateIdWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, params *PatchEstateIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchEstateIdResponse, error)
+
+	PatchEstateIdWithResponse(ctx context.Context, id openapi_types.UUID, params *PatchEstateIdParams, body PatchEstateIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEstateIdResponse, error)
+
+	// GetEstateIdAuditWithResponse request
+	GetEstateIdAuditWithResponse(ctx context.Context, id EstateId, params *GetEstateIdAuditParams, reqEditors ...RequestEditorFn) (*GetEstateIdAuditResponse, error)
+
+	// GetEstateIdBundleWithResponse request
+	GetEstateIdBundleWithResponse(ctx context.Context, id EstateId, reqEditors ...RequestEditorFn) (*GetEstateIdBundleResponse, error)
+
+	// GetEstateIdDronePlanWithResponse request
+	GetEstateIdDronePlanWithResponse(ctx context.Context, id openapi_types.UUID, params *GetEstateIdDronePlanParams, reqEditors ...RequestEditorFn) (*GetEstateIdDronePlanResponse, error)
+
+	// GetEstateIdEventsWithResponse request
+	GetEstateIdEventsWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetEstateIdEventsResponse, error)
+
+	// GetEstateIdExclusionsWithResponse request
+	GetEstateIdExclusionsWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetEstateIdExclusionsResponse, error)
+
+	// PostEstateIdExclusionsWithBodyWithResponse request with any body
+	PostEstateIdExclusionsWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateIdExclusionsResponse, error)
+
+	PostEstateIdExclusionsWithResponse(ctx context.Context, id openapi_types.UUID, body PostEstateIdExclusionsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateIdExclusionsResponse, error)
+
+	// DeleteEstateIdExclusionsZoneIdWithResponse request
+	DeleteEstateIdExclusionsZoneIdWithResponse(ctx context.Context, id openapi_types.UUID, zoneId openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteEstateIdExclusionsZoneIdResponse, error)
+
+	// PutEstateIdGeoWithBodyWithResponse request with any body
+	PutEstateIdGeoWithBodyWithResponse(ctx context.Context, id EstateId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutEstateIdGeoResponse, error)
+
+	PutEstateIdGeoWithResponse(ctx context.Context, id EstateId, body PutEstateIdGeoJSONRequestBody, reqEditors ...RequestEditorFn) (*PutEstateIdGeoResponse, error)
+
+	// GetEstateIdHarvestsWithResponse request
+	GetEstateIdHarvestsWithResponse(ctx context.Context, id openapi_types.UUID, params *GetEstateIdHarvestsParams, reqEditors ...RequestEditorFn) (*GetEstateIdHarvestsResponse, error)
+
+	// PostEstateIdHarvestsWithBodyWithResponse request with any body
+	PostEstateIdHarvestsWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateIdHarvestsResponse, error)
+
+	PostEstateIdHarvestsWithResponse(ctx context.Context, id openapi_types.UUID, body PostEstateIdHarvestsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateIdHarvestsResponse, error)
+
+	// GetEstateIdMapPngWithResponse request
+	GetEstateIdMapPngWithResponse(ctx context.Context, id EstateId, params *GetEstateIdMapPngParams, reqEditors ...RequestEditorFn) (*GetEstateIdMapPngResponse, error)
+
+	// GetEstateIdMapSvgWithResponse request
+	GetEstateIdMapSvgWithResponse(ctx context.Context, id EstateId, params *GetEstateIdMapSvgParams, reqEditors ...RequestEditorFn) (*GetEstateIdMapSvgResponse, error)
+
+	// GetEstateIdObstaclesWithResponse request
+	GetEstateIdObstaclesWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetEstateIdObstaclesResponse, error)
+
+	// PostEstateIdObstaclesWithBodyWithResponse request with any body
+	PostEstateIdObstaclesWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateIdObstaclesResponse, error)
+
+	PostEstateIdObstaclesWithResponse(ctx context.Context, id openapi_types.UUID, body PostEstateIdObstaclesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateIdObstaclesResponse, error)
+
+	// DeleteEstateIdObstaclesObstacleIdWithResponse request
+	DeleteEstateIdObstaclesObstacleIdWithResponse(ctx context.Context, id openapi_types.UUID, obstacleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteEstateIdObstaclesObstacleIdResponse, error)
+
+	// PostEstateIdPlantingPlanWithBodyWithResponse request with any body
+	PostEstateIdPlantingPlanWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, params *PostEstateIdPlantingPlanParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateIdPlantingPlanResponse, error)
+
+	PostEstateIdPlantingPlanWithResponse(ctx context.Context, id openapi_types.UUID, params *PostEstateIdPlantingPlanParams, body PostEstateIdPlantingPlanJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateIdPlantingPlanResponse, error)
+
+	// PostEstateIdRestoreWithResponse request
+	PostEstateIdRestoreWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostEstateIdRestoreResponse, error)
+
+	// GetEstateIdStatsWithResponse request
+	GetEstateIdStatsWithResponse(ctx context.Context, id openapi_types.UUID, params *GetEstateIdStatsParams, reqEditors ...RequestEditorFn) (*GetEstateIdStatsResponse, error)
+
+	// GetEstateIdTilesZXTileWithResponse request
+	GetEstateIdTilesZXTileWithResponse(ctx context.Context, id EstateId, z int, x int, tile string, reqEditors ...RequestEditorFn) (*GetEstateIdTilesZXTileResponse, error)
+
+	// GetEstateIdTreeWithResponse request
+	GetEstateIdTreeWithResponse(ctx context.Context, id openapi_types.UUID, params *GetEstateIdTreeParams, reqEditors ...RequestEditorFn) (*GetEstateIdTreeResponse, error)
+
+	// PostEstateIdTreeWithBodyWithResponse request with any body
+	PostEstateIdTreeWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateIdTreeResponse, error)
+
+	PostEstateIdTreeWithResponse(ctx context.Context, id openapi_types.UUID, body PostEstateIdTreeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateIdTreeResponse, error)
+
+	// PatchEstateIdTreeTreeIdWithBodyWithResponse request with any body
+	PatchEstateIdTreeTreeIdWithBodyWithResponse(ctx context.Context, id EstateId, treeId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchEstateIdTreeTreeIdResponse, error)
+
+	PatchEstateIdTreeTreeIdWithResponse(ctx context.Context, id EstateId, treeId openapi_types.UUID, body PatchEstateIdTreeTreeIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEstateIdTreeTreeIdResponse, error)
+
+	// GetEstateIdYieldWithResponse request
+	GetEstateIdYieldWithResponse(ctx context.Context, id openapi_types.UUID, params *GetEstateIdYieldParams, reqEditors ...RequestEditorFn) (*GetEstateIdYieldResponse, error)
+
+	// GetHealthzWithResponse request
+	GetHealthzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthzResponse, error)
+
+	// PostJobsWithBodyWithResponse request with any body
+	PostJobsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostJobsResponse, error)
+
+	PostJobsWithResponse(ctx context.Context, body PostJobsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostJobsResponse, error)
+
+	// GetJobsIdWithResponse request
+	GetJobsIdWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetJobsIdResponse, error)
+
+	// PostJobsIdCancelWithResponse request
+	PostJobsIdCancelWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostJobsIdCancelResponse, error)
+
+	// GetReadyzWithResponse request
+	GetReadyzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadyzResponse, error)
+
+	// PostWebhooksWithBodyWithResponse request with any body
+	PostWebhooksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error)
+
+	PostWebhooksWithResponse(ctx context.Context, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error)
+
+	// GetWebhooksDeadLettersWithResponse request
+	GetWebhooksDeadLettersWithResponse(ctx context.Context, params *GetWebhooksDeadLettersParams, reqEditors ...RequestEditorFn) (*GetWebhooksDeadLettersResponse, error)
+}
+
+type PostEstateResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *CreateEstateResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostEstateResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostEstateResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostEstateImportBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *CreateEstateResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostEstateImportBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostEstateImportBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteEstateIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteEstateIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteEstateIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Estate
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchEstateIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ResizeEstateResponse
+	JSON409      *EstateResizeConflict
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchEstateIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchEstateIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdAuditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuditEntryList
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdAuditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdAuditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdBundleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdBundleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdBundleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdDronePlanResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DronePlanResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdDronePlanResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdDronePlanResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdEventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdExclusionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ExclusionZoneList
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdExclusionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdExclusionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostEstateIdExclusionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *CreateExclusionZoneResponse
+	JSON409      *ExclusionZoneConflict
+}
+
+// Status returns HTTPResponse.Status
+func (r PostEstateIdExclusionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostEstateIdExclusionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteEstateIdExclusionsZoneIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteEstateIdExclusionsZoneIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteEstateIdExclusionsZoneIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PutEstateIdGeoResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PutEstateIdGeoResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PutEstateIdGeoResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdHarvestsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HarvestList
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdHarvestsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdHarvestsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostEstateIdHarvestsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *CreateHarvestResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostEstateIdHarvestsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostEstateIdHarvestsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdMapPngResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdMapPngResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdMapPngResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdMapSvgResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdMapSvgResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdMapSvgResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdObstaclesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ObstacleList
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdObstaclesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdObstaclesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostEstateIdObstaclesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *CreateObstacleResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostEstateIdObstaclesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostEstateIdObstaclesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteEstateIdObstaclesObstacleIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteEstateIdObstaclesObstacleIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteEstateIdObstaclesObstacleIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostEstateIdPlantingPlanResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlantingPlanResponse
+	JSON201      *PlantingPlanResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostEstateIdPlantingPlanResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostEstateIdPlantingPlanResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostEstateIdRestoreResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostEstateIdRestoreResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostEstateIdRestoreResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *EstateStats
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdTilesZXTileResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Tile
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdTilesZXTileResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdTilesZXTileResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdTreeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TreeList
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdTreeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdTreeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostEstateIdTreeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *AddTreeResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostEstateIdTreeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostEstateIdTreeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchEstateIdTreeTreeIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchEstateIdTreeTreeIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchEstateIdTreeTreeIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetEstateIdYieldResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *YieldReport
+}
+
+// Status returns HTTPResponse.Status
+func (r GetEstateIdYieldResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetEstateIdYieldResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthzResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthzResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthzResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostJobsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *Job
+}
+
+// Status returns HTTPResponse.Status
+func (r PostJobsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostJobsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetJobsIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Job
+}
+
+// Status returns HTTPResponse.Status
+func (r GetJobsIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetJobsIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostJobsIdCancelResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Job
+}
+
+// Status returns HTTPResponse.Status
+func (r PostJobsIdCancelResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostJobsIdCancelResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReadyzResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthResponse
+	JSON503      *HealthResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReadyzResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReadyzResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostWebhooksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *CreateWebhookResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWebhooksDeadLettersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WebhookDeliveryList
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWebhooksDeadLettersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWebhooksDeadLettersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// PostEstateWithBodyWithResponse request with arbitrary body returning *PostEstateResponse
+func (c *ClientWithResponses) PostEstateWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateResponse, error) {
+	rsp, err := c.PostEstateWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostEstateWithResponse(ctx context.Context, body PostEstateJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateResponse, error) {
+	rsp, err := c.PostEstate(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateResponse(rsp)
+}
+
+// PostEstateImportBundleWithBodyWithResponse request with arbitrary body returning *PostEstateImportBundleResponse
+func (c *ClientWithResponses) PostEstateImportBundleWithBodyWithResponse(ctx context.Context, params *PostEstateImportBundleParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateImportBundleResponse, error) {
+	rsp, err := c.PostEstateImportBundleWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateImportBundleResponse(rsp)
+}
+
+// DeleteEstateIdWithResponse request returning *DeleteEstateIdResponse
+func (c *ClientWithResponses) DeleteEstateIdWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteEstateIdResponse, error) {
+	rsp, err := c.DeleteEstateId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteEstateIdResponse(rsp)
+}
+
+// GetEstateIdWithResponse request returning *GetEstateIdResponse
+func (c *ClientWithResponses) GetEstateIdWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetEstateIdResponse, error) {
+	rsp, err := c.GetEstateId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdResponse(rsp)
+}
+
+// PatchEstateIdWithBodyWithResponse request with arbitrary body returning *PatchEstateIdResponse
+func (c *ClientWithResponses) PatchEstateIdWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, params *PatchEstateIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchEstateIdResponse, error) {
+	rsp, err := c.PatchEstateIdWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchEstateIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchEstateIdWithResponse(ctx context.Context, id openapi_types.UUID, params *PatchEstateIdParams, body PatchEstateIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEstateIdResponse, error) {
+	rsp, err := c.PatchEstateId(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchEstateIdResponse(rsp)
+}
+
+// GetEstateIdAuditWithResponse request returning *GetEstateIdAuditResponse
+func (c *ClientWithResponses) GetEstateIdAuditWithResponse(ctx context.Context, id EstateId, params *GetEstateIdAuditParams, reqEditors ...RequestEditorFn) (*GetEstateIdAuditResponse, error) {
+	rsp, err := c.GetEstateIdAudit(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdAuditResponse(rsp)
+}
+
+// GetEstateIdBundleWithResponse request returning *GetEstateIdBundleResponse
+func (c *ClientWithResponses) GetEstateIdBundleWithResponse(ctx context.Context, id EstateId, reqEditors ...RequestEditorFn) (*GetEstateIdBundleResponse, error) {
+	rsp, err := c.GetEstateIdBundle(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdBundleResponse(rsp)
+}
+
+// GetEstateIdDronePlanWithResponse request returning *GetEstateIdDronePlanResponse
+func (c *ClientWithResponses) GetEstateIdDronePlanWithResponse(ctx context.Context, id openapi_types.UUID, params *GetEstateIdDronePlanParams, reqEditors ...RequestEditorFn) (*GetEstateIdDronePlanResponse, error) {
+	rsp, err := c.GetEstateIdDronePlan(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdDronePlanResponse(rsp)
+}
+
+// GetEstateIdEventsWithResponse request returning *GetEstateIdEventsResponse
+func (c *ClientWithResponses) GetEstateIdEventsWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetEstateIdEventsResponse, error) {
+	rsp, err := c.GetEstateIdEvents(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdEventsResponse(rsp)
+}
+
+// GetEstateIdExclusionsWithResponse request returning *GetEstateIdExclusionsResponse
+func (c *ClientWithResponses) GetEstateIdExclusionsWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetEstateIdExclusionsResponse, error) {
+	rsp, err := c.GetEstateIdExclusions(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdExclusionsResponse(rsp)
+}
+
+// PostEstateIdExclusionsWithBodyWithResponse request with arbitrary body returning *PostEstateIdExclusionsResponse
+func (c *ClientWithResponses) PostEstateIdExclusionsWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateIdExclusionsResponse, error) {
+	rsp, err := c.PostEstateIdExclusionsWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdExclusionsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostEstateIdExclusionsWithResponse(ctx context.Context, id openapi_types.UUID, body PostEstateIdExclusionsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateIdExclusionsResponse, error) {
+	rsp, err := c.PostEstateIdExclusions(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdExclusionsResponse(rsp)
+}
+
+// DeleteEstateIdExclusionsZoneIdWithResponse request returning *DeleteEstateIdExclusionsZoneIdResponse
+func (c *ClientWithResponses) DeleteEstateIdExclusionsZoneIdWithResponse(ctx context.Context, id openapi_types.UUID, zoneId openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteEstateIdExclusionsZoneIdResponse, error) {
+	rsp, err := c.DeleteEstateIdExclusionsZoneId(ctx, id, zoneId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteEstateIdExclusionsZoneIdResponse(rsp)
+}
+
+// PutEstateIdGeoWithBodyWithResponse request with arbitrary body returning *PutEstateIdGeoResponse
+func (c *ClientWithResponses) PutEstateIdGeoWithBodyWithResponse(ctx context.Context, id EstateId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutEstateIdGeoResponse, error) {
+	rsp, err := c.PutEstateIdGeoWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutEstateIdGeoResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutEstateIdGeoWithResponse(ctx context.Context, id EstateId, body PutEstateIdGeoJSONRequestBody, reqEditors ...RequestEditorFn) (*PutEstateIdGeoResponse, error) {
+	rsp, err := c.PutEstateIdGeo(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutEstateIdGeoResponse(rsp)
+}
+
+// GetEstateIdHarvestsWithResponse request returning *GetEstateIdHarvestsResponse
+func (c *ClientWithResponses) GetEstateIdHarvestsWithResponse(ctx context.Context, id openapi_types.UUID, params *GetEstateIdHarvestsParams, reqEditors ...RequestEditorFn) (*GetEstateIdHarvestsResponse, error) {
+	rsp, err := c.GetEstateIdHarvests(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdHarvestsResponse(rsp)
+}
+
+// PostEstateIdHarvestsWithBodyWithResponse request with arbitrary body returning *PostEstateIdHarvestsResponse
+func (c *ClientWithResponses) PostEstateIdHarvestsWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateIdHarvestsResponse, error) {
+	rsp, err := c.PostEstateIdHarvestsWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdHarvestsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostEstateIdHarvestsWithResponse(ctx context.Context, id openapi_types.UUID, body PostEstateIdHarvestsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateIdHarvestsResponse, error) {
+	rsp, err := c.PostEstateIdHarvests(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdHarvestsResponse(rsp)
+}
+
+// GetEstateIdMapPngWithResponse request returning *GetEstateIdMapPngResponse
+func (c *ClientWithResponses) GetEstateIdMapPngWithResponse(ctx context.Context, id EstateId, params *GetEstateIdMapPngParams, reqEditors ...RequestEditorFn) (*GetEstateIdMapPngResponse, error) {
+	rsp, err := c.GetEstateIdMapPng(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdMapPngResponse(rsp)
+}
+
+// GetEstateIdMapSvgWithResponse request returning *GetEstateIdMapSvgResponse
+func (c *ClientWithResponses) GetEstateIdMapSvgWithResponse(ctx context.Context, id EstateId, params *GetEstateIdMapSvgParams, reqEditors ...RequestEditorFn) (*GetEstateIdMapSvgResponse, error) {
+	rsp, err := c.GetEstateIdMapSvg(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdMapSvgResponse(rsp)
+}
+
+// GetEstateIdObstaclesWithResponse request returning *GetEstateIdObstaclesResponse
+func (c *ClientWithResponses) GetEstateIdObstaclesWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetEstateIdObstaclesResponse, error) {
+	rsp, err := c.GetEstateIdObstacles(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdObstaclesResponse(rsp)
+}
+
+// PostEstateIdObstaclesWithBodyWithResponse request with arbitrary body returning *PostEstateIdObstaclesResponse
+func (c *ClientWithResponses) PostEstateIdObstaclesWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateIdObstaclesResponse, error) {
+	rsp, err := c.PostEstateIdObstaclesWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdObstaclesResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostEstateIdObstaclesWithResponse(ctx context.Context, id openapi_types.UUID, body PostEstateIdObstaclesJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateIdObstaclesResponse, error) {
+	rsp, err := c.PostEstateIdObstacles(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdObstaclesResponse(rsp)
+}
+
+// DeleteEstateIdObstaclesObstacleIdWithResponse request returning *DeleteEstateIdObstaclesObstacleIdResponse
+func (c *ClientWithResponses) DeleteEstateIdObstaclesObstacleIdWithResponse(ctx context.Context, id openapi_types.UUID, obstacleId openapi_types.UUID, reqEditors ...RequestEditorFn) (*DeleteEstateIdObstaclesObstacleIdResponse, error) {
+	rsp, err := c.DeleteEstateIdObstaclesObstacleId(ctx, id, obstacleId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteEstateIdObstaclesObstacleIdResponse(rsp)
+}
+
+// PostEstateIdPlantingPlanWithBodyWithResponse request with arbitrary body returning *PostEstateIdPlantingPlanResponse
+func (c *ClientWithResponses) PostEstateIdPlantingPlanWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, params *PostEstateIdPlantingPlanParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateIdPlantingPlanResponse, error) {
+	rsp, err := c.PostEstateIdPlantingPlanWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdPlantingPlanResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostEstateIdPlantingPlanWithResponse(ctx context.Context, id openapi_types.UUID, params *PostEstateIdPlantingPlanParams, body PostEstateIdPlantingPlanJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateIdPlantingPlanResponse, error) {
+	rsp, err := c.PostEstateIdPlantingPlan(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdPlantingPlanResponse(rsp)
+}
+
+// PostEstateIdRestoreWithResponse request returning *PostEstateIdRestoreResponse
+func (c *ClientWithResponses) PostEstateIdRestoreWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostEstateIdRestoreResponse, error) {
+	rsp, err := c.PostEstateIdRestore(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdRestoreResponse(rsp)
+}
+
+// GetEstateIdStatsWithResponse request returning *GetEstateIdStatsResponse
+func (c *ClientWithResponses) GetEstateIdStatsWithResponse(ctx context.Context, id openapi_types.UUID, params *GetEstateIdStatsParams, reqEditors ...RequestEditorFn) (*GetEstateIdStatsResponse, error) {
+	rsp, err := c.GetEstateIdStats(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdStatsResponse(rsp)
+}
+
+// GetEstateIdTilesZXTileWithResponse request returning *GetEstateIdTilesZXTileResponse
+func (c *ClientWithResponses) GetEstateIdTilesZXTileWithResponse(ctx context.Context, id EstateId, z int, x int, tile string, reqEditors ...RequestEditorFn) (*GetEstateIdTilesZXTileResponse, error) {
+	rsp, err := c.GetEstateIdTilesZXTile(ctx, id, z, x, tile, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdTilesZXTileResponse(rsp)
+}
+
+// GetEstateIdTreeWithResponse request returning *GetEstateIdTreeResponse
+func (c *ClientWithResponses) GetEstateIdTreeWithResponse(ctx context.Context, id openapi_types.UUID, params *GetEstateIdTreeParams, reqEditors ...RequestEditorFn) (*GetEstateIdTreeResponse, error) {
+	rsp, err := c.GetEstateIdTree(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdTreeResponse(rsp)
+}
+
+// PostEstateIdTreeWithBodyWithResponse request with arbitrary body returning *PostEstateIdTreeResponse
+func (c *ClientWithResponses) PostEstateIdTreeWithBodyWithResponse(ctx context.Context, id openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostEstateIdTreeResponse, error) {
+	rsp, err := c.PostEstateIdTreeWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdTreeResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostEstateIdTreeWithResponse(ctx context.Context, id openapi_types.UUID, body PostEstateIdTreeJSONRequestBody, reqEditors ...RequestEditorFn) (*PostEstateIdTreeResponse, error) {
+	rsp, err := c.PostEstateIdTree(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostEstateIdTreeResponse(rsp)
+}
+
+// PatchEstateIdTreeTreeIdWithBodyWithResponse request with arbitrary body returning *PatchEstateIdTreeTreeIdResponse
+func (c *ClientWithResponses) PatchEstateIdTreeTreeIdWithBodyWithResponse(ctx context.Context, id EstateId, treeId openapi_types.UUID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchEstateIdTreeTreeIdResponse, error) {
+	rsp, err := c.PatchEstateIdTreeTreeIdWithBody(ctx, id, treeId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchEstateIdTreeTreeIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchEstateIdTreeTreeIdWithResponse(ctx context.Context, id EstateId, treeId openapi_types.UUID, body PatchEstateIdTreeTreeIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchEstateIdTreeTreeIdResponse, error) {
+	rsp, err := c.PatchEstateIdTreeTreeId(ctx, id, treeId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchEstateIdTreeTreeIdResponse(rsp)
+}
+
+// GetEstateIdYieldWithResponse request returning *GetEstateIdYieldResponse
+func (c *ClientWithResponses) GetEstateIdYieldWithResponse(ctx context.Context, id openapi_types.UUID, params *GetEstateIdYieldParams, reqEditors ...RequestEditorFn) (*GetEstateIdYieldResponse, error) {
+	rsp, err := c.GetEstateIdYield(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetEstateIdYieldResponse(rsp)
+}
+
+// GetHealthzWithResponse request returning *GetHealthzResponse
+func (c *ClientWithResponses) GetHealthzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthzResponse, error) {
+	rsp, err := c.GetHealthz(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthzResponse(rsp)
+}
+
+// PostJobsWithBodyWithResponse request with arbitrary body returning *PostJobsResponse
+func (c *ClientWithResponses) PostJobsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostJobsResponse, error) {
+	rsp, err := c.PostJobsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostJobsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostJobsWithResponse(ctx context.Context, body PostJobsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostJobsResponse, error) {
+	rsp, err := c.PostJobs(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostJobsResponse(rsp)
+}
+
+// GetJobsIdWithResponse request returning *GetJobsIdResponse
+func (c *ClientWithResponses) GetJobsIdWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*GetJobsIdResponse, error) {
+	rsp, err := c.GetJobsId(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetJobsIdResponse(rsp)
+}
+
+// PostJobsIdCancelWithResponse request returning *PostJobsIdCancelResponse
+func (c *ClientWithResponses) PostJobsIdCancelWithResponse(ctx context.Context, id openapi_types.UUID, reqEditors ...RequestEditorFn) (*PostJobsIdCancelResponse, error) {
+	rsp, err := c.PostJobsIdCancel(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostJobsIdCancelResponse(rsp)
+}
+
+// GetReadyzWithResponse request returning *GetReadyzResponse
+func (c *ClientWithResponses) GetReadyzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetReadyzResponse, error) {
+	rsp, err := c.GetReadyz(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReadyzResponse(rsp)
+}
+
+// PostWebhooksWithBodyWithResponse request with arbitrary body returning *PostWebhooksResponse
+func (c *ClientWithResponses) PostWebhooksWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error) {
+	rsp, err := c.PostWebhooksWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostWebhooksResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostWebhooksWithResponse(ctx context.Context, body PostWebhooksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostWebhooksResponse, error) {
+	rsp, err := c.PostWebhooks(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostWebhooksResponse(rsp)
+}
+
+// GetWebhooksDeadLettersWithResponse request returning *GetWebhooksDeadLettersResponse
+func (c *ClientWithResponses) GetWebhooksDeadLettersWithResponse(ctx context.Context, params *GetWebhooksDeadLettersParams, reqEditors ...RequestEditorFn) (*GetWebhooksDeadLettersResponse, error) {
+	rsp, err := c.GetWebhooksDeadLetters(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWebhooksDeadLettersResponse(rsp)
+}
+
+// ParsePostEstateResponse parses an HTTP response from a PostEstateWithResponse call
+func ParsePostEstateResponse(rsp *http.Response) (*PostEstateResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostEstateResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CreateEstateResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostEstateImportBundleResponse parses an HTTP response from a PostEstateImportBundleWithResponse call
+func ParsePostEstateImportBundleResponse(rsp *http.Response) (*PostEstateImportBundleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostEstateImportBundleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CreateEstateResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteEstateIdResponse parses an HTTP response from a DeleteEstateIdWithResponse call
+func ParseDeleteEstateIdResponse(rsp *http.Response) (*DeleteEstateIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteEstateIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdResponse parses an HTTP response from a GetEstateIdWithResponse call
+func ParseGetEstateIdResponse(rsp *http.Response) (*GetEstateIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Estate
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchEstateIdResponse parses an HTTP response from a PatchEstateIdWithResponse call
+func ParsePatchEstateIdResponse(rsp *http.Response) (*PatchEstateIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchEstateIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ResizeEstateResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest EstateResizeConflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdAuditResponse parses an HTTP response from a GetEstateIdAuditWithResponse call
+func ParseGetEstateIdAuditResponse(rsp *http.Response) (*GetEstateIdAuditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdAuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuditEntryList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdBundleResponse parses an HTTP response from a GetEstateIdBundleWithResponse call
+func ParseGetEstateIdBundleResponse(rsp *http.Response) (*GetEstateIdBundleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdBundleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdDronePlanResponse parses an HTTP response from a GetEstateIdDronePlanWithResponse call
+func ParseGetEstateIdDronePlanResponse(rsp *http.Response) (*GetEstateIdDronePlanResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdDronePlanResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DronePlanResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdEventsResponse parses an HTTP response from a GetEstateIdEventsWithResponse call
+func ParseGetEstateIdEventsResponse(rsp *http.Response) (*GetEstateIdEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdExclusionsResponse parses an HTTP response from a GetEstateIdExclusionsWithResponse call
+func ParseGetEstateIdExclusionsResponse(rsp *http.Response) (*GetEstateIdExclusionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdExclusionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ExclusionZoneList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostEstateIdExclusionsResponse parses an HTTP response from a PostEstateIdExclusionsWithResponse call
+func ParsePostEstateIdExclusionsResponse(rsp *http.Response) (*PostEstateIdExclusionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostEstateIdExclusionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CreateExclusionZoneResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ExclusionZoneConflict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteEstateIdExclusionsZoneIdResponse parses an HTTP response from a DeleteEstateIdExclusionsZoneIdWithResponse call
+func ParseDeleteEstateIdExclusionsZoneIdResponse(rsp *http.Response) (*DeleteEstateIdExclusionsZoneIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteEstateIdExclusionsZoneIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePutEstateIdGeoResponse parses an HTTP response from a PutEstateIdGeoWithResponse call
+func ParsePutEstateIdGeoResponse(rsp *http.Response) (*PutEstateIdGeoResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PutEstateIdGeoResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdHarvestsResponse parses an HTTP response from a GetEstateIdHarvestsWithResponse call
+func ParseGetEstateIdHarvestsResponse(rsp *http.Response) (*GetEstateIdHarvestsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdHarvestsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HarvestList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostEstateIdHarvestsResponse parses an HTTP response from a PostEstateIdHarvestsWithResponse call
+func ParsePostEstateIdHarvestsResponse(rsp *http.Response) (*PostEstateIdHarvestsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostEstateIdHarvestsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CreateHarvestResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdMapPngResponse parses an HTTP response from a GetEstateIdMapPngWithResponse call
+func ParseGetEstateIdMapPngResponse(rsp *http.Response) (*GetEstateIdMapPngResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdMapPngResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdMapSvgResponse parses an HTTP response from a GetEstateIdMapSvgWithResponse call
+func ParseGetEstateIdMapSvgResponse(rsp *http.Response) (*GetEstateIdMapSvgResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdMapSvgResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdObstaclesResponse parses an HTTP response from a GetEstateIdObstaclesWithResponse call
+func ParseGetEstateIdObstaclesResponse(rsp *http.Response) (*GetEstateIdObstaclesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdObstaclesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ObstacleList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostEstateIdObstaclesResponse parses an HTTP response from a PostEstateIdObstaclesWithResponse call
+func ParsePostEstateIdObstaclesResponse(rsp *http.Response) (*PostEstateIdObstaclesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostEstateIdObstaclesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CreateObstacleResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteEstateIdObstaclesObstacleIdResponse parses an HTTP response from a DeleteEstateIdObstaclesObstacleIdWithResponse call
+func ParseDeleteEstateIdObstaclesObstacleIdResponse(rsp *http.Response) (*DeleteEstateIdObstaclesObstacleIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteEstateIdObstaclesObstacleIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePostEstateIdPlantingPlanResponse parses an HTTP response from a PostEstateIdPlantingPlanWithResponse call
+func ParsePostEstateIdPlantingPlanResponse(rsp *http.Response) (*PostEstateIdPlantingPlanResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostEstateIdPlantingPlanResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PlantingPlanResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest PlantingPlanResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostEstateIdRestoreResponse parses an HTTP response from a PostEstateIdRestoreWithResponse call
+func ParsePostEstateIdRestoreResponse(rsp *http.Response) (*PostEstateIdRestoreResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostEstateIdRestoreResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdStatsResponse parses an HTTP response from a GetEstateIdStatsWithResponse call
+func ParseGetEstateIdStatsResponse(rsp *http.Response) (*GetEstateIdStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest EstateStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdTilesZXTileResponse parses an HTTP response from a GetEstateIdTilesZXTileWithResponse call
+func ParseGetEstateIdTilesZXTileResponse(rsp *http.Response) (*GetEstateIdTilesZXTileResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdTilesZXTileResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Tile
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case rsp.StatusCode == 200:
+		// Content-type (image/png) unsupported
+
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdTreeResponse parses an HTTP response from a GetEstateIdTreeWithResponse call
+func ParseGetEstateIdTreeResponse(rsp *http.Response) (*GetEstateIdTreeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdTreeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TreeList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostEstateIdTreeResponse parses an HTTP response from a PostEstateIdTreeWithResponse call
+func ParsePostEstateIdTreeResponse(rsp *http.Response) (*PostEstateIdTreeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostEstateIdTreeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest AddTreeResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchEstateIdTreeTreeIdResponse parses an HTTP response from a PatchEstateIdTreeTreeIdWithResponse call
+func ParsePatchEstateIdTreeTreeIdResponse(rsp *http.Response) (*PatchEstateIdTreeTreeIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchEstateIdTreeTreeIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetEstateIdYieldResponse parses an HTTP response from a GetEstateIdYieldWithResponse call
+func ParseGetEstateIdYieldResponse(rsp *http.Response) (*GetEstateIdYieldResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetEstateIdYieldResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest YieldReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthzResponse parses an HTTP response from a GetHealthzWithResponse call
+func ParseGetHealthzResponse(rsp *http.Response) (*GetHealthzResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthzResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostJobsResponse parses an HTTP response from a PostJobsWithResponse call
+func ParsePostJobsResponse(rsp *http.Response) (*PostJobsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostJobsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest Job
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetJobsIdResponse parses an HTTP response from a GetJobsIdWithResponse call
+func ParseGetJobsIdResponse(rsp *http.Response) (*GetJobsIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetJobsIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Job
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostJobsIdCancelResponse parses an HTTP response from a PostJobsIdCancelWithResponse call
+func ParsePostJobsIdCancelResponse(rsp *http.Response) (*PostJobsIdCancelResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostJobsIdCancelResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Job
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReadyzResponse parses an HTTP response from a GetReadyzWithResponse call
+func ParseGetReadyzResponse(rsp *http.Response) (*GetReadyzResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReadyzResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest HealthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostWebhooksResponse parses an HTTP response from a PostWebhooksWithResponse call
+func ParsePostWebhooksResponse(rsp *http.Response) (*PostWebhooksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostWebhooksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest CreateWebhookResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWebhooksDeadLettersResponse parses an HTTP response from a GetWebhooksDeadLettersWithResponse call
+func ParseGetWebhooksDeadLettersResponse(rsp *http.Response) (*GetWebhooksDeadLettersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWebhooksDeadLettersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WebhookDeliveryList
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
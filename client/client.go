@@ -0,0 +1,174 @@
+// Package client is the Go SDK of the plantation API.
+// client.gen.go is generated from api.yml (make generate_client), PlantationClient wrap it
+// with retries & typed errors for the common estate calls.
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlantationClient is safe for concurrent use
+type PlantationClient struct {
+	api *ClientWithResponses
+}
+
+type NewPlantationClientOptions struct {
+	HTTPClient  HttpRequestDoer // optional, default to http.Client with 30s timeout
+	MaxRetries  int             // retries after the first attempt, default 3, negative disable retries
+	BaseBackoff time.Duration   // first retry delay, doubled on every retry, default 200ms
+	MaxBackoff  time.Duration   // default 5s
+}
+
+func NewPlantationClient(server string, opts NewPlantationClientOptions) (*PlantationClient, error) {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	doer := &retryDoer{
+		next:        httpClient,
+		maxRetries:  opts.MaxRetries,
+		baseBackoff: opts.BaseBackoff,
+		maxBackoff:  opts.MaxBackoff,
+	}
+	if doer.maxRetries == 0 {
+		doer.maxRetries = 3
+	}
+	if doer.baseBackoff <= 0 {
+		doer.baseBackoff = 200 * time.Millisecond
+	}
+	if doer.maxBackoff <= 0 {
+		doer.maxBackoff = 5 * time.Second
+	}
+
+	api, err := NewClientWithResponses(server, WithHTTPClient(doer))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PlantationClient{api: api}, nil
+}
+
+// API return the generated client, for the endpoints without a wrapper
+func (c *PlantationClient) API() *ClientWithResponses {
+	return c.api
+}
+
+// CreateEstate create an estate of width x length plots and return its ID
+func (c *PlantationClient) CreateEstate(ctx context.Context, width, length int) (uuid.UUID, error) {
+	resp, err := c.api.PostEstateWithResponse(ctx, PostEstateJSONRequestBody{Width: width, Length: length})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if resp.JSON201 == nil || resp.JSON201.Id == nil {
+		return uuid.Nil, unexpectedResponse(resp.StatusCode(), resp.Body)
+	}
+
+	return *resp.JSON201.Id, nil
+}
+
+// AddTree plant a tree of height meters at the x,y plot and return its ID
+func (c *PlantationClient) AddTree(ctx context.Context, estateID uuid.UUID, x, y, height int) (uuid.UUID, error) {
+	resp, err := c.api.PostEstateIdTreeWithResponse(ctx, estateID, PostEstateIdTreeJSONRequestBody{X: x, Y: y, Height: height})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if resp.JSON201 == nil || resp.JSON201.Id == nil {
+		return uuid.Nil, unexpectedResponse(resp.StatusCode(), resp.Body)
+	}
+
+	return *resp.JSON201.Id, nil
+}
+
+// GetEstate return the estate size & its trees
+func (c *PlantationClient) GetEstate(ctx context.Context, estateID uuid.UUID) (*Estate, error) {
+	resp, err := c.api.GetEstateIdWithResponse(ctx, estateID)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, unexpectedResponse(resp.StatusCode(), resp.Body)
+	}
+
+	return resp.JSON200, nil
+}
+
+// ResizeEstate change the estate size, nil keep the current value. Without force a shrink leaving
+// trees outside the estate fail with ErrConflict, with force those trees are removed
+func (c *PlantationClient) ResizeEstate(ctx context.Context, estateID uuid.UUID, width, length *int, force bool) (*ResizeEstateResponse, error) {
+	resp, err := c.api.PatchEstateIdWithResponse(ctx, estateID, &PatchEstateIdParams{Force: &force}, PatchEstateIdJSONRequestBody{Width: width, Length: length})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON409 != nil {
+		return nil, &ResizeConflictError{APIError: newAPIError(resp.StatusCode(), resp.Body), Trees: resp.JSON409.Trees}
+	}
+	if resp.JSON200 == nil {
+		return nil, unexpectedResponse(resp.StatusCode(), resp.Body)
+	}
+
+	return resp.JSON200, nil
+}
+
+// DeleteEstate soft delete the estate, it can be brought back with RestoreEstate
+func (c *PlantationClient) DeleteEstate(ctx context.Context, estateID uuid.UUID) error {
+	resp, err := c.api.DeleteEstateIdWithResponse(ctx, estateID)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusNoContent {
+		return newAPIError(resp.StatusCode(), resp.Body)
+	}
+
+	return nil
+}
+
+func (c *PlantationClient) RestoreEstate(ctx context.Context, estateID uuid.UUID) error {
+	resp, err := c.api.PostEstateIdRestoreWithResponse(ctx, estateID)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusNoContent {
+		return newAPIError(resp.StatusCode(), resp.Body)
+	}
+
+	return nil
+}
+
+func (c *PlantationClient) GetStats(ctx context.Context, estateID uuid.UUID) (*EstateStats, error) {
+	resp, err := c.api.GetEstateIdStatsWithResponse(ctx, estateID, &GetEstateIdStatsParams{})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, unexpectedResponse(resp.StatusCode(), resp.Body)
+	}
+
+	return resp.JSON200, nil
+}
+
+// GetDronePlan return the drone travel distance, with the landing plot when maxDistance is given
+func (c *PlantationClient) GetDronePlan(ctx context.Context, estateID uuid.UUID, maxDistance *int) (*DronePlanResponse, error) {
+	resp, err := c.api.GetEstateIdDronePlanWithResponse(ctx, estateID, &GetEstateIdDronePlanParams{MaxDistance: maxDistance})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, unexpectedResponse(resp.StatusCode(), resp.Body)
+	}
+
+	return resp.JSON200, nil
+}
+
+// unexpectedResponse turn a response the generated client could not decode into an error
+func unexpectedResponse(statusCode int, body []byte) error {
+	if statusCode >= 200 && statusCode <= 299 {
+		return errors.New("plantation api: unexpected empty response")
+	}
+	return newAPIError(statusCode, body)
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *PlantationClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := NewPlantationClient(server.URL, NewPlantationClientOptions{
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	return c
+}
+
+func TestCreateEstate(t *testing.T) {
+	estateID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/estate", r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"` + estateID.String() + `"}`))
+		})
+
+		id, err := c.CreateEstate(context.Background(), 10, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, estateID, id)
+	})
+
+	t.Run("Validation Error", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Validation failed","errors":{"width":"number must be at least 1"}}`))
+		})
+
+		_, err := c.CreateEstate(context.Background(), 0, 20)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, map[string]string{"width": "number must be at least 1"}, apiErr.Errors)
+		assert.EqualError(t, err, "plantation api: 400 Validation failed (width: number must be at least 1)")
+	})
+
+	t.Run("Server Error Is Not Retried", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message":"failed to create estate"}`))
+		})
+
+		_, err := c.CreateEstate(context.Background(), 10, 20)
+		assert.ErrorIs(t, err, ErrServer)
+		assert.Equal(t, int32(1), calls.Load(), "POST may have been applied, it must not be retried")
+	})
+
+	t.Run("Unavailable Is Retried", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"` + estateID.String() + `"}`))
+		})
+
+		id, err := c.CreateEstate(context.Background(), 10, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, estateID, id)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+}
+
+func TestGetStats(t *testing.T) {
+	t.Run("Retry Until Max Retries", func(t *testing.T) {
+		var calls atomic.Int32
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		_, err := c.GetStats(context.Background(), uuid.New())
+		assert.ErrorIs(t, err, ErrServer)
+		assert.EqualError(t, err, "plantation api: 502 Bad Gateway")
+		assert.Equal(t, int32(4), calls.Load(), "first attempt and 3 retries")
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"estate not found"}`))
+		})
+
+		_, err := c.GetStats(context.Background(), uuid.New())
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NotErrorIs(t, err, ErrValidation)
+	})
+}
+
+func TestGetDronePlan(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "15", r.URL.Query().Get("max_distance"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"distance":15,"rest":{"x":1,"y":1}}`))
+	})
+
+	maxDistance := 15
+	plan, err := c.GetDronePlan(context.Background(), uuid.New(), &maxDistance)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15), *plan.Distance)
+	assert.Equal(t, 1, *plan.Rest.X)
+}
+
+func TestBackoff(t *testing.T) {
+	d := &retryDoer{baseBackoff: 100 * time.Millisecond, maxBackoff: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, d.backoff(1))
+	assert.Equal(t, 400*time.Millisecond, d.backoff(3))
+	assert.Equal(t, time.Second, d.backoff(10))
+}
+
+func TestResizeEstate(t *testing.T) {
+	estateID := uuid.New()
+	treeID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "true", r.URL.Query().Get("force"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"` + estateID.String() + `","width":2,"length":3,"removed_trees":[{"id":"` + treeID.String() + `","x":5,"y":1,"height":7}]}`))
+		})
+
+		width := 2
+		resized, err := c.ResizeEstate(context.Background(), estateID, &width, nil, true)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, resized.Length)
+		assert.Equal(t, treeID, resized.RemovedTrees[0].Id)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message":"1 trees are outside the new estate boundary","trees":[{"id":"` + treeID.String() + `","x":5,"y":1,"height":7}]}`))
+		})
+
+		length := 3
+		_, err := c.ResizeEstate(context.Background(), estateID, nil, &length, false)
+		assert.ErrorIs(t, err, ErrConflict)
+
+		var conflict *ResizeConflictError
+		assert.True(t, errors.As(err, &conflict))
+		assert.Equal(t, []Tree{{Id: treeID, X: 5, Y: 1, Height: 7}}, conflict.Trees)
+		assert.EqualError(t, err, "plantation api: 409 1 trees are outside the new estate boundary")
+	})
+}
+
+func TestDeleteAndRestoreEstate(t *testing.T) {
+	estateID := uuid.New()
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete && r.URL.Path == "/estate/"+estateID.String():
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/estate/"+estateID.String()+"/restore":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"deleted estate not found"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	assert.NoError(t, c.DeleteEstate(context.Background(), estateID))
+	assert.ErrorIs(t, c.RestoreEstate(context.Background(), estateID), ErrNotFound)
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by APIError with errors.Is
+var (
+	ErrValidation = errors.New("validation failed")
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrServer     = errors.New("server error")
+)
+
+// APIError is a non-2xx response decoded from the API ErrorResponse body
+type APIError struct {
+	StatusCode int
+	Message    string
+	Errors     map[string]string // invalid fields, only set on validation errors
+}
+
+func (e *APIError) Error() string {
+	if len(e.Errors) == 0 {
+		return fmt.Sprintf("plantation api: %d %s", e.StatusCode, e.Message)
+	}
+
+	fields := make([]string, 0, len(e.Errors))
+	for field, reason := range e.Errors {
+		fields = append(fields, field+": "+reason)
+	}
+	return fmt.Sprintf("plantation api: %d %s (%s)", e.StatusCode, e.Message, strings.Join(fields, ", "))
+}
+
+// Is let errors.Is(err, ErrNotFound) work without checking the status code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// ResizeConflictError is returned by ResizeEstate when trees would be outside the new boundary
+type ResizeConflictError struct {
+	*APIError
+	Trees []Tree // trees outside the new boundary
+}
+
+type errorResponse struct {
+	Message string            `json:"message"`
+	Errors  map[string]string `json:"errors,omitempty"`
+}
+
+// newAPIError decode the ErrorResponse body, the status text is used when the body is not one
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+
+	var resp errorResponse
+	if err := json.Unmarshal(body, &resp); err == nil && resp.Message != "" {
+		apiErr.Message = resp.Message
+		apiErr.Errors = resp.Errors
+	} else {
+		apiErr.Message = http.StatusText(statusCode)
+	}
+
+	return apiErr
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryDoer retry the requests that failed before being processed by the server.
+// POST is only retried on 429 & 503, any other failure may have been applied already
+type retryDoer struct {
+	next        HttpRequestDoer
+	maxRetries  int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := d.next.Do(req)
+		if attempt >= d.maxRetries || !shouldRetry(req.Method, resp, err) {
+			return resp, err
+		}
+
+		wait := d.backoff(attempt + 1)
+		if resp != nil {
+			if retryAfter := retryAfterDuration(resp); retryAfter > 0 && retryAfter <= d.maxBackoff {
+				wait = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	idempotent := method != http.MethodPost && method != http.MethodPatch
+	if err != nil {
+		return idempotent
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// backoff return baseBackoff * 2^(attempt-1), capped by maxBackoff
+func (d *retryDoer) backoff(attempt int) time.Duration {
+	wait := d.baseBackoff
+	for i := 1; i < attempt && wait < d.maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > d.maxBackoff {
+		wait = d.maxBackoff
+	}
+	return wait
+}
+
+// retryAfterDuration read the Retry-After header given in seconds, 0 when absent
+func retryAfterDuration(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
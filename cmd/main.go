@@ -1,41 +1,187 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 
 	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/events"
 	"github.com/SawitProRecruitment/UserService/generated"
 	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/jobs"
+	"github.com/SawitProRecruitment/UserService/plancache"
 	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/telemetry"
+	"github.com/SawitProRecruitment/UserService/webhook"
 	"github.com/go-playground/validator/v10"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 )
 
 func main() {
 	cfg := config.LoadConfig()
 
+	// cancelled on SIGINT/SIGTERM, stop the background workers & start the graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	e := echo.New()
 
-	var server generated.ServerInterface = newServer(cfg)
+	logger, err := telemetry.NewLogger(cfg.Log, os.Stdout)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := telemetry.InitTracing(context.Background(), cfg.Telemetry)
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	defer shutdownTracing(context.Background())
+
+	db := newRepository(cfg, logger)
+	// the app still start when the database is down, /readyz report it until it is reachable
+	if err := db.Ping(ctx); err != nil {
+		logger.Warn("database is not reachable", slog.String("error", err.Error()))
+	}
+	var repo repository.RepositoryInterface = repository.NewInstrumentedRepository(db)
+	// the cache wrap the instrumented repository, the traced queries are the ones reaching postgres
+	var cache *repository.CachedRepository
+	if cfg.Cache.Enabled {
+		cache = repository.NewCachedRepository(repo, repository.NewCachedRepositoryOptions{
+			Size: cfg.Cache.Size,
+			TTL:  cfg.Cache.TTL,
+		})
+		repo = cache
+	}
+
+	var workers sync.WaitGroup
+
+	// every replica listen the committed estate events and stream them to its own SSE clients
+	broker := events.NewBroker(cfg.Events.BufferSize)
+	var onEvent func(event repository.EventNotification)
+	if cache != nil {
+		// drop the estates changed by the other replicas, the TTL cover the events lost on reconnect
+		onEvent = func(event repository.EventNotification) { cache.Invalidate(event.EstateId) }
+	}
+	listener := events.NewPostgresListener(events.NewPostgresListenerOptions{
+		Dsn:        cfg.Database.PostgreDSN,
+		Repository: repo,
+		Broker:     broker,
+		Logger:     logger,
+		OnEvent:    onEvent,
+	})
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		if err := listener.Run(ctx); err != nil {
+			logger.Error("estate events listener stopped", slog.String("error", err.Error()))
+		}
+	}()
 
+	handlers := newServer(cfg, repo, broker, logger)
+	var server generated.ServerInterface = handlers
+
+	// deliver outbox events to webhook subscribers in background
+	dispatcher := webhook.NewDispatcher(webhook.NewDispatcherOptions{
+		Repository: repo,
+		Config:     cfg.Webhook,
+		Logger:     logger,
+	})
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		dispatcher.Run(ctx)
+	}()
+
+	// run the queued jobs in background
+	worker := jobs.NewWorker(jobs.NewWorkerOptions{
+		Repository: repo,
+		Handlers:   handlers.JobHandlers(),
+		Config:     cfg.Jobs,
+		Logger:     logger,
+	})
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		worker.Run(ctx)
+	}()
+
+	e.IPExtractor = handler.NewIPExtractor(cfg.App.TrustedProxies)
+	e.Use(telemetry.RequestLogger(logger))
+	e.Use(telemetry.Middleware())
+	e.Use(handler.ActorMiddleware())
+
+	openAPIValidator, err := handler.NewOpenAPIValidator(handler.NewOpenAPIValidatorOptions{
+		ValidateResponses: cfg.App.ValidateResponses,
+	})
+	if err != nil {
+		e.Logger.Fatal(err)
+	}
+	e.Use(openAPIValidator)
+	e.GET("/metrics", echo.WrapHandler(telemetry.MetricsHandler()))
 	generated.RegisterHandlers(e, server)
-	e.Use(middleware.Logger())
-	e.Logger.Fatal(e.Start(fmt.Sprintf(":%d", cfg.App.Port)))
-}
 
-func newServer(cfg *config.Config) *handler.Server {
-	validator := validator.New()
+	go func() {
+		if err := e.Start(fmt.Sprintf(":%d", cfg.App.Port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server stopped", slog.String("error", err.Error()))
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	logger.Info("shutting down", slog.Duration("timeout", cfg.App.ShutdownTimeout))
 
-	var repo repository.RepositoryInterface = repository.NewRepository(repository.NewRepositoryOptions{
-		Dsn: cfg.Database.PostgreDSN,
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.App.ShutdownTimeout)
+	defer cancel()
+
+	// SSE streams never end by themselves, close them so Shutdown only wait for the regular requests
+	broker.Close()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		logger.Error("failed to drain in-flight requests", slog.String("error", err.Error()))
+	}
+
+	// the DB pool is closed last, the requests & workers may still be using it
+	workers.Wait()
+	if err := db.Close(); err != nil {
+		logger.Error("failed to close database", slog.String("error", err.Error()))
+	}
+}
+
+func newRepository(cfg *config.Config, logger *slog.Logger) *repository.Repository {
+	return repository.NewRepository(repository.NewRepositoryOptions{
+		Dsn:             cfg.Database.PostgreDSN,
+		MaxOpenConns:    cfg.Database.MaxOpenConns,
+		MaxIdleConns:    cfg.Database.MaxIdleConns,
+		ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.Database.ConnMaxIdleTime,
+		Logger:          logger,
 	})
+}
+
+func newServer(cfg *config.Config, repo repository.RepositoryInterface, broker *events.Broker, logger *slog.Logger) *handler.Server {
+	validator := validator.New()
 
 	opts := handler.NewServerOptions{
 		Repository: repo,
 		Validator:  validator,
 		Config:     cfg,
+		Broker:     broker,
+		Logger:     logger,
+	}
+	if cfg.Drone.PlanCache.Size > 0 || cfg.Drone.PlanCache.Persist {
+		opts.PlanCache = plancache.NewCache(plancache.NewCacheOptions{
+			Config:     cfg.Drone.PlanCache,
+			Repository: repo,
+			Logger:     logger,
+		})
 	}
 
 	return handler.NewServer(opts)
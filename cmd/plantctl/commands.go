@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/SawitProRecruitment/UserService/client"
+	"github.com/google/uuid"
+)
+
+type command struct {
+	api   *client.PlantationClient
+	out   *printer
+	stdin io.Reader
+}
+
+func (c *command) dispatch(ctx context.Context, args []string, stderr io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	name, args := args[0], args[1:]
+	if (name == "estate" || name == "tree") && len(args) > 0 {
+		name, args = name+" "+args[0], args[1:]
+	}
+
+	flags := flag.NewFlagSet("plantctl "+name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	switch name {
+	case "estate create":
+		width := flags.Int("width", 0, "width of the estate in plots (South-North)")
+		length := flags.Int("length", 0, "length of the estate in plots (West-East)")
+		if err := flags.Parse(args); err != nil {
+			return errUsage
+		}
+		return c.createEstate(ctx, *width, *length)
+	case "estate show":
+		estateID := estateFlag(flags)
+		if err := flags.Parse(args); err != nil {
+			return errUsage
+		}
+		return c.showEstate(ctx, *estateID)
+	case "tree load":
+		estateID := estateFlag(flags)
+		file := flags.String("file", "", `CSV file of x,y,height rows, "-" for stdin`)
+		if err := flags.Parse(args); err != nil || *file == "" {
+			return errUsage
+		}
+		return c.loadTrees(ctx, *estateID, *file)
+	case "stats":
+		estateID := estateFlag(flags)
+		if err := flags.Parse(args); err != nil {
+			return errUsage
+		}
+		return c.stats(ctx, *estateID)
+	case "drone-plan":
+		estateID := estateFlag(flags)
+		maxDistance := flags.Int("max-distance", 0, "battery distance of the drone in meters, 0 for unlimited")
+		if err := flags.Parse(args); err != nil {
+			return errUsage
+		}
+		return c.dronePlan(ctx, *estateID, *maxDistance)
+	}
+
+	return errUsage
+}
+
+// estateFlag register the required -estate flag, uuid.Nil until parsed
+func estateFlag(flags *flag.FlagSet) *uuid.UUID {
+	estateID := new(uuid.UUID)
+	flags.Func("estate", "ID of the estate", func(value string) (err error) {
+		*estateID, err = uuid.Parse(value)
+		return err
+	})
+	return estateID
+}
+
+func requireEstate(estateID uuid.UUID) error {
+	if estateID == uuid.Nil {
+		return fmt.Errorf("-estate is required: %w", errUsage)
+	}
+	return nil
+}
+
+func (c *command) createEstate(ctx context.Context, width, length int) error {
+	id, err := c.api.CreateEstate(ctx, width, length)
+	if err != nil {
+		return err
+	}
+
+	return c.out.print(map[string]any{"id": id, "width": width, "length": length}, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "ID\tWIDTH\tLENGTH")
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", id, width, length)
+	})
+}
+
+func (c *command) showEstate(ctx context.Context, estateID uuid.UUID) error {
+	if err := requireEstate(estateID); err != nil {
+		return err
+	}
+
+	estate, err := c.api.GetEstate(ctx, estateID)
+	if err != nil {
+		return err
+	}
+
+	if c.out.format == formatJSON {
+		return c.out.json(estate)
+	}
+	return renderEstateGrid(c.out.w, estate)
+}
+
+// treeLoadResult is one CSV row, Error is set when the tree was rejected
+type treeLoadResult struct {
+	Line   int        `json:"line"`
+	X      int        `json:"x"`
+	Y      int        `json:"y"`
+	Height int        `json:"height"`
+	Id     *uuid.UUID `json:"id,omitempty"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// loadTrees add the trees one by one, a rejected row does not stop the others
+func (c *command) loadTrees(ctx context.Context, estateID uuid.UUID, file string) error {
+	if err := requireEstate(estateID); err != nil {
+		return err
+	}
+
+	input := c.stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	rows, err := readTreesCSV(input)
+	if err != nil {
+		return err
+	}
+
+	results := make([]treeLoadResult, 0, len(rows))
+	failed := 0
+	for _, row := range rows {
+		result := treeLoadResult{Line: row.line, X: row.x, Y: row.y, Height: row.height}
+		id, err := c.api.AddTree(ctx, estateID, row.x, row.y, row.height)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			result.Error = err.Error()
+			failed++
+		} else {
+			result.Id = &id
+		}
+		results = append(results, result)
+	}
+
+	err = c.out.print(results, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "LINE\tX\tY\tHEIGHT\tRESULT")
+		for _, result := range results {
+			status := result.Error
+			if result.Id != nil {
+				status = result.Id.String()
+			}
+			fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%s\n", result.Line, result.X, result.Y, result.Height, status)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d trees were not added", failed, len(rows))
+	}
+	return nil
+}
+
+func (c *command) stats(ctx context.Context, estateID uuid.UUID) error {
+	if err := requireEstate(estateID); err != nil {
+		return err
+	}
+
+	stats, err := c.api.GetStats(ctx, estateID)
+	if err != nil {
+		return err
+	}
+
+	return c.out.print(stats, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "TREES\tMIN\tMAX\tMEDIAN")
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\n", deref(stats.Count), deref(stats.Min), deref(stats.Max), deref(stats.Median))
+	})
+}
+
+func (c *command) dronePlan(ctx context.Context, estateID uuid.UUID, maxDistance int) error {
+	if err := requireEstate(estateID); err != nil {
+		return err
+	}
+
+	var maxDistanceParam *int
+	if maxDistance > 0 {
+		maxDistanceParam = &maxDistance
+	}
+
+	plan, err := c.api.GetDronePlan(ctx, estateID, maxDistanceParam)
+	if err != nil {
+		return err
+	}
+
+	return c.out.print(plan, func(tw *tabwriter.Writer) {
+		fmt.Fprintln(tw, "DISTANCE\tREST")
+		rest := "-"
+		if plan.Rest != nil {
+			rest = fmt.Sprintf("%d,%d", deref(plan.Rest.X), deref(plan.Rest.Y))
+		}
+		fmt.Fprintf(tw, "%d\t%s\n", deref(plan.Distance), rest)
+	})
+}
+
+func deref[T any](value *T) (res T) {
+	if value != nil {
+		res = *value
+	}
+	return
+}
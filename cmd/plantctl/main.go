@@ -0,0 +1,79 @@
+// plantctl is the command-line tool operating the plantation API.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/SawitProRecruitment/UserService/client"
+)
+
+const usage = `Usage: plantctl [-server URL] [-output table|json] <command> [flags]
+
+Commands:
+  estate create -width W -length L           create an estate
+  estate show -estate ID                     render the estate as an ASCII grid of tree heights
+  tree load -estate ID -file trees.csv       add every tree of a CSV file (x,y,height), "-" read stdin
+  stats -estate ID                           print the estate stats
+  drone-plan -estate ID [-max-distance N]    print the drone plan
+
+The server default to $PLANTCTL_SERVER, then http://localhost:8080.
+`
+
+// errUsage is returned on invalid arguments, the usage is printed & the exit code is 2
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	server := os.Getenv("PLANTCTL_SERVER")
+	if server == "" {
+		server = "http://localhost:8080"
+	}
+
+	flags := flag.NewFlagSet("plantctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	flags.StringVar(&server, "server", server, "base URL of the plantation API")
+	output := flags.String("output", "table", "output format, table or json")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *output != formatTable && *output != formatJSON {
+		fmt.Fprintf(stderr, "unknown output %q, must be table or json\n", *output)
+		return 2
+	}
+
+	api, err := client.NewPlantationClient(server, client.NewPlantationClientOptions{})
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	cmd := &command{
+		api:   api,
+		out:   &printer{w: stdout, format: *output},
+		stdin: stdin,
+	}
+	if err = cmd.dispatch(ctx, flags.Args(), stderr); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(stderr, usage)
+			return 2
+		}
+		fmt.Fprintln(stderr, "error:", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderEstateGrid(t *testing.T) {
+	estateID := uuid.MustParse("6f2b3c1e-0a4d-4f4e-9b1a-2c3d4e5f6a7b")
+	estate := &client.Estate{
+		Id:     estateID,
+		Width:  2,
+		Length: 3,
+		Trees: []client.Tree{
+			{X: 2, Y: 1, Height: 7},
+			{X: 3, Y: 2, Height: 30},
+		},
+	}
+
+	var out bytes.Buffer
+	assert.NoError(t, renderEstateGrid(&out, estate))
+	assert.Equal(t, "Estate 6f2b3c1e-0a4d-4f4e-9b1a-2c3d4e5f6a7b: 3x2 plots, 2 trees\n"+
+		"      1  2  3\n"+
+		"   2  .  . 30\n"+
+		"   1  .  7  .\n", out.String())
+
+	assert.EqualError(t, renderEstateGrid(&out, &client.Estate{Width: 500, Length: 10}),
+		"estate of 10x500 plots is too large to render, max 120 per side")
+}
+
+func TestReadTreesCSV(t *testing.T) {
+	rows, err := readTreesCSV(strings.NewReader("x,y,height\n1,1,5\n2, 1, 10\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []treeRow{{line: 2, x: 1, y: 1, height: 5}, {line: 3, x: 2, y: 1, height: 10}}, rows)
+
+	_, err = readTreesCSV(strings.NewReader("1,1,tall\n"))
+	assert.EqualError(t, err, `line 1: invalid number "tall"`)
+
+	_, err = readTreesCSV(strings.NewReader("x,y,height\n"))
+	assert.EqualError(t, err, "no tree found in the CSV")
+}
+
+func TestRun(t *testing.T) {
+	estateID := uuid.New()
+	var added []map[string]int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/estate/"+estateID.String()+"/tree":
+			var body map[string]int
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["x"] > 3 {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"tree is out of the estate"}`))
+				return
+			}
+			added = append(added, body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"` + uuid.NewString() + `"}`))
+		case r.URL.Path == "/estate/"+estateID.String()+"/drone-plan":
+			assert.Equal(t, "40", r.URL.Query().Get("max_distance"))
+			_, _ = w.Write([]byte(`{"distance":40,"rest":{"x":3,"y":1}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Load Trees", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		stdin := strings.NewReader("x,y,height\n1,1,5\n9,1,5\n")
+
+		code := run(context.Background(), []string{"-server", server.URL, "-output", "json", "tree", "load", "-estate", estateID.String(), "-file", "-"}, stdin, &stdout, &stderr)
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, []map[string]int{{"x": 1, "y": 1, "height": 5}}, added)
+		assert.Contains(t, stderr.String(), "1 of 2 trees were not added")
+
+		var results []treeLoadResult
+		assert.NoError(t, json.Unmarshal(stdout.Bytes(), &results))
+		assert.Len(t, results, 2)
+		assert.NotNil(t, results[0].Id)
+		assert.Equal(t, "plantation api: 400 tree is out of the estate", results[1].Error)
+	})
+
+	t.Run("Drone Plan Table", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"-server", server.URL, "drone-plan", "-estate", estateID.String(), "-max-distance", "40"}, nil, &stdout, &stderr)
+
+		assert.Equal(t, 0, code, stderr.String())
+		assert.Equal(t, "DISTANCE  REST\n40        3,1\n", stdout.String())
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := run(context.Background(), []string{"-server", server.URL, "stats", "-estate", uuid.NewString()}, nil, &stdout, &stderr)
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "error: plantation api: 404 not found\n", stderr.String())
+	})
+
+	t.Run("Usage", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		assert.Equal(t, 2, run(context.Background(), []string{"estate"}, nil, &stdout, &stderr))
+		assert.Equal(t, 2, run(context.Background(), []string{"stats"}, nil, &stdout, &stderr))
+		assert.Contains(t, stderr.String(), "Usage: plantctl")
+	})
+}
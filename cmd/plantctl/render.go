@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/SawitProRecruitment/UserService/client"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+
+	// maxGridSize keep the ASCII grid readable in a terminal
+	maxGridSize = 120
+)
+
+// printer write a value as JSON, or as the table written by the given func
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func (p *printer) print(value any, table func(tw *tabwriter.Writer)) error {
+	if p.format == formatJSON {
+		return p.json(value)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	table(tw)
+	return tw.Flush()
+}
+
+func (p *printer) json(value any) error {
+	encoder := json.NewEncoder(p.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// renderEstateGrid draw one cell per plot with the tree height, "." for an empty plot.
+// North (highest y) is on top and West (x = 1) on the left
+func renderEstateGrid(w io.Writer, estate *client.Estate) error {
+	if estate.Width > maxGridSize || estate.Length > maxGridSize {
+		return fmt.Errorf("estate of %dx%d plots is too large to render, max %d per side", estate.Length, estate.Width, maxGridSize)
+	}
+
+	heights := make(map[[2]int]int, len(estate.Trees))
+	for _, tree := range estate.Trees {
+		heights[[2]int{tree.X, tree.Y}] = tree.Height
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Estate %s: %dx%d plots, %d trees\n", estate.Id, estate.Length, estate.Width, len(estate.Trees))
+
+	sb.WriteString("    ")
+	for x := 1; x <= estate.Length; x++ {
+		fmt.Fprintf(&sb, "%3d", x)
+	}
+	sb.WriteString("\n")
+
+	for y := estate.Width; y >= 1; y-- {
+		fmt.Fprintf(&sb, "%4d", y)
+		for x := 1; x <= estate.Length; x++ {
+			cell := "."
+			if height, ok := heights[[2]int{x, y}]; ok {
+				cell = strconv.Itoa(height)
+			}
+			fmt.Fprintf(&sb, "%3s", cell)
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+type treeRow struct {
+	line   int
+	x, y   int
+	height int
+}
+
+// readTreesCSV parse x,y,height rows, a first row starting with "x" is read as the header
+func readTreesCSV(r io.Reader) ([]treeRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	var rows []treeRow
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if line == 1 && strings.EqualFold(record[0], "x") {
+			continue
+		}
+
+		values := make([]int, len(record))
+		for i, field := range record {
+			if values[i], err = strconv.Atoi(field); err != nil {
+				return nil, fmt.Errorf("line %d: invalid number %q", line, field)
+			}
+		}
+		rows = append(rows, treeRow{line: line, x: values[0], y: values[1], height: values[2]})
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("no tree found in the CSV")
+	}
+	return rows, nil
+}
@@ -2,23 +2,180 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	App      App
-	Database Database
+	App       App
+	Database  Database
+	Webhook   Webhook
+	Events    Events
+	Telemetry Telemetry
+	Log       Log
+	Drone     Drone
+	Jobs      Jobs
+	Cache     Cache
 }
 
 type App struct {
-	Port int
+	Port              int
+	ShutdownTimeout   time.Duration // time given to in-flight requests to finish on SIGTERM
+	ValidateResponses bool          // dev & test only, answer 500 when a response does not match api.yml
+	MaxBundleSize     int           // bytes of an imported estate bundle, compressed & decompressed
+	TrustedProxies    []*net.IPNet  // proxies whose X-Forwarded-For is trusted, empty use the peer address
 }
 type Database struct {
-	PostgreDSN string
+	PostgreDSN      string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+}
+
+type Webhook struct {
+	DispatchInterval time.Duration // how often the dispatcher polls the outbox
+	BatchSize        int           // max deliveries claimed per poll
+	MaxAttempts      int           // attempts before a delivery is moved to dead-letter
+	BaseBackoff      time.Duration // first retry delay, doubled on every attempt
+	MaxBackoff       time.Duration
+	RequestTimeout   time.Duration
+}
+
+type Jobs struct {
+	Workers      int           // jobs run concurrently by every replica
+	PollInterval time.Duration // how often an idle worker polls the queue
+	Lease        time.Duration // a running job without heartbeat for this long is picked again
+	MaxAttempts  int           // attempts before a job is marked failed
+	BaseBackoff  time.Duration // first retry delay, doubled on every attempt
+	MaxBackoff   time.Duration
+}
+
+// Cache is the read-through cache of the estates in front of postgres, kept by every replica
+type Cache struct {
+	Enabled bool
+	Size    int           // estates kept in memory, the least recently used is evicted
+	TTL     time.Duration // an estate is read again from postgres after this long
+}
+
+type Events struct {
+	HeartbeatInterval time.Duration // comment line sent to SSE clients to keep the connection open
+	BufferSize        int           // events buffered per SSE client before they are dropped
+}
+
+type Telemetry struct {
+	ServiceName        string
+	TracingExporter    string  // none, stdout or otlp
+	TracingSampleRatio float64 // fraction of root spans sampled, 0 to 1
+}
+
+type Log struct {
+	Level  string // debug, info, warn or error
+	Format string // json or text
+}
+
+type Drone struct {
+	ExclusionMode string // fly_over or skip, what the planner does with the excluded plots
+	Profile       DroneProfile
+	PlanCache     PlanCache
+}
+
+// PlanCache is the cache of the computed drone plans, keyed by estate version & plan parameters
+type PlanCache struct {
+	Size    int  // plans kept in memory by every replica, 0 disables the in-process cache
+	Persist bool // also save the plans in postgres, shared by the replicas & kept across restarts
+}
+
+// DroneProfile is how the drone flies, every distance is in meters
+type DroneProfile struct {
+	PlotSize          int     // distance between the centers of two neighbour plots
+	Clearance         int     // height kept above trees & obstacles
+	TakeoffAltitude   int     // altitude reached right after take-off
+	CruiseMinAltitude int     // the drone never flies lower until it lands
+	AscentCost        float64 // distance charged per meter climbed
+	DescentCost       float64 // distance charged per meter descended
+	Energy            DroneEnergy
+}
+
+// DroneEnergy is the model estimating the flight time & energy, speeds in m/s and powers in watts
+type DroneEnergy struct {
+	Speed        float64 // horizontal speed
+	ClimbRate    float64 // vertical speed going up
+	DescentRate  float64 // vertical speed going down
+	CruisePower  float64 // drawn while flying horizontally
+	ClimbPower   float64 // drawn while climbing
+	DescentPower float64 // drawn while descending
+}
+
+// DefaultDroneProfile is the historical drone: 10m plots, 1m above the trees, climbing costs as much as descending
+func DefaultDroneProfile() DroneProfile {
+	return DroneProfile{
+		PlotSize:          10,
+		Clearance:         1,
+		TakeoffAltitude:   1,
+		CruiseMinAltitude: 1,
+		AscentCost:        1,
+		DescentCost:       1,
+		Energy:            DefaultDroneEnergy(),
+	}
+}
+
+// DefaultDroneEnergy is a small quadcopter: climbing draws twice the power of level flight
+func DefaultDroneEnergy() DroneEnergy {
+	return DroneEnergy{
+		Speed:        10,
+		ClimbRate:    3,
+		DescentRate:  2,
+		CruisePower:  200,
+		ClimbPower:   400,
+		DescentPower: 150,
+	}
+}
+
+// String identify the profile, stored next to the pre calculated drone distance
+func (p DroneProfile) String() string {
+	return fmt.Sprintf("plot=%d,clearance=%d,takeoff=%d,cruise=%d,ascent=%g,descent=%g,%s",
+		p.PlotSize, p.Clearance, p.TakeoffAltitude, p.CruiseMinAltitude, p.AscentCost, p.DescentCost, p.Energy)
+}
+
+// String identify the energy model, part of the profile string
+func (e DroneEnergy) String() string {
+	return fmt.Sprintf("speed=%g/%g/%g,power=%g/%g/%g",
+		e.Speed, e.ClimbRate, e.DescentRate, e.CruisePower, e.ClimbPower, e.DescentPower)
+}
+
+// Validate report the first value the planner can't fly with
+func (p DroneProfile) Validate() error {
+	switch {
+	case p.PlotSize < 1:
+		return fmt.Errorf("plot size %d is lower than 1", p.PlotSize)
+	case p.Clearance < 0:
+		return fmt.Errorf("clearance %d is negative", p.Clearance)
+	case p.TakeoffAltitude < 1:
+		return fmt.Errorf("takeoff altitude %d is lower than 1", p.TakeoffAltitude)
+	case p.CruiseMinAltitude < 1:
+		return fmt.Errorf("cruise min altitude %d is lower than 1", p.CruiseMinAltitude)
+	case p.AscentCost <= 0 || p.DescentCost <= 0:
+		return fmt.Errorf("ascent cost %g & descent cost %g must be positive", p.AscentCost, p.DescentCost)
+	}
+	return p.Energy.Validate()
+}
+
+// Validate report the first value the energy model can't estimate with
+func (e DroneEnergy) Validate() error {
+	switch {
+	case e.Speed <= 0 || e.ClimbRate <= 0 || e.DescentRate <= 0:
+		return fmt.Errorf("speed %g, climb rate %g & descent rate %g must be positive", e.Speed, e.ClimbRate, e.DescentRate)
+	case e.CruisePower < 0 || e.ClimbPower < 0 || e.DescentPower < 0:
+		return fmt.Errorf("cruise power %g, climb power %g & descent power %g can't be negative", e.CruisePower, e.ClimbPower, e.DescentPower)
+	}
+	return nil
 }
 
 var (
@@ -43,9 +200,165 @@ func LoadConfig() *Config {
 			panic(fmt.Errorf("invalid APP_PORT in .env: %w", err))
 		}
 		cfg.App.Port = port
+		cfg.App.ShutdownTimeout = getEnvDuration("APP_SHUTDOWN_TIMEOUT", 15*time.Second)
+		cfg.App.ValidateResponses = getEnvBool("APP_VALIDATE_RESPONSES", false)
+		cfg.App.MaxBundleSize = getEnvInt("APP_MAX_BUNDLE_SIZE", 256<<20)
+		if cfg.App.MaxBundleSize < 1 {
+			panic(fmt.Errorf("invalid APP_MAX_BUNDLE_SIZE in .env: %d must be positive", cfg.App.MaxBundleSize))
+		}
+		cfg.App.TrustedProxies = getEnvCIDRs("APP_TRUSTED_PROXIES")
 
 		cfg.Database.PostgreDSN = os.Getenv("DATABASE_URL")
+		cfg.Database.MaxOpenConns = getEnvInt("DATABASE_MAX_OPEN_CONNS", 25)
+		cfg.Database.MaxIdleConns = getEnvInt("DATABASE_MAX_IDLE_CONNS", 25)
+		cfg.Database.ConnMaxLifetime = getEnvDuration("DATABASE_CONN_MAX_LIFETIME", 30*time.Minute)
+		cfg.Database.ConnMaxIdleTime = getEnvDuration("DATABASE_CONN_MAX_IDLE_TIME", 5*time.Minute)
+
+		cfg.Webhook.DispatchInterval = getEnvDuration("WEBHOOK_DISPATCH_INTERVAL", 2*time.Second)
+		cfg.Webhook.BatchSize = getEnvInt("WEBHOOK_BATCH_SIZE", 50)
+		cfg.Webhook.MaxAttempts = getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8)
+		cfg.Webhook.BaseBackoff = getEnvDuration("WEBHOOK_BASE_BACKOFF", 5*time.Second)
+		cfg.Webhook.MaxBackoff = getEnvDuration("WEBHOOK_MAX_BACKOFF", time.Hour)
+		cfg.Webhook.RequestTimeout = getEnvDuration("WEBHOOK_REQUEST_TIMEOUT", 10*time.Second)
+
+		cfg.Jobs.Workers = getEnvInt("JOBS_WORKERS", 2)
+		cfg.Jobs.PollInterval = getEnvDuration("JOBS_POLL_INTERVAL", time.Second)
+		cfg.Jobs.Lease = getEnvDuration("JOBS_LEASE", 30*time.Second)
+		cfg.Jobs.MaxAttempts = getEnvInt("JOBS_MAX_ATTEMPTS", 3)
+		cfg.Jobs.BaseBackoff = getEnvDuration("JOBS_BASE_BACKOFF", 5*time.Second)
+		cfg.Jobs.MaxBackoff = getEnvDuration("JOBS_MAX_BACKOFF", 5*time.Minute)
+
+		cfg.Cache.Enabled = getEnvBool("REPOSITORY_CACHE_ENABLED", false)
+		cfg.Cache.Size = getEnvInt("REPOSITORY_CACHE_SIZE", 1000)
+		cfg.Cache.TTL = getEnvDuration("REPOSITORY_CACHE_TTL", 30*time.Second)
+		if cfg.Cache.Enabled && (cfg.Cache.Size < 1 || cfg.Cache.TTL <= 0) {
+			panic(fmt.Errorf("invalid REPOSITORY_CACHE_SIZE or REPOSITORY_CACHE_TTL in .env: size %d & ttl %s must be positive", cfg.Cache.Size, cfg.Cache.TTL))
+		}
+
+		cfg.Events.HeartbeatInterval = getEnvDuration("EVENTS_HEARTBEAT_INTERVAL", 15*time.Second)
+		cfg.Events.BufferSize = getEnvInt("EVENTS_BUFFER_SIZE", 64)
+
+		cfg.Telemetry.ServiceName = getEnvString("OTEL_SERVICE_NAME", "plantation-api")
+		cfg.Telemetry.TracingExporter = getEnvString("TRACING_EXPORTER", "none")
+		cfg.Telemetry.TracingSampleRatio = getEnvFloat("TRACING_SAMPLE_RATIO", 1)
+
+		cfg.Log.Level = getEnvString("LOG_LEVEL", "info")
+		cfg.Log.Format = getEnvString("LOG_FORMAT", "json")
+
+		cfg.Drone.ExclusionMode = getEnvString("DRONE_EXCLUSION_MODE", "fly_over")
+		if cfg.Drone.ExclusionMode != "fly_over" && cfg.Drone.ExclusionMode != "skip" {
+			panic(fmt.Errorf("invalid DRONE_EXCLUSION_MODE in .env: %q is not fly_over or skip", cfg.Drone.ExclusionMode))
+		}
+
+		defaultProfile := DefaultDroneProfile()
+		cfg.Drone.Profile = DroneProfile{
+			PlotSize:          getEnvInt("DRONE_PLOT_SIZE", defaultProfile.PlotSize),
+			Clearance:         getEnvInt("DRONE_CLEARANCE", defaultProfile.Clearance),
+			TakeoffAltitude:   getEnvInt("DRONE_TAKEOFF_ALTITUDE", defaultProfile.TakeoffAltitude),
+			CruiseMinAltitude: getEnvInt("DRONE_CRUISE_MIN_ALTITUDE", defaultProfile.CruiseMinAltitude),
+			AscentCost:        getEnvFloat("DRONE_ASCENT_COST", defaultProfile.AscentCost),
+			DescentCost:       getEnvFloat("DRONE_DESCENT_COST", defaultProfile.DescentCost),
+			Energy: DroneEnergy{
+				Speed:        getEnvFloat("DRONE_SPEED", defaultProfile.Energy.Speed),
+				ClimbRate:    getEnvFloat("DRONE_CLIMB_RATE", defaultProfile.Energy.ClimbRate),
+				DescentRate:  getEnvFloat("DRONE_DESCENT_RATE", defaultProfile.Energy.DescentRate),
+				CruisePower:  getEnvFloat("DRONE_CRUISE_POWER", defaultProfile.Energy.CruisePower),
+				ClimbPower:   getEnvFloat("DRONE_CLIMB_POWER", defaultProfile.Energy.ClimbPower),
+				DescentPower: getEnvFloat("DRONE_DESCENT_POWER", defaultProfile.Energy.DescentPower),
+			},
+		}
+		if err := cfg.Drone.Profile.Validate(); err != nil {
+			panic(fmt.Errorf("invalid drone profile in .env: %w", err))
+		}
+
+		cfg.Drone.PlanCache.Size = getEnvInt("DRONE_PLAN_CACHE_SIZE", 1000)
+		if cfg.Drone.PlanCache.Size < 0 {
+			panic(fmt.Errorf("invalid DRONE_PLAN_CACHE_SIZE in .env: %d is negative", cfg.Drone.PlanCache.Size))
+		}
+		cfg.Drone.PlanCache.Persist = getEnvBool("DRONE_PLAN_CACHE_PERSIST", false)
 	})
 
 	return cfg
 }
+
+// getEnvString read optional env, fallback to default value when empty
+func getEnvString(key string, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvInt read optional integer env, fallback to default value when empty
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	res, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Errorf("invalid %s in .env: %w", key, err))
+	}
+	return res
+}
+
+// getEnvBool read optional boolean env (e.g. "true", "1"), fallback to default value when empty
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	res, err := strconv.ParseBool(value)
+	if err != nil {
+		panic(fmt.Errorf("invalid %s in .env: %w", key, err))
+	}
+	return res
+}
+
+// getEnvDuration read optional duration env (e.g. "5s", "1m"), fallback to default value when empty
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	res, err := time.ParseDuration(value)
+	if err != nil {
+		panic(fmt.Errorf("invalid %s in .env: %w", key, err))
+	}
+	return res
+}
+
+// getEnvCIDRs read optional comma separated CIDR list env (e.g. "10.0.0.0/8,192.168.1.1/32"), nil when empty
+func getEnvCIDRs(key string) []*net.IPNet {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	var res []*net.IPNet
+	for _, cidr := range strings.Split(value, ",") {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			panic(fmt.Errorf("invalid %s in .env: %w", key, err))
+		}
+		res = append(res, network)
+	}
+	return res
+}
+
+// getEnvFloat read optional decimal env, fallback to default value when empty
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	res, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		panic(fmt.Errorf("invalid %s in .env: %w", key, err))
+	}
+	return res
+}
@@ -10,10 +10,26 @@ CREATE TABLE IF NOT EXISTS estates (
     id UUID PRIMARY KEY,
     width INT NOT NULL CHECK (width > 0 AND width <= 50000),
     length INT NOT NULL CHECK (length > 0 AND length <= 50000),
+    version BIGINT NOT NULL DEFAULT 1, -- bumped by every change of the estate, its trees, exclusion zones or obstacles
+    origin_lat DOUBLE PRECISION DEFAULT NULL CHECK (origin_lat BETWEEN -90 AND 90), -- WGS84 South-West corner, NULL when not geo-referenced
+    origin_lon DOUBLE PRECISION DEFAULT NULL CHECK (origin_lon BETWEEN -180 AND 180),
+    rotation DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (rotation >= 0 AND rotation < 360), -- degrees clockwise from true North of the South-North axis
 	created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL -- soft delete, hidden from every read until restored
 );
 
+-- estates created before soft delete was introduced
+ALTER TABLE estates ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+-- estates created before the drone plan cache
+ALTER TABLE estates ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+-- estates created before geo-referencing
+ALTER TABLE estates ADD COLUMN IF NOT EXISTS origin_lat DOUBLE PRECISION DEFAULT NULL CHECK (origin_lat BETWEEN -90 AND 90);
+ALTER TABLE estates ADD COLUMN IF NOT EXISTS origin_lon DOUBLE PRECISION DEFAULT NULL CHECK (origin_lon BETWEEN -180 AND 180);
+ALTER TABLE estates ADD COLUMN IF NOT EXISTS rotation DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (rotation >= 0 AND rotation < 360);
+
 -- Table: trees
 CREATE TABLE IF NOT EXISTS trees (
     id UUID PRIMARY KEY,
@@ -21,10 +37,20 @@ CREATE TABLE IF NOT EXISTS trees (
     x INT NOT NULL CHECK (x > 0),
     y INT NOT NULL CHECK (y > 0),
     height INT NOT NULL CHECK (height >= 1 AND height <= 30),
+    species VARCHAR(100) NOT NULL DEFAULT '', -- oil palm variety, empty when unknown
+    planted_at DATE DEFAULT NULL,
+    health VARCHAR(16) NOT NULL DEFAULT 'healthy' CHECK (health IN ('healthy', 'diseased', 'dead')),
+    tags TEXT[] NOT NULL DEFAULT '{}',
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
 );
 
+-- trees planted before the tree attributes
+ALTER TABLE trees ADD COLUMN IF NOT EXISTS species VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE trees ADD COLUMN IF NOT EXISTS planted_at DATE DEFAULT NULL;
+ALTER TABLE trees ADD COLUMN IF NOT EXISTS health VARCHAR(16) NOT NULL DEFAULT 'healthy' CHECK (health IN ('healthy', 'diseased', 'dead'));
+ALTER TABLE trees ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
 -- Table: estate_stats
 CREATE TABLE IF NOT EXISTS estate_stats (
     id UUID PRIMARY KEY,
@@ -34,9 +60,191 @@ CREATE TABLE IF NOT EXISTS estate_stats (
     min_height INT NOT NULL DEFAULT 0,
     median_height INT NOT NULL DEFAULT 0,
 	drone_distance BIGINT NOT NULL DEFAULT 0,
+    drone_profile VARCHAR(255) NOT NULL DEFAULT '', -- DroneProfile the drone_distance was calculated with
+    drone_duration BIGINT NOT NULL DEFAULT 0, -- flight time in seconds
+    drone_energy DOUBLE PRECISION NOT NULL DEFAULT 0, -- energy in watt-hours
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
 );
 
+-- stats calculated before the drone profile was configurable
+ALTER TABLE estate_stats ADD COLUMN IF NOT EXISTS drone_profile VARCHAR(128) NOT NULL DEFAULT '';
+
+-- stats calculated before the flight time & energy estimation, the profile now includes the energy model
+ALTER TABLE estate_stats ALTER COLUMN drone_profile TYPE VARCHAR(255);
+ALTER TABLE estate_stats ADD COLUMN IF NOT EXISTS drone_duration BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE estate_stats ADD COLUMN IF NOT EXISTS drone_energy DOUBLE PRECISION NOT NULL DEFAULT 0;
+
 -- Index for trees table
-CREATE INDEX IF NOT EXISTS idx_trees_estate_id ON trees(estate_id);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_trees_estate_id ON trees(estate_id);
+
+-- Table: outbox_events
+-- written in the same transaction as the change it describes, fanned out to webhook_deliveries by the dispatcher
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id UUID PRIMARY KEY,
+    estate_id UUID NOT NULL REFERENCES estates(id) ON DELETE CASCADE,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    dispatched_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+-- Table: webhook_subscribers
+CREATE TABLE IF NOT EXISTS webhook_subscribers (
+    id UUID PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+-- Table: webhook_deliveries
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY,
+    event_id UUID NOT NULL REFERENCES outbox_events(id) ON DELETE CASCADE,
+    subscriber_id UUID NOT NULL REFERENCES webhook_subscribers(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    UNIQUE (event_id, subscriber_id)
+);
+
+-- Index for outbox & webhook tables
+CREATE INDEX IF NOT EXISTS idx_outbox_events_undispatched ON outbox_events(created_at) WHERE dispatched_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries(status, updated_at);
+
+-- Table: exclusion_zones
+-- areas of an estate where trees can't be planted (river, road, building), points are plot coordinates
+CREATE TABLE IF NOT EXISTS exclusion_zones (
+    id UUID PRIMARY KEY,
+    estate_id UUID NOT NULL REFERENCES estates(id) ON DELETE CASCADE,
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    kind VARCHAR(16) NOT NULL CHECK (kind IN ('plots', 'polygon')),
+    points JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_exclusion_zones_estate_id ON exclusion_zones(estate_id);
+
+-- Table: obstacles
+-- structures the drone must clear (tower, power line) at height meters, a NULL height is a no-fly plot
+CREATE TABLE IF NOT EXISTS obstacles (
+    id UUID PRIMARY KEY,
+    estate_id UUID NOT NULL REFERENCES estates(id) ON DELETE CASCADE,
+    x INT NOT NULL CHECK (x > 0),
+    y INT NOT NULL CHECK (y > 0),
+    height INT DEFAULT NULL CHECK (height > 0),
+    label VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (estate_id, x, y)
+);
+
+-- Table: harvests
+-- fresh fruit bunches harvested from a tree or from a region of plots, plots are plot coordinates.
+-- A tree harvest keep the plot of the tree so the yield is still reported when the tree is removed.
+-- tree_id has no foreign key so it is kept once the tree is removed, and the harvest is not taken for a region one
+CREATE TABLE IF NOT EXISTS harvests (
+    id UUID PRIMARY KEY,
+    estate_id UUID NOT NULL REFERENCES estates(id) ON DELETE CASCADE,
+    tree_id UUID DEFAULT NULL,
+    plots JSONB NOT NULL,
+    harvested_at DATE NOT NULL,
+    weight_kg DOUBLE PRECISION NOT NULL CHECK (weight_kg > 0),
+    bunch_count INT NOT NULL CHECK (bunch_count >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- the foreign key of the databases created before set tree_id to NULL when the tree was removed
+ALTER TABLE harvests DROP CONSTRAINT IF EXISTS harvests_tree_id_fkey;
+
+CREATE INDEX IF NOT EXISTS idx_harvests_estate_id_harvested_at ON harvests(estate_id, harvested_at);
+
+-- Table: jobs
+-- heavy estate computations run in background by the job workers, claimed with FOR UPDATE SKIP LOCKED.
+-- estate_id has no foreign key as an import job creates its estate
+CREATE TABLE IF NOT EXISTS jobs (
+    id UUID PRIMARY KEY,
+    job_type VARCHAR(32) NOT NULL CHECK (job_type IN ('drone_plan', 'recompute_stats', 'import', 'export', 'rebuild_tiles')),
+    estate_id UUID NOT NULL,
+    params JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(16) NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'succeeded', 'failed', 'cancelled')),
+    progress DOUBLE PRECISION NOT NULL DEFAULT 0,
+    result JSONB DEFAULT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 1,
+    last_error TEXT DEFAULT NULL,
+    cancel_requested BOOLEAN NOT NULL DEFAULT FALSE,
+    run_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NULL
+);
+
+-- jobs created before the rebuild_tiles job type
+ALTER TABLE jobs DROP CONSTRAINT IF EXISTS jobs_job_type_check;
+ALTER TABLE jobs ADD CONSTRAINT jobs_job_type_check CHECK (job_type IN ('drone_plan', 'recompute_stats', 'import', 'export', 'rebuild_tiles'));
+
+CREATE INDEX IF NOT EXISTS idx_jobs_due ON jobs(run_at) WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS idx_jobs_running ON jobs(locked_until) WHERE status = 'running';
+
+-- Table: drone_plan_cache
+-- drone plans computed for a version of the estate, a plan of an older version is never served
+CREATE TABLE IF NOT EXISTS drone_plan_cache (
+    estate_id UUID NOT NULL REFERENCES estates(id) ON DELETE CASCADE,
+    params VARCHAR(512) NOT NULL, -- the resolved plan parameters, drone profile included
+    estate_version BIGINT NOT NULL,
+    plan JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (estate_id, params)
+);
+
+-- Table: tile_cells
+-- trees aggregated by square cells of plots for the map tiles, one level per zoom. A cell at zoom z is
+-- 2^(max zoom - z) plots a side and only cells holding trees are kept. The max zoom, where a cell is a plot,
+-- is read from trees. x & y are the cell column & row from the South-West corner, starting at 0
+CREATE TABLE IF NOT EXISTS tile_cells (
+    estate_id UUID NOT NULL REFERENCES estates(id) ON DELETE CASCADE,
+    zoom INT NOT NULL CHECK (zoom >= 0),
+    x INT NOT NULL CHECK (x >= 0),
+    y INT NOT NULL CHECK (y >= 0),
+    tree_count INT NOT NULL,
+    sum_height BIGINT NOT NULL,
+    max_height INT NOT NULL,
+    PRIMARY KEY (estate_id, zoom, x, y)
+);
+
+-- Table: audit_entries
+-- append-only record of every change of an estate data, written in the same transaction as the change.
+-- estate_id has no foreign key so the entries outlive the estate, before & after are NULL when the
+-- entity did not exist before or does not exist after the change
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id UUID PRIMARY KEY,
+    estate_id UUID NOT NULL,
+    action VARCHAR(64) NOT NULL,
+    entity_id UUID NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255) DEFAULT NULL,
+    source_ip VARCHAR(64) DEFAULT NULL,
+    before JSONB DEFAULT NULL,
+    after JSONB DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_entries_estate_id_created_at ON audit_entries(estate_id, created_at);
+
+-- the audit log is append-only, even for the service database user
+CREATE OR REPLACE FUNCTION reject_audit_entry_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_entries_append_only ON audit_entries;
+CREATE TRIGGER audit_entries_append_only BEFORE UPDATE OR DELETE ON audit_entries
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_entry_change();
@@ -0,0 +1,103 @@
+// This file contains the in-process pub/sub used to stream estate events to SSE clients.
+package events
+
+import (
+	"sync"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/google/uuid"
+)
+
+type subscriber struct {
+	ch chan repository.EventEnvelope
+}
+
+// Broker fan out estate events to every subscriber of the estate, safe for concurrent use
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[uuid.UUID]map[*subscriber]struct{}
+	bufferSize  int
+	closed      bool
+}
+
+func NewBroker(bufferSize int) *Broker {
+	return &Broker{
+		subscribers: make(map[uuid.UUID]map[*subscriber]struct{}),
+		bufferSize:  bufferSize,
+	}
+}
+
+// Subscribe return a channel receiving the events of the estate,
+// the returned func must be called to release the subscription
+func (b *Broker) Subscribe(estateID uuid.UUID) (<-chan repository.EventEnvelope, func()) {
+	sub := &subscriber{ch: make(chan repository.EventEnvelope, b.bufferSize)}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	if b.subscribers[estateID] == nil {
+		b.subscribers[estateID] = make(map[*subscriber]struct{})
+	}
+	b.subscribers[estateID][sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			// the channel is already closed when the broker was closed first
+			if _, ok := b.subscribers[estateID][sub]; !ok {
+				return
+			}
+			delete(b.subscribers[estateID], sub)
+			if len(b.subscribers[estateID]) == 0 {
+				delete(b.subscribers, estateID)
+			}
+			close(sub.ch)
+		})
+	}
+
+	return sub.ch, unsubscribe
+}
+
+// Publish send the event to subscribers of its estate without blocking,
+// a subscriber whose buffer is full miss the event
+func (b *Broker) Publish(event repository.EventEnvelope) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers[event.EstateId] {
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+}
+
+// SubscriberCount return number of active subscribers of the estate
+func (b *Broker) SubscriberCount(estateID uuid.UUID) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subscribers[estateID])
+}
+
+// Close end every subscription, so the open SSE streams return and the server can shut down.
+// Subscribe after Close return a closed channel
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for estateID, subs := range b.subscribers {
+		for sub := range subs {
+			close(sub.ch)
+		}
+		delete(b.subscribers, estateID)
+	}
+}
@@ -0,0 +1,77 @@
+package events
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker(t *testing.T) {
+	broker := NewBroker(1)
+	estateID, otherEstateID := uuid.New(), uuid.New()
+
+	events, unsubscribe := broker.Subscribe(estateID)
+	otherEvents, unsubscribeOther := broker.Subscribe(otherEstateID)
+	defer unsubscribeOther()
+	assert.Equal(t, 1, broker.SubscriberCount(estateID))
+
+	event := repository.EventEnvelope{Id: uuid.New(), Type: repository.EVENT_TREE_ADDED, EstateId: estateID}
+	broker.Publish(event)
+	assert.Equal(t, event, <-events)
+	assert.Len(t, otherEvents, 0, "subscriber of other estate must not receive the event")
+
+	// full buffer must not block the publisher
+	broker.Publish(event)
+	broker.Publish(event)
+	assert.Len(t, events, 1)
+
+	unsubscribe()
+	unsubscribe() // safe to call twice
+	assert.Equal(t, 0, broker.SubscriberCount(estateID))
+	<-events
+	_, ok := <-events
+	assert.False(t, ok, "channel is closed after unsubscribe")
+}
+
+func TestBroker_Concurrent(t *testing.T) {
+	broker := NewBroker(100)
+	estateID := uuid.New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, unsubscribe := broker.Subscribe(estateID)
+			unsubscribe()
+		}()
+		go func() {
+			defer wg.Done()
+			broker.Publish(repository.EventEnvelope{EstateId: estateID})
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 0, broker.SubscriberCount(estateID))
+}
+
+func TestBroker_Close(t *testing.T) {
+	broker := NewBroker(1)
+	estateID := uuid.New()
+
+	events, unsubscribe := broker.Subscribe(estateID)
+	broker.Close()
+
+	_, ok := <-events
+	assert.False(t, ok, "channel is closed when the broker is closed")
+	assert.Equal(t, 0, broker.SubscriberCount(estateID))
+	unsubscribe() // must not close the channel twice
+
+	late, unsubscribeLate := broker.Subscribe(estateID)
+	defer unsubscribeLate()
+	_, ok = <-late
+	assert.False(t, ok, "subscribe after close return a closed channel")
+}
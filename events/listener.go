@@ -0,0 +1,103 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/lib/pq"
+)
+
+// PostgresListener LISTEN on the estate events channel and publish every notified event to the broker,
+// so all replicas stream the events committed by any of them
+type PostgresListener struct {
+	Dsn        string
+	Repository repository.RepositoryInterface
+	Broker     *Broker
+	Logger     *slog.Logger
+	// OnEvent is called with every notification, nil when nothing else follows the events
+	OnEvent func(event repository.EventNotification)
+}
+
+type NewPostgresListenerOptions struct {
+	Dsn        string
+	Repository repository.RepositoryInterface // load the notified events from the outbox
+	Broker     *Broker
+	Logger     *slog.Logger // optional, default to slog.Default()
+	// OnEvent is optional, e.g. to drop the estate cached by this replica when any replica changed it
+	OnEvent func(event repository.EventNotification)
+}
+
+func NewPostgresListener(opts NewPostgresListenerOptions) *PostgresListener {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &PostgresListener{
+		Dsn:        opts.Dsn,
+		Repository: opts.Repository,
+		Broker:     opts.Broker,
+		Logger:     logger,
+		OnEvent:    opts.OnEvent,
+	}
+}
+
+// Run block until ctx is done, pq.Listener reconnect by itself when the connection is lost
+func (l *PostgresListener) Run(ctx context.Context) error {
+	listener := pq.NewListener(l.Dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			l.Logger.WarnContext(ctx, "estate events listener connection", slog.String("error", err.Error()))
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(repository.ESTATE_EVENTS_CHANNEL); err != nil {
+		return fmt.Errorf("failed to listen %s: %w", repository.ESTATE_EVENTS_CHANNEL, err)
+	}
+
+	ping := time.NewTicker(time.Minute)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			// nil notification is sent after reconnect, events in between are lost
+			if notification == nil {
+				continue
+			}
+			if err := l.handleNotification(ctx, notification.Extra); err != nil {
+				l.Logger.ErrorContext(ctx, "failed to handle estate event notification", slog.String("error", err.Error()))
+			}
+		case <-ping.C:
+			go listener.Ping()
+		}
+	}
+}
+
+// handleNotification load the notified event from the outbox, only when this replica stream the estate
+func (l *PostgresListener) handleNotification(ctx context.Context, payload string) error {
+	var notification repository.EventNotification
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		return fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+
+	if l.OnEvent != nil {
+		l.OnEvent(notification)
+	}
+	if l.Broker.SubscriberCount(notification.EstateId) == 0 {
+		return nil
+	}
+
+	event, err := l.Repository.GetOutboxEvent(ctx, notification.Id)
+	if err != nil {
+		return fmt.Errorf("failed to load event %s: %w", notification.Id, err)
+	}
+	l.Broker.Publish(event.Envelope())
+	return nil
+}
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestHandleNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	broker := NewBroker(1)
+	estateID, otherEstateID := uuid.New(), uuid.New()
+	var received []uuid.UUID
+	listener := NewPostgresListener(NewPostgresListenerOptions{
+		Repository: mockRepo,
+		Broker:     broker,
+		OnEvent:    func(event repository.EventNotification) { received = append(received, event.EstateId) },
+	})
+
+	events, unsubscribe := broker.Subscribe(estateID)
+	defer unsubscribe()
+
+	t.Run("Event Loaded From The Outbox", func(t *testing.T) {
+		eventID := uuid.New()
+		outboxEvent := &repository.OutboxEvent{
+			Id:        eventID,
+			EstateId:  estateID,
+			EventType: repository.EVENT_ESTATE_STATS_CHANGED,
+			Payload:   []byte(`{"tree_count":1}`),
+			CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		mockRepo.EXPECT().GetOutboxEvent(gomock.Any(), eventID).Return(outboxEvent, nil)
+
+		payload := `{"id":"` + eventID.String() + `","type":"estate.stats_changed","estate_id":"` + estateID.String() + `"}`
+		assert.NoError(t, listener.handleNotification(context.Background(), payload))
+
+		event := <-events
+		assert.Equal(t, outboxEvent.Envelope(), event)
+		assert.JSONEq(t, `{"tree_count":1}`, string(event.Data))
+	})
+
+	t.Run("Estate Without Subscriber", func(t *testing.T) {
+		payload := `{"id":"` + uuid.NewString() + `","type":"tree.added","estate_id":"` + otherEstateID.String() + `"}`
+		assert.NoError(t, listener.handleNotification(context.Background(), payload))
+	})
+
+	t.Run("Event Not Loaded", func(t *testing.T) {
+		eventID := uuid.New()
+		mockRepo.EXPECT().GetOutboxEvent(gomock.Any(), eventID).Return(nil, errors.New("db error"))
+
+		payload := `{"id":"` + eventID.String() + `","type":"tree.added","estate_id":"` + estateID.String() + `"}`
+		assert.Error(t, listener.handleNotification(context.Background(), payload))
+		assert.Len(t, events, 0)
+	})
+
+	t.Run("Invalid Payload", func(t *testing.T) {
+		assert.Error(t, listener.handleNotification(context.Background(), "not json"))
+	})
+
+	assert.Equal(t, []uuid.UUID{estateID, otherEstateID, estateID}, received)
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderActor name who makes the request, recorded by the audit log. The service has no authentication,
+// the header is trusted as sent by the gateway in front of it
+const HeaderActor = "X-Actor"
+
+// actorMaxLength is the size of audit_entries.actor, in characters
+const actorMaxLength = 255
+
+// NewIPExtractor return the echo IP extractor of the audited source IP. X-Forwarded-For is only
+// trusted when set by one of trustedProxies, without any the peer address is used
+func NewIPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range trustedProxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// ActorMiddleware put the actor & the source IP of the request into the request context,
+// so every change made by the request is audited with them
+func ActorMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			name := strings.TrimSpace(strings.ToValidUTF8(req.Header.Get(HeaderActor), string(utf8.RuneError)))
+			if name == "" {
+				name = "anonymous"
+			}
+			// cut on a rune boundary, postgres reject a truncated multibyte character
+			if runes := []rune(name); len(runes) > actorMaxLength {
+				name = string(runes[:actorMaxLength])
+			}
+
+			ctx := repository.WithActor(req.Context(), repository.Actor{Name: name, SourceIp: sourceIP(c.RealIP())})
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// sourceIP is the canonical form of ip, empty when it is not an IP address
+func sourceIP(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	return addr.WithZone("").String()
+}
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/SawitProRecruitment/UserService/generated"
 	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/telemetry"
 	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
 	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
 	"github.com/SawitProRecruitment/UserService/utils/ptr"
@@ -37,14 +40,19 @@ func (s *Server) PostEstate(c echo.Context) error {
 	}
 
 	estateID := uuid.New()
-	err := s.Repository.CreateEstate(ctx, repository.CreateEstateInput{
+	input := repository.CreateEstateInput{
 		Id:     estateID,
 		Width:  payload.Width,
 		Length: payload.Length,
-	})
+	}
+	if payload.Geo != nil {
+		input.Geo = ptr.ToPointer(toGeoReference(*payload.Geo))
+	}
+	err := s.Repository.CreateEstate(ctx, input)
 	if err != nil {
 		return httphelper.HttpRespError(c, err)
 	}
+	telemetry.EstatePlots.Observe(float64(payload.Width) * float64(payload.Length))
 
 	var resp generated.CreateEstateResponse
 	openapiUUID := openapi_types.UUID(estateID)
@@ -53,17 +61,37 @@ func (s *Server) PostEstate(c echo.Context) error {
 	return c.JSON(http.StatusCreated, resp)
 }
 
+// Get an estate with its trees
+// (GET /estate/{id})
+func (s *Server) GetEstateId(c echo.Context, id openapi_types.UUID) error {
+	ctx := c.Request().Context()
+
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, id, repository.RELATION_STATS)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	resp := generated.Estate{
+		Id:     estate.Id,
+		Width:  estate.Width,
+		Length: estate.Length,
+		Geo:    toGeneratedGeoReference(estate.Geo),
+		Trees:  s.locateTrees(estate, toGeneratedTrees(estate.Trees)),
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
 // Get drone travel plan
 // (GET /estate/{id}/drone-plan)
 func (s *Server) GetEstateIdDronePlan(c echo.Context, id openapi_types.UUID, params generated.GetEstateIdDronePlanParams) error {
 	ctx := c.Request().Context()
 
 	// Validate payload
-	if err := s.Validator.Struct(params); err != nil {
-		validationErrors := err.(validator.ValidationErrors)
+	if errs := s.validateDronePlanParams(params); errs != nil {
 		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
 			Message: "Validation failed",
-			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+			Errors:  errs,
 		})
 	}
 
@@ -72,53 +100,174 @@ func (s *Server) GetEstateIdDronePlan(c echo.Context, id openapi_types.UUID, par
 		return httphelper.HttpRespError(c, err)
 	}
 
-	var (
-		droneRest *Coordinate
-		distance  int64
-	)
+	resp, cacheStatus := s.dronePlanResponse(ctx, estate, params)
+	cacheStatus.setHeaders(c.Response().Header())
+
+	return c.JSON(http.StatusOK, s.completeDronePlan(estate, params, resp))
+}
+
+// validateDronePlanParams return the errors of the drone plan parameters by field, nil when they are valid
+func (s *Server) validateDronePlanParams(params generated.GetEstateIdDronePlanParams) map[string]string {
+	if err := s.Validator.Struct(params); err != nil {
+		return utilvalidator.FormatValidationErrors(err.(validator.ValidationErrors))
+	}
+
+	// the landing point is chosen by a single limit
+	if countProvided(params.MaxDistance != nil, params.MaxEnergy != nil, params.MaxDuration != nil) > 1 {
+		message := "Only one of MaxDistance, MaxEnergy or MaxDuration can be provided"
+		return map[string]string{
+			"MaxDistance": message,
+			"MaxEnergy":   message,
+			"MaxDuration": message,
+		}
+	}
+
+	return nil
+}
+
+// dronePlanResponse plan the drone flight over the estate with the validated parameters. A computed plan is
+// read from the plan cache when it has one for this version of the estate, and saved in it otherwise
+func (s *Server) dronePlanResponse(ctx context.Context, estate *repository.Estate, params generated.GetEstateIdDronePlanParams) (generated.DronePlanResponse, planCacheStatus) {
+	profile := s.droneProfile(params)
+	pattern := patternRows
+	if params.Pattern != nil {
+		pattern = *params.Pattern
+	}
 
-	// currently if param max_distance is not provided,
+	// currently if no limit, exclusion_mode & pattern are provided and the profile is the one of estate_stats,
 	// we can directly get it from estate_stats table (pre calculate on create tree)
-	// else must calculate it manually
-	if params.MaxDistance == nil {
-		distance = estate.Stats.DroneDistance
-	} else {
-		distance, droneRest = calculateDroneDistance(estate, params.MaxDistance)
+	// else must calculate it manually, as well as when no-fly plots may leave part of the estate uncovered
+	noLimit := countProvided(params.MaxDistance != nil, params.MaxEnergy != nil, params.MaxDuration != nil) == 0
+	if noLimit && params.ExclusionMode == nil && params.Pattern == nil &&
+		!estate.HasNoFlyPlots() && estate.Stats.DroneProfile == profile.String() {
+		return toGeneratedDronePlan(dronePlan{
+			Distance: estate.Stats.DroneDistance,
+			Duration: estate.Stats.DroneDuration,
+			Energy:   estate.Stats.DroneEnergy,
+			Pattern:  pattern,
+		}), planCacheStatus{}
+	}
+
+	options := droneOptions{
+		Profile:      profile,
+		MaxDistance:  params.MaxDistance,
+		MaxEnergy:    params.MaxEnergy,
+		MaxDuration:  params.MaxDuration,
+		SkipExcluded: s.skipExcludedPlots(params.ExclusionMode),
+		Pattern:      pattern,
+	}
+	if s.PlanCache == nil {
+		return toGeneratedDronePlan(calculateDroneDistance(estate, options)), planCacheStatus{}
+	}
+
+	status := planCacheStatus{used: true}
+	key := repository.DronePlanCacheKey{EstateId: estate.Id, Version: estate.Version, Params: options.cacheKey()}
+	if cached, layer := s.PlanCache.Get(ctx, key); cached != nil {
+		var resp generated.DronePlanResponse
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			status.layer = layer
+			return resp, status
+		}
 	}
 
+	resp := toGeneratedDronePlan(calculateDroneDistance(estate, options))
+	if plan, err := json.Marshal(resp); err == nil {
+		s.PlanCache.Set(ctx, key, plan)
+	}
+	return resp, status
+}
+
+func toGeneratedDronePlan(plan dronePlan) generated.DronePlanResponse {
 	var resp generated.DronePlanResponse
-	resp.Distance = &distance
-	if droneRest != nil {
-		resp.Rest = &struct {
-			X *int "json:\"x,omitempty\""
-			Y *int "json:\"y,omitempty\""
-		}{X: &droneRest.X, Y: &droneRest.Y}
+	resp.Distance = &plan.Distance
+	resp.Duration = &plan.Duration
+	resp.Energy = &plan.Energy
+	resp.Pattern = &plan.Pattern
+	if plan.Rest != nil {
+		resp.Rest = &generated.DronePoint{X: &plan.Rest.X, Y: &plan.Rest.Y}
+	}
+	resp.Coverage = &generated.DroneCoverage{
+		FullyCovered:   plan.UncoveredPlots == 0,
+		UncoveredPlots: plan.UncoveredPlots,
+		UncoveredTrees: toGeneratedTrees(plan.UncoveredTrees),
 	}
 
-	return c.JSON(http.StatusOK, resp)
+	return resp
 }
 
 // Get estate statistics
 // (GET /estate/{id}/stats)
-func (s *Server) GetEstateIdStats(c echo.Context, id openapi_types.UUID) error {
+func (s *Server) GetEstateIdStats(c echo.Context, id openapi_types.UUID, params generated.GetEstateIdStatsParams) error {
 	ctx := c.Request().Context()
 
-	var resp generated.EstateStats
+	// Validate payload
+	if err := s.Validator.Struct(params); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+
 	result, err := s.Repository.GetEstateWithAllDetails(ctx, id, repository.RELATION_TREES)
 	if err != nil {
 		return httphelper.HttpRespError(c, err)
 	}
+	resp := toGeneratedEstateStats(result)
 
-	resp = generated.EstateStats{
-		Count:  ptr.ToPointer[int64](result.Stats.TreeCount),
-		Max:    ptr.ToPointer[int](result.Stats.MaxHeight),
-		Median: ptr.ToPointer[int](result.Stats.MedianHeight),
-		Min:    ptr.ToPointer[int](result.Stats.MinHeight),
+	if params.GroupBy != nil {
+		groups, err := s.Repository.GetTreeGroupStats(ctx, id, repository.TreeGroup(*params.GroupBy))
+		if err != nil {
+			return httphelper.HttpRespError(c, err)
+		}
+		resp.Groups = ptr.ToPointer(toGeneratedTreeGroups(groups))
 	}
 
 	return c.JSON(http.StatusOK, resp)
 }
 
+// toGeneratedEstateStats build the stats response from the stored stats of the estate
+func toGeneratedEstateStats(estate *repository.Estate) generated.EstateStats {
+	// density only count the plots outside the exclusion zones
+	plantablePlots := estate.PlantablePlots()
+	var density float64
+	if plantablePlots > 0 {
+		density = float64(estate.Stats.TreeCount) / float64(plantablePlots)
+	}
+
+	return generated.EstateStats{
+		Count:          ptr.ToPointer[int64](estate.Stats.TreeCount),
+		Max:            ptr.ToPointer[int](estate.Stats.MaxHeight),
+		Median:         ptr.ToPointer[int](estate.Stats.MedianHeight),
+		Min:            ptr.ToPointer[int](estate.Stats.MinHeight),
+		PlantablePlots: ptr.ToPointer[int64](plantablePlots),
+		Density:        ptr.ToPointer[float64](density),
+	}
+}
+
+// List the trees of an estate
+// (GET /estate/{id}/tree)
+func (s *Server) GetEstateIdTree(c echo.Context, id openapi_types.UUID, params generated.GetEstateIdTreeParams) error {
+	ctx := c.Request().Context()
+
+	// Validate payload
+	if err := s.Validator.Struct(params); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, id,
+		repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, generated.TreeList{Data: s.locateTrees(estate, toGeneratedTrees(filterTrees(estate.Trees, params)))})
+}
+
 // Add a tree to an estate
 // (POST /estate/{id}/tree)
 func (s *Server) PostEstateIdTree(c echo.Context, id openapi_types.UUID) error {
@@ -139,16 +288,35 @@ func (s *Server) PostEstateIdTree(c echo.Context, id openapi_types.UUID) error {
 			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
 		})
 	}
+	errs := validateTreePosition(payload)
+	if errs == nil {
+		errs = validateTreeAttributes(payload)
+	}
+	if errs != nil {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+	}
+
+	// a tree given by lat & lon is planted on the nearest plot
+	if payload.Lat != nil {
+		estate, err := s.Repository.GetEstateWithAllDetails(ctx, id,
+			repository.RELATION_TREES, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES)
+		if err != nil {
+			return httphelper.HttpRespError(c, err)
+		}
+		if errs := s.snapTree(estate, &payload); errs != nil {
+			return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+				Message: "Validation failed",
+				Errors:  errs,
+			})
+		}
+	}
 
 	// #1. Insert the tree to DB
 	treeId := uuid.New()
-	err := s.Repository.CreateTree(ctx, repository.CreateTreeInput{
-		Id:       treeId,
-		EstateId: id,
-		X:        payload.X,
-		Y:        payload.Y,
-		Height:   payload.Height,
-	})
+	err := s.Repository.CreateTree(ctx, toCreateTreeInput(treeId, id, payload))
 	if err != nil {
 		return httphelper.HttpRespError(c, err)
 	}
@@ -165,3 +333,36 @@ func (s *Server) PostEstateIdTree(c echo.Context, id openapi_types.UUID) error {
 
 	return c.JSON(http.StatusCreated, resp)
 }
+
+// Update the attributes of a tree
+// (PATCH /estate/{id}/tree/{treeId})
+func (s *Server) PatchEstateIdTreeTreeId(c echo.Context, id openapi_types.UUID, treeId openapi_types.UUID) error {
+	ctx := c.Request().Context()
+	payload := generated.UpdateTreeRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return httphelper.HttpRespError(c,
+			apperror.WrapWithCode(fmt.Errorf("failed to unmarshall request: %w", err),
+				http.StatusBadRequest))
+	}
+
+	// Validate payload
+	if err := s.Validator.Struct(payload); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+	if payload.Species == nil && payload.Health == nil && payload.Tags == nil {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  map[string]string{"UpdateTreeRequest": "at least one of Species, Health or Tags is required"},
+		})
+	}
+
+	if err := s.Repository.UpdateTree(ctx, toUpdateTreeInput(treeId, id, payload)); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	utilvalidator "github.com/SawitProRecruitment/UserService/utils/validator"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// List the audit log of an estate
+// (GET /estate/{id}/audit)
+func (s *Server) GetEstateIdAudit(c echo.Context, id openapi_types.UUID, params generated.GetEstateIdAuditParams) error {
+	ctx := c.Request().Context()
+
+	// Validate payload
+	if err := s.Validator.Struct(params); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  map[string]string{"To": fmt.Sprintf("To %s is not after From %s", params.To, params.From)},
+		})
+	}
+
+	// the estate is not checked, the log of a deleted estate is still listed
+	input := repository.GetAuditEntriesInput{
+		EstateId: id,
+		From:     params.From,
+		To:       params.To,
+		Limit:    defaultPageLimit,
+	}
+	if params.Action != nil {
+		input.Action = repository.AuditAction(*params.Action)
+	}
+	if params.Actor != nil {
+		input.Actor = *params.Actor
+	}
+	if params.Limit != nil {
+		input.Limit = *params.Limit
+	}
+	if params.Offset != nil {
+		input.Offset = *params.Offset
+	}
+
+	entries, err := s.Repository.GetAuditEntries(ctx, input)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	resp := generated.AuditEntryList{
+		Data: make([]generated.AuditEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		item := generated.AuditEntry{
+			Id:        entry.Id,
+			EstateId:  entry.EstateId,
+			Action:    string(entry.Action),
+			EntityId:  entry.EntityId,
+			Actor:     entry.Actor,
+			Before:    toAuditState(entry.Before),
+			After:     toAuditState(entry.After),
+			CreatedAt: entry.CreatedAt,
+		}
+		if entry.RequestId != "" {
+			item.RequestId = &entry.RequestId
+		}
+		if entry.SourceIp != "" {
+			item.SourceIp = &entry.SourceIp
+		}
+		resp.Data = append(resp.Data, item)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// toAuditState decode the JSON state of an audit entry, nil when the entity did not exist
+func toAuditState(data json.RawMessage) *map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+	state := map[string]interface{}{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+	return &state
+}
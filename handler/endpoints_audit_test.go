@@ -0,0 +1,221 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetEstateIdAudit(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	entryID := uuid.New()
+	createdAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateIdAuditParams
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "Success",
+			params: generated.GetEstateIdAuditParams{Action: ptr("estate.resized"), Actor: ptr("alice"), From: &from, To: &to, Limit: ptr(10), Offset: ptr(10)},
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetAuditEntries(gomock.Any(), repository.GetAuditEntriesInput{
+					EstateId: testID,
+					Action:   repository.AUDIT_ESTATE_RESIZED,
+					Actor:    "alice",
+					From:     &from,
+					To:       &to,
+					Limit:    10,
+					Offset:   10,
+				}).Return([]repository.AuditEntry{{
+					Id:        entryID,
+					EstateId:  testID,
+					Action:    repository.AUDIT_ESTATE_RESIZED,
+					EntityId:  testID,
+					Actor:     "alice",
+					RequestId: "req-1",
+					SourceIp:  "10.0.0.7",
+					Before:    json.RawMessage(`{"width":10,"length":10}`),
+					After:     json.RawMessage(`{"width":20,"length":10}`),
+					CreatedAt: createdAt,
+				}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"id":"` + entryID.String() + `","estate_id":"` + testID.String() + `","action":"estate.resized","entity_id":"` + testID.String() + `",` +
+				`"actor":"alice","request_id":"req-1","source_ip":"10.0.0.7","before":{"width":10,"length":10},"after":{"width":20,"length":10},"created_at":"2024-03-01T08:00:00Z"}]}`,
+		},
+		{
+			name: "Creation By The System",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetAuditEntries(gomock.Any(), repository.GetAuditEntriesInput{EstateId: testID, Limit: 20}).
+					Return([]repository.AuditEntry{{
+						Id:        entryID,
+						EstateId:  testID,
+						Action:    repository.AUDIT_ESTATE_CREATED,
+						EntityId:  testID,
+						Actor:     repository.ACTOR_SYSTEM,
+						After:     json.RawMessage(`{"width":10,"length":10}`),
+						CreatedAt: createdAt,
+					}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":[{"id":"` + entryID.String() + `","estate_id":"` + testID.String() + `","action":"estate.created","entity_id":"` + testID.String() + `",` +
+				`"actor":"system","after":{"width":10,"length":10},"created_at":"2024-03-01T08:00:00Z"}]}`,
+		},
+		{
+			name: "No Entries",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetAuditEntries(gomock.Any(), gomock.Any()).Return([]repository.AuditEntry{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[]}`,
+		},
+		{
+			name:           "Unknown Action",
+			params:         generated.GetEstateIdAuditParams{Action: ptr("tree.painted")},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Limit Too High",
+			params:         generated.GetEstateIdAuditParams{Limit: ptr(101)},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Reversed Range",
+			params:         generated.GetEstateIdAuditParams{From: &to, To: &from},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Repository Error",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetAuditEntries(gomock.Any(), gomock.Any()).
+					Return(nil, apperror.WrapWithCode(errors.New("db error"), http.StatusInternalServerError))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+			if tc.setup != nil {
+				tc.setup(mockRepo)
+			}
+
+			rec := httptest.NewRecorder()
+			err := server.GetEstateIdAudit(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestActorMiddleware(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+
+	tests := []struct {
+		name           string
+		header         string
+		remoteAddr     string
+		forwardedFor   string
+		trustedProxies []*net.IPNet
+		expectedActor  repository.Actor
+	}{
+		{
+			name:          "Actor Header",
+			header:        " alice ",
+			remoteAddr:    "10.0.0.7:5100",
+			expectedActor: repository.Actor{Name: "alice", SourceIp: "10.0.0.7"},
+		},
+		{
+			name:          "Anonymous",
+			remoteAddr:    "10.0.0.7:5100",
+			expectedActor: repository.Actor{Name: "anonymous", SourceIp: "10.0.0.7"},
+		},
+		{
+			name:          "Forwarded For Ignored Without Trusted Proxy",
+			remoteAddr:    "203.0.113.9:5100",
+			forwardedFor:  "198.51.100.1, " + strings.Repeat("1", 70),
+			expectedActor: repository.Actor{Name: "anonymous", SourceIp: "203.0.113.9"},
+		},
+		{
+			name:           "Forwarded For By Trusted Proxy",
+			remoteAddr:     "10.0.0.7:5100",
+			forwardedFor:   "198.51.100.1",
+			trustedProxies: []*net.IPNet{proxies},
+			expectedActor:  repository.Actor{Name: "anonymous", SourceIp: "198.51.100.1"},
+		},
+		{
+			name:           "Forwarded For By Untrusted Peer",
+			remoteAddr:     "203.0.113.9:5100",
+			forwardedFor:   "198.51.100.1",
+			trustedProxies: []*net.IPNet{proxies},
+			expectedActor:  repository.Actor{Name: "anonymous", SourceIp: "203.0.113.9"},
+		},
+		{
+			name:           "Forwarded For Not An IP",
+			remoteAddr:     "10.0.0.7:5100",
+			forwardedFor:   strings.Repeat("x", 70),
+			trustedProxies: []*net.IPNet{proxies},
+			expectedActor:  repository.Actor{Name: "anonymous", SourceIp: "10.0.0.7"},
+		},
+		{
+			name:          "Actor Truncated On A Rune Boundary",
+			header:        strings.Repeat("é", 300),
+			remoteAddr:    "10.0.0.7:5100",
+			expectedActor: repository.Actor{Name: strings.Repeat("é", 255), SourceIp: "10.0.0.7"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e := echo.New()
+			e.IPExtractor = handler.NewIPExtractor(tc.trustedProxies)
+
+			req := httptest.NewRequest(http.MethodPost, "/estate", nil)
+			req.RemoteAddr = tc.remoteAddr
+			req.Header.Set(handler.HeaderActor, tc.header)
+			if tc.forwardedFor != "" {
+				req.Header.Set(echo.HeaderXForwardedFor, tc.forwardedFor)
+			}
+
+			var actor repository.Actor
+			next := func(c echo.Context) error {
+				actor = repository.ActorFromContext(c.Request().Context())
+				return nil
+			}
+
+			err := handler.ActorMiddleware()(next)(e.NewContext(req, httptest.NewRecorder()))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedActor, actor)
+		})
+	}
+}
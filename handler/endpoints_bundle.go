@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/bundle"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/telemetry"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// defaultMaxBundleSize is the decompressed size of an imported bundle when the server has no config
+const defaultMaxBundleSize = 256 << 20
+
+// Export an estate bundle
+// (GET /estate/{id}/bundle)
+func (s *Server) GetEstateIdBundle(c echo.Context, id openapi_types.UUID) error {
+	b, err := s.Repository.ExportEstate(c.Request().Context(), id)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	var archive bytes.Buffer
+	if err := bundle.Write(&archive, b, time.Now()); err != nil {
+		return httphelper.HttpRespError(c, apperror.WrapWithCode(fmt.Errorf("failed to write estate bundle: %w", err), http.StatusInternalServerError))
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="estate-%s.bundle.tar.gz"`, id))
+	return c.Blob(http.StatusOK, bundle.ContentType, archive.Bytes())
+}
+
+// Restore an estate bundle
+// (POST /estate/import-bundle)
+func (s *Server) PostEstateImportBundle(c echo.Context, params generated.PostEstateImportBundleParams) error {
+	ctx := c.Request().Context()
+	b, _, err := bundle.Read(c.Request().Body, s.maxBundleSize())
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	input := repository.ImportEstateInput{Bundle: *b}
+	if params.EstateId != nil && *params.EstateId != b.Estate.Id {
+		input.Bundle = b.WithEstateId(*params.EstateId)
+		input.ImportedFrom = ptr.ToPointer(b.Estate.Id)
+	}
+	if err := s.Repository.ImportEstate(ctx, input); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	estate := input.Bundle.Estate
+	telemetry.EstatePlots.Observe(float64(estate.Width) * float64(estate.Length))
+	telemetry.EstateTrees.Observe(float64(len(estate.Trees)))
+
+	return c.JSON(http.StatusCreated, generated.CreateEstateResponse{Id: ptr.ToPointer(estate.Id)})
+}
+
+// maxBundleSize is the configured decompressed size of an imported bundle
+func (s *Server) maxBundleSize() int64 {
+	if s.Config != nil && s.Config.App.MaxBundleSize > 0 {
+		return int64(s.Config.App.MaxBundleSize)
+	}
+	return defaultMaxBundleSize
+}
@@ -0,0 +1,184 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/bundle"
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func testEstateBundle(estateID uuid.UUID) *repository.EstateBundle {
+	createdAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	return &repository.EstateBundle{
+		Estate: repository.Estate{
+			Id: estateID, Width: 10, Length: 20, Version: 2, CreatedAt: createdAt,
+			Trees: []repository.Tree{{Id: uuid.New(), EstateId: estateID, X: 3, Y: 4, Height: 12, Health: repository.TREE_HEALTHY, CreatedAt: createdAt}},
+		},
+		AuditEntries: []repository.AuditEntry{{
+			Id: uuid.New(), EstateId: estateID, Action: repository.AUDIT_ESTATE_CREATED, EntityId: estateID, Actor: "alice", CreatedAt: createdAt,
+		}},
+	}
+}
+
+func TestGetEstateIdBundle(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+		exported := testEstateBundle(testID)
+		mockRepo.EXPECT().ExportEstate(gomock.Any(), testID).Return(exported, nil)
+
+		rec := httptest.NewRecorder()
+		err := server.GetEstateIdBundle(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, bundle.ContentType, rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, fmt.Sprintf(`attachment; filename="estate-%s.bundle.tar.gz"`, testID), rec.Header().Get(echo.HeaderContentDisposition))
+
+		read, manifest, err := bundle.Read(rec.Body, 1<<20)
+		assert.NoError(t, err)
+		assert.Equal(t, exported, read)
+		assert.Equal(t, testID, manifest.EstateId)
+	})
+
+	t.Run("Estate Not Found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+		mockRepo.EXPECT().ExportEstate(gomock.Any(), testID).
+			Return(nil, apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", testID), http.StatusNotFound))
+
+		rec := httptest.NewRecorder()
+		err := server.GetEstateIdBundle(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestPostEstateImportBundle(t *testing.T) {
+	e := echo.New()
+	sourceID, newID := uuid.New(), uuid.New()
+	source := testEstateBundle(sourceID)
+	var archive bytes.Buffer
+	assert.NoError(t, bundle.Write(&archive, source, time.Now()))
+
+	tests := []struct {
+		name           string
+		params         generated.PostEstateImportBundleParams
+		body           []byte
+		config         *config.Config
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Same ID",
+			body: archive.Bytes(),
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().ImportEstate(gomock.Any(), repository.ImportEstateInput{Bundle: *source}).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"` + sourceID.String() + `"}`,
+		},
+		{
+			name:   "Requested ID Of The Bundle",
+			params: generated.PostEstateImportBundleParams{EstateId: &sourceID},
+			body:   archive.Bytes(),
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().ImportEstate(gomock.Any(), repository.ImportEstateInput{Bundle: *source}).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"` + sourceID.String() + `"}`,
+		},
+		{
+			name:   "New ID",
+			params: generated.PostEstateImportBundleParams{EstateId: &newID},
+			body:   archive.Bytes(),
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().ImportEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, input repository.ImportEstateInput) error {
+					assert.Equal(t, newID, input.Bundle.Estate.Id)
+					assert.Equal(t, newID, input.Bundle.Estate.Trees[0].EstateId)
+					assert.NotEqual(t, source.Estate.Trees[0].Id, input.Bundle.Estate.Trees[0].Id)
+					assert.Equal(t, newID, input.Bundle.AuditEntries[0].EntityId)
+					assert.Equal(t, &sourceID, input.ImportedFrom)
+					return nil
+				})
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"` + newID.String() + `"}`,
+		},
+		{
+			name:           "Invalid Bundle",
+			body:           []byte("this is not an estate bundle"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Larger Than The Configured Size",
+			body:           archive.Bytes(),
+			config:         &config.Config{App: config.App{MaxBundleSize: 512}},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"message":"bundle is larger than 512 bytes once decompressed"}`,
+		},
+		{
+			name: "Estate Already Exists",
+			body: archive.Bytes(),
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().ImportEstate(gomock.Any(), gomock.Any()).
+					Return(apperror.WrapWithCode(fmt.Errorf("estate with ID %s already exists", sourceID), http.StatusConflict))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"estate with ID ` + sourceID.String() + ` already exists"}`,
+		},
+		{
+			name: "Repository Error",
+			body: archive.Bytes(),
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().ImportEstate(gomock.Any(), gomock.Any()).
+					Return(apperror.WrapWithCode(errors.New("db error"), http.StatusInternalServerError))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New(), Config: tc.config}
+			if tc.setup != nil {
+				tc.setup(mockRepo)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/estate/import-bundle", bytes.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, bundle.ContentType)
+			rec := httptest.NewRecorder()
+			err := server.PostEstateImportBundle(e.NewContext(req, rec), tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	utilvalidator "github.com/SawitProRecruitment/UserService/utils/validator"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Resize an estate
+// (PATCH /estate/{id})
+func (s *Server) PatchEstateId(c echo.Context, id openapi_types.UUID, params generated.PatchEstateIdParams) error {
+	ctx := c.Request().Context()
+	payload := generated.UpdateEstateRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return httphelper.HttpRespError(c,
+			apperror.WrapWithCode(fmt.Errorf("failed to unmarshall request: %w", err),
+				http.StatusBadRequest))
+	}
+
+	// Validate payload
+	if err := s.Validator.Struct(payload); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+	if payload.Width == nil && payload.Length == nil {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors: map[string]string{
+				"Width":  "Width or Length is required",
+				"Length": "Width or Length is required",
+			},
+		})
+	}
+
+	// #1. Resize the estate, trees outside the new boundary are removed only with force
+	estate, removedTrees, err := s.Repository.ResizeEstate(ctx, repository.ResizeEstateInput{
+		Id:     id,
+		Width:  payload.Width,
+		Length: payload.Length,
+		Force:  params.Force != nil && *params.Force,
+	})
+	if err != nil {
+		var conflict *repository.EstateResizeConflictError
+		if errors.As(err, &conflict) {
+			return c.JSON(http.StatusConflict, generated.EstateResizeConflict{
+				Message: conflict.Error(),
+				Trees:   toGeneratedTrees(conflict.Trees),
+			})
+		}
+		return httphelper.HttpRespError(c, err)
+	}
+
+	// #2. Recalculate the stats, drone distance depends on the estate size
+	if err = s.calculateStats(ctx, id); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, generated.ResizeEstateResponse{
+		Id:           estate.Id,
+		Width:        estate.Width,
+		Length:       estate.Length,
+		RemovedTrees: toGeneratedTrees(removedTrees),
+	})
+}
+
+// Delete an estate
+// (DELETE /estate/{id})
+func (s *Server) DeleteEstateId(c echo.Context, id openapi_types.UUID) error {
+	ctx := c.Request().Context()
+
+	if err := s.Repository.DeleteEstate(ctx, id); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Restore a deleted estate
+// (POST /estate/{id}/restore)
+func (s *Server) PostEstateIdRestore(c echo.Context, id openapi_types.UUID) error {
+	ctx := c.Request().Context()
+
+	if err := s.Repository.RestoreEstate(ctx, id); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Geo-reference an estate
+// (PUT /estate/{id}/geo)
+func (s *Server) PutEstateIdGeo(c echo.Context, id openapi_types.UUID) error {
+	ctx := c.Request().Context()
+	payload := generated.GeoReference{}
+	if err := c.Bind(&payload); err != nil {
+		return httphelper.HttpRespError(c,
+			apperror.WrapWithCode(fmt.Errorf("failed to unmarshall request: %w", err),
+				http.StatusBadRequest))
+	}
+
+	// Validate payload
+	if err := s.Validator.Struct(payload); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+
+	if err := s.Repository.UpdateEstateGeo(ctx, id, toGeoReference(payload)); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toGeneratedTrees(trees []repository.Tree) []generated.Tree {
+	res := make([]generated.Tree, 0, len(trees))
+	for _, tree := range trees {
+		item := generated.Tree{
+			Id:     tree.Id,
+			X:      tree.X,
+			Y:      tree.Y,
+			Height: tree.Height,
+			Health: string(tree.Health),
+			Tags:   tree.Tags,
+		}
+		if item.Tags == nil {
+			item.Tags = []string{}
+		}
+		if tree.Species != "" {
+			item.Species = ptr.ToPointer(tree.Species)
+		}
+		if tree.PlantedAt != nil {
+			item.PlantedAt = &openapi_types.Date{Time: *tree.PlantedAt}
+		}
+		res = append(res, item)
+	}
+	return res
+}
@@ -0,0 +1,260 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestPatchEstateId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{
+		Repository: mockRepo,
+		Validator:  validator.New(),
+	}
+	e := echo.New()
+
+	testID := uuid.New()
+	treeID := uuid.New()
+	outsideTree := repository.Tree{Id: treeID, EstateId: testID, X: 5, Y: 1, Height: 7, Health: repository.TREE_HEALTHY}
+
+	expectCalculateStats := func(mockRepo *repository.MockRepositoryInterface, estate *repository.Estate) {
+		mockRepo.EXPECT().
+			GetCalculatedEstateStats(gomock.Any(), testID).
+			Return(&repository.EstateStats{TreeCount: int64(len(estate.Trees))}, nil)
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), testID).
+			Return(estate, nil)
+		mockRepo.EXPECT().
+			UpsertEstateStats(gomock.Any(), testID, gomock.Any()).
+			DoAndReturn(func(_ any, _ uuid.UUID, stats *repository.EstateStats) error {
+				// 3x2 empty estate: 1 take off + 5 plot moves + 1 landing
+				assert.Equal(t, int64(52), stats.DroneDistance)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		params         generated.PatchEstateIdParams
+		expectedStatus int
+		expectedBody   string
+		setup          func(*repository.MockRepositoryInterface)
+	}{
+		{
+			name:           "Success",
+			requestBody:    `{"width": 2, "length": 3}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"` + testID.String() + `","width":2,"length":3,"removed_trees":[]}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					ResizeEstate(gomock.Any(), repository.ResizeEstateInput{Id: testID, Width: ptr(2), Length: ptr(3)}).
+					Return(&repository.Estate{Id: testID, Width: 2, Length: 3}, nil, nil)
+				expectCalculateStats(mockRepo, &repository.Estate{Id: testID, Width: 2, Length: 3, Stats: &repository.EstateStats{}})
+			},
+		},
+		{
+			name:           "Success - Force Remove Trees",
+			requestBody:    `{"length": 3}`,
+			params:         generated.PatchEstateIdParams{Force: ptr(true)},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"` + testID.String() + `","width":2,"length":3,"removed_trees":[{"id":"` + treeID.String() + `","x":5,"y":1,"height":7,"health":"healthy","tags":[]}]}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					ResizeEstate(gomock.Any(), repository.ResizeEstateInput{Id: testID, Length: ptr(3), Force: true}).
+					Return(&repository.Estate{Id: testID, Width: 2, Length: 3}, []repository.Tree{outsideTree}, nil)
+				expectCalculateStats(mockRepo, &repository.Estate{Id: testID, Width: 2, Length: 3, Stats: &repository.EstateStats{}})
+			},
+		},
+		{
+			name:           "Trees Outside New Boundary",
+			requestBody:    `{"length": 3}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"1 trees are outside the new estate boundary","trees":[{"id":"` + treeID.String() + `","x":5,"y":1,"height":7,"health":"healthy","tags":[]}]}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					ResizeEstate(gomock.Any(), gomock.Any()).
+					Return(nil, nil, apperror.WrapWithCode(&repository.EstateResizeConflictError{Trees: []repository.Tree{outsideTree}}, http.StatusConflict))
+			},
+		},
+		{
+			name:           "Invalid JSON (Bind Error)",
+			requestBody:    `{invalid_json}`,
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Payload Validation Error",
+			requestBody:    `{"width": 0}`,
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Empty Payload",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"Width":"Width or Length is required","Length":"Width or Length is required"}}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Estate Not Found",
+			requestBody:    `{"width": 2}`,
+			expectedStatus: http.StatusNotFound,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					ResizeEstate(gomock.Any(), gomock.Any()).
+					Return(nil, nil, apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound))
+			},
+		},
+		{
+			name:           "Recalculate Stats Error",
+			requestBody:    `{"width": 2}`,
+			expectedStatus: http.StatusInternalServerError,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					ResizeEstate(gomock.Any(), gomock.Any()).
+					Return(&repository.Estate{Id: testID, Width: 2, Length: 3}, nil, nil)
+				mockRepo.EXPECT().
+					GetCalculatedEstateStats(gomock.Any(), testID).
+					Return(nil, apperror.WrapWithCode(errors.New("db error"), http.StatusInternalServerError))
+				mockRepo.EXPECT().
+					GetEstateWithAllDetails(gomock.Any(), testID).
+					Return(&repository.Estate{Id: testID, Width: 2, Length: 3}, nil).
+					AnyTimes()
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/estate/"+testID.String(), strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tc.setup(mockRepo)
+
+			err := server.PatchEstateId(c, testID, tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeleteEstateId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo}
+	e := echo.New()
+
+	testID := uuid.New()
+
+	tests := []struct {
+		name           string
+		expectedStatus int
+		setup          func(*repository.MockRepositoryInterface)
+	}{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusNoContent,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().DeleteEstate(gomock.Any(), testID).Return(nil)
+			},
+		},
+		{
+			name:           "Estate Not Found",
+			expectedStatus: http.StatusNotFound,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					DeleteEstate(gomock.Any(), testID).
+					Return(apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/estate/"+testID.String(), nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tc.setup(mockRepo)
+
+			err := server.DeleteEstateId(c, testID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestPostEstateIdRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo}
+	e := echo.New()
+
+	testID := uuid.New()
+
+	tests := []struct {
+		name           string
+		expectedStatus int
+		setup          func(*repository.MockRepositoryInterface)
+	}{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusNoContent,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().RestoreEstate(gomock.Any(), testID).Return(nil)
+			},
+		},
+		{
+			name:           "Deleted Estate Not Found",
+			expectedStatus: http.StatusNotFound,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					RestoreEstate(gomock.Any(), testID).
+					Return(apperror.WrapWithCode(errors.New("deleted estate not found"), http.StatusNotFound))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/estate/"+testID.String()+"/restore", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tc.setup(mockRepo)
+
+			err := server.PostEstateIdRestore(c, testID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
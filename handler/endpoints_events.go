@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Stream live estate events
+// (GET /estate/{id}/events)
+func (s *Server) GetEstateIdEvents(c echo.Context, id openapi_types.UUID) error {
+	ctx := c.Request().Context()
+
+	// make sure estate exist before opening the stream
+	_, err := s.Repository.GetEstateWithAllDetails(ctx, id, repository.RELATION_TREES, repository.RELATION_STATS)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	// subscribe before writing the header, so no event is missed once client see the stream opened
+	events, unsubscribe := s.Broker.Subscribe(id)
+	defer unsubscribe()
+	s.logger().DebugContext(ctx, "event stream opened", slog.Int("subscribers", s.Broker.SubscriberCount(id)))
+	defer s.logger().DebugContext(ctx, "event stream closed")
+
+	resp := c.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set(echo.HeaderCacheControl, "no-cache")
+	resp.Header().Set(echo.HeaderConnection, "keep-alive")
+	resp.WriteHeader(http.StatusOK)
+	resp.Flush()
+
+	heartbeatInterval := 15 * time.Second
+	if s.Config != nil && s.Config.Events.HeartbeatInterval > 0 {
+		heartbeatInterval = s.Config.Events.HeartbeatInterval
+	}
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(resp, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			resp.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeServerSentEvent(resp, event); err != nil {
+				return nil
+			}
+			resp.Flush()
+		}
+	}
+}
+
+// write event in SSE format, event id let the client know the last event it received
+func writeServerSentEvent(resp *echo.Response, event repository.EventEnvelope) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(resp, "id: %s\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data)
+	return err
+}
@@ -0,0 +1,112 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/events"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetEstateIdEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	broker := events.NewBroker(10)
+	server := &handler.Server{
+		Repository: mockRepo,
+		Broker:     broker,
+		Config:     &config.Config{Events: config.Events{HeartbeatInterval: time.Hour}},
+	}
+	e := echo.New()
+	estateID := uuid.New()
+
+	t.Run("Stream Events", func(t *testing.T) {
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), estateID, repository.RELATION_TREES, repository.RELATION_STATS).
+			Return(&repository.Estate{Id: estateID}, nil).
+			Times(1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		req := httptest.NewRequest(http.MethodGet, "/estate/"+estateID.String()+"/events", nil).WithContext(ctx)
+		rec := &flushRecorder{ResponseRecorder: httptest.NewRecorder(), flushed: make(chan struct{}, 10)}
+		c := e.NewContext(req, rec)
+
+		done := make(chan error)
+		go func() {
+			done <- server.GetEstateIdEvents(c, estateID)
+		}()
+
+		// wait the handler subscribe before publishing
+		assert.Eventually(t, func() bool { return broker.SubscriberCount(estateID) == 1 }, time.Second, time.Millisecond)
+
+		eventID := uuid.New()
+		broker.Publish(repository.EventEnvelope{
+			Id:       eventID,
+			Type:     repository.EVENT_TREE_ADDED,
+			EstateId: estateID,
+			Data:     []byte(`{"x":1,"y":1,"height":5}`),
+		})
+		// event of other estate must not be streamed
+		broker.Publish(repository.EventEnvelope{Id: uuid.New(), Type: repository.EVENT_TREE_ADDED, EstateId: uuid.New()})
+		broker.Publish(repository.EventEnvelope{
+			Id:       uuid.New(),
+			Type:     repository.EVENT_TREE_UPDATED,
+			EstateId: estateID,
+			Data:     []byte(`{"x":1,"y":1,"health":"diseased"}`),
+		})
+
+		<-rec.flushed // stream opened
+		<-rec.flushed // tree.added written
+		<-rec.flushed // tree.updated written
+		cancel()
+		assert.NoError(t, <-done)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Body.String(), "id: "+eventID.String()+"\nevent: tree.added\ndata: {")
+		assert.Contains(t, rec.Body.String(), "event: tree.updated\ndata: {")
+		assert.Equal(t, 2, strings.Count(rec.Body.String(), "event: "))
+		assert.Equal(t, 0, broker.SubscriberCount(estateID), "subscription released when client disconnect")
+	})
+
+	t.Run("Estate Not Found", func(t *testing.T) {
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), estateID, repository.RELATION_TREES, repository.RELATION_STATS).
+			Return(nil, apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound)).
+			Times(1)
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/"+estateID.String()+"/events", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := server.GetEstateIdEvents(c, estateID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+// flushRecorder signal every flush, so test know when the streamed event is written
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushed chan struct{}
+}
+
+func (r *flushRecorder) Flush() {
+	r.ResponseRecorder.Flush()
+	r.flushed <- struct{}{}
+}
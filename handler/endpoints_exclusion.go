@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	utilvalidator "github.com/SawitProRecruitment/UserService/utils/validator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// List the exclusion zones of an estate
+// (GET /estate/{id}/exclusions)
+func (s *Server) GetEstateIdExclusions(c echo.Context, id openapi_types.UUID) error {
+	ctx := c.Request().Context()
+
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, id, repository.RELATION_TREES, repository.RELATION_STATS)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	resp := generated.ExclusionZoneList{
+		Data:          make([]generated.ExclusionZone, 0, len(estate.ExclusionZones)),
+		ExcludedPlots: estate.ExcludedPlotCount(),
+	}
+	for _, zone := range estate.ExclusionZones {
+		item := generated.ExclusionZone{Id: zone.Id, Label: zone.Label}
+		points := toGeneratedPlotPoints(zone.Points)
+		if zone.Kind == repository.EXCLUSION_KIND_POLYGON {
+			item.Polygon = &points
+		} else {
+			item.Plots = &points
+		}
+		resp.Data = append(resp.Data, item)
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Add an exclusion zone to an estate
+// (POST /estate/{id}/exclusions)
+func (s *Server) PostEstateIdExclusions(c echo.Context, id openapi_types.UUID) error {
+	ctx := c.Request().Context()
+	payload := generated.CreateExclusionZoneRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return httphelper.HttpRespError(c,
+			apperror.WrapWithCode(fmt.Errorf("failed to unmarshall request: %w", err),
+				http.StatusBadRequest))
+	}
+
+	// Validate payload
+	if err := s.Validator.Struct(payload); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+	if (payload.Plots == nil) == (payload.Polygon == nil) {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors: map[string]string{
+				"Plots":   "Exactly one of Plots or Polygon is required",
+				"Polygon": "Exactly one of Plots or Polygon is required",
+			},
+		})
+	}
+
+	input := repository.CreateExclusionZoneInput{
+		Id:       uuid.New(),
+		EstateId: id,
+		Kind:     repository.EXCLUSION_KIND_PLOTS,
+	}
+	if payload.Label != nil {
+		input.Label = *payload.Label
+	}
+	points := payload.Plots
+	if payload.Polygon != nil {
+		input.Kind = repository.EXCLUSION_KIND_POLYGON
+		points = payload.Polygon
+	}
+	for _, p := range *points {
+		input.Points = append(input.Points, repository.Point{X: p.X, Y: p.Y})
+	}
+
+	// #1. Insert the zone, rejected when trees are already planted inside
+	if err := s.Repository.CreateExclusionZone(ctx, input); err != nil {
+		var conflict *repository.ExclusionZoneConflictError
+		if errors.As(err, &conflict) {
+			return c.JSON(http.StatusConflict, generated.ExclusionZoneConflict{
+				Message: conflict.Error(),
+				Trees:   toGeneratedTrees(conflict.Trees),
+			})
+		}
+		return httphelper.HttpRespError(c, err)
+	}
+
+	// #2. Recalculate the stats, skipped plots change the drone distance
+	if err := s.calculateStats(ctx, id); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	zoneID := openapi_types.UUID(input.Id)
+	return c.JSON(http.StatusCreated, generated.CreateExclusionZoneResponse{Id: &zoneID})
+}
+
+// Remove an exclusion zone
+// (DELETE /estate/{id}/exclusions/{zoneId})
+func (s *Server) DeleteEstateIdExclusionsZoneId(c echo.Context, id openapi_types.UUID, zoneId openapi_types.UUID) error {
+	ctx := c.Request().Context()
+
+	if err := s.Repository.DeleteExclusionZone(ctx, id, zoneId); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	if err := s.calculateStats(ctx, id); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toGeneratedPlotPoints(points []repository.Point) []generated.PlotPoint {
+	res := make([]generated.PlotPoint, 0, len(points))
+	for _, p := range points {
+		res = append(res, generated.PlotPoint{X: p.X, Y: p.Y})
+	}
+	return res
+}
@@ -0,0 +1,258 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetEstateIdExclusions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo}
+	e := echo.New()
+
+	testID := uuid.New()
+	pondID := uuid.New()
+	roadID := uuid.New()
+
+	mockRepo.EXPECT().
+		GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_TREES, repository.RELATION_STATS).
+		Return(&repository.Estate{
+			Id:     testID,
+			Width:  5,
+			Length: 5,
+			ExclusionZones: []repository.ExclusionZone{
+				{Id: pondID, Label: "pond", Kind: repository.EXCLUSION_KIND_POLYGON, Points: []repository.Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 2}}},
+				{Id: roadID, Kind: repository.EXCLUSION_KIND_PLOTS, Points: []repository.Point{{X: 2, Y: 2}, {X: 3, Y: 2}}},
+			},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/estate/"+testID.String()+"/exclusions", nil)
+	rec := httptest.NewRecorder()
+
+	err := server.GetEstateIdExclusions(e.NewContext(req, rec), testID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"data": [
+			{"id":"`+pondID.String()+`","label":"pond","polygon":[{"x":1,"y":1},{"x":2,"y":1},{"x":2,"y":2},{"x":1,"y":2}]},
+			{"id":"`+roadID.String()+`","label":"","plots":[{"x":2,"y":2},{"x":3,"y":2}]}
+		],
+		"excluded_plots": 5
+	}`, rec.Body.String())
+}
+
+func TestPostEstateIdExclusions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{
+		Repository: mockRepo,
+		Validator:  validator.New(),
+	}
+	e := echo.New()
+
+	testID := uuid.New()
+	treeID := uuid.New()
+
+	expectCalculateStats := func(mockRepo *repository.MockRepositoryInterface) {
+		mockRepo.EXPECT().
+			GetCalculatedEstateStats(gomock.Any(), testID).
+			Return(&repository.EstateStats{}, nil)
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), testID).
+			Return(&repository.Estate{Id: testID, Width: 2, Length: 2, Stats: &repository.EstateStats{}}, nil)
+		mockRepo.EXPECT().
+			UpsertEstateStats(gomock.Any(), testID, gomock.Any()).
+			Return(nil)
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+		setup          func(*repository.MockRepositoryInterface)
+	}{
+		{
+			name:           "Success - Polygon",
+			requestBody:    `{"label": "river", "polygon": [{"x": 1, "y": 1}, {"x": 2, "y": 1}, {"x": 2, "y": 2}]}`,
+			expectedStatus: http.StatusCreated,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateExclusionZone(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateExclusionZoneInput) error {
+						assert.Equal(t, testID, input.EstateId)
+						assert.Equal(t, "river", input.Label)
+						assert.Equal(t, repository.EXCLUSION_KIND_POLYGON, input.Kind)
+						assert.Equal(t, []repository.Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}}, input.Points)
+						return nil
+					})
+				expectCalculateStats(mockRepo)
+			},
+		},
+		{
+			name:           "Success - Plots",
+			requestBody:    `{"plots": [{"x": 2, "y": 2}]}`,
+			expectedStatus: http.StatusCreated,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateExclusionZone(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateExclusionZoneInput) error {
+						assert.Equal(t, repository.EXCLUSION_KIND_PLOTS, input.Kind)
+						assert.Equal(t, []repository.Point{{X: 2, Y: 2}}, input.Points)
+						return nil
+					})
+				expectCalculateStats(mockRepo)
+			},
+		},
+		{
+			name:           "Trees Inside Zone",
+			requestBody:    `{"plots": [{"x": 2, "y": 2}]}`,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"message":"1 trees are planted inside the exclusion zone","trees":[{"id":"` + treeID.String() + `","x":2,"y":2,"height":5,"health":"healthy","tags":[]}]}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateExclusionZone(gomock.Any(), gomock.Any()).
+					Return(apperror.WrapWithCode(&repository.ExclusionZoneConflictError{
+						Trees: []repository.Tree{{Id: treeID, X: 2, Y: 2, Height: 5, Health: repository.TREE_HEALTHY}},
+					}, http.StatusConflict))
+			},
+		},
+		{
+			name:           "Both Plots And Polygon",
+			requestBody:    `{"plots": [{"x": 2, "y": 2}], "polygon": [{"x": 1, "y": 1}, {"x": 2, "y": 1}, {"x": 2, "y": 2}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"Plots":"Exactly one of Plots or Polygon is required","Polygon":"Exactly one of Plots or Polygon is required"}}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Polygon With Two Vertices",
+			requestBody:    `{"polygon": [{"x": 1, "y": 1}, {"x": 2, "y": 1}]}`,
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Invalid Point",
+			requestBody:    `{"plots": [{"x": 0, "y": 2}]}`,
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Estate Not Found",
+			requestBody:    `{"plots": [{"x": 2, "y": 2}]}`,
+			expectedStatus: http.StatusNotFound,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateExclusionZone(gomock.Any(), gomock.Any()).
+					Return(apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/estate/"+testID.String()+"/exclusions", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tc.setup(mockRepo)
+
+			err := server.PostEstateIdExclusions(c, testID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeleteEstateIdExclusionsZoneId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo}
+	e := echo.New()
+
+	testID := uuid.New()
+	zoneID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteExclusionZone(gomock.Any(), testID, zoneID).Return(nil)
+		mockRepo.EXPECT().GetCalculatedEstateStats(gomock.Any(), testID).Return(&repository.EstateStats{}, nil)
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), testID).
+			Return(&repository.Estate{Id: testID, Width: 2, Length: 2, Stats: &repository.EstateStats{}}, nil)
+		mockRepo.EXPECT().UpsertEstateStats(gomock.Any(), testID, gomock.Any()).Return(nil)
+
+		rec := httptest.NewRecorder()
+		err := server.DeleteEstateIdExclusionsZoneId(e.NewContext(httptest.NewRequest(http.MethodDelete, "/", nil), rec), testID, zoneID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("Zone Not Found", func(t *testing.T) {
+		mockRepo.EXPECT().
+			DeleteExclusionZone(gomock.Any(), testID, zoneID).
+			Return(apperror.WrapWithCode(errors.New("exclusion zone not found"), http.StatusNotFound))
+
+		rec := httptest.NewRecorder()
+		err := server.DeleteEstateIdExclusionsZoneId(e.NewContext(httptest.NewRequest(http.MethodDelete, "/", nil), rec), testID, zoneID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetEstateIdStats_Density(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+	e := echo.New()
+
+	testID := uuid.New()
+
+	// 4x5 estate with a 2x2 pond, 3 trees on the 16 plantable plots
+	mockRepo.EXPECT().
+		GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_TREES).
+		Return(&repository.Estate{
+			Id:     testID,
+			Width:  4,
+			Length: 5,
+			Stats:  &repository.EstateStats{TreeCount: 3, MaxHeight: 9, MinHeight: 2, MedianHeight: 4},
+			ExclusionZones: []repository.ExclusionZone{
+				{Kind: repository.EXCLUSION_KIND_POLYGON, Points: []repository.Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 1, Y: 2}}},
+			},
+		}, nil)
+
+	rec := httptest.NewRecorder()
+	err := server.GetEstateIdStats(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, generated.GetEstateIdStatsParams{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"count":3,"max":9,"min":2,"median":4,"plantable_plots":16,"density":0.1875}`, rec.Body.String())
+}
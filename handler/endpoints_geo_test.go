@@ -0,0 +1,300 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestPutEstateIdGeo(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+	}{
+		{
+			name:        "Success",
+			requestBody: `{"origin_lat":2.1,"origin_lon":101.5,"rotation":30}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().UpdateEstateGeo(gomock.Any(), testID, repository.GeoReference{OriginLat: 2.1, OriginLon: 101.5, Rotation: 30}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:        "Rotation Defaults To North",
+			requestBody: `{"origin_lat":-2.1,"origin_lon":101.5}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().UpdateEstateGeo(gomock.Any(), testID, repository.GeoReference{OriginLat: -2.1, OriginLon: 101.5}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Invalid Latitude",
+			requestBody:    `{"origin_lat":91,"origin_lon":101.5}`,
+			setup:          func(*repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Rotation",
+			requestBody:    `{"origin_lat":2.1,"origin_lon":101.5,"rotation":360}`,
+			setup:          func(*repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: `{"origin_lat":2.1,"origin_lon":101.5}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().UpdateEstateGeo(gomock.Any(), testID, gomock.Any()).
+					Return(apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+			tc.setup(mockRepo)
+
+			req := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			err := server.PutEstateIdGeo(e.NewContext(req, rec), testID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestPostEstateIdTree_LatLon(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	geo := &repository.GeoReference{OriginLat: 2.1, OriginLon: 101.5, Rotation: 30}
+	lat, lon := geo.LatLon(4, 2, 10)
+	outsideLat, outsideLon := geo.LatLon(6, 2, 10)
+	body := func(lat, lon float64) string {
+		b, _ := json.Marshal(map[string]any{"lat": lat, "lon": lon, "height": 5})
+		return string(b)
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		estate         *repository.Estate
+		expectedPlot   *repository.Point
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "Snapped To The Nearest Plot",
+			requestBody:    body(lat+2e-5, lon),
+			estate:         &repository.Estate{Id: testID, Width: 3, Length: 5, Geo: geo},
+			expectedPlot:   &repository.Point{X: 4, Y: 2},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Outside The Estate",
+			requestBody:    body(outsideLat, outsideLon),
+			estate:         &repository.Estate{Id: testID, Width: 3, Length: 5, Geo: geo},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Lat",
+		},
+		{
+			name:           "Estate Not Geo-Referenced",
+			requestBody:    body(lat, lon),
+			estate:         &repository.Estate{Id: testID, Width: 3, Length: 5},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Lat",
+		},
+		{
+			name:           "Both Plot & Position",
+			requestBody:    `{"x":1,"y":1,"lat":2.1,"lon":101.5,"height":5}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "X",
+		},
+		{
+			name:           "Latitude Without Longitude",
+			requestBody:    `{"lat":2.1,"height":5}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Lon",
+		},
+		{
+			name:           "No Position",
+			requestBody:    `{"height":5}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "X",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+
+			if tc.estate != nil {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID,
+					repository.RELATION_TREES, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES).
+					Return(tc.estate, nil)
+			}
+			if tc.expectedPlot != nil {
+				mockRepo.EXPECT().CreateTree(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateTreeInput) error {
+						assert.Equal(t, *tc.expectedPlot, repository.Point{X: input.X, Y: input.Y})
+						return nil
+					})
+				mockRepo.EXPECT().GetCalculatedEstateStats(gomock.Any(), testID).Return(&repository.EstateStats{TreeCount: 1}, nil)
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID).
+					Return(&repository.Estate{Id: testID, Width: 3, Length: 5, Stats: &repository.EstateStats{}}, nil)
+				mockRepo.EXPECT().UpsertEstateStats(gomock.Any(), testID, gomock.Any()).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			err := server.PostEstateIdTree(e.NewContext(req, rec), testID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedError != "" {
+				assert.Contains(t, rec.Body.String(), `"`+tc.expectedError+`"`)
+			}
+		})
+	}
+}
+
+func TestGetEstateId_Geo(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	geo := &repository.GeoReference{OriginLat: 2.1, OriginLon: 101.5, Rotation: 30}
+
+	ctrl := gomock.NewController(t)
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+	mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS).
+		Return(&repository.Estate{Id: testID, Width: 3, Length: 5, Geo: geo, Trees: []repository.Tree{{X: 4, Y: 2, Height: 5}}}, nil)
+
+	rec := httptest.NewRecorder()
+	err := server.GetEstateId(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body generated.Estate
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, &generated.GeoReference{OriginLat: 2.1, OriginLon: 101.5, Rotation: ptr(30.0)}, body.Geo)
+	lat, lon := geo.LatLon(4, 2, 10)
+	assert.InDelta(t, lat, *body.Trees[0].Lat, 1e-9)
+	assert.InDelta(t, lon, *body.Trees[0].Lon, 1e-9)
+}
+
+func TestGetEstateIdDronePlan_Waypoints(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	geo := &repository.GeoReference{OriginLat: 2.1, OriginLon: 101.5}
+
+	tests := []struct {
+		name              string
+		estate            *repository.Estate
+		params            generated.GetEstateIdDronePlanParams
+		expectedWaypoints []repository.Point
+		expectedGeo       bool
+	}{
+		{
+			name:   "No Waypoints By Default",
+			estate: &repository.Estate{Id: testID, Width: 2, Length: 3, Geo: geo},
+			params: generated.GetEstateIdDronePlanParams{MaxDistance: ptr(35)},
+		},
+		{
+			name:              "Serpentine Turns",
+			estate:            &repository.Estate{Id: testID, Width: 2, Length: 3, Geo: geo},
+			params:            generated.GetEstateIdDronePlanParams{MaxDistance: ptr(35), Waypoints: ptr(true)},
+			expectedWaypoints: []repository.Point{{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 2}, {X: 1, Y: 2}},
+			expectedGeo:       true,
+		},
+		{
+			name:              "Not Geo-Referenced",
+			estate:            &repository.Estate{Id: testID, Width: 2, Length: 3},
+			params:            generated.GetEstateIdDronePlanParams{MaxDistance: ptr(35), Waypoints: ptr(true)},
+			expectedWaypoints: []repository.Point{{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 2}, {X: 1, Y: 2}},
+		},
+		{
+			name:              "Flight Plot Size Does Not Move The Plots",
+			estate:            &repository.Estate{Id: testID, Width: 2, Length: 3, Geo: geo},
+			params:            generated.GetEstateIdDronePlanParams{MaxDistance: ptr(70), Waypoints: ptr(true), PlotSize: ptr(20)},
+			expectedWaypoints: []repository.Point{{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 2}, {X: 1, Y: 2}},
+			expectedGeo:       true,
+		},
+		{
+			name: "Routed Around A No-Fly Plot",
+			estate: &repository.Estate{Id: testID, Width: 3, Length: 3, Geo: geo,
+				Obstacles: []repository.Obstacle{{X: 2, Y: 2}}},
+			params: generated.GetEstateIdDronePlanParams{MaxDistance: ptr(35), Waypoints: ptr(true)},
+			expectedWaypoints: []repository.Point{
+				// (3,2) to (1,2) over the third row, then the third row again
+				{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 3}, {X: 1, Y: 3}, {X: 1, Y: 2}, {X: 1, Y: 3}, {X: 3, Y: 3},
+			},
+			expectedGeo: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+			mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID).Return(tc.estate, nil)
+
+			rec := httptest.NewRecorder()
+			err := server.GetEstateIdDronePlan(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var body generated.DronePlanResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+			assert.NotNil(t, body.Rest)
+			assert.Equal(t, tc.estate.Geo != nil, body.Rest.Lat != nil)
+			if tc.estate.Geo != nil {
+				// the plots are located on the estate grid, whatever the plot size of the flight
+				lat, lon := tc.estate.Geo.LatLon(*body.Rest.X, *body.Rest.Y, 10)
+				assert.InDelta(t, lat, *body.Rest.Lat, 1e-9)
+				assert.InDelta(t, lon, *body.Rest.Lon, 1e-9)
+			}
+			if tc.expectedWaypoints == nil {
+				assert.Nil(t, body.Waypoints)
+				return
+			}
+			waypoints := make([]repository.Point, 0, len(*body.Waypoints))
+			for _, point := range *body.Waypoints {
+				waypoints = append(waypoints, repository.Point{X: *point.X, Y: *point.Y})
+				assert.Equal(t, tc.expectedGeo, point.Lat != nil && point.Lon != nil)
+				if tc.expectedGeo {
+					lat, lon := tc.estate.Geo.LatLon(*point.X, *point.Y, 10)
+					assert.InDelta(t, lat, *point.Lat, 1e-9)
+					assert.InDelta(t, lon, *point.Lon, 1e-9)
+				}
+			}
+			assert.Equal(t, tc.expectedWaypoints, waypoints)
+		})
+	}
+}
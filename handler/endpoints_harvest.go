@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	utilvalidator "github.com/SawitProRecruitment/UserService/utils/validator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// List the harvests of an estate
+// (GET /estate/{id}/harvests)
+func (s *Server) GetEstateIdHarvests(c echo.Context, id openapi_types.UUID, params generated.GetEstateIdHarvestsParams) error {
+	ctx := c.Request().Context()
+
+	if errs := validateDateRange(params.From, params.To); errs != nil {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+	}
+
+	// the harvests are not read from a missing or deleted estate
+	_, err := s.Repository.GetEstateWithAllDetails(ctx, id, repository.RELATION_TREES, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	harvests, err := s.Repository.GetHarvests(ctx, toGetHarvestsInput(id, params.From, params.To))
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	resp := generated.HarvestList{
+		Data: make([]generated.Harvest, 0, len(harvests)),
+	}
+	for _, harvest := range harvests {
+		resp.Data = append(resp.Data, generated.Harvest{
+			Id:          harvest.Id,
+			TreeId:      harvest.TreeId,
+			Plots:       toGeneratedPlotPoints(harvest.Plots),
+			HarvestedAt: openapi_types.Date{Time: harvest.HarvestedAt},
+			WeightKg:    harvest.WeightKg,
+			BunchCount:  harvest.BunchCount,
+		})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Record a harvest
+// (POST /estate/{id}/harvests)
+func (s *Server) PostEstateIdHarvests(c echo.Context, id openapi_types.UUID) error {
+	ctx := c.Request().Context()
+	payload := generated.CreateHarvestRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return httphelper.HttpRespError(c,
+			apperror.WrapWithCode(fmt.Errorf("failed to unmarshall request: %w", err),
+				http.StatusBadRequest))
+	}
+
+	// Validate payload
+	if err := s.Validator.Struct(payload); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+	if (payload.TreeId == nil) == (payload.Plots == nil) {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors: map[string]string{
+				"TreeId": "Exactly one of TreeId or Plots is required",
+				"Plots":  "Exactly one of TreeId or Plots is required",
+			},
+		})
+	}
+	if errs := validateHarvestedAt(payload.HarvestedAt); errs != nil {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+	}
+
+	input := repository.CreateHarvestInput{
+		Id:          uuid.New(),
+		EstateId:    id,
+		TreeId:      payload.TreeId,
+		HarvestedAt: payload.HarvestedAt.Time,
+		WeightKg:    payload.WeightKg,
+		BunchCount:  payload.BunchCount,
+	}
+	if payload.Plots != nil {
+		for _, p := range *payload.Plots {
+			input.Plots = append(input.Plots, repository.Point{X: p.X, Y: p.Y})
+		}
+	}
+
+	if err := s.Repository.CreateHarvest(ctx, input); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	harvestID := openapi_types.UUID(input.Id)
+	return c.JSON(http.StatusCreated, generated.CreateHarvestResponse{Id: &harvestID})
+}
+
+// Get the yield report of an estate
+// (GET /estate/{id}/yield)
+func (s *Server) GetEstateIdYield(c echo.Context, id openapi_types.UUID, params generated.GetEstateIdYieldParams) error {
+	ctx := c.Request().Context()
+
+	if err := s.Validator.Struct(params); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+	if errs := validateDateRange(params.From, params.To); errs != nil {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+	}
+
+	// #1. The trees & exclusion zones the harvests are shared by and the hectares counted on
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, id, repository.RELATION_STATS, repository.RELATION_OBSTACLES)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	// #2. The harvests of the report, by date
+	harvests, err := s.Repository.GetHarvests(ctx, toGetHarvestsInput(id, params.From, params.To))
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	report := calculateYield(estate, harvests, newYieldOptions(params))
+	report.From = params.From
+	report.To = params.To
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,265 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestPostEstateIdHarvests(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	treeID := uuid.New()
+	inTwoDays := time.Now().AddDate(0, 0, 2).Format(time.DateOnly)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "Tree Harvest",
+			requestBody: `{"tree_id":"` + treeID.String() + `","harvested_at":"2024-03-01","weight_kg":25.5,"bunch_count":2}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().CreateHarvest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateHarvestInput) error {
+						assert.Equal(t, repository.CreateHarvestInput{
+							Id:          input.Id,
+							EstateId:    testID,
+							TreeId:      &treeID,
+							HarvestedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+							WeightKg:    25.5,
+							BunchCount:  2,
+						}, input)
+						return nil
+					})
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "Region Harvest",
+			requestBody: `{"plots":[{"x":1,"y":1},{"x":2,"y":1}],"harvested_at":"2024-03-01","weight_kg":100,"bunch_count":5}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().CreateHarvest(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateHarvestInput) error {
+						assert.Nil(t, input.TreeId)
+						assert.Equal(t, []repository.Point{{X: 1, Y: 1}, {X: 2, Y: 1}}, input.Plots)
+						return nil
+					})
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Tree & Plots",
+			requestBody:    `{"tree_id":"` + treeID.String() + `","plots":[{"x":1,"y":1}],"harvested_at":"2024-03-01","weight_kg":25,"bunch_count":2}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "TreeId",
+		},
+		{
+			name:           "Neither Tree Nor Plots",
+			requestBody:    `{"harvested_at":"2024-03-01","weight_kg":25,"bunch_count":2}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Plots",
+		},
+		{
+			name:           "No Weight",
+			requestBody:    `{"plots":[{"x":1,"y":1}],"harvested_at":"2024-03-01","weight_kg":0,"bunch_count":2}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "WeightKg",
+		},
+		{
+			name:           "No Date",
+			requestBody:    `{"plots":[{"x":1,"y":1}],"weight_kg":25,"bunch_count":2}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "HarvestedAt",
+		},
+		{
+			name:           "Harvested In The Future",
+			requestBody:    `{"plots":[{"x":1,"y":1}],"harvested_at":"` + inTwoDays + `","weight_kg":25,"bunch_count":2}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "HarvestedAt",
+		},
+		{
+			name:        "Tree Not Found",
+			requestBody: `{"tree_id":"` + treeID.String() + `","harvested_at":"2024-03-01","weight_kg":25,"bunch_count":2}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().CreateHarvest(gomock.Any(), gomock.Any()).
+					Return(apperror.WrapWithCode(errors.New("tree not found"), http.StatusNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+			if tc.setup != nil {
+				tc.setup(mockRepo)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			err := server.PostEstateIdHarvests(e.NewContext(req, rec), testID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedError != "" {
+				assert.Contains(t, rec.Body.String(), `"`+tc.expectedError+`"`)
+			}
+		})
+	}
+}
+
+func TestGetEstateIdHarvests(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	harvestID := uuid.New()
+	from := openapi_types.Date{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	to := openapi_types.Date{Time: time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateIdHarvestsParams
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "Success",
+			params: generated.GetEstateIdHarvestsParams{From: &from, To: &to},
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, gomock.Any()).Return(&repository.Estate{Id: testID}, nil)
+				mockRepo.EXPECT().GetHarvests(gomock.Any(), repository.GetHarvestsInput{EstateId: testID, From: &from.Time, To: &to.Time}).
+					Return([]repository.Harvest{{
+						Id:          harvestID,
+						Plots:       []repository.Point{{X: 1, Y: 1}},
+						HarvestedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+						WeightKg:    25.5,
+						BunchCount:  2,
+					}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":[{"bunch_count":2,"harvested_at":"2024-03-01","id":"` + harvestID.String() + `","plots":[{"x":1,"y":1}],"weight_kg":25.5}]}`,
+		},
+		{
+			name:           "Reversed Range",
+			params:         generated.GetEstateIdHarvestsParams{From: &to, To: &from},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Estate Not Found",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, gomock.Any()).
+					Return(nil, apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+			if tc.setup != nil {
+				tc.setup(mockRepo)
+			}
+
+			rec := httptest.NewRecorder()
+			err := server.GetEstateIdHarvests(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetEstateIdYield(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateIdYieldParams
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+	}{
+		{
+			name:   "Success",
+			params: generated.GetEstateIdYieldParams{Period: ptr("week"), BlockSize: ptr(5)},
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS, repository.RELATION_OBSTACLES).
+					Return(&repository.Estate{Id: testID, Width: 10, Length: 10, Trees: []repository.Tree{{X: 1, Y: 1, Height: 5}}}, nil)
+				mockRepo.EXPECT().GetHarvests(gomock.Any(), repository.GetHarvestsInput{EstateId: testID}).
+					Return([]repository.Harvest{{Plots: []repository.Point{{X: 1, Y: 1}}, HarvestedAt: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), WeightKg: 25, BunchCount: 2}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Period",
+			params:         generated.GetEstateIdYieldParams{Period: ptr("decade")},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Block Too Large",
+			params:         generated.GetEstateIdYieldParams{BlockSize: ptr(101)},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Estate Not Found",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, gomock.Any()).
+					Return(nil, apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+			if tc.setup != nil {
+				tc.setup(mockRepo)
+			}
+
+			rec := httptest.NewRecorder()
+			err := server.GetEstateIdYield(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var report generated.YieldReport
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+				assert.Equal(t, "week", report.Period)
+				assert.Equal(t, 1.0, report.Hectares)
+				assert.Equal(t, time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC), report.Periods[0].Start.Time)
+				assert.Equal(t, []generated.YieldBlock{{X: 1, Y: 1, Length: 5, Width: 5, TreeCount: 1, Hectares: 0.25, WeightKg: 25, WeightKgPerHectare: 100}}, report.LeastProductiveBlocks)
+			}
+		})
+	}
+}
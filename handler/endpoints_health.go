@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	healthStatusOk          = "ok"
+	healthStatusUnavailable = "unavailable"
+
+	// readinessTimeout bound the checks, so a hanging database fail the probe instead of blocking it
+	readinessTimeout = 2 * time.Second
+)
+
+// Liveness probe
+// (GET /healthz)
+func (s *Server) GetHealthz(c echo.Context) error {
+	return c.JSON(http.StatusOK, generated.HealthResponse{Status: healthStatusOk})
+}
+
+// Readiness probe
+// (GET /readyz)
+func (s *Server) GetReadyz(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), readinessTimeout)
+	defer cancel()
+
+	checks := map[string]string{"database": "ok", "migrations": "ok"}
+	status, code := healthStatusOk, http.StatusOK
+
+	if err := s.Repository.Ping(ctx); err != nil {
+		checks["database"] = err.Error()
+		checks["migrations"] = "skipped"
+		status, code = healthStatusUnavailable, http.StatusServiceUnavailable
+	} else if err := s.Repository.CheckSchema(ctx); err != nil {
+		checks["migrations"] = err.Error()
+		status, code = healthStatusUnavailable, http.StatusServiceUnavailable
+	}
+
+	return c.JSON(code, generated.HealthResponse{Status: status, Checks: &checks})
+}
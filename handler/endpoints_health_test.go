@@ -0,0 +1,84 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetHealthz(t *testing.T) {
+	server := &handler.Server{Validator: validator.New(), Config: &config.Config{}}
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, server.GetHealthz(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestGetReadyz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo, Validator: validator.New(), Config: &config.Config{}}
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Ready",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CheckSchema(gomock.Any()).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"ok","checks":{"database":"ok","migrations":"ok"}}`,
+		},
+		{
+			name: "Database Unreachable",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(errors.New("failed to ping database: connection refused"))
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status":"unavailable","checks":{"database":"failed to ping database: connection refused","migrations":"skipped"}}`,
+		},
+		{
+			name: "Schema Not Applied",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().Ping(gomock.Any()).Return(nil)
+				mockRepo.EXPECT().CheckSchema(gomock.Any()).Return(errors.New("missing tables: webhook_deliveries"))
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status":"unavailable","checks":{"database":"ok","migrations":"missing tables: webhook_deliveries"}}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rec := httptest.NewRecorder()
+
+			tc.setup(mockRepo)
+
+			assert.NoError(t, server.GetReadyz(e.NewContext(req, rec)))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+		})
+	}
+}
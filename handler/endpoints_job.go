@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	utilvalidator "github.com/SawitProRecruitment/UserService/utils/validator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Queue a job
+// (POST /jobs)
+func (s *Server) PostJobs(c echo.Context) error {
+	ctx := c.Request().Context()
+	payload := generated.CreateJobRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return httphelper.HttpRespError(c,
+			apperror.WrapWithCode(fmt.Errorf("failed to unmarshall request: %w", err),
+				http.StatusBadRequest))
+	}
+
+	// Validate payload
+	if err := s.Validator.Struct(payload); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+
+	jobType := repository.JobType(payload.Type)
+	params, errs := s.jobParams(jobType, payload)
+	if errs != nil {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+	}
+
+	// an import job creates its estate, the others work on an existing one
+	estateID := uuid.New()
+	if jobType != repository.JOB_IMPORT {
+		estateID = *payload.EstateId
+		_, err := s.Repository.GetEstateWithAllDetails(ctx, estateID,
+			repository.RELATION_TREES, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES)
+		if err != nil {
+			return httphelper.HttpRespError(c, err)
+		}
+	}
+
+	job := repository.Job{
+		Id:          uuid.New(),
+		Type:        jobType,
+		EstateId:    estateID,
+		Params:      params,
+		Status:      repository.JOB_QUEUED,
+		MaxAttempts: s.jobMaxAttempts(),
+		CreatedAt:   time.Now().UTC(),
+	}
+	err := s.Repository.CreateJob(ctx, repository.CreateJobInput{
+		Id:          job.Id,
+		Type:        job.Type,
+		EstateId:    job.EstateId,
+		Params:      job.Params,
+		MaxAttempts: job.MaxAttempts,
+	})
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, toGeneratedJob(&job))
+}
+
+// Get a job
+// (GET /jobs/{id})
+func (s *Server) GetJobsId(c echo.Context, id openapi_types.UUID) error {
+	job, err := s.Repository.GetJob(c.Request().Context(), id)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, toGeneratedJob(job))
+}
+
+// Cancel a job
+// (POST /jobs/{id}/cancel)
+func (s *Server) PostJobsIdCancel(c echo.Context, id openapi_types.UUID) error {
+	job, err := s.Repository.CancelJob(c.Request().Context(), id)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, toGeneratedJob(job))
+}
+
+// jobParams validate the fields of the job type and return the params saved with the job
+func (s *Server) jobParams(jobType repository.JobType, payload generated.CreateJobRequest) (json.RawMessage, map[string]string) {
+	if jobType == repository.JOB_IMPORT {
+		if payload.Estate == nil {
+			return nil, map[string]string{"Estate": "Estate is required for import jobs"}
+		}
+		if errs := validateEstateDocument(*payload.Estate); errs != nil {
+			return nil, errs
+		}
+		params, err := json.Marshal(payload.Estate)
+		if err != nil {
+			return nil, map[string]string{"Estate": err.Error()}
+		}
+		return params, nil
+	}
+
+	if payload.EstateId == nil {
+		return nil, map[string]string{"EstateId": fmt.Sprintf("EstateId is required for %s jobs", jobType)}
+	}
+
+	if jobType != repository.JOB_DRONE_PLAN {
+		return json.RawMessage(`{}`), nil
+	}
+
+	// the drone plan params are the query parameters of GET /estate/{id}/drone-plan
+	var droneParams generated.GetEstateIdDronePlanParams
+	if payload.DronePlan != nil {
+		raw, err := json.Marshal(payload.DronePlan)
+		if err == nil {
+			err = json.Unmarshal(raw, &droneParams)
+		}
+		if err != nil {
+			return nil, map[string]string{"DronePlan": fmt.Sprintf("invalid drone plan parameters: %s", err)}
+		}
+	}
+	if errs := s.validateDronePlanParams(droneParams); errs != nil {
+		return nil, errs
+	}
+
+	params, err := json.Marshal(droneParams)
+	if err != nil {
+		return nil, map[string]string{"DronePlan": err.Error()}
+	}
+	return params, nil
+}
+
+// validateEstateDocument check the trees are inside the estate, one tree per plot
+func validateEstateDocument(document generated.EstateDocument) map[string]string {
+	plots := make(map[string]bool, len(document.Trees))
+	for _, tree := range document.Trees {
+		// the estate of a document is not geo-referenced
+		if tree.X == 0 || tree.Y == 0 || tree.Lat != nil || tree.Lon != nil {
+			return map[string]string{"Trees": "every tree must be placed by x & y"}
+		}
+		if tree.X > document.Length || tree.Y > document.Width {
+			return map[string]string{"Trees": fmt.Sprintf("tree (%d,%d) is outside the %dx%d estate", tree.X, tree.Y, document.Length, document.Width)}
+		}
+		if errs := validateTreeAttributes(tree); errs != nil {
+			return errs
+		}
+		key := getCoordinateKey(tree.X, tree.Y)
+		if plots[key] {
+			return map[string]string{"Trees": fmt.Sprintf("plot (%d,%d) has more than one tree", tree.X, tree.Y)}
+		}
+		plots[key] = true
+	}
+	return nil
+}
+
+// jobMaxAttempts is the configured attempts, a job runs at least once
+func (s *Server) jobMaxAttempts() int {
+	if s.Config != nil {
+		return max(s.Config.Jobs.MaxAttempts, 1)
+	}
+	return 1
+}
+
+func toGeneratedJob(job *repository.Job) generated.Job {
+	resp := generated.Job{
+		Id:          job.Id,
+		Type:        string(job.Type),
+		EstateId:    job.EstateId,
+		Status:      string(job.Status),
+		Progress:    job.Progress,
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		LastError:   job.LastError,
+		CreatedAt:   job.CreatedAt,
+		StartedAt:   job.StartedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+	if len(job.Result) > 0 {
+		var result map[string]interface{}
+		if err := json.Unmarshal(job.Result, &result); err == nil {
+			resp.Result = &result
+		}
+	}
+	return resp
+}
@@ -0,0 +1,417 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestPostJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{
+		Repository: mockRepo,
+		Validator:  validator.New(),
+		Config:     &config.Config{Jobs: config.Jobs{MaxAttempts: 3}},
+	}
+	e := echo.New()
+
+	testID := uuid.New()
+	expectEstate := func(mockRepo *repository.MockRepositoryInterface, err error) {
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), testID,
+				repository.RELATION_TREES, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES).
+			Return(&repository.Estate{Id: testID}, err)
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+		setup          func(*repository.MockRepositoryInterface)
+	}{
+		{
+			name:           "Success - Drone Plan",
+			requestBody:    `{"type": "drone_plan", "estate_id": "` + testID.String() + `", "drone_plan": {"max_distance": 100, "pattern": "spiral"}}`,
+			expectedStatus: http.StatusAccepted,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				expectEstate(mockRepo, nil)
+				mockRepo.EXPECT().
+					CreateJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateJobInput) error {
+						assert.Equal(t, repository.JOB_DRONE_PLAN, input.Type)
+						assert.Equal(t, testID, input.EstateId)
+						assert.Equal(t, 3, input.MaxAttempts)
+						assert.JSONEq(t, `{"max_distance":100,"pattern":"spiral"}`, string(input.Params))
+						return nil
+					})
+			},
+		},
+		{
+			name:           "Success - Import",
+			requestBody:    `{"type": "import", "estate": {"width": 2, "length": 3, "trees": [{"x": 1, "y": 1, "height": 10}, {"x": 3, "y": 2, "height": 5}]}}`,
+			expectedStatus: http.StatusAccepted,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateJob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateJobInput) error {
+						assert.Equal(t, repository.JOB_IMPORT, input.Type)
+						assert.NotEqual(t, uuid.Nil, input.EstateId)
+						assert.JSONEq(t, `{"width":2,"length":3,"trees":[{"x":1,"y":1,"height":10},{"x":3,"y":2,"height":5}]}`, string(input.Params))
+						return nil
+					})
+			},
+		},
+		{
+			name:           "Invalid Type",
+			requestBody:    `{"type": "harvest", "estate_id": "` + testID.String() + `"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"Type":"Invalid value"}}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Missing Estate Id",
+			requestBody:    `{"type": "export"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"EstateId":"EstateId is required for export jobs"}}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Missing Estate Document",
+			requestBody:    `{"type": "import"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"Estate":"Estate is required for import jobs"}}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Tree Outside Estate",
+			requestBody:    `{"type": "import", "estate": {"width": 2, "length": 3, "trees": [{"x": 4, "y": 1, "height": 10}]}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"Trees":"tree (4,1) is outside the 3x2 estate"}}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Tree By Lat & Lon",
+			requestBody:    `{"type": "import", "estate": {"width": 2, "length": 3, "trees": [{"lat": 2.1, "lon": 101.5, "height": 10}]}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"Trees":"every tree must be placed by x & y"}}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Two Trees On A Plot",
+			requestBody:    `{"type": "import", "estate": {"width": 2, "length": 3, "trees": [{"x": 1, "y": 1, "height": 10}, {"x": 1, "y": 1, "height": 5}]}}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"Trees":"plot (1,1) has more than one tree"}}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Invalid Drone Plan",
+			requestBody:    `{"type": "drone_plan", "estate_id": "` + testID.String() + `", "drone_plan": {"max_distance": 100, "max_duration": 60}}`,
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Estate Not Found",
+			requestBody:    `{"type": "recompute_stats", "estate_id": "` + testID.String() + `"}`,
+			expectedStatus: http.StatusNotFound,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				expectEstate(mockRepo, apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", testID), http.StatusNotFound))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tc.setup(mockRepo)
+
+			err := server.PostJobs(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetJobsId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo}
+	e := echo.New()
+
+	jobID := uuid.New()
+	estateID := uuid.New()
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().GetJob(gomock.Any(), jobID).Return(&repository.Job{
+			Id:          jobID,
+			Type:        repository.JOB_EXPORT,
+			EstateId:    estateID,
+			Status:      repository.JOB_SUCCEEDED,
+			Progress:    1,
+			Result:      []byte(`{"width":2,"length":3,"trees":[]}`),
+			Attempts:    1,
+			MaxAttempts: 3,
+			CreatedAt:   createdAt,
+			StartedAt:   &createdAt,
+			FinishedAt:  &createdAt,
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		err := server.GetJobsId(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), jobID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"id":"`+jobID.String()+`",
+			"type":"export",
+			"estate_id":"`+estateID.String()+`",
+			"status":"succeeded",
+			"progress":1,
+			"attempts":1,
+			"max_attempts":3,
+			"result":{"width":2,"length":3,"trees":[]},
+			"created_at":"2025-01-01T00:00:00Z",
+			"started_at":"2025-01-01T00:00:00Z",
+			"finished_at":"2025-01-01T00:00:00Z"
+		}`, rec.Body.String())
+	})
+
+	t.Run("Job Not Found", func(t *testing.T) {
+		mockRepo.EXPECT().GetJob(gomock.Any(), jobID).
+			Return(nil, apperror.WrapWithCode(fmt.Errorf("job with ID %s not found", jobID), http.StatusNotFound))
+
+		rec := httptest.NewRecorder()
+		err := server.GetJobsId(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), jobID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestPostJobsIdCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo}
+	e := echo.New()
+
+	jobID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().CancelJob(gomock.Any(), jobID).Return(&repository.Job{
+			Id:              jobID,
+			Type:            repository.JOB_DRONE_PLAN,
+			Status:          repository.JOB_CANCELLED,
+			CancelRequested: true,
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		err := server.PostJobsIdCancel(e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec), jobID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"status":"cancelled"`)
+	})
+
+	t.Run("Job Already Finished", func(t *testing.T) {
+		mockRepo.EXPECT().CancelJob(gomock.Any(), jobID).
+			Return(nil, apperror.WrapWithCode(fmt.Errorf("job %s is already succeeded", jobID), http.StatusConflict))
+
+		rec := httptest.NewRecorder()
+		err := server.PostJobsIdCancel(e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec), jobID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestJobHandlers(t *testing.T) {
+	estateID := uuid.New()
+	noProgress := func(float64) {}
+
+	t.Run("Drone Plan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		server := &handler.Server{Repository: mockRepo}
+
+		mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID).Return(&repository.Estate{
+			Id:     estateID,
+			Width:  1,
+			Length: 5,
+			Stats: &repository.EstateStats{
+				DroneDistance: 54,
+				DroneDuration: 10,
+				DroneEnergy:   0.63,
+				DroneProfile:  config.DefaultDroneProfile().String(),
+			},
+		}, nil)
+
+		result, err := server.JobHandlers()[repository.JOB_DRONE_PLAN](context.Background(), repository.Job{
+			Type:     repository.JOB_DRONE_PLAN,
+			EstateId: estateID,
+			Params:   []byte(`{}`),
+		}, noProgress)
+
+		assert.NoError(t, err)
+		plan := result.(generated.DronePlanResponse)
+		assert.Equal(t, int64(54), *plan.Distance)
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		server := &handler.Server{Repository: mockRepo}
+		plantedAt := time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)
+
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), estateID, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES).
+			Return(&repository.Estate{
+				Id:     estateID,
+				Width:  2,
+				Length: 3,
+				Trees: []repository.Tree{
+					{X: 1, Y: 1, Height: 10, Species: "Tenera", PlantedAt: &plantedAt, Health: repository.TREE_HEALTHY, Tags: []string{"block-a"}},
+					{X: 3, Y: 2, Height: 5, Health: repository.TREE_DEAD},
+				},
+			}, nil)
+
+		result, err := server.JobHandlers()[repository.JOB_EXPORT](context.Background(), repository.Job{
+			Type:     repository.JOB_EXPORT,
+			EstateId: estateID,
+		}, noProgress)
+
+		assert.NoError(t, err)
+		assert.Equal(t, generated.EstateDocument{
+			Width:  2,
+			Length: 3,
+			Trees: []generated.AddTreeRequest{
+				{X: 1, Y: 1, Height: 10, Species: ptr("Tenera"), PlantedAt: &openapi_types.Date{Time: plantedAt}, Health: ptr("healthy"), Tags: &[]string{"block-a"}},
+				{X: 3, Y: 2, Height: 5, Health: ptr("dead")},
+			},
+		}, result)
+	})
+
+	t.Run("Rebuild Tiles", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		server := &handler.Server{Repository: mockRepo}
+
+		mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID, gomock.Any()).
+			Return(&repository.Estate{Id: estateID, Width: 600, Length: 1000}, nil)
+		mockRepo.EXPECT().RebuildTiles(gomock.Any(), estateID).Return(nil)
+
+		result, err := server.JobHandlers()[repository.JOB_REBUILD_TILES](context.Background(), repository.Job{
+			Type:     repository.JOB_REBUILD_TILES,
+			EstateId: estateID,
+		}, noProgress)
+
+		assert.NoError(t, err)
+		assert.Equal(t, handler.RebuildTilesJobResult{MaxZoom: 2}, result)
+	})
+
+	importJob := repository.Job{
+		Type:     repository.JOB_IMPORT,
+		EstateId: estateID,
+		Params:   []byte(`{"width":2,"length":3,"trees":[{"x":1,"y":1,"height":10},{"x":3,"y":2,"height":5}]}`),
+	}
+	expectCalculateStats := func(mockRepo *repository.MockRepositoryInterface) {
+		mockRepo.EXPECT().GetCalculatedEstateStats(gomock.Any(), estateID).Return(&repository.EstateStats{}, nil)
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), estateID).
+			Return(&repository.Estate{Id: estateID, Width: 2, Length: 3, Stats: &repository.EstateStats{}}, nil)
+		mockRepo.EXPECT().UpsertEstateStats(gomock.Any(), estateID, gomock.Any()).Return(nil)
+	}
+
+	t.Run("Import", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		server := &handler.Server{Repository: mockRepo}
+
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), estateID, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES).
+			Return(nil, apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound))
+		mockRepo.EXPECT().
+			CreateEstate(gomock.Any(), repository.CreateEstateInput{Id: estateID, Width: 2, Length: 3}).
+			Return(nil)
+		mockRepo.EXPECT().CreateTree(gomock.Any(), gomock.Any()).Times(2).Return(nil)
+		expectCalculateStats(mockRepo)
+
+		var progress []float64
+		result, err := server.JobHandlers()[repository.JOB_IMPORT](context.Background(), importJob, func(p float64) {
+			progress = append(progress, p)
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, handler.ImportJobResult{EstateId: estateID, Trees: 2}, result)
+		assert.Equal(t, []float64{1.0 / 3, 2.0 / 3}, progress)
+	})
+
+	t.Run("Retried Import Skips Planted Trees", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		server := &handler.Server{Repository: mockRepo}
+
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), estateID, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES).
+			Return(&repository.Estate{Id: estateID, Width: 2, Length: 3, Trees: []repository.Tree{{X: 1, Y: 1, Height: 10}}}, nil)
+		mockRepo.EXPECT().
+			CreateTree(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, input repository.CreateTreeInput) error {
+				assert.Equal(t, 3, input.X)
+				assert.Equal(t, 2, input.Y)
+				return nil
+			})
+		expectCalculateStats(mockRepo)
+
+		result, err := server.JobHandlers()[repository.JOB_IMPORT](context.Background(), importJob, noProgress)
+
+		assert.NoError(t, err)
+		assert.Equal(t, handler.ImportJobResult{EstateId: estateID, Trees: 1}, result)
+	})
+
+	t.Run("Import Failure", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		server := &handler.Server{Repository: mockRepo}
+
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), estateID, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES).
+			Return(nil, errors.New("db error"))
+
+		_, err := server.JobHandlers()[repository.JOB_IMPORT](context.Background(), importJob, noProgress)
+
+		assert.EqualError(t, err, "db error")
+	})
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	utilvalidator "github.com/SawitProRecruitment/UserService/utils/validator"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Render the estate as SVG
+// (GET /estate/{id}/map.svg)
+func (s *Server) GetEstateIdMapSvg(c echo.Context, id openapi_types.UUID, params generated.GetEstateIdMapSvgParams) error {
+	m, err := s.estateMap(c, id, params)
+	if err != nil || m == nil {
+		return err
+	}
+
+	return c.Blob(http.StatusOK, "image/svg+xml", m.renderSVG())
+}
+
+// Render the estate as PNG
+// (GET /estate/{id}/map.png)
+func (s *Server) GetEstateIdMapPng(c echo.Context, id openapi_types.UUID, params generated.GetEstateIdMapPngParams) error {
+	m, err := s.estateMap(c, id, generated.GetEstateIdMapSvgParams(params))
+	if err != nil || m == nil {
+		return err
+	}
+
+	img, err := m.renderPNG()
+	if err != nil {
+		return httphelper.HttpRespError(c, apperror.WrapWithCode(err, http.StatusInternalServerError))
+	}
+	return c.Blob(http.StatusOK, "image/png", img)
+}
+
+// estateMap validate the parameters & aggregate the estate, a nil map means the error response is written
+func (s *Server) estateMap(c echo.Context, id openapi_types.UUID, params generated.GetEstateIdMapSvgParams) (*estateMap, error) {
+	// Validate payload
+	if err := s.Validator.Struct(params); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return nil, c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+
+	estate, err := s.Repository.GetEstateWithAllDetails(c.Request().Context(), id)
+	if err != nil {
+		return nil, httphelper.HttpRespError(c, err)
+	}
+
+	return newEstateMap(estate, s.newMapOptions(params)), nil
+}
@@ -0,0 +1,103 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetEstateIdMap(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	estate := &repository.Estate{Id: testID, Width: 3, Length: 5, Trees: []repository.Tree{{X: 2, Y: 2, Height: 10}}}
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateIdMapSvgParams
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedSvg    string
+	}{
+		{
+			name: "Grid",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID).Return(estate, nil).Times(2)
+			},
+			expectedStatus: http.StatusOK,
+			expectedSvg:    `width="100" height="60"`,
+		},
+		{
+			name:   "Drone Path",
+			params: generated.GetEstateIdMapSvgParams{DronePath: ptr(true), Pattern: ptr("columns"), MaxDistance: ptr(50)},
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID).Return(estate, nil).Times(2)
+			},
+			expectedStatus: http.StatusOK,
+			expectedSvg:    `<circle`,
+		},
+		{
+			name:           "Too Few Cells",
+			params:         generated.GetEstateIdMapSvgParams{MaxCells: ptr(5)},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown Pattern",
+			params:         generated.GetEstateIdMapSvgParams{Pattern: ptr("zigzag")},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Estate Not Found",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID).
+					Return(nil, apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound)).Times(2)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+			if tc.setup != nil {
+				tc.setup(mockRepo)
+			}
+
+			rec := httptest.NewRecorder()
+			err := server.GetEstateIdMapSvg(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, tc.params)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "image/svg+xml", rec.Header().Get(echo.HeaderContentType))
+				assert.True(t, strings.HasPrefix(rec.Body.String(), "<svg"))
+				assert.Contains(t, rec.Body.String(), tc.expectedSvg)
+			}
+
+			rec = httptest.NewRecorder()
+			err = server.GetEstateIdMapPng(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, generated.GetEstateIdMapPngParams(tc.params))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+				img, err := png.Decode(bytes.NewReader(rec.Body.Bytes()))
+				assert.NoError(t, err)
+				assert.Equal(t, 100, img.Bounds().Dx())
+			}
+		})
+	}
+}
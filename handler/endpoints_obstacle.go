@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	utilvalidator "github.com/SawitProRecruitment/UserService/utils/validator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// List the obstacles of an estate
+// (GET /estate/{id}/obstacles)
+func (s *Server) GetEstateIdObstacles(c echo.Context, id openapi_types.UUID) error {
+	ctx := c.Request().Context()
+
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, id, repository.RELATION_TREES, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	resp := generated.ObstacleList{
+		Data: make([]generated.Obstacle, 0, len(estate.Obstacles)),
+	}
+	for _, obstacle := range estate.Obstacles {
+		resp.Data = append(resp.Data, generated.Obstacle{
+			Id:     obstacle.Id,
+			X:      obstacle.X,
+			Y:      obstacle.Y,
+			Height: obstacle.Height,
+			NoFly:  obstacle.IsNoFly(),
+			Label:  obstacle.Label,
+		})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// Add an obstacle to an estate
+// (POST /estate/{id}/obstacles)
+func (s *Server) PostEstateIdObstacles(c echo.Context, id openapi_types.UUID) error {
+	ctx := c.Request().Context()
+	payload := generated.CreateObstacleRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return httphelper.HttpRespError(c,
+			apperror.WrapWithCode(fmt.Errorf("failed to unmarshall request: %w", err),
+				http.StatusBadRequest))
+	}
+
+	// Validate payload
+	if err := s.Validator.Struct(payload); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+	noFly := payload.NoFly != nil && *payload.NoFly
+	if (payload.Height != nil) == noFly {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors: map[string]string{
+				"Height": "Exactly one of Height or NoFly is required",
+				"NoFly":  "Exactly one of Height or NoFly is required",
+			},
+		})
+	}
+
+	input := repository.CreateObstacleInput{
+		Id:       uuid.New(),
+		EstateId: id,
+		X:        payload.X,
+		Y:        payload.Y,
+		Height:   payload.Height,
+	}
+	if payload.Label != nil {
+		input.Label = *payload.Label
+	}
+
+	// #1. Insert the obstacle, one per plot
+	if err := s.Repository.CreateObstacle(ctx, input); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	// #2. Recalculate the stats, the drone climbs over or routes around the obstacle
+	if err := s.calculateStats(ctx, id); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	obstacleID := openapi_types.UUID(input.Id)
+	return c.JSON(http.StatusCreated, generated.CreateObstacleResponse{Id: &obstacleID})
+}
+
+// Remove an obstacle
+// (DELETE /estate/{id}/obstacles/{obstacleId})
+func (s *Server) DeleteEstateIdObstaclesObstacleId(c echo.Context, id openapi_types.UUID, obstacleId openapi_types.UUID) error {
+	ctx := c.Request().Context()
+
+	if err := s.Repository.DeleteObstacle(ctx, id, obstacleId); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	if err := s.calculateStats(ctx, id); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,273 @@
+package handler_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetEstateIdObstacles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo}
+	e := echo.New()
+
+	testID := uuid.New()
+	towerID := uuid.New()
+	substationID := uuid.New()
+
+	mockRepo.EXPECT().
+		GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_TREES, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS).
+		Return(&repository.Estate{
+			Id:     testID,
+			Width:  5,
+			Length: 5,
+			Obstacles: []repository.Obstacle{
+				{Id: towerID, X: 2, Y: 1, Height: ptr(30), Label: "tower"},
+				{Id: substationID, X: 4, Y: 3, Label: "substation"},
+			},
+		}, nil)
+
+	rec := httptest.NewRecorder()
+	err := server.GetEstateIdObstacles(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"data": [
+			{"id":"`+towerID.String()+`","x":2,"y":1,"height":30,"no_fly":false,"label":"tower"},
+			{"id":"`+substationID.String()+`","x":4,"y":3,"no_fly":true,"label":"substation"}
+		]
+	}`, rec.Body.String())
+}
+
+func TestPostEstateIdObstacles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{
+		Repository: mockRepo,
+		Validator:  validator.New(),
+	}
+	e := echo.New()
+
+	testID := uuid.New()
+
+	expectCalculateStats := func(mockRepo *repository.MockRepositoryInterface) {
+		mockRepo.EXPECT().
+			GetCalculatedEstateStats(gomock.Any(), testID).
+			Return(&repository.EstateStats{}, nil)
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), testID).
+			Return(&repository.Estate{Id: testID, Width: 2, Length: 2, Stats: &repository.EstateStats{}}, nil)
+		mockRepo.EXPECT().
+			UpsertEstateStats(gomock.Any(), testID, gomock.Any()).
+			Return(nil)
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		expectedBody   string
+		setup          func(*repository.MockRepositoryInterface)
+	}{
+		{
+			name:           "Success - Height",
+			requestBody:    `{"x": 2, "y": 1, "height": 30, "label": "tower"}`,
+			expectedStatus: http.StatusCreated,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateObstacle(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateObstacleInput) error {
+						assert.Equal(t, testID, input.EstateId)
+						assert.Equal(t, 2, input.X)
+						assert.Equal(t, 1, input.Y)
+						assert.Equal(t, ptr(30), input.Height)
+						assert.Equal(t, "tower", input.Label)
+						return nil
+					})
+				expectCalculateStats(mockRepo)
+			},
+		},
+		{
+			name:           "Success - No Fly",
+			requestBody:    `{"x": 2, "y": 2, "no_fly": true}`,
+			expectedStatus: http.StatusCreated,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateObstacle(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateObstacleInput) error {
+						assert.Nil(t, input.Height)
+						return nil
+					})
+				expectCalculateStats(mockRepo)
+			},
+		},
+		{
+			name:           "Both Height And No Fly",
+			requestBody:    `{"x": 2, "y": 2, "height": 30, "no_fly": true}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"Height":"Exactly one of Height or NoFly is required","NoFly":"Exactly one of Height or NoFly is required"}}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Neither Height Nor No Fly",
+			requestBody:    `{"x": 2, "y": 2, "no_fly": false}`,
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Invalid Height",
+			requestBody:    `{"x": 2, "y": 2, "height": 501}`,
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Plot Already Has Obstacle",
+			requestBody:    `{"x": 2, "y": 2, "no_fly": true}`,
+			expectedStatus: http.StatusConflict,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateObstacle(gomock.Any(), gomock.Any()).
+					Return(apperror.WrapWithCode(errors.New("plot (2,2) already has an obstacle"), http.StatusConflict))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/estate/"+testID.String()+"/obstacles", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tc.setup(mockRepo)
+
+			err := server.PostEstateIdObstacles(c, testID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeleteEstateIdObstaclesObstacleId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo}
+	e := echo.New()
+
+	testID := uuid.New()
+	obstacleID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().DeleteObstacle(gomock.Any(), testID, obstacleID).Return(nil)
+		mockRepo.EXPECT().GetCalculatedEstateStats(gomock.Any(), testID).Return(&repository.EstateStats{}, nil)
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), testID).
+			Return(&repository.Estate{Id: testID, Width: 2, Length: 2, Stats: &repository.EstateStats{}}, nil)
+		mockRepo.EXPECT().UpsertEstateStats(gomock.Any(), testID, gomock.Any()).Return(nil)
+
+		rec := httptest.NewRecorder()
+		err := server.DeleteEstateIdObstaclesObstacleId(e.NewContext(httptest.NewRequest(http.MethodDelete, "/", nil), rec), testID, obstacleID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("Obstacle Not Found", func(t *testing.T) {
+		mockRepo.EXPECT().
+			DeleteObstacle(gomock.Any(), testID, obstacleID).
+			Return(apperror.WrapWithCode(errors.New("obstacle not found"), http.StatusNotFound))
+
+		rec := httptest.NewRecorder()
+		err := server.DeleteEstateIdObstaclesObstacleId(e.NewContext(httptest.NewRequest(http.MethodDelete, "/", nil), rec), testID, obstacleID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetEstateIdDronePlan_Coverage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo, Validator: validator.New(), Config: &config.Config{}}
+	e := echo.New()
+
+	testID := uuid.New()
+	treeID := uuid.New()
+
+	t.Run("Stored distance without no-fly plot", func(t *testing.T) {
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), testID).
+			Return(&repository.Estate{
+				Id:        testID,
+				Width:     1,
+				Length:    3,
+				Stats:     &repository.EstateStats{DroneDistance: 40, DroneDuration: 10, DroneEnergy: 0.69, DroneProfile: config.DefaultDroneProfile().String()},
+				Obstacles: []repository.Obstacle{{X: 2, Y: 1, Height: ptr(9)}},
+			}, nil)
+
+		rec := httptest.NewRecorder()
+		err := server.GetEstateIdDronePlan(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, generated.GetEstateIdDronePlanParams{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"distance":40,"duration":10,"energy":0.69,"pattern":"rows","coverage":{"fully_covered":true,"uncovered_plots":0,"uncovered_trees":[]}}`, rec.Body.String())
+	})
+
+	t.Run("Plot walled in by no-fly plots", func(t *testing.T) {
+		// (1,3) is walled in by the no-fly plots (1,2) & (2,3)
+		mockRepo.EXPECT().
+			GetEstateWithAllDetails(gomock.Any(), testID).
+			Return(&repository.Estate{
+				Id:        testID,
+				Width:     3,
+				Length:    3,
+				Stats:     &repository.EstateStats{},
+				Trees:     []repository.Tree{{Id: treeID, X: 1, Y: 3, Height: 5, Health: repository.TREE_HEALTHY}},
+				Obstacles: []repository.Obstacle{{X: 1, Y: 2}, {X: 2, Y: 3}},
+			}, nil)
+
+		rec := httptest.NewRecorder()
+		err := server.GetEstateIdDronePlan(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, generated.GetEstateIdDronePlanParams{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"distance": 62,
+			"duration": 7,
+			"energy": 0.39,
+			"pattern": "rows",
+			"coverage": {
+				"fully_covered": false,
+				"uncovered_plots": 3,
+				"uncovered_trees": [{"id":"`+treeID.String()+`","x":1,"y":3,"height":5,"health":"healthy","tags":[]}]
+			}
+		}`, rec.Body.String())
+	})
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	utilvalidator "github.com/SawitProRecruitment/UserService/utils/validator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	// maxDroneImpactTrees bound the drone plans computed for a planting plan, one per suggestion
+	maxDroneImpactTrees = 100
+	// maxDroneImpactPlots bound the plots walked by all these drone plans, as each one walks the whole estate
+	maxDroneImpactPlots = 10_000_000
+)
+
+// Suggest plots for new trees
+// (POST /estate/{id}/planting-plan)
+func (s *Server) PostEstateIdPlantingPlan(c echo.Context, id openapi_types.UUID, params generated.PostEstateIdPlantingPlanParams) error {
+	ctx := c.Request().Context()
+	payload := generated.PlantingPlanRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return httphelper.HttpRespError(c,
+			apperror.WrapWithCode(fmt.Errorf("failed to unmarshall request: %w", err),
+				http.StatusBadRequest))
+	}
+
+	// Validate payload
+	if err := s.Validator.Struct(payload); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+	droneImpact := payload.DroneImpact != nil && *payload.DroneImpact
+	if droneImpact && payload.Count > maxDroneImpactTrees {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors: map[string]string{
+				"DroneImpact": fmt.Sprintf("DroneImpact is limited to %d trees", maxDroneImpactTrees),
+			},
+		})
+	}
+
+	opts := plantingOptions{Count: payload.Count, MinDistance: 1, Pattern: plantingFill}
+	if payload.MinDistance != nil {
+		opts.MinDistance = *payload.MinDistance
+	}
+	if payload.Pattern != nil {
+		opts.Pattern = *payload.Pattern
+	}
+	height := 1
+	if payload.Height != nil {
+		height = *payload.Height
+	}
+
+	// #1. The planted, excluded & obstacle plots of the estate
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, id, repository.RELATION_STATS)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+	// the current plan, a plan per suggestion & the plan with every suggestion
+	estatePlots := max(int64(estate.Width)*int64(estate.Length), 1)
+	if droneImpact && (int64(payload.Count)+2)*estatePlots > maxDroneImpactPlots {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors: map[string]string{
+				"DroneImpact": fmt.Sprintf("DroneImpact is limited to %d trees on this estate", max(maxDroneImpactPlots/estatePlots-2, 0)),
+			},
+		})
+	}
+
+	// #2. Lay out the new trees
+	plots := planPlanting(estate, opts)
+	resp := generated.PlantingPlanResponse{
+		Requested:   payload.Count,
+		Pattern:     opts.Pattern,
+		MinDistance: opts.MinDistance,
+		Suggestions: make([]generated.PlantingSuggestion, 0, len(plots)),
+	}
+	for _, p := range plots {
+		resp.Suggestions = append(resp.Suggestions, generated.PlantingSuggestion{X: p.X, Y: p.Y})
+	}
+
+	// #3. The drone distance with each suggestion alone, then with all of them, planned like the stored one
+	if droneImpact {
+		options := droneOptions{Profile: s.defaultDroneProfile(), SkipExcluded: s.skipExcludedPlots(nil)}
+		current := calculateDroneDistance(estate, options).Distance
+		for i, p := range plots {
+			planned := calculateDroneDistance(withTrees(estate, []repository.Point{p}, height), options).Distance
+			resp.Suggestions[i].DroneDistanceDelta = ptr.ToPointer(planned - current)
+		}
+		resp.DroneDistance = &current
+		resp.PlannedDroneDistance = ptr.ToPointer(calculateDroneDistance(withTrees(estate, plots, height), options).Distance)
+	}
+
+	if params.Apply == nil || !*params.Apply || len(plots) == 0 {
+		return c.JSON(http.StatusOK, resp)
+	}
+
+	// #4. Plant every suggestion together, then recalculate the stats
+	y, m, d := time.Now().Date()
+	plantedAt := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	inputs := make([]repository.CreateTreeInput, 0, len(plots))
+	for i, p := range plots {
+		input := repository.CreateTreeInput{
+			Id:        uuid.New(),
+			EstateId:  id,
+			X:         p.X,
+			Y:         p.Y,
+			Height:    height,
+			PlantedAt: &plantedAt,
+		}
+		if payload.Species != nil {
+			input.Species = *payload.Species
+		}
+		inputs = append(inputs, input)
+		resp.Suggestions[i].TreeId = &input.Id
+	}
+	if err := s.Repository.CreateTrees(ctx, id, inputs); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+	if err := s.calculateStats(ctx, id); err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	resp.Applied = true
+	return c.JSON(http.StatusCreated, resp)
+}
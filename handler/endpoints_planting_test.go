@@ -0,0 +1,181 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestPostEstateIdPlantingPlan(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	estate := &repository.Estate{Id: testID, Width: 1, Length: 5, Trees: []repository.Tree{{X: 1, Y: 1, Height: 5}}}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		params         generated.PostEstateIdPlantingPlanParams
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedError  string
+		check          func(*testing.T, generated.PlantingPlanResponse)
+	}{
+		{
+			name:        "Plan Only",
+			requestBody: `{"count":2,"min_distance":2,"pattern":"square"}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS).Return(estate, nil)
+			},
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, resp generated.PlantingPlanResponse) {
+				assert.False(t, resp.Applied)
+				assert.Equal(t, "square", resp.Pattern)
+				assert.Equal(t, []generated.PlantingSuggestion{{X: 3, Y: 1}, {X: 5, Y: 1}}, resp.Suggestions)
+			},
+		},
+		{
+			name:        "Drone Impact",
+			requestBody: `{"count":1,"height":10,"drone_impact":true}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS).Return(estate, nil)
+			},
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, resp generated.PlantingPlanResponse) {
+				// a tree of 10 meters next to a tree of 5 meters, the drone climb 5 meters more & come down 10 meters more
+				assert.Equal(t, []generated.PlantingSuggestion{{X: 2, Y: 1, DroneDistanceDelta: ptr(int64(10))}}, resp.Suggestions)
+				assert.Equal(t, *resp.DroneDistance+10, *resp.PlannedDroneDistance)
+			},
+		},
+		{
+			name:        "Apply",
+			requestBody: `{"count":2,"species":"Tenera"}`,
+			params:      generated.PostEstateIdPlantingPlanParams{Apply: ptr(true)},
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS).Return(estate, nil)
+				mockRepo.EXPECT().CreateTrees(gomock.Any(), testID, gomock.Any()).
+					DoAndReturn(func(_ any, _ uuid.UUID, inputs []repository.CreateTreeInput) error {
+						assert.Len(t, inputs, 2)
+						for i, input := range inputs {
+							assert.Equal(t, i+2, input.X)
+							assert.Equal(t, 1, input.Height)
+							assert.Equal(t, "Tenera", input.Species)
+							assert.NotNil(t, input.PlantedAt)
+						}
+						return nil
+					})
+				mockRepo.EXPECT().GetCalculatedEstateStats(gomock.Any(), testID).Return(&repository.EstateStats{TreeCount: 3}, nil)
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID).
+					Return(&repository.Estate{Id: testID, Width: 1, Length: 5, Stats: &repository.EstateStats{}}, nil)
+				mockRepo.EXPECT().UpsertEstateStats(gomock.Any(), testID, gomock.Any()).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+			check: func(t *testing.T, resp generated.PlantingPlanResponse) {
+				assert.True(t, resp.Applied)
+				for _, suggestion := range resp.Suggestions {
+					assert.NotNil(t, suggestion.TreeId)
+				}
+			},
+		},
+		{
+			name:        "Apply Without Free Plots",
+			requestBody: `{"count":2,"min_distance":5}`,
+			params:      generated.PostEstateIdPlantingPlanParams{Apply: ptr(true)},
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS).Return(estate, nil)
+			},
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, resp generated.PlantingPlanResponse) {
+				assert.False(t, resp.Applied)
+				assert.Empty(t, resp.Suggestions)
+			},
+		},
+		{
+			name:        "Plot Taken Meanwhile",
+			requestBody: `{"count":1}`,
+			params:      generated.PostEstateIdPlantingPlanParams{Apply: ptr(true)},
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS).Return(estate, nil)
+				mockRepo.EXPECT().CreateTrees(gomock.Any(), testID, gomock.Any()).
+					Return(apperror.WrapWithCode(errors.New("plot (2,1) already has a tree"), http.StatusUnprocessableEntity))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "No Count",
+			requestBody:    `{"min_distance":2}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Count",
+		},
+		{
+			name:           "Unknown Pattern",
+			requestBody:    `{"count":1,"pattern":"hexagonal"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Pattern",
+		},
+		{
+			name:           "Drone Impact Of Too Many Trees",
+			requestBody:    `{"count":101,"drone_impact":true}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "DroneImpact",
+		},
+		{
+			name:        "Drone Impact On A Large Estate",
+			requestBody: `{"count":10,"drone_impact":true}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				large := &repository.Estate{Id: testID, Width: 1000, Length: 1000}
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS).Return(large, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "DroneImpact",
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: `{"count":1}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, gomock.Any()).
+					Return(nil, apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+			if tc.setup != nil {
+				tc.setup(mockRepo)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			err := server.PostEstateIdPlantingPlan(e.NewContext(req, rec), testID, tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedError != "" {
+				assert.Contains(t, rec.Body.String(), `"`+tc.expectedError+`"`)
+			}
+			if tc.check != nil {
+				var resp generated.PlantingPlanResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				tc.check(t, resp)
+			}
+		})
+	}
+}
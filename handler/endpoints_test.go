@@ -2,15 +2,18 @@ package handler_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/SawitProRecruitment/UserService/config"
 	"github.com/SawitProRecruitment/UserService/generated"
 	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/plancache"
 	"github.com/SawitProRecruitment/UserService/repository"
 	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
 	"github.com/go-playground/validator/v10"
@@ -165,6 +168,26 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 		// 			Times(1)
 		// 	},
 		// },
+		{
+			name:           "Success with max energy",
+			id:             validID,
+			params:         generated.GetEstateIdDronePlanParams{MaxEnergy: ptr(0.5)},
+			mockReturnData: mockEstate,
+			expectedStatus: http.StatusOK,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					GetEstateWithAllDetails(gomock.Any(), validID).
+					Return(mockEstate, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "More than one limit",
+			id:             validID,
+			params:         generated.GetEstateIdDronePlanParams{MaxDistance: ptr(1000), MaxDuration: ptr(60)},
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
 		{
 			name:           "Repository Error",
 			id:             validID,
@@ -196,6 +219,300 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 	}
 }
 
+func TestGetEstateIdDronePlan_Profile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo, Validator: validator.New(), Config: &config.Config{}}
+	e := echo.New()
+
+	validID := openapi_types.UUID(uuid.New())
+	newEstate := func(stats *repository.EstateStats) *repository.Estate {
+		return &repository.Estate{
+			Id:     validID,
+			Width:  1,
+			Length: 5,
+			Trees: []repository.Tree{
+				{X: 2, Y: 1, Height: 5},
+				{X: 3, Y: 1, Height: 3},
+				{X: 4, Y: 1, Height: 4},
+			},
+			Stats: stats,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateIdDronePlanParams
+		stats          *repository.EstateStats
+		expectedStatus int
+		expectedPlan   string
+	}{
+		{
+			name:           "Stored distance of the default profile",
+			stats:          &repository.EstateStats{DroneDistance: 54, DroneDuration: 10, DroneEnergy: 0.63, DroneProfile: config.DefaultDroneProfile().String()},
+			expectedStatus: http.StatusOK,
+			expectedPlan:   `"distance":54,"duration":10,"energy":0.63`,
+		},
+		{
+			name:           "Stored distance of another profile is recalculated",
+			stats:          &repository.EstateStats{DroneDistance: 38, DroneProfile: "plot=5,clearance=3,takeoff=1,cruise=1,ascent=1,descent=1"},
+			expectedStatus: http.StatusOK,
+			expectedPlan:   `"distance":54,"duration":10,"energy":0.63`,
+		},
+		{
+			name:           "Request profile",
+			params:         generated.GetEstateIdDronePlanParams{PlotSize: ptr(5), Clearance: ptr(3)},
+			stats:          &repository.EstateStats{DroneDistance: 54, DroneDuration: 10, DroneEnergy: 0.63, DroneProfile: config.DefaultDroneProfile().String()},
+			expectedStatus: http.StatusOK,
+			expectedPlan:   `"distance":38,"duration":10,"energy":0.63`,
+		},
+		{
+			name:           "Invalid ascent cost",
+			params:         generated.GetEstateIdDronePlanParams{AscentCost: ptr(0.0)},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.stats != nil {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), validID).Return(newEstate(tc.stats), nil)
+			}
+
+			rec := httptest.NewRecorder()
+			err := server.GetEstateIdDronePlan(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), validID, tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedPlan != "" {
+				assert.JSONEq(t, `{`+tc.expectedPlan+`,"pattern":"rows","coverage":{"fully_covered":true,"uncovered_plots":0,"uncovered_trees":[]}}`, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetEstateIdDronePlan_Pattern(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo, Validator: validator.New(), Config: &config.Config{}}
+	e := echo.New()
+
+	validID := openapi_types.UUID(uuid.New())
+	estate := &repository.Estate{
+		Id:     validID,
+		Width:  2,
+		Length: 3,
+		Trees: []repository.Tree{
+			{X: 1, Y: 1, Height: 10}, {X: 1, Y: 2, Height: 10},
+			{X: 2, Y: 1, Height: 1}, {X: 2, Y: 2, Height: 1},
+			{X: 3, Y: 1, Height: 10}, {X: 3, Y: 2, Height: 10},
+		},
+		Stats: &repository.EstateStats{DroneDistance: 108, DroneDuration: 29, DroneEnergy: 1.96, DroneProfile: config.DefaultDroneProfile().String()},
+	}
+
+	tests := []struct {
+		name           string
+		pattern        *string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Stored distance of rows",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"distance":108,"duration":29,"energy":1.96,"pattern":"rows","coverage":{"fully_covered":true,"uncovered_plots":0,"uncovered_trees":[]}}`,
+		},
+		{
+			name:           "Spiral",
+			pattern:        ptr("spiral"),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"distance":108,"duration":29,"energy":1.96,"pattern":"spiral","coverage":{"fully_covered":true,"uncovered_plots":0,"uncovered_trees":[]}}`,
+		},
+		{
+			name:           "Auto picks the shortest pattern",
+			pattern:        ptr("auto"),
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"distance":90,"duration":22,"energy":1.44,"pattern":"columns","coverage":{"fully_covered":true,"uncovered_plots":0,"uncovered_trees":[]}}`,
+		},
+		{
+			name:           "Unknown pattern",
+			pattern:        ptr("zigzag"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.expectedStatus == http.StatusOK {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), validID).Return(estate, nil)
+			}
+
+			rec := httptest.NewRecorder()
+			params := generated.GetEstateIdDronePlanParams{Pattern: tc.pattern}
+			err := server.GetEstateIdDronePlan(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), validID, params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetEstateIdDronePlan_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{
+		Repository: mockRepo,
+		Validator:  validator.New(),
+		Config:     &config.Config{},
+		PlanCache:  plancache.NewCache(plancache.NewCacheOptions{Config: config.PlanCache{Size: 10}}),
+	}
+	e := echo.New()
+
+	validID := openapi_types.UUID(uuid.New())
+	estate := repository.Estate{
+		Id:      validID,
+		Width:   2,
+		Length:  3,
+		Version: 1,
+		Trees: []repository.Tree{
+			{X: 1, Y: 1, Height: 10}, {X: 1, Y: 2, Height: 10},
+			{X: 2, Y: 1, Height: 1}, {X: 2, Y: 2, Height: 1},
+			{X: 3, Y: 1, Height: 10}, {X: 3, Y: 2, Height: 10},
+		},
+		Stats: &repository.EstateStats{DroneDistance: 108, DroneDuration: 29, DroneEnergy: 1.96, DroneProfile: config.DefaultDroneProfile().String()},
+	}
+	changed := estate
+	changed.Version = 2
+
+	spiral := generated.GetEstateIdDronePlanParams{Pattern: ptr("spiral")}
+	spiralBody := `{"distance":108,"duration":29,"energy":1.96,"pattern":"spiral","coverage":{"fully_covered":true,"uncovered_plots":0,"uncovered_trees":[]}}`
+	rowsBody := `{"distance":108,"duration":29,"energy":1.96,"pattern":"rows","coverage":{"fully_covered":true,"uncovered_plots":0,"uncovered_trees":[]}}`
+	tests := []struct {
+		name          string
+		estate        *repository.Estate
+		params        generated.GetEstateIdDronePlanParams
+		expectedCache string
+		expectedLayer string
+		expectedBody  string
+	}{
+		{name: "First request is a miss", estate: &estate, params: spiral, expectedCache: "MISS", expectedBody: spiralBody},
+		{name: "Same parameters hit the cache", estate: &estate, params: spiral, expectedCache: "HIT", expectedLayer: "memory", expectedBody: spiralBody},
+		{name: "Stored distance does not use the cache", estate: &estate, expectedBody: rowsBody},
+		{name: "Changed estate is a miss", estate: &changed, params: spiral, expectedCache: "MISS", expectedBody: spiralBody},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), validID).Return(tc.estate, nil)
+
+			rec := httptest.NewRecorder()
+			err := server.GetEstateIdDronePlan(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), validID, tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.expectedCache, rec.Header().Get("X-Cache"))
+			assert.Equal(t, tc.expectedLayer, rec.Header().Get("X-Cache-Layer"))
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestGetEstateId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{
+		Repository: mockRepo,
+	}
+	e := echo.New()
+
+	testID := uuid.New()
+	treeID := uuid.New()
+	plantedAt := time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		expectedStatus int
+		expectedBody   string
+		setup          func(*repository.MockRepositoryInterface)
+	}{
+		{
+			name:           "Success",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"` + testID.String() + `","width":2,"length":3,"trees":[{"id":"` + treeID.String() + `","x":2,"y":1,"height":7,"species":"Tenera","planted_at":"2020-01-15","health":"diseased","tags":["block-a"]}]}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS).
+					Return(&repository.Estate{
+						Id:     testID,
+						Width:  2,
+						Length: 3,
+						Trees: []repository.Tree{{
+							Id:        treeID,
+							EstateId:  testID,
+							X:         2,
+							Y:         1,
+							Height:    7,
+							Species:   "Tenera",
+							PlantedAt: &plantedAt,
+							Health:    repository.TREE_DISEASED,
+							Tags:      []string{"block-a"},
+						}},
+					}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "Success - Without Trees",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"` + testID.String() + `","width":2,"length":3,"trees":[]}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS).
+					Return(&repository.Estate{Id: testID, Width: 2, Length: 3}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "Estate Not Found",
+			expectedStatus: http.StatusNotFound,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS).
+					Return(nil, apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound)).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/estate/"+testID.String(), nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tc.setup(mockRepo)
+
+			err := server.GetEstateId(c, testID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestGetEstateIdStats(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -203,6 +520,7 @@ func TestGetEstateIdStats(t *testing.T) {
 	mockRepo := repository.NewMockRepositoryInterface(ctrl)
 	server := &handler.Server{
 		Repository: mockRepo,
+		Validator:  validator.New(),
 	}
 	e := echo.New()
 
@@ -212,8 +530,10 @@ func TestGetEstateIdStats(t *testing.T) {
 	tests := []struct {
 		name           string
 		estateID       uuid.UUID
+		params         generated.GetEstateIdStatsParams
 		expectedStatus int
 		expectedBody   generated.EstateStats
+		expectedGroups string // JSON of the groups, empty when not grouped
 		setup          func(*repository.MockRepositoryInterface)
 	}{
 		{
@@ -240,6 +560,31 @@ func TestGetEstateIdStats(t *testing.T) {
 					Times(1)
 			},
 		},
+		{
+			name:           "Group By Species",
+			estateID:       testID,
+			params:         generated.GetEstateIdStatsParams{GroupBy: ptr("species")},
+			expectedStatus: http.StatusOK,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_TREES).
+					Return(&repository.Estate{Stats: &repository.EstateStats{TreeCount: 3}}, nil)
+				mockRepo.EXPECT().
+					GetTreeGroupStats(gomock.Any(), testID, repository.TREE_GROUP_SPECIES).
+					Return([]repository.TreeGroupStats{
+						{Value: "", TreeCount: 1, MaxHeight: 4, MinHeight: 4, MedianHeight: 4},
+						{Value: "Tenera", TreeCount: 2, MaxHeight: 9, MinHeight: 5, MedianHeight: 7},
+					}, nil)
+			},
+			expectedGroups: `[{"value":"","count":1,"max":4,"min":4,"median":4},{"value":"Tenera","count":2,"max":9,"min":5,"median":7}]`,
+		},
+		{
+			name:           "Invalid Group By",
+			estateID:       testID,
+			params:         generated.GetEstateIdStatsParams{GroupBy: ptr("height")},
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
 		{
 			name:           "Estate Not Found",
 			estateID:       testID,
@@ -274,10 +619,17 @@ func TestGetEstateIdStats(t *testing.T) {
 
 			tc.setup(mockRepo)
 
-			err := server.GetEstateIdStats(c, testID)
+			err := server.GetEstateIdStats(c, testID, tc.params)
 
 			assert.NoError(t, err)
 			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedGroups != "" {
+				var body struct {
+					Groups json.RawMessage `json:"groups"`
+				}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.JSONEq(t, tc.expectedGroups, string(body.Groups))
+			}
 		})
 	}
 }
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Get a map tile of the estate
+// (GET /estate/{id}/tiles/{z}/{x}/{tile})
+func (s *Server) GetEstateIdTilesZXTile(c echo.Context, id openapi_types.UUID, z int, x int, tile string) error {
+	ctx := c.Request().Context()
+
+	// Validate payload
+	y, format, errs := parseTile(tile)
+	if errs == nil && (z < 0 || x < 0) {
+		errs = map[string]string{"Z": "Z and X must be at least 0", "X": "Z and X must be at least 0"}
+	}
+	if errs != nil {
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  errs,
+		})
+	}
+
+	// the tiles only need the size of the estate
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, id,
+		repository.RELATION_TREES, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+	input, err := tileInput(estate, z, x, y)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	cells, err := s.Repository.GetTileCells(ctx, input)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	if format == tileFormatJSON {
+		return c.JSON(http.StatusOK, toGeneratedTile(estate, input, cells))
+	}
+	img, err := renderTilePNG(estate, input, cells)
+	if err != nil {
+		return httphelper.HttpRespError(c, apperror.WrapWithCode(err, http.StatusInternalServerError))
+	}
+	return c.Blob(http.StatusOK, "image/png", img)
+}
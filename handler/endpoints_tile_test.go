@@ -0,0 +1,128 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetEstateIdTilesZXTile(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	// max zoom 2, a cell is 4 plots at zoom 0 & 2 plots at zoom 1
+	estate := &repository.Estate{Id: testID, Width: 600, Length: 1000}
+	expectEstate := func(mockRepo *repository.MockRepositoryInterface) {
+		mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID,
+			repository.RELATION_TREES, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES).
+			Return(estate, nil)
+	}
+
+	tests := []struct {
+		name           string
+		z, x           int
+		tile           string
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedTile   *generated.Tile
+	}{
+		{
+			name: "JSON",
+			z:    1,
+			x:    1,
+			tile: "0.json",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				expectEstate(mockRepo)
+				mockRepo.EXPECT().GetTileCells(gomock.Any(), repository.GetTileCellsInput{EstateId: testID, Zoom: 1, MaxZoom: 2, X: 1, Y: 0}).
+					Return([]repository.TileCell{{X: 300, Y: 2, TreeCount: 3, SumHeight: 20, MaxHeight: 9}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedTile: &generated.Tile{
+				Z: 1, X: 1, Y: 0, MaxZoom: 2, CellSize: 2, TileSize: 256,
+				Cells: []generated.TileCell{{X: 601, Y: 5, TreeCount: 3, AvgHeight: 20.0 / 3, MaxHeight: 9}},
+			},
+		},
+		{
+			name: "PNG",
+			z:    0,
+			x:    0,
+			tile: "0.png",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				expectEstate(mockRepo)
+				mockRepo.EXPECT().GetTileCells(gomock.Any(), repository.GetTileCellsInput{EstateId: testID, Zoom: 0, MaxZoom: 2}).
+					Return([]repository.TileCell{{X: 0, Y: 0, TreeCount: 1, SumHeight: 30, MaxHeight: 30}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Format",
+			tile:           "0.jpg",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Past The Max Zoom",
+			z:              3,
+			tile:           "0.json",
+			setup:          expectEstate,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Outside The Estate",
+			z:              2,
+			x:              0,
+			tile:           "3.png",
+			setup:          expectEstate,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "Estate Not Found",
+			tile: "0.json",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, gomock.Any()).
+					Return(nil, apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+			if tc.setup != nil {
+				tc.setup(mockRepo)
+			}
+
+			rec := httptest.NewRecorder()
+			err := server.GetEstateIdTilesZXTile(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, tc.z, tc.x, tc.tile)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedTile != nil {
+				var tile generated.Tile
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tile))
+				assert.Equal(t, *tc.expectedTile, tile)
+			}
+			if tc.expectedStatus == http.StatusOK && tc.expectedTile == nil {
+				assert.Equal(t, "image/png", rec.Header().Get(echo.HeaderContentType))
+				img, err := png.Decode(bytes.NewReader(rec.Body.Bytes()))
+				assert.NoError(t, err)
+				assert.Equal(t, 256, img.Bounds().Dx())
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestGetEstateIdTree(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	tenera, dura, pisifera := uuid.New(), uuid.New(), uuid.New()
+	trees := []repository.Tree{
+		{Id: tenera, X: 2, Y: 2, Height: 9, Species: "Tenera", Health: repository.TREE_HEALTHY, Tags: []string{"block-a"}},
+		{Id: dura, X: 3, Y: 1, Height: 4, Species: "Dura", Health: repository.TREE_DISEASED, Tags: []string{"block-a", "treated"}},
+		{Id: pisifera, X: 1, Y: 2, Height: 6, Species: "Tenera", Health: repository.TREE_DEAD},
+	}
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateIdTreeParams
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedIds    []uuid.UUID
+	}{
+		{
+			name:           "All Trees By Plot",
+			expectedStatus: http.StatusOK,
+			expectedIds:    []uuid.UUID{dura, pisifera, tenera},
+		},
+		{
+			name:           "Filter By Species",
+			params:         generated.GetEstateIdTreeParams{Species: ptr("Tenera")},
+			expectedStatus: http.StatusOK,
+			expectedIds:    []uuid.UUID{pisifera, tenera},
+		},
+		{
+			name:           "Filter By Health & Tag",
+			params:         generated.GetEstateIdTreeParams{Health: ptr("diseased"), Tag: ptr("block-a")},
+			expectedStatus: http.StatusOK,
+			expectedIds:    []uuid.UUID{dura},
+		},
+		{
+			name:           "No Match",
+			params:         generated.GetEstateIdTreeParams{Tag: ptr("block-b")},
+			expectedStatus: http.StatusOK,
+			expectedIds:    []uuid.UUID{},
+		},
+		{
+			name:           "Invalid Health",
+			params:         generated.GetEstateIdTreeParams{Health: ptr("sick")},
+			setup:          func(*repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Estate Not Found",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID, gomock.Any()).
+					Return(nil, apperror.WrapWithCode(errors.New("estate not found"), http.StatusNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+
+			if tc.setup != nil {
+				tc.setup(mockRepo)
+			} else {
+				mockRepo.EXPECT().
+					GetEstateWithAllDetails(gomock.Any(), testID, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES).
+					Return(&repository.Estate{Id: testID, Width: 3, Length: 3, Trees: trees}, nil)
+			}
+
+			rec := httptest.NewRecorder()
+			err := server.GetEstateIdTree(e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec), testID, tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedIds != nil {
+				var body generated.TreeList
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				ids := make([]uuid.UUID, 0, len(body.Data))
+				for _, tree := range body.Data {
+					ids = append(ids, tree.Id)
+				}
+				assert.Equal(t, tc.expectedIds, ids)
+			}
+		})
+	}
+}
+
+func TestPostEstateIdTree_Attributes(t *testing.T) {
+	e := echo.New()
+	testID := uuid.New()
+	tomorrow := time.Now().AddDate(0, 0, 2).Format(time.DateOnly)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		expectedInput  *repository.CreateTreeInput
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "Every Attribute",
+			requestBody: `{"x":1,"y":1,"height":5,"species":"Tenera","planted_at":"2020-01-15","health":"diseased","tags":["block-a","treated"]}`,
+			expectedInput: &repository.CreateTreeInput{
+				EstateId:  testID,
+				X:         1,
+				Y:         1,
+				Height:    5,
+				Species:   "Tenera",
+				PlantedAt: ptr(time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)),
+				Health:    repository.TREE_DISEASED,
+				Tags:      []string{"block-a", "treated"},
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Planted In The Future",
+			requestBody:    `{"x":1,"y":1,"height":5,"planted_at":"` + tomorrow + `"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "PlantedAt",
+		},
+		{
+			name:           "Unknown Health",
+			requestBody:    `{"x":1,"y":1,"height":5,"health":"sick"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Health",
+		},
+		{
+			name:           "Empty Tag",
+			requestBody:    `{"x":1,"y":1,"height":5,"tags":[""]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Tags[0]",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+
+			if tc.expectedInput != nil {
+				mockRepo.EXPECT().CreateTree(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateTreeInput) error {
+						tc.expectedInput.Id = input.Id
+						assert.Equal(t, *tc.expectedInput, input)
+						return nil
+					})
+				mockRepo.EXPECT().GetCalculatedEstateStats(gomock.Any(), testID).Return(&repository.EstateStats{TreeCount: 1}, nil)
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), testID).
+					Return(&repository.Estate{Id: testID, Width: 1, Length: 1, Stats: &repository.EstateStats{}}, nil)
+				mockRepo.EXPECT().UpsertEstateStats(gomock.Any(), testID, gomock.Any()).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			err := server.PostEstateIdTree(e.NewContext(req, rec), testID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedError != "" {
+				assert.Contains(t, rec.Body.String(), `"`+tc.expectedError+`"`)
+			}
+		})
+	}
+}
+
+func TestPatchEstateIdTreeTreeId(t *testing.T) {
+	e := echo.New()
+	testID, treeID := uuid.New(), uuid.New()
+	diseased := repository.TREE_DISEASED
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "Health",
+			requestBody: `{"health":"diseased"}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().UpdateTree(gomock.Any(), repository.UpdateTreeInput{Id: treeID, EstateId: testID, Health: &diseased}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:        "Clear Species & Tags",
+			requestBody: `{"species":"","tags":[]}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				species := ""
+				mockRepo.EXPECT().UpdateTree(gomock.Any(), repository.UpdateTreeInput{Id: treeID, EstateId: testID, Species: &species, Tags: []string{}}).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Nothing To Update",
+			requestBody:    `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "UpdateTreeRequest",
+		},
+		{
+			name:           "Unknown Health",
+			requestBody:    `{"health":"sick"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Health",
+		},
+		{
+			name:        "Tree Not Found",
+			requestBody: `{"tags":["north"]}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().UpdateTree(gomock.Any(), gomock.Any()).
+					Return(apperror.WrapWithCode(errors.New("tree not found"), http.StatusNotFound))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			server := &handler.Server{Repository: mockRepo, Validator: validator.New()}
+			if tc.setup != nil {
+				tc.setup(mockRepo)
+			}
+
+			req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			err := server.PatchEstateIdTreeTreeId(e.NewContext(req, rec), testID, treeID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedError != "" {
+				assert.Contains(t, rec.Body.String(), `"`+tc.expectedError+`"`)
+			}
+		})
+	}
+}
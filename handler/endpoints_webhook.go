@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	utilvalidator "github.com/SawitProRecruitment/UserService/utils/validator"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	defaultPageLimit = 20
+)
+
+// Register a webhook subscriber
+// (POST /webhooks)
+func (s *Server) PostWebhooks(c echo.Context) error {
+	ctx := c.Request().Context()
+	payload := generated.CreateWebhookRequest{}
+	if err := c.Bind(&payload); err != nil {
+		return httphelper.HttpRespError(c,
+			apperror.WrapWithCode(fmt.Errorf("failed to unmarshall request: %w", err),
+				http.StatusBadRequest))
+	}
+
+	// Validate payload
+	if err := s.Validator.Struct(payload); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+
+	// generate the secret when subscriber does not provide one
+	secret := ""
+	if payload.Secret != nil {
+		secret = *payload.Secret
+	} else {
+		newSecret, err := generateWebhookSecret()
+		if err != nil {
+			return httphelper.HttpRespError(c, apperror.WrapWithCode(err, http.StatusInternalServerError))
+		}
+		secret = newSecret
+	}
+
+	subscriberID := uuid.New()
+	err := s.Repository.CreateWebhookSubscriber(ctx, repository.CreateWebhookSubscriberInput{
+		Id:     subscriberID,
+		Url:    payload.Url,
+		Secret: secret,
+	})
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	resp := generated.CreateWebhookResponse{
+		Id:     ptr.ToPointer(openapi_types.UUID(subscriberID)),
+		Url:    ptr.ToPointer(payload.Url),
+		Secret: ptr.ToPointer(secret),
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// List dead-lettered webhook deliveries
+// (GET /webhooks/dead-letters)
+func (s *Server) GetWebhooksDeadLetters(c echo.Context, params generated.GetWebhooksDeadLettersParams) error {
+	ctx := c.Request().Context()
+
+	// Validate payload
+	if err := s.Validator.Struct(params); err != nil {
+		validationErrors := err.(validator.ValidationErrors)
+		return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+			Message: "Validation failed",
+			Errors:  utilvalidator.FormatValidationErrors(validationErrors),
+		})
+	}
+
+	input := repository.GetWebhookDeliveriesInput{
+		Status: repository.WEBHOOK_DELIVERY_DEAD,
+		Limit:  defaultPageLimit,
+	}
+	if params.Limit != nil {
+		input.Limit = *params.Limit
+	}
+	if params.Offset != nil {
+		input.Offset = *params.Offset
+	}
+
+	deliveries, err := s.Repository.GetWebhookDeliveries(ctx, input)
+	if err != nil {
+		return httphelper.HttpRespError(c, err)
+	}
+
+	data := make([]generated.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		data = append(data, generated.WebhookDelivery{
+			Id:           ptr.ToPointer(openapi_types.UUID(delivery.Id)),
+			EventId:      ptr.ToPointer(openapi_types.UUID(delivery.EventId)),
+			EventType:    ptr.ToPointer(string(delivery.Event.EventType)),
+			EstateId:     ptr.ToPointer(openapi_types.UUID(delivery.Event.EstateId)),
+			SubscriberId: ptr.ToPointer(openapi_types.UUID(delivery.SubscriberId)),
+			Url:          ptr.ToPointer(delivery.Subscriber.Url),
+			Attempts:     ptr.ToPointer(delivery.Attempts),
+			LastError:    delivery.LastError,
+			CreatedAt:    ptr.ToPointer(delivery.CreatedAt),
+			UpdatedAt:    delivery.UpdatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, generated.WebhookDeliveryList{Data: &data})
+}
+
+// generate random 32 bytes secret in hex format
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,215 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestPostWebhooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo, Validator: validator.New(), Config: &config.Config{}}
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		expectedSecret string
+		setup          func(*repository.MockRepositoryInterface)
+	}{
+		{
+			name:           "Success - With Secret",
+			requestBody:    `{"url": "http://finance.local/hooks", "secret": "0123456789abcdef"}`,
+			expectedStatus: http.StatusCreated,
+			expectedSecret: "0123456789abcdef",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateWebhookSubscriber(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, input repository.CreateWebhookSubscriberInput) error {
+						assert.Equal(t, "http://finance.local/hooks", input.Url)
+						assert.Equal(t, "0123456789abcdef", input.Secret)
+						return nil
+					}).
+					Times(1)
+			},
+		},
+		{
+			name:           "Success - Generated Secret",
+			requestBody:    `{"url": "http://finance.local/hooks"}`,
+			expectedStatus: http.StatusCreated,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateWebhookSubscriber(gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "Invalid JSON (Bind Error)",
+			requestBody:    `{invalid_json}`,
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Payload Validation Error",
+			requestBody:    `{"url": "not a url", "secret": "short"}`,
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Repository Error",
+			requestBody:    `{"url": "http://finance.local/hooks"}`,
+			expectedStatus: http.StatusInternalServerError,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					CreateWebhookSubscriber(gomock.Any(), gomock.Any()).
+					Return(apperror.WrapWithCode(errors.New("database error"), http.StatusInternalServerError)).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tc.setup(mockRepo)
+
+			err := server.PostWebhooks(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.CreateWebhookResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.NotNil(t, resp.Id)
+				assert.NotEmpty(t, *resp.Secret)
+				if tc.expectedSecret != "" {
+					assert.Equal(t, tc.expectedSecret, *resp.Secret)
+				}
+			}
+		})
+	}
+}
+
+func TestGetWebhooksDeadLetters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo, Validator: validator.New(), Config: &config.Config{}}
+	e := echo.New()
+
+	lastError := "subscriber responded with status 500"
+	deadDelivery := repository.WebhookDelivery{
+		Id:        uuid.New(),
+		EventId:   uuid.New(),
+		Status:    repository.WEBHOOK_DELIVERY_DEAD,
+		Attempts:  8,
+		LastError: &lastError,
+		CreatedAt: time.Now(),
+		Event:     &repository.OutboxEvent{EventType: repository.EVENT_TREE_ADDED, EstateId: uuid.New()},
+		Subscriber: &repository.WebhookSubscriber{
+			Url: "http://finance.local/hooks",
+		},
+	}
+
+	tests := []struct {
+		name           string
+		params         generated.GetWebhooksDeadLettersParams
+		expectedStatus int
+		expectedCount  int
+		setup          func(*repository.MockRepositoryInterface)
+	}{
+		{
+			name:           "Success - Default Pagination",
+			expectedStatus: http.StatusOK,
+			expectedCount:  1,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					GetWebhookDeliveries(gomock.Any(), repository.GetWebhookDeliveriesInput{
+						Status: repository.WEBHOOK_DELIVERY_DEAD,
+						Limit:  20,
+					}).
+					Return([]repository.WebhookDelivery{deadDelivery}, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "Success - Custom Pagination",
+			params:         generated.GetWebhooksDeadLettersParams{Limit: ptr(5), Offset: ptr(10)},
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					GetWebhookDeliveries(gomock.Any(), repository.GetWebhookDeliveriesInput{
+						Status: repository.WEBHOOK_DELIVERY_DEAD,
+						Limit:  5,
+						Offset: 10,
+					}).
+					Return(nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:           "Params Validation Error",
+			params:         generated.GetWebhooksDeadLettersParams{Limit: ptr(1000)},
+			expectedStatus: http.StatusBadRequest,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+		},
+		{
+			name:           "Repository Error",
+			expectedStatus: http.StatusInternalServerError,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().
+					GetWebhookDeliveries(gomock.Any(), gomock.Any()).
+					Return(nil, apperror.WrapWithCode(errors.New("database error"), http.StatusInternalServerError)).
+					Times(1)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/webhooks/dead-letters", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			tc.setup(mockRepo)
+
+			err := server.GetWebhooksDeadLetters(c, tc.params)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.WebhookDeliveryList
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Len(t, *resp.Data, tc.expectedCount)
+			}
+		})
+	}
+}
@@ -2,9 +2,14 @@ package handler
 
 import (
 	"context"
+	"math"
 	"strconv"
+	"time"
 
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/generated"
 	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/telemetry"
 	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
 )
@@ -31,10 +36,23 @@ func getCoordinateKey(x, y int) (res string) {
 }
 
 type Plot struct {
-	X          int
-	Y          int
-	treeHeight *int // will null if tree not exist
-	nextPlot   *Plot
+	X           int
+	Y           int
+	treeHeight  *int // will null if tree not exist
+	minAltitude int  // lowest altitude over the plot, to clear its obstacle or the cruise min altitude
+	nextPlot    *Plot
+	travel      int // plots flown to reach next plot, more than 1 when plots are skipped or routed around
+	transit     int // lowest altitude to fly over the plots between this plot & next plot
+}
+
+// altitude the drone fly over the plot when it comes at lastHeight, clearance above the tree to monitor it
+// and never below the min altitude of the plot
+func (p *Plot) altitude(lastHeight, clearance int) int {
+	altitude := lastHeight
+	if p.treeHeight != nil {
+		altitude = *p.treeHeight + clearance
+	}
+	return max(altitude, p.minAltitude)
 }
 
 type Coordinate struct {
@@ -42,29 +60,95 @@ type Coordinate struct {
 	Y int
 }
 
-// calculate drone distance until reach end destination estate plot
-func calculateDroneDistance(estate *repository.Estate, maxDistance *int) (int64, *Coordinate) {
+// droneCoverage is the part of the estate the drone can't monitor
+type droneCoverage struct {
+	UncoveredPlots int64             // no-fly plots & the plots walled in by them
+	UncoveredTrees []repository.Tree // trees planted on the uncovered plots
+}
+
+type dronePlan struct {
+	Distance int64
+	Duration int64       // flight time in seconds
+	Energy   float64     // energy drawn in watt-hours, rounded to 2 decimals
+	Rest     *Coordinate // where the drone land when a max distance, energy or duration is provided
+	Pattern  string      // coverage pattern the drone flies
+	droneCoverage
+}
+
+// exclusion modes of the drone planner, what the drone does with the plots trees can't be planted on
+const (
+	exclusionModeFlyOver = "fly_over" // visited like any empty plot
+	exclusionModeSkip    = "skip"     // left out of the path, the drone fly straight to the next plot
+)
+
+// droneOptions is how the drone plan is calculated
+type droneOptions struct {
+	Profile      config.DroneProfile
+	MaxDistance  *int     // battery of the drone, the plan report where it must rest
+	MaxEnergy    *float64 // battery of the drone in watt-hours, alternative to MaxDistance
+	MaxDuration  *int     // flight time of the drone in seconds, alternative to MaxDistance
+	SkipExcluded bool     // exclusionModeSkip
+	Pattern      string   // coverage pattern, rows when empty
+}
+
+// calculate drone distance until reach end destination estate plot. With patternAuto every pattern
+// is planned and the one covering the most plots with the least distance is returned
+func calculateDroneDistance(estate *repository.Estate, opts droneOptions) (plan dronePlan) {
+	defer func(start time.Time) {
+		telemetry.DroneDistanceDuration.Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	if opts.Pattern == "" {
+		opts.Pattern = patternRows
+	}
+	if opts.Pattern != patternAuto {
+		return planDroneRoute(estate, opts)
+	}
+
+	for i, pattern := range coveragePatterns {
+		opts.Pattern = pattern
+		candidate := planDroneRoute(estate, opts)
+		if i == 0 || candidate.UncoveredPlots < plan.UncoveredPlots ||
+			(candidate.UncoveredPlots == plan.UncoveredPlots && candidate.Distance < plan.Distance) {
+			plan = candidate
+		}
+	}
+	return plan
+}
+
+// planDroneRoute calculate the plan of a single coverage pattern
+func planDroneRoute(estate *repository.Estate, opts droneOptions) (plan dronePlan) {
+	plan.Pattern = opts.Pattern
+	profile := opts.Profile
+	limit, measure, hasLimit := opts.flightBudget()
+
 	var (
-		totalDistance            int64 = 1 // initial value
-		lastDroneHeight                = 1 // initial value
-		lastDroneCoordinate      *Coordinate
-		restDroneBatteryDistance = 0
+		totalFlight         = verticalLeg(0, profile.TakeoffAltitude) // initial value
+		lastDroneHeight     = profile.TakeoffAltitude                 // initial value
+		lastDroneCoordinate *Coordinate
+		restDroneBattery    float64
 	)
 
-	// init last drone coordinate
-	if maxDistance != nil {
-		restDroneBatteryDistance = *maxDistance - 1 // because for the first ditance groun 1m
-		lastDroneCoordinate = &Coordinate{X: 1, Y: 1}
+	current, coverage := createLinkedListByEstate(estate, opts)
+	plan.droneCoverage = coverage
+	if current == nil {
+		// every plot is skipped or can't be flown over, the drone does not take off
+		return
 	}
 
-	current := createLinkedListByEstate(estate)
+	// init last drone coordinate
+	if hasLimit {
+		restDroneBattery = limit - measure(totalFlight) // because for the first flight to the takeoff altitude
+		lastDroneCoordinate = &Coordinate{X: current.X, Y: current.Y}
+	}
 
 	// pre calculate for first head
-	if current.treeHeight != nil {
-		totalDistance += int64(*current.treeHeight)
-		lastDroneHeight += *current.treeHeight
-		if restDroneBatteryDistance > 0 {
-			restDroneBatteryDistance -= *current.treeHeight
+	if target := current.altitude(lastDroneHeight, profile.Clearance); target != lastDroneHeight {
+		upDown := verticalLeg(lastDroneHeight, target)
+		totalFlight = totalFlight.add(upDown)
+		lastDroneHeight = target
+		if restDroneBattery > 0 {
+			restDroneBattery -= measure(upDown)
 		}
 	}
 
@@ -75,103 +159,176 @@ func calculateDroneDistance(estate *repository.Estate, maxDistance *int) (int64,
 			continue
 		}
 
-		var distanceUpDown int // distance for up or down drone
-		// check for next plot tree exist, for go up or down the drone
-		if current.nextPlot.treeHeight != nil {
-			heightForMonitorTree := *current.nextPlot.treeHeight + 1
-			if heightForMonitorTree > lastDroneHeight {
-				distanceUpDown = heightForMonitorTree - lastDroneHeight
-				totalDistance += int64(distanceUpDown)
-				lastDroneHeight += distanceUpDown
-			} else if heightForMonitorTree < lastDroneHeight {
-				distanceUpDown = lastDroneHeight - heightForMonitorTree
-				totalDistance += int64(distanceUpDown)
-				lastDroneHeight -= distanceUpDown
-			}
-		}
+		// go up or down the drone for the next plot, higher when the plots flown over on the way need it
+		target := current.nextPlot.altitude(lastDroneHeight, profile.Clearance)
+		flight := max(target, current.transit)
+		upDown := verticalLeg(lastDroneHeight, flight)
 
-		// travel to next plot
-		totalDistance += 10
+		// travel to next plot, more than one plot away when plots are skipped or routed around
+		travel := flightLeg{horizontal: float64(profile.PlotSize * current.travel)}
+
+		// come down over the next plot when the transit was higher than its altitude
+		descent := verticalLeg(flight, target)
+		totalFlight = totalFlight.add(upDown).add(travel).add(descent)
+		lastDroneHeight = target
 
 		// calculate rest drone battery if > 0
-		if restDroneBatteryDistance > 0 {
-			restDroneBatteryDistance -= distanceUpDown
+		if restDroneBattery > 0 {
+			restDroneBattery -= measure(upDown)
 
 			// check if better to rest at current plot or continue next plot (consider length drone to ground for avoid crash)
-			nextPlotWithSafeLanding := 10 + lastDroneHeight
-			if restDroneBatteryDistance < nextPlotWithSafeLanding {
+			nextPlotWithSafeLanding := measure(travel.add(verticalLeg(flight, 0)))
+			if restDroneBattery < nextPlotWithSafeLanding {
 				lastDroneCoordinate = &Coordinate{X: current.X, Y: current.Y}
-				restDroneBatteryDistance = 0 // bacause we dont want for next iterate to update it
+				restDroneBattery = 0 // bacause we dont want for next iterate to update it
 			} else {
 				lastDroneCoordinate = &Coordinate{X: current.nextPlot.X, Y: current.nextPlot.Y}
 			}
 
-			restDroneBatteryDistance -= 10
+			restDroneBattery -= measure(travel.add(descent))
 		}
 
 		current = current.nextPlot
 	}
 
-	// add distance for grounding drone
-	totalDistance += int64(lastDroneHeight)
+	// add flight for grounding drone
+	totalFlight = totalFlight.add(verticalLeg(lastDroneHeight, 0))
 
-	return totalDistance, lastDroneCoordinate
+	plan.Distance = int64(math.Round(totalFlight.distance(profile)))
+	plan.Duration = int64(math.Round(totalFlight.duration(profile.Energy)))
+	plan.Energy = math.Round(totalFlight.energy(profile.Energy)*100) / 100
+	plan.Rest = lastDroneCoordinate
+	return
 }
 
-// create linked list to easy calculate the distance
-func createLinkedListByEstate(estate *repository.Estate) (head *Plot) {
+// create linked list to easy calculate the distance in the pattern order, excluded plots are left out when skipExcluded.
+// No-fly plots & the plots they wall in are left out too and reported in coverage
+func createLinkedListByEstate(estate *repository.Estate, opts droneOptions) (head *Plot, coverage droneCoverage) {
 	var (
-		current *Plot
-		width   = estate.Width
-		length  = estate.Length
+		current      *Plot
+		reachable    []bool
+		profile      = opts.Profile
+		skipExcluded = opts.SkipExcluded
 	)
 
-	// used map for easy access checking availibility tree in each plot
-	// key => x,y and val => Tree
-	mapTrees := newMapTree(estate.Trees)
-
-	// loop coordinate every Y axis in estate (South to North)
-	for y := 1; y <= width; y++ {
-
-		// 1 => then go right
-		// -1 => then go left
-		direction := 1
-		// determine for start, end & direction loop
-		start, end := 1, length
-		if y%2 == 1 {
-			direction = 1
-			start = 1
-			end = length
-		} else {
-			direction = -1
-			start = length
-			end = 1
-		}
+	// used map for easy access checking availibility tree & obstacle in each plot
+	air := newAirspace(estate, profile.Clearance)
+	inPath := func(x, y int) bool {
+		return !air.isNoFly(x, y) && !(skipExcluded && estate.IsExcluded(x, y))
+	}
 
-		// loop coordinate every X axis in estate (West to East)
-		// loop every column in estate base on direction
-		for x := start; x != end+direction; x += direction {
-			// creating plot for every x,y coordinate
-			plot := &Plot{X: x, Y: y}
+	// the drone take off from the first plot of the path, plots it can't reach from there are not covered
+	if air.hasNoFly {
+		forEachPlot(estate, opts.Pattern, func(x, y int) bool {
+			if inPath(x, y) {
+				reachable = air.reachable(Coordinate{X: x, Y: y})
+				return false
+			}
+			return true
+		})
+	}
+
+	forEachPlot(estate, opts.Pattern, func(x, y int) bool {
+		if skipExcluded && estate.IsExcluded(x, y) && !air.isNoFly(x, y) {
+			return true
+		}
 
-			tree, isExist := mapTrees.getTreeByCoordinate(x, y)
+		tree, isExist := air.trees.getTreeByCoordinate(x, y)
+		if air.isNoFly(x, y) || (reachable != nil && !reachable[air.plotIndex(Coordinate{X: x, Y: y})]) {
+			coverage.UncoveredPlots++
 			if isExist {
-				plot.treeHeight = &tree.Height
+				coverage.UncoveredTrees = append(coverage.UncoveredTrees, tree)
 			}
+			return true
+		}
 
-			if head == nil {
-				head = plot
-				current = plot
-			} else {
-				current.nextPlot = plot
-				current = plot
-			}
+		// creating plot for every x,y coordinate
+		plot := &Plot{X: x, Y: y, minAltitude: max(air.obstacleAltitude(x, y), profile.CruiseMinAltitude)}
+		if isExist {
+			plot.treeHeight = &tree.Height
 		}
-	}
+
+		if head == nil {
+			head = plot
+			current = plot
+			return true
+		}
+
+		// the plots flown over to reach the new plot, none when they are next to each other
+		path, _ := air.route(Coordinate{X: current.X, Y: current.Y}, Coordinate{X: x, Y: y})
+		current.travel = len(path) + 1
+		for _, c := range path {
+			current.transit = max(current.transit, air.safeAltitude(c.X, c.Y))
+		}
+
+		current.nextPlot = plot
+		current = plot
+		return true
+	})
 
 	return
 }
 
+// skipExcludedPlots resolve the exclusion mode of the request, fallback to the configured one
+func (s *Server) skipExcludedPlots(mode *string) bool {
+	if mode != nil {
+		return *mode == exclusionModeSkip
+	}
+	if s.Config != nil {
+		return s.Config.Drone.ExclusionMode == exclusionModeSkip
+	}
+	return false
+}
+
+// defaultDroneProfile is the configured profile, the one of the pre calculated drone distance
+func (s *Server) defaultDroneProfile() config.DroneProfile {
+	if s.Config != nil && s.Config.Drone.Profile.PlotSize > 0 {
+		return s.Config.Drone.Profile
+	}
+	return config.DefaultDroneProfile()
+}
+
+// droneProfile override the default profile with the flight parameters of the request
+func (s *Server) droneProfile(params generated.GetEstateIdDronePlanParams) config.DroneProfile {
+	profile := s.defaultDroneProfile()
+	if params.PlotSize != nil {
+		profile.PlotSize = *params.PlotSize
+	}
+	if params.Clearance != nil {
+		profile.Clearance = *params.Clearance
+	}
+	if params.TakeoffAltitude != nil {
+		profile.TakeoffAltitude = *params.TakeoffAltitude
+	}
+	if params.CruiseMinAltitude != nil {
+		profile.CruiseMinAltitude = *params.CruiseMinAltitude
+	}
+	if params.AscentCost != nil {
+		profile.AscentCost = *params.AscentCost
+	}
+	if params.DescentCost != nil {
+		profile.DescentCost = *params.DescentCost
+	}
+	return profile
+}
+
+// countProvided count the true values, the optional parameters provided together
+func countProvided(provided ...bool) (count int) {
+	for _, isProvided := range provided {
+		if isProvided {
+			count++
+		}
+	}
+	return count
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
 // calculate & save stats into estate stats
 func (s *Server) calculateStats(ctx context.Context, estateId uuid.UUID) (err error) {
 	var calculatedStats *repository.EstateStats
@@ -193,14 +350,18 @@ func (s *Server) calculateStats(ctx context.Context, estateId uuid.UUID) (err er
 	}
 
 	// pre calculate the distance after inserting the tree
-	distance, _ := calculateDroneDistance(estate, nil)
+	profile := s.defaultDroneProfile()
+	plan := calculateDroneDistance(estate, droneOptions{Profile: profile, SkipExcluded: s.skipExcludedPlots(nil)})
 
 	// set stats for saving to DB
 	if estate.Stats.Id == uuid.Nil {
 		estate.Stats.Id = uuid.New()
 	}
 	estate.Stats.TreeCount = calculatedStats.TreeCount
-	estate.Stats.DroneDistance = int64(distance)
+	estate.Stats.DroneDistance = plan.Distance
+	estate.Stats.DroneProfile = profile.String()
+	estate.Stats.DroneDuration = plan.Duration
+	estate.Stats.DroneEnergy = plan.Energy
 	estate.Stats.MaxHeight = calculatedStats.MaxHeight
 	estate.Stats.MedianHeight = calculatedStats.MedianHeight
 	estate.Stats.MinHeight = calculatedStats.MinHeight
@@ -209,5 +370,8 @@ func (s *Server) calculateStats(ctx context.Context, estateId uuid.UUID) (err er
 		return
 	}
 
+	telemetry.EstatePlots.Observe(float64(estate.Width) * float64(estate.Length))
+	telemetry.EstateTrees.Observe(float64(estate.Stats.TreeCount))
+
 	return
 }
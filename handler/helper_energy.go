@@ -0,0 +1,58 @@
+package handler
+
+import "github.com/SawitProRecruitment/UserService/config"
+
+// flightLeg is a part of the flight in meters, split by phase as each phase has its own cost, speed & power
+type flightLeg struct {
+	horizontal float64
+	climb      float64
+	descent    float64
+}
+
+// verticalLeg is the leg going from an altitude to another
+func verticalLeg(from, to int) flightLeg {
+	if to > from {
+		return flightLeg{climb: float64(to - from)}
+	}
+	return flightLeg{descent: float64(from - to)}
+}
+
+func (l flightLeg) add(other flightLeg) flightLeg {
+	return flightLeg{
+		horizontal: l.horizontal + other.horizontal,
+		climb:      l.climb + other.climb,
+		descent:    l.descent + other.descent,
+	}
+}
+
+// distance charged for the leg, the vertical meters are weighted by the ascent & descent costs
+func (l flightLeg) distance(profile config.DroneProfile) float64 {
+	return l.horizontal + l.climb*profile.AscentCost + l.descent*profile.DescentCost
+}
+
+// duration of the leg in seconds
+func (l flightLeg) duration(model config.DroneEnergy) float64 {
+	return l.horizontal/model.Speed + l.climb/model.ClimbRate + l.descent/model.DescentRate
+}
+
+// energy drawn by the leg in watt-hours
+func (l flightLeg) energy(model config.DroneEnergy) float64 {
+	joules := l.horizontal/model.Speed*model.CruisePower +
+		l.climb/model.ClimbRate*model.ClimbPower +
+		l.descent/model.DescentRate*model.DescentPower
+	return joules / 3600
+}
+
+// flightBudget is how much the drone can fly before it must land, in the unit of the limit provided.
+// ok is false when there is no limit
+func (o droneOptions) flightBudget() (limit float64, measure func(flightLeg) float64, ok bool) {
+	switch {
+	case o.MaxDistance != nil:
+		return float64(*o.MaxDistance), func(l flightLeg) float64 { return l.distance(o.Profile) }, true
+	case o.MaxEnergy != nil:
+		return *o.MaxEnergy, func(l flightLeg) float64 { return l.energy(o.Profile.Energy) }, true
+	case o.MaxDuration != nil:
+		return float64(*o.MaxDuration), func(l flightLeg) float64 { return l.duration(o.Profile.Energy) }, true
+	}
+	return 0, nil, false
+}
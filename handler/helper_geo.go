@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+)
+
+func toGeoReference(geo generated.GeoReference) repository.GeoReference {
+	res := repository.GeoReference{OriginLat: geo.OriginLat, OriginLon: geo.OriginLon}
+	if geo.Rotation != nil {
+		res.Rotation = *geo.Rotation
+	}
+	return res
+}
+
+func toGeneratedGeoReference(geo *repository.GeoReference) *generated.GeoReference {
+	if geo == nil {
+		return nil
+	}
+	return &generated.GeoReference{OriginLat: geo.OriginLat, OriginLon: geo.OriginLon, Rotation: ptr.ToPointer(geo.Rotation)}
+}
+
+// plotLatLon is the WGS84 center of the plot, nil when the estate is not geo-referenced
+func plotLatLon(estate *repository.Estate, x, y, plotSize int) (lat, lon *float64) {
+	if estate.Geo == nil {
+		return nil, nil
+	}
+	la, lo := estate.Geo.LatLon(x, y, plotSize)
+	return &la, &lo
+}
+
+// gridPlotSize is the meters between two neighbour plots of the estate grid, the geo positions use it
+// whatever the plot_size of the drone flight asked by a request
+func (s *Server) gridPlotSize() int {
+	return s.defaultDroneProfile().PlotSize
+}
+
+// locateTrees set the WGS84 position of the trees of a geo-referenced estate
+func (s *Server) locateTrees(estate *repository.Estate, trees []generated.Tree) []generated.Tree {
+	plotSize := s.gridPlotSize()
+	for i := range trees {
+		trees[i].Lat, trees[i].Lon = plotLatLon(estate, trees[i].X, trees[i].Y, plotSize)
+	}
+	return trees
+}
+
+// validateTreePosition check the tree is placed by x & y or by lat & lon, the validate tags only check their range
+func validateTreePosition(tree generated.AddTreeRequest) map[string]string {
+	byPlot := tree.X != 0 || tree.Y != 0
+	byGeo := tree.Lat != nil || tree.Lon != nil
+	switch {
+	case byPlot && byGeo:
+		message := "Only one of X & Y or Lat & Lon can be provided"
+		return map[string]string{"X": message, "Y": message, "Lat": message, "Lon": message}
+	case byGeo:
+		errs := map[string]string{}
+		if tree.Lat == nil {
+			errs["Lat"] = "Lat is required with Lon"
+		}
+		if tree.Lon == nil {
+			errs["Lon"] = "Lon is required with Lat"
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+	default:
+		errs := map[string]string{}
+		if tree.X == 0 {
+			errs["X"] = "X is required"
+		}
+		if tree.Y == 0 {
+			errs["Y"] = "Y is required"
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+	}
+	return nil
+}
+
+// snapTree place the tree given by lat & lon on the nearest plot of the estate
+func (s *Server) snapTree(estate *repository.Estate, tree *generated.AddTreeRequest) map[string]string {
+	if estate.Geo == nil {
+		message := "The estate is not geo-referenced, X & Y are required"
+		return map[string]string{"Lat": message, "Lon": message}
+	}
+	plot, ok := estate.PlotAt(*tree.Lat, *tree.Lon, s.gridPlotSize())
+	if !ok {
+		message := fmt.Sprintf("Point (%g,%g) is outside the estate", *tree.Lat, *tree.Lon)
+		return map[string]string{"Lat": message, "Lon": message}
+	}
+	tree.X, tree.Y = plot.X, plot.Y
+	tree.Lat, tree.Lon = nil, nil
+	return nil
+}
+
+// droneWaypoints is the plots where the drone path turns, the path goes along the plots routed
+// around the no-fly plots so the drone flies straight between two waypoints
+func droneWaypoints(estate *repository.Estate, opts droneOptions) []Coordinate {
+	head, _ := createLinkedListByEstate(estate, opts)
+	if head == nil {
+		return []Coordinate{}
+	}
+
+	var air *airspace
+	waypoints := []Coordinate{{X: head.X, Y: head.Y}}
+	add := func(c Coordinate) {
+		// the last waypoint moves forward while the path keeps its direction
+		if n := len(waypoints); n >= 2 {
+			a, b := waypoints[n-2], waypoints[n-1]
+			if sign(b.X-a.X) == sign(c.X-b.X) && sign(b.Y-a.Y) == sign(c.Y-b.Y) {
+				waypoints[n-1] = c
+				return
+			}
+		}
+		waypoints = append(waypoints, c)
+	}
+	for plot := head; plot.nextPlot != nil; plot = plot.nextPlot {
+		if plot.travel > 1 {
+			if air == nil {
+				air = newAirspace(estate, opts.Profile.Clearance)
+			}
+			path, _ := air.route(Coordinate{X: plot.X, Y: plot.Y}, Coordinate{X: plot.nextPlot.X, Y: plot.nextPlot.Y})
+			for _, c := range path {
+				add(c)
+			}
+		}
+		add(Coordinate{X: plot.nextPlot.X, Y: plot.nextPlot.Y})
+	}
+	return waypoints
+}
+
+// completeDronePlan add the waypoints of the plan when asked, and the WGS84 position of its plots when the
+// estate is geo-referenced. Done on every plan, the cached ones included, as neither is kept in the plan cache
+func (s *Server) completeDronePlan(estate *repository.Estate, params generated.GetEstateIdDronePlanParams, resp generated.DronePlanResponse) generated.DronePlanResponse {
+	plotSize := s.gridPlotSize()
+	if resp.Rest != nil && resp.Rest.X != nil && resp.Rest.Y != nil {
+		resp.Rest.Lat, resp.Rest.Lon = plotLatLon(estate, *resp.Rest.X, *resp.Rest.Y, plotSize)
+	}
+
+	if params.Waypoints == nil || !*params.Waypoints {
+		return resp
+	}
+	opts := droneOptions{
+		Profile:      s.droneProfile(params),
+		SkipExcluded: s.skipExcludedPlots(params.ExclusionMode),
+		Pattern:      patternRows,
+	}
+	if resp.Pattern != nil {
+		opts.Pattern = *resp.Pattern
+	}
+	waypoints := droneWaypoints(estate, opts)
+	points := make([]generated.DronePoint, 0, len(waypoints))
+	for _, c := range waypoints {
+		point := generated.DronePoint{X: ptr.ToPointer(c.X), Y: ptr.ToPointer(c.Y)}
+		point.Lat, point.Lon = plotLatLon(estate, c.X, c.Y, plotSize)
+		points = append(points, point)
+	}
+	resp.Waypoints = &points
+	return resp
+}
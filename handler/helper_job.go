@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/jobs"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+)
+
+// ImportJobResult is the result of an import job
+type ImportJobResult struct {
+	EstateId uuid.UUID `json:"estate_id"`
+	Trees    int       `json:"trees"` // trees planted by the job, the ones of a previous attempt excluded
+}
+
+// RebuildTilesJobResult is the result of a rebuild_tiles job
+type RebuildTilesJobResult struct {
+	MaxZoom int `json:"max_zoom"` // zoom where a tile cell is a plot, the zooms below it were rebuilt
+}
+
+// JobHandlers run every job type for the job worker
+func (s *Server) JobHandlers() map[repository.JobType]jobs.Handler {
+	return map[repository.JobType]jobs.Handler{
+		repository.JOB_DRONE_PLAN:      s.runDronePlanJob,
+		repository.JOB_RECOMPUTE_STATS: s.runRecomputeStatsJob,
+		repository.JOB_IMPORT:          s.runImportJob,
+		repository.JOB_EXPORT:          s.runExportJob,
+		repository.JOB_REBUILD_TILES:   s.runRebuildTilesJob,
+	}
+}
+
+func (s *Server) runDronePlanJob(ctx context.Context, job repository.Job, progress func(float64)) (any, error) {
+	var params generated.GetEstateIdDronePlanParams
+	if err := json.Unmarshal(job.Params, &params); err != nil {
+		return nil, apperror.WrapWithCode(fmt.Errorf("invalid drone plan parameters: %w", err), http.StatusBadRequest)
+	}
+
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, job.EstateId)
+	if err != nil {
+		return nil, err
+	}
+	progress(0.5)
+
+	resp, _ := s.dronePlanResponse(ctx, estate, params)
+	return s.completeDronePlan(estate, params, resp), nil
+}
+
+func (s *Server) runRecomputeStatsJob(ctx context.Context, job repository.Job, progress func(float64)) (any, error) {
+	if err := s.calculateStats(ctx, job.EstateId); err != nil {
+		return nil, err
+	}
+	progress(0.9)
+
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, job.EstateId, repository.RELATION_TREES, repository.RELATION_OBSTACLES)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGeneratedEstateStats(estate), nil
+}
+
+func (s *Server) runRebuildTilesJob(ctx context.Context, job repository.Job, progress func(float64)) (any, error) {
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, job.EstateId,
+		repository.RELATION_TREES, repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES)
+	if err != nil {
+		return nil, err
+	}
+	progress(0.1)
+
+	if err := s.Repository.RebuildTiles(ctx, job.EstateId); err != nil {
+		return nil, err
+	}
+
+	return RebuildTilesJobResult{MaxZoom: estate.MaxTileZoom()}, nil
+}
+
+func (s *Server) runExportJob(ctx context.Context, job repository.Job, progress func(float64)) (any, error) {
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, job.EstateId,
+		repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES)
+	if err != nil {
+		return nil, err
+	}
+	progress(0.5)
+
+	document := generated.EstateDocument{
+		Width:  estate.Width,
+		Length: estate.Length,
+		Trees:  make([]generated.AddTreeRequest, 0, len(estate.Trees)),
+	}
+	for _, tree := range estate.Trees {
+		document.Trees = append(document.Trees, toAddTreeRequest(tree))
+	}
+
+	return document, nil
+}
+
+// runImportJob create the estate of the job then plant its trees. A retried job goes on with the estate
+// & the trees of the previous attempt
+func (s *Server) runImportJob(ctx context.Context, job repository.Job, progress func(float64)) (any, error) {
+	var document generated.EstateDocument
+	if err := json.Unmarshal(job.Params, &document); err != nil {
+		return nil, apperror.WrapWithCode(fmt.Errorf("invalid estate document: %w", err), http.StatusBadRequest)
+	}
+
+	planted := mapTree{}
+	estate, err := s.Repository.GetEstateWithAllDetails(ctx, job.EstateId,
+		repository.RELATION_STATS, repository.RELATION_EXCLUSIONS, repository.RELATION_OBSTACLES)
+	var appErr *apperror.AppError
+	switch {
+	case errors.As(err, &appErr) && appErr.Code == http.StatusNotFound:
+		err = s.Repository.CreateEstate(ctx, repository.CreateEstateInput{
+			Id:     job.EstateId,
+			Width:  document.Width,
+			Length: document.Length,
+		})
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		planted = newMapTree(estate.Trees)
+	}
+
+	result := ImportJobResult{EstateId: job.EstateId}
+	for i, tree := range document.Trees {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if _, isExist := planted.getTreeByCoordinate(tree.X, tree.Y); !isExist {
+			err := s.Repository.CreateTree(ctx, toCreateTreeInput(uuid.New(), job.EstateId, tree))
+			if err != nil {
+				return nil, err
+			}
+			result.Trees++
+		}
+		progress(float64(i+1) / float64(len(document.Trees)+1))
+	}
+
+	if err := s.calculateStats(ctx, job.EstateId); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+const (
+	defaultMapMaxCells = 200
+	mapImageSize       = 800 // pixels along the longest side of the estate, at most
+	mapMaxCellPixels   = 20
+	mapMaxTreeHeight   = 30 // darkest tree colour, the highest tree accepted
+)
+
+var (
+	mapSoilColor     = color.RGBA{R: 239, G: 230, B: 210, A: 255}
+	mapExcludedColor = color.RGBA{R: 189, G: 189, B: 189, A: 255}
+	mapObstacleColor = color.RGBA{R: 93, G: 64, B: 55, A: 255}
+	mapShortColor    = color.RGBA{R: 199, G: 233, B: 192, A: 255} // tree of 1 meter
+	mapTallColor     = color.RGBA{R: 0, G: 90, B: 50, A: 255}     // tree of mapMaxTreeHeight meters
+	mapPathColor     = color.RGBA{R: 21, G: 101, B: 192, A: 255}
+	mapRestColor     = color.RGBA{R: 211, G: 47, B: 47, A: 255}
+)
+
+// mapCell is a square of scale x scale plots of the estate map
+type mapCell struct {
+	Trees     int
+	SumHeight int
+	Excluded  bool // every plot of the cell is excluded
+	Obstacle  bool
+}
+
+// estateMap is the estate downsampled to at most maxCells cells a side, row 0 is the South
+type estateMap struct {
+	Scale int // plots a side of a cell
+	Cols  int
+	Rows  int
+	Cells []mapCell
+	Path  []Coordinate // cells the drone flies over in order, none when the path is not drawn
+	Rest  *Coordinate  // cell the drone land on
+}
+
+// mapOptions is what is drawn on the estate map
+type mapOptions struct {
+	MaxCells  int
+	DronePath bool
+	Drone     droneOptions
+}
+
+func (s *Server) newMapOptions(params generated.GetEstateIdMapSvgParams) mapOptions {
+	opts := mapOptions{
+		MaxCells:  defaultMapMaxCells,
+		DronePath: params.DronePath != nil && *params.DronePath,
+		Drone: droneOptions{
+			Profile:      s.defaultDroneProfile(),
+			MaxDistance:  params.MaxDistance,
+			SkipExcluded: s.skipExcludedPlots(params.ExclusionMode),
+			Pattern:      patternRows,
+		},
+	}
+	if params.MaxCells != nil {
+		opts.MaxCells = *params.MaxCells
+	}
+	if params.Pattern != nil {
+		opts.Drone.Pattern = *params.Pattern
+	}
+	return opts
+}
+
+// newEstateMap aggregate the trees, exclusions & obstacles of the estate by cell
+func newEstateMap(estate *repository.Estate, opts mapOptions) *estateMap {
+	longest := max(estate.Length, estate.Width, 1)
+	scale := (longest + opts.MaxCells - 1) / opts.MaxCells
+	m := &estateMap{
+		Scale: scale,
+		Cols:  (estate.Length + scale - 1) / scale,
+		Rows:  (estate.Width + scale - 1) / scale,
+	}
+	m.Cells = make([]mapCell, m.Cols*m.Rows)
+
+	for _, tree := range estate.Trees {
+		cell := m.cell(tree.X, tree.Y)
+		cell.Trees++
+		cell.SumHeight += tree.Height
+	}
+	for _, obstacle := range estate.Obstacles {
+		m.cell(obstacle.X, obstacle.Y).Obstacle = true
+	}
+	if len(estate.ExclusionZones) > 0 {
+		excluded := estate.ExcludedPlots(repository.Point{X: 1, Y: 1}, repository.Point{X: estate.Length, Y: estate.Width})
+		for row := 0; row < m.Rows; row++ {
+			for col := 0; col < m.Cols; col++ {
+				lo := repository.Point{X: col*scale + 1, Y: row*scale + 1}
+				hi := repository.Point{X: min(lo.X+scale-1, estate.Length), Y: min(lo.Y+scale-1, estate.Width)}
+				plots := int64(hi.X-lo.X+1) * int64(hi.Y-lo.Y+1)
+				m.Cells[row*m.Cols+col].Excluded = excluded.CountIn(lo, hi) == plots
+			}
+		}
+	}
+
+	if !opts.DronePath {
+		return m
+	}
+
+	// the path of the pattern the plan flies, auto pick one
+	plan := calculateDroneDistance(estate, opts.Drone)
+	// walked on the cells rather than the plots, so the path has at most a point a cell
+	cells := &repository.Estate{Length: m.Cols, Width: m.Rows}
+	forEachPlot(cells, plan.Pattern, func(x, y int) bool {
+		c := Coordinate{X: x - 1, Y: y - 1}
+		if !(opts.Drone.SkipExcluded && m.Cells[c.Y*m.Cols+c.X].Excluded) {
+			m.Path = append(m.Path, c)
+		}
+		return true
+	})
+	if plan.Rest != nil {
+		m.Rest = &Coordinate{X: (plan.Rest.X - 1) / scale, Y: (plan.Rest.Y - 1) / scale}
+	}
+	return m
+}
+
+func (m *estateMap) cell(x, y int) *mapCell {
+	return &m.Cells[(y-1)/m.Scale*m.Cols+(x-1)/m.Scale]
+}
+
+// color of the cell, the average height of its trees wins over its obstacles & its exclusions
+func (c mapCell) color() color.RGBA {
+	switch {
+	case c.Trees > 0:
+		avg := float64(c.SumHeight) / float64(c.Trees)
+		t := min(max((avg-1)/(mapMaxTreeHeight-1), 0), 1)
+		lerp := func(a, b uint8) uint8 { return uint8(float64(a) + (float64(b)-float64(a))*t + 0.5) }
+		return color.RGBA{
+			R: lerp(mapShortColor.R, mapTallColor.R),
+			G: lerp(mapShortColor.G, mapTallColor.G),
+			B: lerp(mapShortColor.B, mapTallColor.B),
+			A: 255,
+		}
+	case c.Obstacle:
+		return mapObstacleColor
+	case c.Excluded:
+		return mapExcludedColor
+	default:
+		return mapSoilColor
+	}
+}
+
+// cellPixels is the side of a cell in the rendered map
+func (m *estateMap) cellPixels() int {
+	return min(max(mapImageSize/max(m.Cols, m.Rows), 1), mapMaxCellPixels)
+}
+
+// renderSVG draw the map, the South-West corner of the estate at the bottom left
+func (m *estateMap) renderSVG() []byte {
+	px := m.cellPixels()
+	width, height := m.Cols*px, m.Rows*px
+	center := func(c Coordinate) (int, int) {
+		return c.X*px + px/2, height - c.Y*px - px/2
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="%s"/>`, width, height, hexColor(mapSoilColor))
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			fill := m.Cells[row*m.Cols+col].color()
+			if fill == mapSoilColor {
+				continue
+			}
+			fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`,
+				col*px, height-(row+1)*px, px, px, hexColor(fill))
+		}
+	}
+
+	if len(m.Path) > 0 {
+		b.WriteString(`<polyline fill="none" stroke="` + hexColor(mapPathColor) + `" stroke-width="1" points="`)
+		for i, c := range m.Path {
+			x, y := center(c)
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			fmt.Fprintf(&b, "%d,%d", x, y)
+		}
+		b.WriteString(`"/>`)
+	}
+	if m.Rest != nil {
+		x, y := center(*m.Rest)
+		fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`, x, y, max(px/2, 3), hexColor(mapRestColor))
+	}
+	b.WriteString(`</svg>`)
+	return []byte(b.String())
+}
+
+// renderPNG draw the same map as renderSVG
+func (m *estateMap) renderPNG() ([]byte, error) {
+	px := m.cellPixels()
+	width, height := m.Cols*px, m.Rows*px
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	fillRect := func(x0, y0, x1, y1 int, c color.RGBA) {
+		for y := max(y0, 0); y < min(y1, height); y++ {
+			for x := max(x0, 0); x < min(x1, width); x++ {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+	center := func(c Coordinate) (int, int) {
+		return c.X*px + px/2, height - 1 - (c.Y*px + px/2)
+	}
+
+	for row := 0; row < m.Rows; row++ {
+		for col := 0; col < m.Cols; col++ {
+			fillRect(col*px, height-(row+1)*px, (col+1)*px, height-row*px, m.Cells[row*m.Cols+col].color())
+		}
+	}
+
+	// the path goes from cell center to cell center, the cells are neighbours unless plots are skipped
+	for i := 1; i < len(m.Path); i++ {
+		x0, y0 := center(m.Path[i-1])
+		x1, y1 := center(m.Path[i])
+		steps := max(absInt(x1-x0), absInt(y1-y0))
+		for step := 0; step <= steps; step++ {
+			x, y := x0, y0
+			if steps > 0 {
+				x += (x1 - x0) * step / steps
+				y += (y1 - y0) * step / steps
+			}
+			img.SetRGBA(x, y, mapPathColor)
+		}
+	}
+	if m.Rest != nil {
+		x, y := center(*m.Rest)
+		r := max(px/2, 2)
+		fillRect(x-r, y-r, x+r+1, y+r+1, mapRestColor)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,108 @@
+package handler
+
+import "github.com/SawitProRecruitment/UserService/repository"
+
+// coverage patterns of the drone planner, the order the drone visits the plots
+const (
+	patternRows    = "rows"    // serpentine row by row, south to north, alternating east & west
+	patternColumns = "columns" // serpentine column by column, west to east, alternating north & south
+	patternSpiral  = "spiral"  // ring by ring from the edge of the estate to its center, counterclockwise
+	patternAuto    = "auto"    // every pattern is planned, the shortest one is flown
+)
+
+// coveragePatterns are the patterns patternAuto choose from, the first one wins a tie
+var coveragePatterns = []string{patternRows, patternColumns, patternSpiral}
+
+// forEachPlot walk the estate plots in the drone path order of the pattern, until fn return false
+func forEachPlot(estate *repository.Estate, pattern string, fn func(x, y int) bool) {
+	switch pattern {
+	case patternColumns:
+		forEachPlotByColumn(estate, fn)
+	case patternSpiral:
+		forEachPlotBySpiral(estate, fn)
+	default:
+		forEachPlotByRow(estate, fn)
+	}
+}
+
+func forEachPlotByRow(estate *repository.Estate, fn func(x, y int) bool) {
+	// loop coordinate every Y axis in estate (South to North)
+	for y := 1; y <= estate.Width; y++ {
+
+		// 1 => then go right
+		// -1 => then go left
+		direction := 1
+		// determine for start, end & direction loop
+		start, end := 1, estate.Length
+		if y%2 == 0 {
+			direction = -1
+			start = estate.Length
+			end = 1
+		}
+
+		// loop coordinate every X axis in estate (West to East)
+		// loop every column in estate base on direction
+		for x := start; x != end+direction; x += direction {
+			if !fn(x, y) {
+				return
+			}
+		}
+	}
+}
+
+func forEachPlotByColumn(estate *repository.Estate, fn func(x, y int) bool) {
+	// loop coordinate every X axis in estate (West to East)
+	for x := 1; x <= estate.Length; x++ {
+
+		// odd columns go north, even columns go south
+		direction := 1
+		start, end := 1, estate.Width
+		if x%2 == 0 {
+			direction = -1
+			start = estate.Width
+			end = 1
+		}
+
+		for y := start; y != end+direction; y += direction {
+			if !fn(x, y) {
+				return
+			}
+		}
+	}
+}
+
+func forEachPlotBySpiral(estate *repository.Estate, fn func(x, y int) bool) {
+	minX, maxX, minY, maxY := 1, estate.Length, 1, estate.Width
+	for minX <= maxX && minY <= maxY {
+		// east along the south edge of the ring
+		for x := minX; x <= maxX; x++ {
+			if !fn(x, minY) {
+				return
+			}
+		}
+		// north along the east edge
+		for y := minY + 1; y <= maxY; y++ {
+			if !fn(maxX, y) {
+				return
+			}
+		}
+		// west along the north edge, unless the ring is a single row
+		if minY < maxY {
+			for x := maxX - 1; x >= minX; x-- {
+				if !fn(x, maxY) {
+					return
+				}
+			}
+		}
+		// south along the west edge, unless the ring is a single column
+		if minX < maxX {
+			for y := maxY - 1; y > minY; y-- {
+				if !fn(minX, y) {
+					return
+				}
+			}
+		}
+
+		minX, maxX, minY, maxY = minX+1, maxX-1, minY+1, maxY-1
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/SawitProRecruitment/UserService/plancache"
+)
+
+const (
+	headerCache      = "X-Cache"       // HIT or MISS, set when the drone plan cache was looked up
+	headerCacheLayer = "X-Cache-Layer" // memory or postgres, the layer of a hit
+)
+
+// planCacheStatus is how a drone plan was served by the plan cache
+type planCacheStatus struct {
+	used  bool            // false when the plan came from the stored stats or the cache is disabled
+	layer plancache.Layer // empty on a miss
+}
+
+func (st planCacheStatus) setHeaders(header http.Header) {
+	if !st.used {
+		return
+	}
+	if st.layer == "" {
+		header.Set(headerCache, "MISS")
+		return
+	}
+	header.Set(headerCache, "HIT")
+	header.Set(headerCacheLayer, string(st.layer))
+}
+
+// cacheKey identify the resolved options, two requests planning the same flight share the key
+func (o droneOptions) cacheKey() string {
+	return fmt.Sprintf("%s,pattern=%s,skip=%t,max_distance=%s,max_energy=%s,max_duration=%s",
+		o.Profile, o.Pattern, o.SkipExcluded, formatOptional(o.MaxDistance), formatOptionalFloat(o.MaxEnergy), formatOptional(o.MaxDuration))
+}
+
+func formatOptional(value *int) string {
+	if value == nil {
+		return "none"
+	}
+	return strconv.Itoa(*value)
+}
+
+func formatOptionalFloat(value *float64) string {
+	if value == nil {
+		return "none"
+	}
+	return strconv.FormatFloat(*value, 'g', -1, 64)
+}
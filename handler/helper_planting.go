@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"slices"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// layouts of the planting planner
+const (
+	plantingFill       = "fill"       // every free plot, row by row from the South-West corner
+	plantingSquare     = "square"     // a grid of min distance plots
+	plantingTriangular = "triangular" // rows shifted by half the min distance every other row, closer than the grid rows
+)
+
+// plantingOptions is how the new trees are laid out
+type plantingOptions struct {
+	Count       int
+	MinDistance int // in plots, between plot centers
+	Pattern     string
+}
+
+// planPlanting suggest up to opts.Count free plots at least opts.MinDistance away from every tree, old or new.
+// Excluded plots & obstacle plots are never suggested
+func planPlanting(estate *repository.Estate, opts plantingOptions) []repository.Point {
+	planted := make(map[repository.Point]bool, len(estate.Trees)+opts.Count)
+	for _, tree := range estate.Trees {
+		planted[repository.Point{X: tree.X, Y: tree.Y}] = true
+	}
+	obstacles := make(map[repository.Point]bool, len(estate.Obstacles))
+	for _, obstacle := range estate.Obstacles {
+		obstacles[repository.Point{X: obstacle.X, Y: obstacle.Y}] = true
+	}
+
+	// a tree closer than the min distance is within the square of side 2*MinDistance-1 around the plot
+	d := opts.MinDistance
+	tooClose := func(x, y int) bool {
+		for dy := -(d - 1); dy <= d-1; dy++ {
+			for dx := -(d - 1); dx <= d-1; dx++ {
+				if dx*dx+dy*dy < d*d && planted[repository.Point{X: x + dx, Y: y + dy}] {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	var suggestions []repository.Point
+	forEachPlantingPlot(estate, opts, func(x, y int) bool {
+		if estate.IsExcluded(x, y) || obstacles[repository.Point{X: x, Y: y}] || tooClose(x, y) {
+			return true
+		}
+		planted[repository.Point{X: x, Y: y}] = true
+		suggestions = append(suggestions, repository.Point{X: x, Y: y})
+		return len(suggestions) < opts.Count
+	})
+
+	return suggestions
+}
+
+// forEachPlantingPlot walk the candidate plots of the layout row by row, until fn return false
+func forEachPlantingPlot(estate *repository.Estate, opts plantingOptions, fn func(x, y int) bool) {
+	step, rowStep, offset := 1, 1, 0
+	switch opts.Pattern {
+	case plantingSquare:
+		step, rowStep = opts.MinDistance, opts.MinDistance
+	case plantingTriangular:
+		// the shortest row step keeping the shifted rows at the min distance
+		step, offset = opts.MinDistance, opts.MinDistance/2
+		for offset*offset+rowStep*rowStep < opts.MinDistance*opts.MinDistance {
+			rowStep++
+		}
+	}
+
+	for row, y := 0, 1; y <= estate.Width; row, y = row+1, y+rowStep {
+		for x := 1 + (row%2)*offset; x <= estate.Length; x += step {
+			if !fn(x, y) {
+				return
+			}
+		}
+	}
+}
+
+// withTrees is a copy of the estate with the trees planted on the plots
+func withTrees(estate *repository.Estate, plots []repository.Point, height int) *repository.Estate {
+	clone := *estate
+	clone.Trees = slices.Clip(estate.Trees)
+	for _, p := range plots {
+		clone.Trees = append(clone.Trees, repository.Tree{X: p.X, Y: p.Y, Height: height})
+	}
+	return &clone
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"container/heap"
+
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+// airspace is what the drone meets above the estate: the trees, the obstacles it must clear
+// and the no-fly plots it must route around
+type airspace struct {
+	width     int
+	length    int
+	trees     mapTree
+	obstacles map[string]repository.Obstacle // key => x,y
+	hasNoFly  bool
+	clearance int // height kept above trees & obstacles
+}
+
+func newAirspace(estate *repository.Estate, clearance int) *airspace {
+	a := &airspace{
+		clearance: clearance,
+		width:     estate.Width,
+		length:    estate.Length,
+		trees:     newMapTree(estate.Trees),
+		obstacles: make(map[string]repository.Obstacle, len(estate.Obstacles)),
+	}
+	for _, obstacle := range estate.Obstacles {
+		a.obstacles[getCoordinateKey(obstacle.X, obstacle.Y)] = obstacle
+		if obstacle.IsNoFly() {
+			a.hasNoFly = true
+		}
+	}
+	return a
+}
+
+func (a *airspace) isNoFly(x, y int) bool {
+	obstacle, exists := a.obstacles[getCoordinateKey(x, y)]
+	return exists && obstacle.IsNoFly()
+}
+
+// obstacleAltitude is the lowest altitude to clear the obstacle of the plot, 0 when there is none
+func (a *airspace) obstacleAltitude(x, y int) int {
+	obstacle, exists := a.obstacles[getCoordinateKey(x, y)]
+	if !exists || obstacle.IsNoFly() {
+		return 0
+	}
+	return *obstacle.Height + a.clearance
+}
+
+// safeAltitude is the lowest altitude to fly over the plot without hitting its tree or obstacle
+func (a *airspace) safeAltitude(x, y int) int {
+	altitude := a.obstacleAltitude(x, y)
+	if tree, isExist := a.trees.getTreeByCoordinate(x, y); isExist {
+		altitude = max(altitude, tree.Height+a.clearance)
+	}
+	return altitude
+}
+
+func (a *airspace) inEstate(c Coordinate) bool {
+	return c.X >= 1 && c.X <= a.length && c.Y >= 1 && c.Y <= a.width
+}
+
+// reachable flood fill the plots the drone can reach from start without crossing a no-fly plot,
+// the result is indexed by plotIndex
+func (a *airspace) reachable(start Coordinate) []bool {
+	visited := make([]bool, a.width*a.length)
+	visited[a.plotIndex(start)] = true
+
+	queue := []Coordinate{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, n := range a.neighbours(current) {
+			if !visited[a.plotIndex(n)] {
+				visited[a.plotIndex(n)] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	return visited
+}
+
+func (a *airspace) plotIndex(c Coordinate) int {
+	return (c.Y-1)*a.length + c.X - 1
+}
+
+// neighbours list the plots the drone can fly to from c, no-fly plots excluded
+func (a *airspace) neighbours(c Coordinate) []Coordinate {
+	res := make([]Coordinate, 0, 4)
+	for _, d := range [4]Coordinate{{X: 1}, {X: -1}, {Y: 1}, {Y: -1}} {
+		n := Coordinate{X: c.X + d.X, Y: c.Y + d.Y}
+		if a.inEstate(n) && !a.isNoFly(n.X, n.Y) {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+// route return the plots flown over between from & to, both excluded. Without no-fly plot
+// the drone fly along x then along y, otherwise it takes the shortest path around them.
+// ok is false when no-fly plots wall to off from from
+func (a *airspace) route(from, to Coordinate) (path []Coordinate, ok bool) {
+	if from == to {
+		return nil, true
+	}
+	if !a.hasNoFly {
+		for x := from.X; x != to.X; {
+			x += sign(to.X - x)
+			path = append(path, Coordinate{X: x, Y: from.Y})
+		}
+		for y := from.Y; y != to.Y; {
+			y += sign(to.Y - y)
+			path = append(path, Coordinate{X: to.X, Y: y})
+		}
+		return path[:len(path)-1], true
+	}
+
+	// A* search, the manhattan distance never overestimate the remaining plots
+	cost := map[Coordinate]int{from: 0}
+	cameFrom := map[Coordinate]Coordinate{}
+	open := &routeQueue{{plot: from, cost: 0, estimate: manhattan(from, to)}}
+	for open.Len() > 0 {
+		node := heap.Pop(open).(routeNode)
+		if node.plot == to {
+			break
+		}
+		if node.cost > cost[node.plot] {
+			continue // a shorter way to this plot was already expanded
+		}
+		for _, n := range a.neighbours(node.plot) {
+			nextCost := node.cost + 1
+			if known, exists := cost[n]; exists && known <= nextCost {
+				continue
+			}
+			cost[n] = nextCost
+			cameFrom[n] = node.plot
+			heap.Push(open, routeNode{plot: n, cost: nextCost, estimate: nextCost + manhattan(n, to)})
+		}
+	}
+
+	if _, exists := cameFrom[to]; !exists {
+		return nil, false
+	}
+	for c := cameFrom[to]; c != from; c = cameFrom[c] {
+		path = append(path, c)
+	}
+	// walked backward from to
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, true
+}
+
+type routeNode struct {
+	plot     Coordinate
+	cost     int // plots flown from the start
+	estimate int // cost + manhattan distance to the destination
+}
+
+// routeQueue is a min-heap of routeNode by estimate, the deepest node first on tie
+type routeQueue []routeNode
+
+func (q routeQueue) Len() int { return len(q) }
+func (q routeQueue) Less(i, j int) bool {
+	if q[i].estimate != q[j].estimate {
+		return q[i].estimate < q[j].estimate
+	}
+	return q[i].cost > q[j].cost
+}
+func (q routeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *routeQueue) Push(x any)   { *q = append(*q, x.(routeNode)) }
+func (q *routeQueue) Pop() any {
+	old := *q
+	node := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return node
+}
+
+func manhattan(a, b Coordinate) int {
+	return absInt(a.X-b.X) + absInt(a.Y-b.Y)
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
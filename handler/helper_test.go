@@ -1,9 +1,19 @@
 package handler
 
 import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/generated"
 	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -13,8 +23,10 @@ func TestCalculateDroneDistance(t *testing.T) {
 		name               string
 		estate             *repository.Estate
 		maxDistance        *int
+		skipExcluded       bool
 		expectedDistance   int64
 		expectedCoordinate *Coordinate
+		expectedUncovered  int64
 	}{
 		{
 			name: "Estate with different tree heights (from test example)",
@@ -129,17 +141,660 @@ func TestCalculateDroneDistance(t *testing.T) {
 			expectedCoordinate: &Coordinate{X: 2, Y: 1},
 			expectedDistance:   54,
 		},
+		{
+			name:             "Excluded plots are flown over like empty plots",
+			estate:           estateWithPond(),
+			expectedDistance: 92,
+		},
+		{
+			name:             "Excluded plots are skipped, drone fly from (3,1) to (3,2)",
+			estate:           estateWithPond(),
+			skipExcluded:     true,
+			expectedDistance: 52,
+		},
+		{
+			name:               "Excluded plots are skipped and max_distance provided (landed after the skipped plots)",
+			estate:             estateWithPond(),
+			skipExcluded:       true,
+			maxDistance:        pointerInt(35),
+			expectedCoordinate: &Coordinate{X: 3, Y: 2},
+			expectedDistance:   52,
+		},
+		{
+			name: "Every plot is skipped",
+			estate: &repository.Estate{
+				Width:  1,
+				Length: 1,
+				ExclusionZones: []repository.ExclusionZone{
+					{Kind: repository.EXCLUSION_KIND_PLOTS, Points: []repository.Point{{X: 1, Y: 1}}},
+				},
+			},
+			skipExcluded:     true,
+			expectedDistance: 0,
+		},
+		{
+			name: "Drone climb over the tower at (2,1) and keep the altitude",
+			estate: &repository.Estate{
+				Width:     1,
+				Length:    3,
+				Obstacles: []repository.Obstacle{{X: 2, Y: 1, Height: pointerInt(9)}},
+			},
+			expectedDistance: 40,
+		},
+		{
+			name: "Drone route around the no-fly plot (2,1) by the north row",
+			estate: &repository.Estate{
+				Width:     2,
+				Length:    3,
+				Obstacles: []repository.Obstacle{{X: 2, Y: 1}},
+			},
+			expectedDistance:  72,
+			expectedUncovered: 1,
+		},
+		{
+			name: "Drone climb over the tree on the way around the no-fly plot",
+			estate: &repository.Estate{
+				Width:     2,
+				Length:    3,
+				Trees:     []repository.Tree{{X: 2, Y: 2, Height: 9}},
+				Obstacles: []repository.Obstacle{{X: 2, Y: 1}},
+			},
+			expectedDistance:  108,
+			expectedUncovered: 1,
+		},
+		{
+			name:              "Plot walled in by no-fly plots is not covered",
+			estate:            estateWithWalledCorner(),
+			expectedDistance:  62,
+			expectedUncovered: 3,
+		},
+		{
+			name: "Every plot is no-fly",
+			estate: &repository.Estate{
+				Width:     1,
+				Length:    1,
+				Obstacles: []repository.Obstacle{{X: 1, Y: 1}},
+			},
+			expectedDistance:  0,
+			expectedUncovered: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := calculateDroneDistance(tt.estate, droneOptions{
+				Profile:      config.DefaultDroneProfile(),
+				MaxDistance:  tt.maxDistance,
+				SkipExcluded: tt.skipExcluded,
+			})
+			assert.Equal(t, tt.expectedDistance, plan.Distance)
+			assert.Equal(t, tt.expectedCoordinate, plan.Rest)
+			assert.Equal(t, tt.expectedUncovered, plan.UncoveredPlots)
+		})
+	}
+}
+
+func TestCalculateDroneDistance_Profile(t *testing.T) {
+	// 1x5 estate from the test example, 54m with the default profile
+	estate := &repository.Estate{
+		Width:  1,
+		Length: 5,
+		Trees: []repository.Tree{
+			{X: 2, Y: 1, Height: 5},
+			{X: 3, Y: 1, Height: 3},
+			{X: 4, Y: 1, Height: 4},
+		},
+	}
+
+	withProfile := func(update func(*config.DroneProfile)) config.DroneProfile {
+		profile := config.DefaultDroneProfile()
+		update(&profile)
+		return profile
+	}
+
+	tests := []struct {
+		name             string
+		profile          config.DroneProfile
+		expectedDistance int64
+	}{
+		{
+			name:             "Default profile",
+			profile:          config.DefaultDroneProfile(),
+			expectedDistance: 54,
+		},
+		{
+			name: "5m plots & 3m clearance",
+			profile: withProfile(func(p *config.DroneProfile) {
+				p.PlotSize = 5
+				p.Clearance = 3
+			}),
+			expectedDistance: 38,
+		},
+		{
+			name: "Cruise above every tree",
+			profile: withProfile(func(p *config.DroneProfile) {
+				p.TakeoffAltitude = 10
+				p.CruiseMinAltitude = 10
+			}),
+			expectedDistance: 60,
+		},
+		{
+			name: "Climbing costs 4 times descending",
+			profile: withProfile(func(p *config.DroneProfile) {
+				p.AscentCost = 2
+				p.DescentCost = 0.5
+			}),
+			expectedDistance: 58, // 57.5 rounded
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := calculateDroneDistance(estate, droneOptions{Profile: tt.profile})
+			assert.Equal(t, tt.expectedDistance, plan.Distance)
+		})
+	}
+}
+
+func TestCalculateDroneDistance_Energy(t *testing.T) {
+	// 1x5 estate from the test example: 40m flown horizontally, 7m climbed & 7m descended
+	estate := &repository.Estate{
+		Width:  1,
+		Length: 5,
+		Trees: []repository.Tree{
+			{X: 2, Y: 1, Height: 5},
+			{X: 3, Y: 1, Height: 3},
+			{X: 4, Y: 1, Height: 4},
+		},
+	}
+
+	t.Run("Default energy model", func(t *testing.T) {
+		plan := calculateDroneDistance(estate, droneOptions{Profile: config.DefaultDroneProfile()})
+		assert.Equal(t, int64(10), plan.Duration) // 4s + 2.33s climbing + 3.5s descending
+		assert.Equal(t, 0.63, plan.Energy)        // 800J + 933J + 525J
+		assert.Nil(t, plan.Rest)
+	})
+
+	t.Run("Slow drone", func(t *testing.T) {
+		profile := config.DefaultDroneProfile()
+		profile.Energy = config.DroneEnergy{Speed: 5, ClimbRate: 1, DescentRate: 1, CruisePower: 100, ClimbPower: 100, DescentPower: 100}
+
+		plan := calculateDroneDistance(estate, droneOptions{Profile: profile})
+		assert.Equal(t, int64(54), plan.Distance)
+		assert.Equal(t, int64(22), plan.Duration)
+		assert.Equal(t, 0.61, plan.Energy)
+	})
+
+	tests := []struct {
+		name               string
+		opts               droneOptions
+		expectedCoordinate *Coordinate
+	}{
+		{
+			// the 5m climb to the first tree draws 667J, not enough left to land safely after it
+			name:               "Max energy too low to climb",
+			opts:               droneOptions{MaxEnergy: pointerFloat(0.3)},
+			expectedCoordinate: &Coordinate{X: 1, Y: 1},
+		},
+		{
+			name:               "Max energy",
+			opts:               droneOptions{MaxEnergy: pointerFloat(0.5)},
+			expectedCoordinate: &Coordinate{X: 3, Y: 1},
+		},
+		{
+			name:               "Max duration",
+			opts:               droneOptions{MaxDuration: pointerInt(8)},
+			expectedCoordinate: &Coordinate{X: 3, Y: 1},
+		},
+		{
+			name:               "Max duration of the whole flight",
+			opts:               droneOptions{MaxDuration: pointerInt(60)},
+			expectedCoordinate: &Coordinate{X: 5, Y: 1},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			distance, lastCoordinate := calculateDroneDistance(tt.estate, tt.maxDistance)
-			assert.Equal(t, tt.expectedDistance, distance)
-			assert.Equal(t, tt.expectedCoordinate, lastCoordinate)
+			tt.opts.Profile = config.DefaultDroneProfile()
+			plan := calculateDroneDistance(estate, tt.opts)
+			assert.Equal(t, int64(54), plan.Distance)
+			assert.Equal(t, tt.expectedCoordinate, plan.Rest)
+		})
+	}
+}
+
+func TestCalculateDroneDistance_Pattern(t *testing.T) {
+	tests := []struct {
+		pattern          string
+		expectedDistance int64
+		expectedPattern  string
+	}{
+		{pattern: patternRows, expectedDistance: 108, expectedPattern: patternRows},
+		{pattern: patternColumns, expectedDistance: 90, expectedPattern: patternColumns},
+		{pattern: patternSpiral, expectedDistance: 108, expectedPattern: patternSpiral},
+		{pattern: patternAuto, expectedDistance: 90, expectedPattern: patternColumns},
+		{pattern: "", expectedDistance: 108, expectedPattern: patternRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			plan := calculateDroneDistance(estateWithTallColumns(), droneOptions{
+				Profile: config.DefaultDroneProfile(),
+				Pattern: tt.pattern,
+			})
+			assert.Equal(t, tt.expectedDistance, plan.Distance)
+			assert.Equal(t, tt.expectedPattern, plan.Pattern)
+		})
+	}
+}
+
+func TestCalculateDroneDistance_AutoTie(t *testing.T) {
+	// every pattern takes off from (1,1), walled in by the no-fly plots (2,1) & (1,2),
+	// so they all leave the same plots uncovered and the first pattern wins
+	estate := &repository.Estate{
+		Width:     3,
+		Length:    3,
+		Obstacles: []repository.Obstacle{{X: 2, Y: 1}, {X: 1, Y: 2}},
+	}
+
+	plan := calculateDroneDistance(estate, droneOptions{Profile: config.DefaultDroneProfile(), Pattern: patternAuto})
+	assert.Equal(t, patternRows, plan.Pattern)
+	assert.Equal(t, int64(8), plan.UncoveredPlots)
+}
+
+func TestForEachPlot(t *testing.T) {
+	estate := &repository.Estate{Width: 3, Length: 3}
+
+	tests := []struct {
+		pattern  string
+		expected []Coordinate
+	}{
+		{
+			pattern:  patternRows,
+			expected: []Coordinate{{1, 1}, {2, 1}, {3, 1}, {3, 2}, {2, 2}, {1, 2}, {1, 3}, {2, 3}, {3, 3}},
+		},
+		{
+			pattern:  patternColumns,
+			expected: []Coordinate{{1, 1}, {1, 2}, {1, 3}, {2, 3}, {2, 2}, {2, 1}, {3, 1}, {3, 2}, {3, 3}},
+		},
+		{
+			pattern:  patternSpiral,
+			expected: []Coordinate{{1, 1}, {2, 1}, {3, 1}, {3, 2}, {3, 3}, {2, 3}, {1, 3}, {1, 2}, {2, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			var visited []Coordinate
+			forEachPlot(estate, tt.pattern, func(x, y int) bool {
+				visited = append(visited, Coordinate{X: x, Y: y})
+				return true
+			})
+			assert.Equal(t, tt.expected, visited)
 		})
 	}
+
+	t.Run("Spiral on a single row & a single column", func(t *testing.T) {
+		var visited []Coordinate
+		collect := func(x, y int) bool {
+			visited = append(visited, Coordinate{X: x, Y: y})
+			return true
+		}
+
+		forEachPlot(&repository.Estate{Width: 1, Length: 3}, patternSpiral, collect)
+		forEachPlot(&repository.Estate{Width: 2, Length: 1}, patternSpiral, collect)
+		assert.Equal(t, []Coordinate{{1, 1}, {2, 1}, {3, 1}, {1, 1}, {1, 2}}, visited)
+	})
+}
+
+func TestCalculateDroneDistance_UncoveredTrees(t *testing.T) {
+	plan := calculateDroneDistance(estateWithWalledCorner(), droneOptions{Profile: config.DefaultDroneProfile()})
+
+	assert.Equal(t, []repository.Tree{{X: 1, Y: 3, Height: 5}}, plan.UncoveredTrees)
+}
+
+func TestAirspaceRoute(t *testing.T) {
+	t.Run("Without no-fly plot, along x then y", func(t *testing.T) {
+		air := newAirspace(&repository.Estate{Width: 3, Length: 3}, 1)
+
+		path, ok := air.route(Coordinate{X: 1, Y: 1}, Coordinate{X: 3, Y: 3})
+		assert.True(t, ok)
+		assert.Equal(t, []Coordinate{{X: 2, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 2}}, path)
+	})
+
+	t.Run("Around a no-fly wall", func(t *testing.T) {
+		// no-fly from (2,1) to (2,2), the only way is through (2,3)
+		air := newAirspace(&repository.Estate{
+			Width:     3,
+			Length:    3,
+			Obstacles: []repository.Obstacle{{X: 2, Y: 1}, {X: 2, Y: 2}},
+		}, 1)
+
+		path, ok := air.route(Coordinate{X: 1, Y: 1}, Coordinate{X: 3, Y: 1})
+		assert.True(t, ok)
+		assert.Equal(t, []Coordinate{{X: 1, Y: 2}, {X: 1, Y: 3}, {X: 2, Y: 3}, {X: 3, Y: 3}, {X: 3, Y: 2}}, path)
+	})
+
+	t.Run("Walled in destination", func(t *testing.T) {
+		air := estateWithWalledCorner()
+
+		_, ok := newAirspace(air, 1).route(Coordinate{X: 1, Y: 1}, Coordinate{X: 1, Y: 3})
+		assert.False(t, ok)
+	})
 }
 
 func pointerInt(i int) *int {
 	return &i
 }
+
+func pointerFloat(f float64) *float64 {
+	return &f
+}
+
+// estateWithPond is a 5x2 estate whose east end, (4,1) to (5,2), is a pond
+func estateWithPond() *repository.Estate {
+	return &repository.Estate{
+		Width:  2,
+		Length: 5,
+		ExclusionZones: []repository.ExclusionZone{
+			{
+				Kind:   repository.EXCLUSION_KIND_POLYGON,
+				Points: []repository.Point{{X: 4, Y: 1}, {X: 5, Y: 1}, {X: 5, Y: 2}, {X: 4, Y: 2}},
+			},
+		},
+	}
+}
+
+// estateWithWalledCorner is a 3x3 estate whose north-west plot (1,3), with a tree, is walled in by the
+// no-fly plots (1,2) & (2,3)
+func estateWithWalledCorner() *repository.Estate {
+	return &repository.Estate{
+		Width:     3,
+		Length:    3,
+		Trees:     []repository.Tree{{X: 1, Y: 3, Height: 5}},
+		Obstacles: []repository.Obstacle{{X: 1, Y: 2}, {X: 2, Y: 3}},
+	}
+}
+
+// estateWithTallColumns is a 3x2 estate, columns 1 & 3 have 10m trees and column 2 has 1m trees.
+// The drone climbs & descends on every row but only between the columns
+func estateWithTallColumns() *repository.Estate {
+	return &repository.Estate{
+		Width:  2,
+		Length: 3,
+		Trees: []repository.Tree{
+			{X: 1, Y: 1, Height: 10}, {X: 1, Y: 2, Height: 10},
+			{X: 2, Y: 1, Height: 1}, {X: 2, Y: 2, Height: 1},
+			{X: 3, Y: 1, Height: 10}, {X: 3, Y: 2, Height: 10},
+		},
+	}
+}
+
+func TestCalculateYield(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC) }
+	short, tall, young := repository.Tree{Id: uuid.New(), X: 1, Y: 1, Height: 3}, repository.Tree{Id: uuid.New(), X: 2, Y: 1, Height: 8}, repository.Tree{Id: uuid.New(), X: 12, Y: 2, Height: 12}
+	estate := &repository.Estate{
+		Width:  10,
+		Length: 20,
+		Trees:  []repository.Tree{short, tall, young},
+		// the north half of the east block is a pond, 0.5 hectare
+		ExclusionZones: []repository.ExclusionZone{
+			{Kind: repository.EXCLUSION_KIND_POLYGON, Points: []repository.Point{{X: 11, Y: 6}, {X: 20, Y: 6}, {X: 20, Y: 10}, {X: 11, Y: 10}}},
+		},
+	}
+	harvests := []repository.Harvest{
+		// a region harvest shared by the 2 trees of its plots
+		{Plots: []repository.Point{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}}, HarvestedAt: day(time.January, 15), WeightKg: 90, BunchCount: 6},
+		{TreeId: &young.Id, Plots: []repository.Point{{X: 12, Y: 2}}, HarvestedAt: day(time.January, 31), WeightKg: 30, BunchCount: 2},
+		// loose fruits picked where no tree stands only count for the block
+		{Plots: []repository.Point{{X: 15, Y: 3}, {X: 16, Y: 3}}, HarvestedAt: day(time.February, 5), WeightKg: 10, BunchCount: 1},
+	}
+
+	report := calculateYield(estate, harvests, yieldOptions{period: "month", band: 5, blockSize: 10, least: 5})
+
+	assert.InDelta(t, 1.5, report.Hectares, 1e-9)
+	assert.Equal(t, int64(3), report.Total.Harvests)
+	assert.Equal(t, 130.0, report.Total.WeightKg)
+	assert.Equal(t, int64(9), report.Total.BunchCount)
+	assert.InDelta(t, 130/1.5, report.Total.WeightKgPerHectare, 1e-9)
+
+	assert.Len(t, report.Periods, 2)
+	assert.Equal(t, day(time.January, 1), report.Periods[0].Start.Time)
+	assert.Equal(t, int64(2), report.Periods[0].Harvests)
+	assert.Equal(t, 120.0, report.Periods[0].WeightKg)
+	assert.InDelta(t, 80, report.Periods[0].WeightKgPerHectare, 1e-9)
+	assert.Equal(t, day(time.February, 1), report.Periods[1].Start.Time)
+	assert.Equal(t, int64(1), report.Periods[1].BunchCount)
+
+	assert.Equal(t, []generated.YieldHeightBand{
+		{MinHeight: 1, MaxHeight: 5, TreeCount: 1, WeightKg: 45, BunchCount: 3, WeightKgPerTree: 45},
+		{MinHeight: 6, MaxHeight: 10, TreeCount: 1, WeightKg: 45, BunchCount: 3, WeightKgPerTree: 45},
+		{MinHeight: 11, MaxHeight: 15, TreeCount: 1, WeightKg: 30, BunchCount: 2, WeightKgPerTree: 30},
+	}, report.HeightBands)
+
+	// the east block yield less per plantable hectare than the west one
+	assert.Len(t, report.LeastProductiveBlocks, 2)
+	east, west := report.LeastProductiveBlocks[0], report.LeastProductiveBlocks[1]
+	assert.Equal(t, []int{11, 1, 10, 10}, []int{east.X, east.Y, east.Length, east.Width})
+	assert.Equal(t, int64(1), east.TreeCount)
+	assert.InDelta(t, 0.5, east.Hectares, 1e-9)
+	assert.Equal(t, 40.0, east.WeightKg)
+	assert.InDelta(t, 80, east.WeightKgPerHectare, 1e-9)
+	assert.Equal(t, []int{1, 1}, []int{west.X, west.Y})
+	assert.Equal(t, int64(2), west.TreeCount)
+	assert.InDelta(t, 90, west.WeightKgPerHectare, 1e-9)
+
+	t.Run("Least productive blocks are limited", func(t *testing.T) {
+		report := calculateYield(estate, harvests, yieldOptions{period: "year", band: 30, blockSize: 10, least: 1})
+
+		assert.Len(t, report.Periods, 1)
+		assert.Equal(t, []generated.YieldHeightBand{{MinHeight: 1, MaxHeight: 30, TreeCount: 3, WeightKg: 120, BunchCount: 8, WeightKgPerTree: 40}}, report.HeightBands)
+		assert.Len(t, report.LeastProductiveBlocks, 1)
+		assert.Equal(t, 11, report.LeastProductiveBlocks[0].X)
+	})
+
+	t.Run("Harvests of a removed tree are not credited to its replacement", func(t *testing.T) {
+		removedID := uuid.New()
+		harvests := []repository.Harvest{
+			{TreeId: &removedID, Plots: []repository.Point{{X: 1, Y: 1}}, HarvestedAt: day(time.January, 15), WeightKg: 40, BunchCount: 3},
+			{TreeId: &short.Id, Plots: []repository.Point{{X: 1, Y: 1}}, HarvestedAt: day(time.March, 1), WeightKg: 20, BunchCount: 1},
+		}
+
+		report := calculateYield(estate, harvests, yieldOptions{period: "year", band: 5, blockSize: 10, least: 5})
+
+		assert.Equal(t, 60.0, report.Total.WeightKg)
+		assert.Equal(t, generated.YieldHeightBand{MinHeight: 1, MaxHeight: 5, TreeCount: 1, WeightKg: 20, BunchCount: 1, WeightKgPerTree: 20}, report.HeightBands[0])
+		// the removed tree's harvest still counts for the block of its plot
+		west := report.LeastProductiveBlocks[1]
+		assert.Equal(t, []int{1, 1}, []int{west.X, west.Y})
+		assert.Equal(t, 60.0, west.WeightKg)
+	})
+
+	t.Run("No harvest", func(t *testing.T) {
+		report := calculateYield(&repository.Estate{Width: 10, Length: 10}, nil, yieldOptions{period: "month", band: 5, blockSize: 10, least: 5})
+
+		assert.Equal(t, generated.YieldTotals{}, report.Total)
+		assert.Empty(t, report.Periods)
+		assert.NotNil(t, report.HeightBands)
+		assert.NotNil(t, report.LeastProductiveBlocks)
+	})
+}
+
+func TestPeriodStart(t *testing.T) {
+	// a Sunday
+	date := time.Date(2024, time.February, 4, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, date, periodStart(date, "day"))
+	assert.Equal(t, time.Date(2024, time.January, 29, 0, 0, 0, 0, time.UTC), periodStart(date, "week"))
+	assert.Equal(t, time.Date(2024, time.January, 29, 0, 0, 0, 0, time.UTC), periodStart(date.AddDate(0, 0, -6), "week"))
+	assert.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), periodStart(date, "month"))
+	assert.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), periodStart(date, "year"))
+}
+
+func TestPlanPlanting(t *testing.T) {
+	estate := &repository.Estate{
+		Width:  5,
+		Length: 5,
+		Trees:  []repository.Tree{{X: 1, Y: 1, Height: 5}},
+		ExclusionZones: []repository.ExclusionZone{
+			{Kind: repository.EXCLUSION_KIND_PLOTS, Points: []repository.Point{{X: 2, Y: 1}}},
+		},
+		Obstacles: []repository.Obstacle{{X: 4, Y: 1}},
+	}
+
+	tests := []struct {
+		name     string
+		opts     plantingOptions
+		expected []repository.Point
+	}{
+		{
+			name:     "Fill Skips Trees, Exclusions & Obstacles",
+			opts:     plantingOptions{Count: 3, MinDistance: 1, Pattern: plantingFill},
+			expected: []repository.Point{{X: 3, Y: 1}, {X: 5, Y: 1}, {X: 1, Y: 2}},
+		},
+		{
+			name:     "Fill Keeps The Min Distance",
+			opts:     plantingOptions{Count: 3, MinDistance: 2, Pattern: plantingFill},
+			expected: []repository.Point{{X: 3, Y: 1}, {X: 5, Y: 1}, {X: 1, Y: 3}},
+		},
+		{
+			name:     "Square",
+			opts:     plantingOptions{Count: 10, MinDistance: 2, Pattern: plantingSquare},
+			expected: []repository.Point{{X: 3, Y: 1}, {X: 5, Y: 1}, {X: 1, Y: 3}, {X: 3, Y: 3}, {X: 5, Y: 3}, {X: 1, Y: 5}, {X: 3, Y: 5}, {X: 5, Y: 5}},
+		},
+		{
+			name:     "Triangular",
+			opts:     plantingOptions{Count: 10, MinDistance: 3, Pattern: plantingTriangular},
+			expected: []repository.Point{{X: 2, Y: 4}, {X: 5, Y: 4}},
+		},
+		{
+			name:     "Fewer Plots Than Requested",
+			opts:     plantingOptions{Count: 100, MinDistance: 4, Pattern: plantingFill},
+			expected: []repository.Point{{X: 5, Y: 1}, {X: 1, Y: 5}, {X: 5, Y: 5}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, planPlanting(estate, tc.opts))
+		})
+	}
+}
+
+func TestNewEstateMap(t *testing.T) {
+	estate := &repository.Estate{
+		Width:  2,
+		Length: 4,
+		Trees:  []repository.Tree{{X: 1, Y: 1, Height: 1}, {X: 4, Y: 2, Height: 30}},
+		ExclusionZones: []repository.ExclusionZone{
+			{Kind: repository.EXCLUSION_KIND_PLOTS, Points: []repository.Point{{X: 3, Y: 1}}},
+		},
+		Obstacles: []repository.Obstacle{{X: 2, Y: 2, Height: ptr.ToPointer(5)}},
+	}
+
+	m := newEstateMap(estate, mapOptions{MaxCells: 10})
+	assert.Equal(t, 1, m.Scale)
+	assert.Equal(t, 4, m.Cols)
+	assert.Equal(t, 2, m.Rows)
+	assert.Equal(t, mapShortColor, m.Cells[0].color())
+	assert.Equal(t, mapSoilColor, m.Cells[1].color())
+	assert.Equal(t, mapExcludedColor, m.Cells[2].color())
+	assert.Equal(t, mapObstacleColor, m.Cells[5].color())
+	assert.Equal(t, mapTallColor, m.Cells[7].color())
+	assert.Nil(t, m.Path)
+
+	// the South-West corner is at the bottom left of the image
+	img, err := m.renderPNG()
+	assert.NoError(t, err)
+	decoded, err := png.Decode(bytes.NewReader(img))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 80, 40), decoded.Bounds())
+	assert.Equal(t, mapShortColor, color.RGBAModel.Convert(decoded.At(0, 39)))
+	assert.Equal(t, mapTallColor, color.RGBAModel.Convert(decoded.At(79, 0)))
+
+	svg := string(m.renderSVG())
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="80" height="40"`))
+	assert.Contains(t, svg, `<rect x="0" y="20" width="20" height="20" fill="#c7e9c0"/>`)
+	assert.NotContains(t, svg, "<polyline")
+}
+
+func TestNewEstateMap_Downsampled(t *testing.T) {
+	estate := &repository.Estate{
+		Width:  10,
+		Length: 25,
+		Trees:  []repository.Tree{{X: 1, Y: 1, Height: 2}, {X: 3, Y: 3, Height: 4}},
+	}
+	opts := mapOptions{
+		MaxCells:  10,
+		DronePath: true,
+		Drone:     droneOptions{Profile: config.DefaultDroneProfile(), MaxDistance: ptr.ToPointer(100), Pattern: patternRows},
+	}
+
+	m := newEstateMap(estate, opts)
+	assert.Equal(t, 3, m.Scale)
+	assert.Equal(t, 9, m.Cols)
+	assert.Equal(t, 4, m.Rows)
+	assert.Equal(t, mapCell{Trees: 2, SumHeight: 6}, m.Cells[0])
+
+	// the serpentine goes row by row of cells, a point a cell
+	assert.Len(t, m.Path, 36)
+	assert.Equal(t, []Coordinate{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}, m.Path[:3])
+	assert.Equal(t, []Coordinate{{X: 7, Y: 0}, {X: 8, Y: 0}, {X: 8, Y: 1}, {X: 7, Y: 1}}, m.Path[7:11])
+	assert.Equal(t, Coordinate{X: 0, Y: 3}, m.Path[len(m.Path)-1])
+	assert.NotNil(t, m.Rest)
+
+	svg := string(m.renderSVG())
+	assert.Contains(t, svg, "<polyline")
+	assert.Contains(t, svg, "<circle")
+}
+
+func TestNewEstateMap_PathSkipExcluded(t *testing.T) {
+	estate := &repository.Estate{
+		Width:  2,
+		Length: 3,
+		ExclusionZones: []repository.ExclusionZone{
+			{Kind: repository.EXCLUSION_KIND_PLOTS, Points: []repository.Point{{X: 2, Y: 1}}},
+		},
+	}
+	opts := mapOptions{
+		MaxCells:  10,
+		DronePath: true,
+		Drone:     droneOptions{Profile: config.DefaultDroneProfile(), SkipExcluded: true, Pattern: patternRows},
+	}
+
+	m := newEstateMap(estate, opts)
+	assert.Equal(t, []Coordinate{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 1, Y: 1}, {X: 0, Y: 1}}, m.Path)
+}
+
+func TestRenderTilePNG(t *testing.T) {
+	// 2 tiles a side at the max zoom, the second column of tiles is 44 plots wide
+	estate := &repository.Estate{Width: 10, Length: 300}
+	input := repository.GetTileCellsInput{Zoom: 1, MaxZoom: 1, X: 1, Y: 0}
+
+	img, err := renderTilePNG(estate, input, []repository.TileCell{{X: 256, Y: 0, TreeCount: 1, SumHeight: 30, MaxHeight: 30}})
+	assert.NoError(t, err)
+	decoded, err := png.Decode(bytes.NewReader(img))
+	assert.NoError(t, err)
+
+	rgba := func(x, y int) color.RGBA { return color.RGBAModel.Convert(decoded.At(x, y)).(color.RGBA) }
+	assert.Equal(t, mapTallColor, rgba(0, 255))
+	assert.Equal(t, mapSoilColor, rgba(43, 246))
+	assert.Equal(t, color.RGBA{}, rgba(44, 255))
+	assert.Equal(t, color.RGBA{}, rgba(0, 245))
+}
+
+func TestParseTile(t *testing.T) {
+	y, format, errs := parseTile("12.png")
+	assert.Nil(t, errs)
+	assert.Equal(t, 12, y)
+	assert.Equal(t, tileFormatPNG, format)
+
+	_, format, errs = parseTile("0.json")
+	assert.Nil(t, errs)
+	assert.Equal(t, tileFormatJSON, format)
+
+	for _, tile := range []string{"12", "x.png", "-1.png", "1.gif"} {
+		_, _, errs = parseTile(tile)
+		assert.Contains(t, errs, "Tile", tile)
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+)
+
+// tile formats, the extension of the {y} path segment
+const (
+	tileFormatPNG  = "png"
+	tileFormatJSON = "json"
+)
+
+// parseTile split the {tile} path segment into the tile row & its format
+func parseTile(tile string) (y int, format string, errs map[string]string) {
+	row, format, _ := strings.Cut(tile, ".")
+	y, err := strconv.Atoi(row)
+	if err != nil || y < 0 || (format != tileFormatPNG && format != tileFormatJSON) {
+		return 0, "", map[string]string{"Tile": fmt.Sprintf("Tile %q must be {y}.png or {y}.json", tile)}
+	}
+	return y, format, nil
+}
+
+// tileInput check the tile is inside the estate at the zoom, a tile past the estate is not found
+func tileInput(estate *repository.Estate, z, x, y int) (repository.GetTileCellsInput, error) {
+	maxZoom := estate.MaxTileZoom()
+	if z > maxZoom {
+		return repository.GetTileCellsInput{}, apperror.WrapWithCode(
+			fmt.Errorf("zoom %d is past the max zoom %d of the estate", z, maxZoom), http.StatusNotFound)
+	}
+	span := repository.TILE_SIZE * estate.TileCellSize(z) // plots a side of a tile
+	if x*span >= estate.Length || y*span >= estate.Width {
+		return repository.GetTileCellsInput{}, apperror.WrapWithCode(
+			errors.New("tile is outside the estate"), http.StatusNotFound)
+	}
+
+	return repository.GetTileCellsInput{EstateId: estate.Id, Zoom: z, MaxZoom: maxZoom, X: x, Y: y}, nil
+}
+
+func toGeneratedTile(estate *repository.Estate, input repository.GetTileCellsInput, cells []repository.TileCell) generated.Tile {
+	size := estate.TileCellSize(input.Zoom)
+	tile := generated.Tile{
+		Z:        input.Zoom,
+		X:        input.X,
+		Y:        input.Y,
+		MaxZoom:  input.MaxZoom,
+		CellSize: size,
+		TileSize: repository.TILE_SIZE,
+		Cells:    make([]generated.TileCell, 0, len(cells)),
+	}
+	for _, c := range cells {
+		tile.Cells = append(tile.Cells, generated.TileCell{
+			X:         c.X*size + 1,
+			Y:         c.Y*size + 1,
+			TreeCount: c.TreeCount,
+			AvgHeight: float64(c.SumHeight) / float64(c.TreeCount),
+			MaxHeight: c.MaxHeight,
+		})
+	}
+	return tile
+}
+
+// renderTilePNG draw a pixel per cell coloured like the estate map, the North at the top.
+// The cells past the estate are transparent
+func renderTilePNG(estate *repository.Estate, input repository.GetTileCellsInput, cells []repository.TileCell) ([]byte, error) {
+	size := estate.TileCellSize(input.Zoom)
+	lo := repository.Point{X: input.X * repository.TILE_SIZE, Y: input.Y * repository.TILE_SIZE}
+	cols := min(repository.TILE_SIZE, (estate.Length+size-1)/size-lo.X)
+	rows := min(repository.TILE_SIZE, (estate.Width+size-1)/size-lo.Y)
+
+	img := image.NewRGBA(image.Rect(0, 0, repository.TILE_SIZE, repository.TILE_SIZE))
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			img.SetRGBA(col, repository.TILE_SIZE-1-row, mapSoilColor)
+		}
+	}
+	for _, c := range cells {
+		cell := mapCell{Trees: c.TreeCount, SumHeight: int(c.SumHeight)}
+		img.SetRGBA(c.X-lo.X, repository.TILE_SIZE-1-(c.Y-lo.Y), cell.color())
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
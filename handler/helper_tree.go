@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// validateTreeAttributes check what the validate tags can't, the tree is planted already.
+// A day of slack is given as the date is in the timezone of the estate
+func validateTreeAttributes(tree generated.AddTreeRequest) map[string]string {
+	if tree.PlantedAt != nil && tree.PlantedAt.Time.After(time.Now().AddDate(0, 0, 1)) {
+		return map[string]string{"PlantedAt": fmt.Sprintf("PlantedAt %s is in the future", tree.PlantedAt)}
+	}
+	return nil
+}
+
+func toCreateTreeInput(id, estateID uuid.UUID, tree generated.AddTreeRequest) repository.CreateTreeInput {
+	input := repository.CreateTreeInput{
+		Id:       id,
+		EstateId: estateID,
+		X:        tree.X,
+		Y:        tree.Y,
+		Height:   tree.Height,
+	}
+	if tree.Species != nil {
+		input.Species = *tree.Species
+	}
+	if tree.PlantedAt != nil {
+		input.PlantedAt = &tree.PlantedAt.Time
+	}
+	if tree.Health != nil {
+		input.Health = repository.TreeHealth(*tree.Health)
+	}
+	if tree.Tags != nil {
+		input.Tags = *tree.Tags
+	}
+	return input
+}
+
+func toUpdateTreeInput(id, estateID uuid.UUID, tree generated.UpdateTreeRequest) repository.UpdateTreeInput {
+	input := repository.UpdateTreeInput{
+		Id:       id,
+		EstateId: estateID,
+		Species:  tree.Species,
+	}
+	if tree.Health != nil {
+		input.Health = ptr.ToPointer(repository.TreeHealth(*tree.Health))
+	}
+	if tree.Tags != nil {
+		input.Tags = append([]string{}, *tree.Tags...)
+	}
+	return input
+}
+
+// toAddTreeRequest is the tree of an exported estate document, importing it plant the same tree
+func toAddTreeRequest(tree repository.Tree) generated.AddTreeRequest {
+	res := generated.AddTreeRequest{
+		X:      tree.X,
+		Y:      tree.Y,
+		Height: tree.Height,
+		Health: ptr.ToPointer(string(tree.Health)),
+	}
+	if tree.Species != "" {
+		res.Species = ptr.ToPointer(tree.Species)
+	}
+	if tree.PlantedAt != nil {
+		res.PlantedAt = &openapi_types.Date{Time: *tree.PlantedAt}
+	}
+	if len(tree.Tags) > 0 {
+		res.Tags = ptr.ToPointer(tree.Tags)
+	}
+	return res
+}
+
+// filterTrees keep the trees matching every given filter, ordered by plot like the other tree lists
+func filterTrees(trees []repository.Tree, params generated.GetEstateIdTreeParams) []repository.Tree {
+	res := make([]repository.Tree, 0, len(trees))
+	for _, tree := range trees {
+		if params.Species != nil && tree.Species != *params.Species {
+			continue
+		}
+		if params.Health != nil && string(tree.Health) != *params.Health {
+			continue
+		}
+		if params.Tag != nil && !tree.HasTag(*params.Tag) {
+			continue
+		}
+		res = append(res, tree)
+	}
+
+	slices.SortFunc(res, func(a, b repository.Tree) int {
+		return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+	return res
+}
+
+func toGeneratedTreeGroups(groups []repository.TreeGroupStats) []generated.TreeGroupStats {
+	res := make([]generated.TreeGroupStats, 0, len(groups))
+	for _, group := range groups {
+		res = append(res, generated.TreeGroupStats{
+			Value:  group.Value,
+			Count:  group.TreeCount,
+			Max:    group.MaxHeight,
+			Min:    group.MinHeight,
+			Median: group.MedianHeight,
+		})
+	}
+	return res
+}
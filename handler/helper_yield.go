@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// plotHectares is the area of a 10x10 meters plot
+const plotHectares = 0.01
+
+type yieldOptions struct {
+	period    string // day, week, month or year
+	band      int    // meters of tree height per band
+	blockSize int    // side of a block in plots
+	least     int    // number of least productive blocks reported
+}
+
+func newYieldOptions(params generated.GetEstateIdYieldParams) yieldOptions {
+	opts := yieldOptions{period: "month", band: 5, blockSize: 10, least: 5}
+	if params.Period != nil {
+		opts.period = *params.Period
+	}
+	if params.Band != nil {
+		opts.band = *params.Band
+	}
+	if params.BlockSize != nil {
+		opts.blockSize = *params.BlockSize
+	}
+	if params.Least != nil {
+		opts.least = *params.Least
+	}
+	return opts
+}
+
+// validateDateRange check the report window is not reversed
+func validateDateRange(from, to *openapi_types.Date) map[string]string {
+	if from != nil && to != nil && from.Time.After(to.Time) {
+		return map[string]string{"To": fmt.Sprintf("To %s is before From %s", to, from)}
+	}
+	return nil
+}
+
+// validateHarvestedAt check the harvest happened already, with a day of slack like validateTreeAttributes
+func validateHarvestedAt(harvestedAt openapi_types.Date) map[string]string {
+	if harvestedAt.Time.IsZero() {
+		return map[string]string{"HarvestedAt": "HarvestedAt is required"}
+	}
+	if harvestedAt.Time.After(time.Now().AddDate(0, 0, 1)) {
+		return map[string]string{"HarvestedAt": fmt.Sprintf("HarvestedAt %s is in the future", harvestedAt)}
+	}
+	return nil
+}
+
+func toGetHarvestsInput(estateID openapi_types.UUID, from, to *openapi_types.Date) repository.GetHarvestsInput {
+	input := repository.GetHarvestsInput{EstateId: estateID}
+	if from != nil {
+		input.From = &from.Time
+	}
+	if to != nil {
+		input.To = &to.Time
+	}
+	return input
+}
+
+// periodStart is the first day of the period holding the date, weeks start on Monday
+func periodStart(date time.Time, period string) time.Time {
+	y, m, d := date.Date()
+	switch period {
+	case "day":
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	case "week":
+		return time.Date(y, m, d-(int(date.Weekday())+6)%7, 0, 0, 0, 0, time.UTC)
+	case "year":
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func perHectare(weight, hectares float64) float64 {
+	if hectares <= 0 {
+		return 0
+	}
+	return weight / hectares
+}
+
+// calculateYield report the harvests, ordered by date, of the estate.
+// A tree harvest counts for its tree, or only for the block of its plot once the tree was removed, so it is
+// not credited to a tree planted later on the same plot. A region harvest is shared evenly by the trees
+// planted in its plots, or by its plots when none hold a tree, so it counts for the height bands & the blocks
+// of the trees it was picked from
+func calculateYield(estate *repository.Estate, harvests []repository.Harvest, opts yieldOptions) generated.YieldReport {
+	hectares := float64(estate.PlantablePlots()) * plotHectares
+	report := generated.YieldReport{
+		Period:                opts.period,
+		Hectares:              hectares,
+		Periods:               []generated.YieldPeriod{},
+		HeightBands:           []generated.YieldHeightBand{},
+		LeastProductiveBlocks: []generated.YieldBlock{},
+	}
+
+	trees := newMapTree(estate.Trees)
+	treesByID := make(map[uuid.UUID]repository.Tree, len(estate.Trees))
+	for _, tree := range estate.Trees {
+		treesByID[tree.Id] = tree
+	}
+	blockOf := func(x, y int) repository.Point {
+		return repository.Point{X: (x - 1) / opts.blockSize, Y: (y - 1) / opts.blockSize}
+	}
+	type share struct{ weight, bunches float64 }
+	treeShares := make(map[uuid.UUID]share)
+	blockWeights := make(map[repository.Point]float64)
+
+	for _, harvest := range harvests {
+		report.Total.Harvests++
+		report.Total.WeightKg += harvest.WeightKg
+		report.Total.BunchCount += int64(harvest.BunchCount)
+
+		// harvests are ordered by date, a new period start after the last one
+		start := periodStart(harvest.HarvestedAt, opts.period)
+		if len(report.Periods) == 0 || !report.Periods[len(report.Periods)-1].Start.Time.Equal(start) {
+			report.Periods = append(report.Periods, generated.YieldPeriod{Start: openapi_types.Date{Time: start}})
+		}
+		period := &report.Periods[len(report.Periods)-1]
+		period.Harvests++
+		period.WeightKg += harvest.WeightKg
+		period.BunchCount += int64(harvest.BunchCount)
+
+		var harvested []repository.Tree
+		if harvest.TreeId != nil {
+			if tree, ok := treesByID[*harvest.TreeId]; ok {
+				harvested = append(harvested, tree)
+			}
+		} else {
+			for _, p := range harvest.Plots {
+				if tree, ok := trees.getTreeByCoordinate(p.X, p.Y); ok {
+					harvested = append(harvested, tree)
+				}
+			}
+		}
+		if len(harvested) == 0 {
+			for _, p := range harvest.Plots {
+				blockWeights[blockOf(p.X, p.Y)] += harvest.WeightKg / float64(len(harvest.Plots))
+			}
+			continue
+		}
+		for _, tree := range harvested {
+			s := treeShares[tree.Id]
+			s.weight += harvest.WeightKg / float64(len(harvested))
+			s.bunches += float64(harvest.BunchCount) / float64(len(harvested))
+			treeShares[tree.Id] = s
+			blockWeights[blockOf(tree.X, tree.Y)] += harvest.WeightKg / float64(len(harvested))
+		}
+	}
+
+	report.Total.WeightKgPerHectare = perHectare(report.Total.WeightKg, hectares)
+	for i := range report.Periods {
+		report.Periods[i].WeightKgPerHectare = perHectare(report.Periods[i].WeightKg, hectares)
+	}
+
+	// #1. Height bands of the trees, harvested or not
+	bands := make(map[int]*generated.YieldHeightBand)
+	// #2. Blocks holding trees, the blocks without trees have nothing to be productive with
+	blocks := make(map[repository.Point]*generated.YieldBlock)
+	excluded := estate.ExcludedPlots(repository.Point{X: 1, Y: 1}, repository.Point{X: estate.Length, Y: estate.Width})
+	for _, tree := range estate.Trees {
+		idx := (tree.Height - 1) / opts.band
+		band, ok := bands[idx]
+		if !ok {
+			band = &generated.YieldHeightBand{MinHeight: idx*opts.band + 1, MaxHeight: (idx + 1) * opts.band}
+			bands[idx] = band
+		}
+		band.TreeCount++
+		band.WeightKg += treeShares[tree.Id].weight
+		band.BunchCount += treeShares[tree.Id].bunches
+
+		key := blockOf(tree.X, tree.Y)
+		block, ok := blocks[key]
+		if !ok {
+			lo := repository.Point{X: key.X*opts.blockSize + 1, Y: key.Y*opts.blockSize + 1}
+			hi := repository.Point{X: min(lo.X+opts.blockSize-1, estate.Length), Y: min(lo.Y+opts.blockSize-1, estate.Width)}
+			plots := int64(hi.X-lo.X+1)*int64(hi.Y-lo.Y+1) - excluded.CountIn(lo, hi)
+			block = &generated.YieldBlock{
+				X:        lo.X,
+				Y:        lo.Y,
+				Length:   hi.X - lo.X + 1,
+				Width:    hi.Y - lo.Y + 1,
+				Hectares: float64(plots) * plotHectares,
+				WeightKg: blockWeights[key],
+			}
+			block.WeightKgPerHectare = perHectare(block.WeightKg, block.Hectares)
+			blocks[key] = block
+		}
+		block.TreeCount++
+	}
+
+	for _, band := range bands {
+		band.WeightKgPerTree = band.WeightKg / float64(band.TreeCount)
+		report.HeightBands = append(report.HeightBands, *band)
+	}
+	slices.SortFunc(report.HeightBands, func(a, b generated.YieldHeightBand) int {
+		return cmp.Compare(a.MinHeight, b.MinHeight)
+	})
+
+	for _, block := range blocks {
+		report.LeastProductiveBlocks = append(report.LeastProductiveBlocks, *block)
+	}
+	slices.SortFunc(report.LeastProductiveBlocks, func(a, b generated.YieldBlock) int {
+		return cmp.Or(cmp.Compare(a.WeightKgPerHectare, b.WeightKgPerHectare), cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+	})
+	if len(report.LeastProductiveBlocks) > opts.least {
+		report.LeastProductiveBlocks = report.LeastProductiveBlocks[:opts.least]
+	}
+
+	return report
+}
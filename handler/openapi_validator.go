@@ -0,0 +1,226 @@
+// This file contains the middleware validating requests, and optionally responses, against api.yml.
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/telemetry"
+	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/labstack/echo/v4"
+)
+
+type NewOpenAPIValidatorOptions struct {
+	// ValidateResponses replace any response not matching api.yml with a 500, for dev & test only
+	ValidateResponses bool
+}
+
+var echoPathParam = regexp.MustCompile(`:([^/]+)`)
+
+// NewOpenAPIValidator return a middleware validating path params, query & body of every request
+// against the embedded spec. Routes missing from the spec (e.g. /metrics) are not validated
+func NewOpenAPIValidator(opts NewOpenAPIValidatorOptions) (echo.MiddlewareFunc, error) {
+	spec, err := generated.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi spec: %w", err)
+	}
+	// the servers of the spec are only documentation, the route is matched by echo
+	spec.Servers = nil
+
+	openapi3.DefineStringFormat("uuid", openapi3.FormatOfStringForUUIDOfRFC4122)
+	// the estate maps are images, only their content type is checked
+	openapi3filter.RegisterBodyDecoder("image/png", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("image/svg+xml", openapi3filter.FileBodyDecoder)
+	// the estate bundles are checked against their manifest by the handler
+	openapi3filter.RegisterBodyDecoder("application/gzip", openapi3filter.FileBodyDecoder)
+
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route := findRoute(spec, c)
+			if route == nil {
+				return next(c)
+			}
+
+			req := c.Request()
+			ctx := req.Context()
+			pathParams := make(map[string]string, len(c.ParamNames()))
+			for i, name := range c.ParamNames() {
+				pathParams[name] = c.ParamValues()[i]
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+				return c.JSON(http.StatusBadRequest, httphelper.ErrorResponse{
+					Message: "Validation failed",
+					Errors:  formatOpenAPIErrors(err),
+				})
+			}
+
+			if !opts.ValidateResponses || isStreamingOperation(route.Operation) {
+				return next(c)
+			}
+			return validateResponse(ctx, c, next, input)
+		}
+	}, nil
+}
+
+// findRoute return the spec operation of the echo route, nil when the spec does not have it
+func findRoute(spec *openapi3.T, c echo.Context) *routers.Route {
+	path := echoPathParam.ReplaceAllString(c.Path(), "{$1}")
+	pathItem := spec.Paths.Find(path)
+	if pathItem == nil {
+		return nil
+	}
+
+	method := c.Request().Method
+	operation := pathItem.GetOperation(method)
+	if operation == nil {
+		return nil
+	}
+
+	return &routers.Route{
+		Spec:      spec,
+		Path:      path,
+		PathItem:  pathItem,
+		Method:    method,
+		Operation: operation,
+	}
+}
+
+// isStreamingOperation report whether the operation respond with SSE, which can not be buffered
+func isStreamingOperation(operation *openapi3.Operation) bool {
+	for _, response := range operation.Responses {
+		if response.Value != nil && response.Value.Content.Get("text/event-stream") != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// validateResponse buffer the handler response and only send it when it match the spec,
+// a drift is logged and answered with a 500 so it can not go unnoticed
+func validateResponse(ctx context.Context, c echo.Context, next echo.HandlerFunc, input *openapi3filter.RequestValidationInput) error {
+	resp := c.Response()
+	writer := resp.Writer
+	buffer := &bufferedResponseWriter{ResponseWriter: writer, status: http.StatusOK}
+	resp.Writer = buffer
+
+	err := next(c)
+
+	resp.Writer = writer
+	if err != nil || !resp.Committed {
+		// nothing was written, the error is rendered by the echo error handler
+		return err
+	}
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 buffer.status,
+		Header:                 writer.Header(),
+		Options:                input.Options,
+	}
+	responseInput.SetBodyBytes(buffer.body.Bytes())
+
+	validationErr := openapi3filter.ValidateResponse(ctx, responseInput)
+
+	if validationErr != nil {
+		telemetry.LoggerFromContext(ctx).ErrorContext(ctx, "response does not match api.yml",
+			slog.Int("status", buffer.status),
+			slog.String("error", validationErr.Error()),
+		)
+
+		resp.Committed = false
+		resp.Size = 0
+		writer.Header().Del(echo.HeaderContentLength)
+		return c.JSON(http.StatusInternalServerError, httphelper.ErrorResponse{
+			Message: "Response validation failed",
+			Errors:  formatOpenAPIErrors(validationErr),
+		})
+	}
+
+	writer.WriteHeader(buffer.status)
+	_, err = writer.Write(buffer.body.Bytes())
+	return err
+}
+
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// Flush is a no-op, the body is sent once validated
+func (w *bufferedResponseWriter) Flush() {}
+
+// formatOpenAPIErrors fold the kin-openapi errors into the ErrorResponse errors, keyed by field
+func formatOpenAPIErrors(err error) map[string]string {
+	errs := make(map[string]string)
+	collectOpenAPIErrors(err, "body", errs)
+	return errs
+}
+
+func collectOpenAPIErrors(err error, field string, errs map[string]string) {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		for _, inner := range e {
+			collectOpenAPIErrors(inner, field, errs)
+		}
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			field = e.Parameter.Name
+		}
+		if e.Err == nil {
+			errs[field] = e.Reason
+			return
+		}
+		collectOpenAPIErrors(e.Err, field, errs)
+	case *openapi3filter.ResponseError:
+		if e.Err == nil {
+			errs[field] = e.Reason
+			return
+		}
+		collectOpenAPIErrors(e.Err, field, errs)
+	case *openapi3.SchemaError:
+		if pointer := e.JSONPointer(); len(pointer) > 0 {
+			field = strings.Join(pointer, ".")
+		}
+		switch e.SchemaField {
+		case "required":
+			errs[field] = "This field is required"
+		case "format":
+			// the kin-openapi reason contain the whole format regular expression
+			errs[field] = fmt.Sprintf("Must be a valid %s", e.Schema.Format)
+		default:
+			errs[field] = e.Reason
+		}
+	default:
+		errs[field] = err.Error()
+	}
+}
@@ -0,0 +1,169 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/generated"
+	"github.com/SawitProRecruitment/UserService/handler"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestOpenAPIValidator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	server := &handler.Server{Repository: mockRepo, Validator: validator.New(), Config: &config.Config{}}
+
+	newEcho := func(validateResponses bool) *echo.Echo {
+		e := echo.New()
+		middleware, err := handler.NewOpenAPIValidator(handler.NewOpenAPIValidatorOptions{ValidateResponses: validateResponses})
+		assert.NoError(t, err)
+		e.Use(middleware)
+		return e
+	}
+
+	e := newEcho(true)
+	generated.RegisterHandlers(e, server)
+	e.GET("/metrics", func(c echo.Context) error { return c.String(http.StatusOK, "not in spec") })
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		setup          func(*repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Body Out Of Range",
+			method:         http.MethodPost,
+			path:           "/estate",
+			body:           `{"width": 0, "length": 10}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"width":"number must be at least 1"}}`,
+		},
+		{
+			name:           "Body Missing Required Fields",
+			method:         http.MethodPost,
+			path:           "/estate",
+			body:           `{"width": 10}`,
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"length":"This field is required"}}`,
+		},
+		{
+			name:           "Query Out Of Range",
+			method:         http.MethodGet,
+			path:           "/webhooks/dead-letters?limit=500",
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"limit":"number must be at most 100"}}`,
+		},
+		{
+			name:           "Invalid Path Param",
+			method:         http.MethodGet,
+			path:           "/estate/not-a-uuid/stats",
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"message":"Validation failed","errors":{"id":"Must be a valid uuid"}}`,
+		},
+		{
+			name:   "Valid Request And Response",
+			method: http.MethodPost,
+			path:   "/estate",
+			body:   `{"width": 10, "length": 20}`,
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().CreateEstate(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:   "Valid Image Response",
+			method: http.MethodGet,
+			path:   "/estate/" + uuid.NewString() + "/map.png?max_cells=10",
+			setup: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstateWithAllDetails(gomock.Any(), gomock.Any()).Return(&repository.Estate{Width: 1, Length: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Route Not In Spec",
+			method:         http.MethodGet,
+			path:           "/metrics",
+			setup:          func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			tc.setup(mockRepo)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("Valid Bundle Request And Response", func(t *testing.T) {
+		estateID := uuid.New()
+		exported := &repository.EstateBundle{Estate: repository.Estate{Id: estateID, Width: 1, Length: 1, Version: 1}}
+		mockRepo.EXPECT().ExportEstate(gomock.Any(), estateID).Return(exported, nil)
+		mockRepo.EXPECT().ImportEstate(gomock.Any(), gomock.Any()).Return(nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/estate/"+estateID.String()+"/bundle", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		req := httptest.NewRequest(http.MethodPost, "/estate/import-bundle", rec.Body)
+		req.Header.Set(echo.HeaderContentType, "application/gzip")
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("Response Drift", func(t *testing.T) {
+		drifting := newEcho(true)
+		drifting.GET("/estate/:id/stats", func(c echo.Context) error {
+			return c.JSON(http.StatusOK, map[string]any{"count": "many"})
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/"+uuid.NewString()+"/stats", nil)
+		rec := httptest.NewRecorder()
+		drifting.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.JSONEq(t, `{"message":"Response validation failed","errors":{"count":"value must be an integer"}}`, rec.Body.String())
+	})
+
+	t.Run("Response Not Validated By Default", func(t *testing.T) {
+		lenient := newEcho(false)
+		lenient.GET("/estate/:id/stats", func(c echo.Context) error {
+			return c.JSON(http.StatusOK, map[string]any{"count": "many"})
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/estate/"+uuid.NewString()+"/stats", nil)
+		rec := httptest.NewRecorder()
+		lenient.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"log/slog"
+
 	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/events"
+	"github.com/SawitProRecruitment/UserService/plancache"
 	"github.com/SawitProRecruitment/UserService/repository"
 	"github.com/go-playground/validator/v10"
 )
@@ -10,18 +14,40 @@ type Server struct {
 	Validator  *validator.Validate
 	Repository repository.RepositoryInterface
 	Config     *config.Config
+	Broker     *events.Broker
+	PlanCache  *plancache.Cache // nil when the drone plans are not cached
+	Logger     *slog.Logger
 }
 
 type NewServerOptions struct {
 	Validator  *validator.Validate
 	Repository repository.RepositoryInterface
 	Config     *config.Config
+	Broker     *events.Broker
+	PlanCache  *plancache.Cache // optional, the drone plans are computed on every request when nil
+	Logger     *slog.Logger     // optional, default to slog.Default()
 }
 
 func NewServer(opts NewServerOptions) *Server {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
 	return &Server{
 		Validator:  opts.Validator,
 		Repository: opts.Repository,
 		Config:     opts.Config,
+		Broker:     opts.Broker,
+		PlanCache:  opts.PlanCache,
+		Logger:     logger,
+	}
+}
+
+// logger return the server logger, tests build Server without one
+func (s *Server) logger() *slog.Logger {
+	if s.Logger != nil {
+		return s.Logger
 	}
+	return slog.Default()
 }
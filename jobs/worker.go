@@ -0,0 +1,243 @@
+// This file contains the worker running the queued jobs.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/telemetry"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+)
+
+// Handler run a job of its type and return the result saved in the job. It must stop when ctx is done,
+// the job is cancelled or the worker is shutting down. progress takes the done fraction of the job, 0 to 1.
+// An AppError with a 4xx code fails the job without retry
+type Handler func(ctx context.Context, job repository.Job, progress func(float64)) (result any, err error)
+
+type Worker struct {
+	Repository repository.RepositoryInterface
+	Handlers   map[repository.JobType]Handler
+	Config     config.Jobs
+	Logger     *slog.Logger
+	now        func() time.Time
+}
+
+type NewWorkerOptions struct {
+	Repository repository.RepositoryInterface
+	Handlers   map[repository.JobType]Handler
+	Config     config.Jobs
+	Logger     *slog.Logger // optional, default to slog.Default()
+}
+
+func NewWorker(opts NewWorkerOptions) *Worker {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Worker{
+		Repository: opts.Repository,
+		Handlers:   opts.Handlers,
+		Config:     opts.Config,
+		Logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Run poll the queue with Config.Workers goroutines until ctx is done, and wait for the running jobs
+func (w *Worker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < max(w.Config.Workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.poll(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (w *Worker) poll(ctx context.Context) {
+	ticker := time.NewTicker(w.Config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		claimed, err := w.RunOnce(ctx)
+		if err != nil {
+			w.Logger.ErrorContext(ctx, "job run failed", slog.String("error", err.Error()))
+		}
+
+		// more jobs may be due, poll again right away
+		if claimed && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce claim a due job and run it, claimed is false when no job is due
+func (w *Worker) RunOnce(ctx context.Context) (claimed bool, err error) {
+	job, err := w.Repository.ClaimJob(ctx, repository.ClaimJobInput{Lease: w.Config.Lease})
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	return true, w.run(ctx, *job)
+}
+
+// run execute the job while a heartbeat save its progress, extend its lease & watch for cancellation,
+// then save the outcome
+func (w *Worker) run(ctx context.Context, job repository.Job) error {
+	start := w.now()
+	// the changes made by the job are audited as made by it
+	jobCtx, cancel := context.WithCancel(repository.WithActor(ctx, repository.Actor{Name: "job:" + job.Id.String()}))
+	defer cancel()
+
+	var (
+		progress  atomic.Uint64 // float64 bits
+		cancelled atomic.Bool
+		stopped   = make(chan struct{})
+		beating   sync.WaitGroup
+	)
+	progress.Store(math.Float64bits(job.Progress))
+	setProgress := func(p float64) {
+		progress.Store(math.Float64bits(min(max(p, 0), 1)))
+	}
+
+	beating.Add(1)
+	go func() {
+		defer beating.Done()
+		ticker := time.NewTicker(w.heartbeatInterval())
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stopped:
+				return
+			case <-ticker.C:
+			}
+
+			cancelRequested, err := w.Repository.HeartbeatJob(jobCtx, repository.HeartbeatJobInput{
+				Id:       job.Id,
+				Attempts: job.Attempts,
+				Progress: math.Float64frombits(progress.Load()),
+				Lease:    w.Config.Lease,
+			})
+			if err != nil {
+				w.Logger.WarnContext(ctx, "job heartbeat failed", slog.String("job_id", job.Id.String()), slog.String("error", err.Error()))
+				continue
+			}
+			if cancelRequested {
+				cancelled.Store(true)
+				cancel()
+				return
+			}
+		}
+	}()
+
+	result, errRun := w.execute(jobCtx, job, setProgress)
+	close(stopped)
+	beating.Wait()
+
+	input := repository.FinishJobInput{
+		Id:       job.Id,
+		Attempts: job.Attempts,
+		Progress: math.Float64frombits(progress.Load()),
+		RunAt:    w.now(),
+	}
+	outcome := ""
+	if errRun == nil && !cancelled.Load() {
+		if input.Result, errRun = json.Marshal(result); errRun != nil {
+			errRun = apperror.WrapWithCode(fmt.Errorf("failed to marshal job result: %w", errRun), http.StatusUnprocessableEntity)
+		}
+	}
+
+	switch {
+	case cancelled.Load():
+		input.Status, outcome = repository.JOB_CANCELLED, "cancelled"
+	case errRun == nil:
+		input.Status, outcome = repository.JOB_SUCCEEDED, "succeeded"
+		input.Progress = 1
+	case ctx.Err() != nil:
+		// shutting down, the job is handed back to the queue for another worker
+		input.Status, outcome = repository.JOB_QUEUED, "requeued"
+	case isPermanent(errRun) || job.Attempts >= job.MaxAttempts:
+		input.Status, outcome = repository.JOB_FAILED, "failed"
+	default:
+		input.Status, outcome = repository.JOB_QUEUED, "retried"
+		input.RunAt = w.now().Add(w.backoff(job.Attempts))
+	}
+	if errRun != nil && !cancelled.Load() {
+		lastError := errRun.Error()
+		input.LastError = &lastError
+		w.Logger.WarnContext(ctx, "job failed",
+			slog.String("job_id", job.Id.String()),
+			slog.String("type", string(job.Type)),
+			slog.Int("attempts", job.Attempts),
+			slog.String("outcome", outcome),
+			slog.String("error", lastError),
+		)
+	}
+
+	telemetry.JobsTotal.WithLabelValues(string(job.Type), outcome).Inc()
+	telemetry.JobDuration.WithLabelValues(string(job.Type)).Observe(w.now().Sub(start).Seconds())
+
+	// the outcome is saved even when the worker is shutting down
+	return w.Repository.FinishJob(context.WithoutCancel(ctx), input)
+}
+
+// execute call the handler of the job type, a panic fails the job instead of the worker
+func (w *Worker) execute(ctx context.Context, job repository.Job, progress func(float64)) (result any, err error) {
+	handler, exists := w.Handlers[job.Type]
+	if !exists {
+		return nil, apperror.WrapWithCode(fmt.Errorf("no handler for job type %s", job.Type), http.StatusBadRequest)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return handler(ctx, job, progress)
+}
+
+// heartbeatInterval is a third of the lease, so a slow heartbeat does not let the lease expire
+func (w *Worker) heartbeatInterval() time.Duration {
+	if interval := w.Config.Lease / 3; interval > 0 {
+		return interval
+	}
+	return time.Second
+}
+
+// backoff return BaseBackoff doubled for every previous attempt, capped at MaxBackoff
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.Config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if w.Config.MaxBackoff > 0 && delay >= w.Config.MaxBackoff {
+			return w.Config.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// isPermanent is true for the errors a retry can't fix, the client errors
+func isPermanent(err error) bool {
+	var appErr *apperror.AppError
+	return errors.As(err, &appErr) && appErr.Code >= 400 && appErr.Code < 500
+}
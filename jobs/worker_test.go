@@ -0,0 +1,260 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestBackoff(t *testing.T) {
+	w := &Worker{Config: config.Jobs{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second}}
+
+	assert.Equal(t, time.Second, w.backoff(1))
+	assert.Equal(t, 2*time.Second, w.backoff(2))
+	assert.Equal(t, 8*time.Second, w.backoff(4))
+	assert.Equal(t, 10*time.Second, w.backoff(5))
+	assert.Equal(t, 10*time.Second, w.backoff(50))
+}
+
+func TestRunOnce(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := config.Jobs{
+		Workers:      1,
+		PollInterval: time.Second,
+		Lease:        time.Minute,
+		MaxAttempts:  3,
+		BaseBackoff:  5 * time.Second,
+		MaxBackoff:   time.Minute,
+	}
+
+	newJob := func(jobType repository.JobType, attempts int) *repository.Job {
+		return &repository.Job{
+			Id:          uuid.New(),
+			Type:        jobType,
+			EstateId:    uuid.New(),
+			Params:      []byte(`{}`),
+			Status:      repository.JOB_RUNNING,
+			Attempts:    attempts,
+			MaxAttempts: 3,
+		}
+	}
+	newWorker := func(mockRepo *repository.MockRepositoryInterface, cfg config.Jobs, handler Handler) *Worker {
+		w := NewWorker(NewWorkerOptions{
+			Repository: mockRepo,
+			Handlers:   map[repository.JobType]Handler{repository.JOB_EXPORT: handler},
+			Config:     cfg,
+		})
+		w.now = func() time.Time { return now }
+		return w
+	}
+	lastError := func(msg string) *string { return &msg }
+
+	t.Run("No due job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), repository.ClaimJobInput{Lease: time.Minute}).Return(nil, nil)
+
+		claimed, err := newWorker(mockRepo, cfg, nil).RunOnce(context.Background())
+		assert.NoError(t, err)
+		assert.False(t, claimed)
+	})
+
+	t.Run("Succeeded job saves its result", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		job := newJob(repository.JOB_EXPORT, 1)
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().FinishJob(gomock.Any(), repository.FinishJobInput{
+			Id:       job.Id,
+			Attempts: job.Attempts,
+			Status:   repository.JOB_SUCCEEDED,
+			Progress: 1,
+			Result:   []byte(`{"width":10}`),
+			RunAt:    now,
+		}).Return(nil)
+
+		w := newWorker(mockRepo, cfg, func(ctx context.Context, got repository.Job, progress func(float64)) (any, error) {
+			assert.Equal(t, job.Id, got.Id)
+			assert.Equal(t, repository.Actor{Name: "job:" + job.Id.String()}, repository.ActorFromContext(ctx))
+			progress(0.5)
+			return map[string]int{"width": 10}, nil
+		})
+		claimed, err := w.RunOnce(context.Background())
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+	})
+
+	t.Run("Failed job is retried with backoff", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		job := newJob(repository.JOB_EXPORT, 2)
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().FinishJob(gomock.Any(), repository.FinishJobInput{
+			Id:        job.Id,
+			Attempts:  job.Attempts,
+			Status:    repository.JOB_QUEUED,
+			Progress:  0.25,
+			LastError: lastError("database is down"),
+			RunAt:     now.Add(10 * time.Second),
+		}).Return(nil)
+
+		w := newWorker(mockRepo, cfg, func(ctx context.Context, job repository.Job, progress func(float64)) (any, error) {
+			progress(0.25)
+			return nil, errors.New("database is down")
+		})
+		_, err := w.RunOnce(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Last attempt fails the job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		job := newJob(repository.JOB_EXPORT, 3)
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().FinishJob(gomock.Any(), repository.FinishJobInput{
+			Id:        job.Id,
+			Attempts:  job.Attempts,
+			Status:    repository.JOB_FAILED,
+			LastError: lastError("database is down"),
+			RunAt:     now,
+		}).Return(nil)
+
+		w := newWorker(mockRepo, cfg, func(ctx context.Context, job repository.Job, progress func(float64)) (any, error) {
+			return nil, errors.New("database is down")
+		})
+		_, err := w.RunOnce(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Client error fails the job without retry", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		job := newJob(repository.JOB_EXPORT, 1)
+		notFound := apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", job.EstateId), http.StatusNotFound)
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().FinishJob(gomock.Any(), repository.FinishJobInput{
+			Id:        job.Id,
+			Attempts:  job.Attempts,
+			Status:    repository.JOB_FAILED,
+			LastError: lastError(notFound.Error()),
+			RunAt:     now,
+		}).Return(nil)
+
+		w := newWorker(mockRepo, cfg, func(ctx context.Context, job repository.Job, progress func(float64)) (any, error) {
+			return nil, notFound
+		})
+		_, err := w.RunOnce(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Unknown job type fails the job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		job := newJob(repository.JOB_IMPORT, 1)
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().FinishJob(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, input repository.FinishJobInput) error {
+				assert.Equal(t, repository.JOB_FAILED, input.Status)
+				assert.Equal(t, "no handler for job type import", *input.LastError)
+				return nil
+			})
+
+		_, err := newWorker(mockRepo, cfg, nil).RunOnce(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Panic is retried", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		job := newJob(repository.JOB_EXPORT, 1)
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().FinishJob(gomock.Any(), repository.FinishJobInput{
+			Id:        job.Id,
+			Attempts:  job.Attempts,
+			Status:    repository.JOB_QUEUED,
+			LastError: lastError("job panicked: nil map"),
+			RunAt:     now.Add(5 * time.Second),
+		}).Return(nil)
+
+		w := newWorker(mockRepo, cfg, func(ctx context.Context, job repository.Job, progress func(float64)) (any, error) {
+			panic("nil map")
+		})
+		_, err := w.RunOnce(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Cancel requested stops the running job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		fastCfg := cfg
+		fastCfg.Lease = 30 * time.Millisecond
+		job := newJob(repository.JOB_EXPORT, 1)
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), repository.ClaimJobInput{Lease: 30 * time.Millisecond}).Return(job, nil)
+		mockRepo.EXPECT().HeartbeatJob(gomock.Any(), repository.HeartbeatJobInput{
+			Id:       job.Id,
+			Attempts: job.Attempts,
+			Progress: 0.5,
+			Lease:    30 * time.Millisecond,
+		}).Return(true, nil)
+		mockRepo.EXPECT().FinishJob(gomock.Any(), repository.FinishJobInput{
+			Id:       job.Id,
+			Attempts: job.Attempts,
+			Status:   repository.JOB_CANCELLED,
+			Progress: 0.5,
+			RunAt:    now,
+		}).Return(nil)
+
+		w := newWorker(mockRepo, fastCfg, func(ctx context.Context, job repository.Job, progress func(float64)) (any, error) {
+			progress(0.5)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+		_, err := w.RunOnce(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Shutdown requeues the running job", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		job := newJob(repository.JOB_EXPORT, 3)
+		mockRepo.EXPECT().ClaimJob(gomock.Any(), gomock.Any()).Return(job, nil)
+		mockRepo.EXPECT().FinishJob(gomock.Any(), repository.FinishJobInput{
+			Id:        job.Id,
+			Attempts:  job.Attempts,
+			Status:    repository.JOB_QUEUED,
+			LastError: lastError(context.Canceled.Error()),
+			RunAt:     now,
+		}).DoAndReturn(func(ctx context.Context, input repository.FinishJobInput) error {
+			// the outcome is saved with a live context
+			assert.NoError(t, ctx.Err())
+			return nil
+		})
+
+		w := newWorker(mockRepo, cfg, func(jobCtx context.Context, job repository.Job, progress func(float64)) (any, error) {
+			cancel()
+			<-jobCtx.Done()
+			return nil, jobCtx.Err()
+		})
+		_, err := w.RunOnce(ctx)
+		assert.NoError(t, err)
+	})
+}
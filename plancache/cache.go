@@ -0,0 +1,179 @@
+// This file contains the cache of the computed drone plans.
+package plancache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/SawitProRecruitment/UserService/telemetry"
+	"github.com/google/uuid"
+)
+
+// Layer is where a cached plan was found
+type Layer string
+
+const (
+	LayerMemory   Layer = "memory"
+	LayerPostgres Layer = "postgres"
+)
+
+type entry struct {
+	key  repository.DronePlanCacheKey
+	plan json.RawMessage
+}
+
+// Cache keep the computed drone plans in an in-process LRU, backed by postgres when persisted.
+// Every key holds the estate version, so a change of the estate makes its plans unreachable. Safe for concurrent use
+type Cache struct {
+	mu       sync.Mutex
+	size     int
+	entries  *list.List // of *entry, the most recently used first
+	byKey    map[repository.DronePlanCacheKey]*list.Element
+	byEstate map[uuid.UUID]map[repository.DronePlanCacheKey]*list.Element
+
+	repository repository.RepositoryInterface // nil when the plans are not persisted
+	logger     *slog.Logger
+}
+
+type NewCacheOptions struct {
+	Config     config.PlanCache
+	Repository repository.RepositoryInterface // used when Config.Persist
+	Logger     *slog.Logger                   // optional, default to slog.Default()
+}
+
+func NewCache(opts NewCacheOptions) *Cache {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	c := &Cache{
+		size:     opts.Config.Size,
+		entries:  list.New(),
+		byKey:    make(map[repository.DronePlanCacheKey]*list.Element),
+		byEstate: make(map[uuid.UUID]map[repository.DronePlanCacheKey]*list.Element),
+		logger:   logger,
+	}
+	if opts.Config.Persist {
+		c.repository = opts.Repository
+	}
+	return c
+}
+
+// Get return the plan and the layer it was found in, layer is empty when no layer has the plan.
+// A plan found in postgres is kept in memory for the next lookups
+func (c *Cache) Get(ctx context.Context, key repository.DronePlanCacheKey) (json.RawMessage, Layer) {
+	if c.size > 0 {
+		plan, ok := c.getMemory(key)
+		observe(LayerMemory, ok)
+		if ok {
+			return plan, LayerMemory
+		}
+	}
+
+	if c.repository == nil {
+		return nil, ""
+	}
+
+	// a failing cache is a miss, the plan is computed again
+	plan, err := c.repository.GetCachedDronePlan(ctx, key)
+	if err != nil {
+		c.logger.WarnContext(ctx, "failed to get cached drone plan", slog.String("estate_id", key.EstateId.String()), slog.String("error", err.Error()))
+	}
+	observe(LayerPostgres, plan != nil)
+	if plan == nil {
+		return nil, ""
+	}
+
+	c.setMemory(key, plan)
+	return plan, LayerPostgres
+}
+
+// Set save the plan in every layer, the plans of the older versions of the estate are dropped
+func (c *Cache) Set(ctx context.Context, key repository.DronePlanCacheKey, plan json.RawMessage) {
+	c.setMemory(key, plan)
+
+	if c.repository == nil {
+		return
+	}
+
+	err := c.repository.SaveCachedDronePlan(ctx, repository.SaveCachedDronePlanInput{Key: key, Plan: plan})
+	if err != nil {
+		c.logger.WarnContext(ctx, "failed to save cached drone plan", slog.String("estate_id", key.EstateId.String()), slog.String("error", err.Error()))
+	}
+}
+
+// Len is the number of plans kept in memory
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+func (c *Cache) getMemory(key repository.DronePlanCacheKey) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.byKey[key]
+	if !ok {
+		return nil, false
+	}
+	c.entries.MoveToFront(elem)
+	return elem.Value.(*entry).plan, true
+}
+
+func (c *Cache) setMemory(key repository.DronePlanCacheKey, plan json.RawMessage) {
+	if c.size <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the estate changed since these plans were computed, they can't be served anymore
+	for other, elem := range c.byEstate[key.EstateId] {
+		if other.Version < key.Version {
+			c.remove(elem)
+		}
+	}
+
+	if elem, ok := c.byKey[key]; ok {
+		elem.Value.(*entry).plan = plan
+		c.entries.MoveToFront(elem)
+		return
+	}
+
+	elem := c.entries.PushFront(&entry{key: key, plan: plan})
+	c.byKey[key] = elem
+	if c.byEstate[key.EstateId] == nil {
+		c.byEstate[key.EstateId] = make(map[repository.DronePlanCacheKey]*list.Element)
+	}
+	c.byEstate[key.EstateId][key] = elem
+
+	for c.entries.Len() > c.size {
+		c.remove(c.entries.Back())
+	}
+}
+
+// remove must be called with mu held
+func (c *Cache) remove(elem *list.Element) {
+	key := c.entries.Remove(elem).(*entry).key
+	delete(c.byKey, key)
+	delete(c.byEstate[key.EstateId], key)
+	if len(c.byEstate[key.EstateId]) == 0 {
+		delete(c.byEstate, key.EstateId)
+	}
+}
+
+func observe(layer Layer, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	telemetry.DronePlanCacheTotal.WithLabelValues(string(layer), result).Inc()
+}
@@ -0,0 +1,128 @@
+package plancache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestCacheMemory(t *testing.T) {
+	ctx := context.Background()
+	estateID := uuid.New()
+	key := func(version int64, params string) repository.DronePlanCacheKey {
+		return repository.DronePlanCacheKey{EstateId: estateID, Version: version, Params: params}
+	}
+
+	t.Run("Hit after set", func(t *testing.T) {
+		cache := NewCache(NewCacheOptions{Config: config.PlanCache{Size: 10}})
+
+		plan, layer := cache.Get(ctx, key(1, "a"))
+		assert.Nil(t, plan)
+		assert.Equal(t, Layer(""), layer)
+
+		cache.Set(ctx, key(1, "a"), json.RawMessage(`{"distance":54}`))
+		plan, layer = cache.Get(ctx, key(1, "a"))
+		assert.JSONEq(t, `{"distance":54}`, string(plan))
+		assert.Equal(t, LayerMemory, layer)
+
+		// other parameters are another plan
+		plan, _ = cache.Get(ctx, key(1, "b"))
+		assert.Nil(t, plan)
+	})
+
+	t.Run("Newer estate version drops the older plans", func(t *testing.T) {
+		cache := NewCache(NewCacheOptions{Config: config.PlanCache{Size: 10}})
+		cache.Set(ctx, key(1, "a"), json.RawMessage(`{"distance":54}`))
+		cache.Set(ctx, key(1, "b"), json.RawMessage(`{"distance":60}`))
+		cache.Set(ctx, repository.DronePlanCacheKey{EstateId: uuid.New(), Version: 1, Params: "a"}, json.RawMessage(`{}`))
+
+		plan, _ := cache.Get(ctx, key(2, "a"))
+		assert.Nil(t, plan)
+
+		cache.Set(ctx, key(2, "a"), json.RawMessage(`{"distance":74}`))
+		assert.Equal(t, 2, cache.Len())
+		plan, _ = cache.Get(ctx, key(1, "b"))
+		assert.Nil(t, plan)
+	})
+
+	t.Run("Least recently used plan is evicted", func(t *testing.T) {
+		cache := NewCache(NewCacheOptions{Config: config.PlanCache{Size: 2}})
+		cache.Set(ctx, key(1, "a"), json.RawMessage(`1`))
+		cache.Set(ctx, key(1, "b"), json.RawMessage(`2`))
+		cache.Get(ctx, key(1, "a"))
+		cache.Set(ctx, key(1, "c"), json.RawMessage(`3`))
+
+		assert.Equal(t, 2, cache.Len())
+		_, layer := cache.Get(ctx, key(1, "a"))
+		assert.Equal(t, LayerMemory, layer)
+		_, layer = cache.Get(ctx, key(1, "b"))
+		assert.Equal(t, Layer(""), layer)
+	})
+}
+
+func TestCachePersisted(t *testing.T) {
+	ctx := context.Background()
+	key := repository.DronePlanCacheKey{EstateId: uuid.New(), Version: 3, Params: "a"}
+
+	t.Run("Postgres hit is kept in memory", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetCachedDronePlan(gomock.Any(), key).Return(json.RawMessage(`{"distance":54}`), nil)
+
+		cache := NewCache(NewCacheOptions{Config: config.PlanCache{Size: 10, Persist: true}, Repository: mockRepo})
+
+		plan, layer := cache.Get(ctx, key)
+		assert.JSONEq(t, `{"distance":54}`, string(plan))
+		assert.Equal(t, LayerPostgres, layer)
+
+		_, layer = cache.Get(ctx, key)
+		assert.Equal(t, LayerMemory, layer)
+	})
+
+	t.Run("Set saves the plan in postgres", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().
+			SaveCachedDronePlan(gomock.Any(), repository.SaveCachedDronePlanInput{Key: key, Plan: json.RawMessage(`{"distance":54}`)}).
+			Return(nil)
+
+		cache := NewCache(NewCacheOptions{Config: config.PlanCache{Persist: true}, Repository: mockRepo})
+		cache.Set(ctx, key, json.RawMessage(`{"distance":54}`))
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("Postgres error is a miss", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetCachedDronePlan(gomock.Any(), key).Return(nil, errors.New("db error"))
+		mockRepo.EXPECT().SaveCachedDronePlan(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+		cache := NewCache(NewCacheOptions{Config: config.PlanCache{Size: 10, Persist: true}, Repository: mockRepo})
+
+		plan, layer := cache.Get(ctx, key)
+		assert.Nil(t, plan)
+		assert.Equal(t, Layer(""), layer)
+
+		// the plan is still kept in memory
+		cache.Set(ctx, key, json.RawMessage(`{"distance":54}`))
+		_, layer = cache.Get(ctx, key)
+		assert.Equal(t, LayerMemory, layer)
+	})
+
+	t.Run("Not persisted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		cache := NewCache(NewCacheOptions{Config: config.PlanCache{Size: 10}, Repository: mockRepo})
+		cache.Set(ctx, key, json.RawMessage(`{"distance":54}`))
+		plan, _ := cache.Get(ctx, key)
+		assert.NotNil(t, plan)
+	})
+}
@@ -0,0 +1,28 @@
+package repository
+
+import "context"
+
+// ACTOR_SYSTEM is the actor of the changes made outside of an HTTP request or a job
+const ACTOR_SYSTEM = "system"
+
+// Actor is who changes the estate data, recorded by the audit log
+type Actor struct {
+	Name     string // the X-Actor header of the request, "job:<id>" for the job workers
+	SourceIp string // empty when the change is not from an HTTP request
+}
+
+type actorKey struct{}
+
+// WithActor return a copy of ctx carrying the actor of the changes made with it
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext return the actor of ctx, ACTOR_SYSTEM when ctx has none
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	if actor.Name == "" {
+		actor.Name = ACTOR_SYSTEM
+	}
+	return actor
+}
@@ -0,0 +1,72 @@
+// This file contains the estate bundle, the whole data of an estate moved between environments.
+package repository
+
+import "github.com/google/uuid"
+
+// EstateBundle is an estate with its trees, stats, exclusion zones & obstacles, and its history:
+// the harvests & the audit entries, oldest first
+type EstateBundle struct {
+	Estate       Estate
+	Harvests     []Harvest
+	AuditEntries []AuditEntry
+}
+
+// WithEstateId return a copy of the bundle moved to the estate id. Every tree, zone, obstacle, harvest,
+// stats & audit entry get a new ID too, so the copy can live next to the original estate.
+// The before & after states of the audit entries are kept as they were recorded
+func (b EstateBundle) WithEstateId(id uuid.UUID) EstateBundle {
+	ids := map[uuid.UUID]uuid.UUID{b.Estate.Id: id}
+	remap := func(old uuid.UUID) uuid.UUID {
+		if newID, ok := ids[old]; ok {
+			return newID
+		}
+		ids[old] = uuid.New()
+		return ids[old]
+	}
+
+	moved := EstateBundle{Estate: b.Estate}
+	moved.Estate.Id = id
+
+	moved.Estate.Trees = make([]Tree, len(b.Estate.Trees))
+	for i, tree := range b.Estate.Trees {
+		tree.Id, tree.EstateId = remap(tree.Id), id
+		moved.Estate.Trees[i] = tree
+	}
+
+	if b.Estate.Stats != nil {
+		stats := *b.Estate.Stats
+		stats.Id, stats.EstateID = remap(stats.Id), id.String()
+		moved.Estate.Stats = &stats
+	}
+
+	moved.Estate.ExclusionZones = make([]ExclusionZone, len(b.Estate.ExclusionZones))
+	for i, zone := range b.Estate.ExclusionZones {
+		zone.Id, zone.EstateId = remap(zone.Id), id
+		moved.Estate.ExclusionZones[i] = zone
+	}
+
+	moved.Estate.Obstacles = make([]Obstacle, len(b.Estate.Obstacles))
+	for i, obstacle := range b.Estate.Obstacles {
+		obstacle.Id, obstacle.EstateId = remap(obstacle.Id), id
+		moved.Estate.Obstacles[i] = obstacle
+	}
+
+	moved.Harvests = make([]Harvest, len(b.Harvests))
+	for i, harvest := range b.Harvests {
+		harvest.Id, harvest.EstateId = remap(harvest.Id), id
+		if harvest.TreeId != nil {
+			treeID := remap(*harvest.TreeId)
+			harvest.TreeId = &treeID
+		}
+		moved.Harvests[i] = harvest
+	}
+
+	// the entities removed before the export are remapped too, their entries stay grouped by entity
+	moved.AuditEntries = make([]AuditEntry, len(b.AuditEntries))
+	for i, entry := range b.AuditEntries {
+		entry.Id, entry.EstateId, entry.EntityId = uuid.New(), id, remap(entry.EntityId)
+		moved.AuditEntries[i] = entry
+	}
+
+	return moved
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstateBundleWithEstateId(t *testing.T) {
+	estateID, treeID, removedTreeID, zoneID, obstacleID, harvestID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	bundle := EstateBundle{
+		Estate: Estate{
+			Id:             estateID,
+			Width:          10,
+			Length:         20,
+			Version:        4,
+			Trees:          []Tree{{Id: treeID, EstateId: estateID, X: 3, Y: 4, Tags: []string{"north"}}},
+			Stats:          &EstateStats{Id: uuid.New(), EstateID: estateID.String(), TreeCount: 1},
+			ExclusionZones: []ExclusionZone{{Id: zoneID, EstateId: estateID, Points: []Point{{X: 1, Y: 1}}}},
+			Obstacles:      []Obstacle{{Id: obstacleID, EstateId: estateID, X: 5, Y: 5}},
+		},
+		Harvests: []Harvest{
+			{Id: harvestID, EstateId: estateID, TreeId: &treeID},
+			{Id: uuid.New(), EstateId: estateID, Plots: []Point{{X: 2, Y: 2}}},
+		},
+		AuditEntries: []AuditEntry{
+			{Id: uuid.New(), EstateId: estateID, Action: AUDIT_ESTATE_CREATED, EntityId: estateID},
+			{Id: uuid.New(), EstateId: estateID, Action: AUDIT_TREE_CREATED, EntityId: treeID, After: json.RawMessage(`{"tree_id":"` + treeID.String() + `"}`)},
+			{Id: uuid.New(), EstateId: estateID, Action: AUDIT_TREE_CREATED, EntityId: removedTreeID},
+			{Id: uuid.New(), EstateId: estateID, Action: AUDIT_ESTATE_RESIZED, EntityId: estateID},
+		},
+	}
+	newID := uuid.New()
+
+	moved := bundle.WithEstateId(newID)
+
+	estate := moved.Estate
+	assert.Equal(t, newID, estate.Id)
+	assert.Equal(t, int64(4), estate.Version)
+	assert.Equal(t, newID, estate.Trees[0].EstateId)
+	assert.NotEqual(t, treeID, estate.Trees[0].Id)
+	assert.Equal(t, []string{"north"}, estate.Trees[0].Tags)
+	assert.Equal(t, newID.String(), estate.Stats.EstateID)
+	assert.NotEqual(t, bundle.Estate.Stats.Id, estate.Stats.Id)
+	assert.Equal(t, newID, estate.ExclusionZones[0].EstateId)
+	assert.NotEqual(t, zoneID, estate.ExclusionZones[0].Id)
+	assert.Equal(t, newID, estate.Obstacles[0].EstateId)
+	assert.NotEqual(t, obstacleID, estate.Obstacles[0].Id)
+
+	// the harvest & the audit entries follow the new tree ID
+	assert.Equal(t, newID, moved.Harvests[0].EstateId)
+	assert.NotEqual(t, harvestID, moved.Harvests[0].Id)
+	assert.Equal(t, estate.Trees[0].Id, *moved.Harvests[0].TreeId)
+	assert.Nil(t, moved.Harvests[1].TreeId)
+	for i, entry := range moved.AuditEntries {
+		assert.Equal(t, newID, entry.EstateId)
+		assert.NotEqual(t, bundle.AuditEntries[i].Id, entry.Id)
+	}
+	assert.Equal(t, newID, moved.AuditEntries[0].EntityId)
+	assert.Equal(t, estate.Trees[0].Id, moved.AuditEntries[1].EntityId)
+	assert.Equal(t, bundle.AuditEntries[1].After, moved.AuditEntries[1].After)
+	assert.NotEqual(t, removedTreeID, moved.AuditEntries[2].EntityId)
+	assert.Equal(t, newID, moved.AuditEntries[3].EntityId)
+
+	// the original bundle is unchanged
+	assert.Equal(t, estateID, bundle.Estate.Id)
+	assert.Equal(t, treeID, bundle.Estate.Trees[0].Id)
+	assert.Equal(t, treeID, *bundle.Harvests[0].TreeId)
+	assert.Equal(t, estateID.String(), bundle.Estate.Stats.EstateID)
+}
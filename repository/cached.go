@@ -0,0 +1,371 @@
+// This file contains the repository decorator caching the estates read from postgres.
+package repository
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/telemetry"
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+// CachedRepository wrap a RepositoryInterface and keep the estates read by GetEstateWithAllDetails in an LRU.
+// An estate is dropped when its TTL expires or when this replica writes it, Invalidate drop the estates
+// written by the other replicas. Concurrent misses of the same estate share one postgres read. Safe for concurrent use
+type CachedRepository struct {
+	next RepositoryInterface
+	size int
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	entries *list.List // of *cacheEntry, the most recently used first
+	byId    map[uuid.UUID]*list.Element
+	seq     uint64 // source of the entry generations
+	loads   singleflight.Group
+
+	now func() time.Time
+}
+
+// cacheEntry exist while the estate is cached or being loaded, generation change on every invalidation
+// so a load started before a write never stores the estate it read
+type cacheEntry struct {
+	id         uuid.UUID
+	generation uint64
+	estate     *Estate // nil until loaded
+	expiresAt  time.Time
+}
+
+var _ RepositoryInterface = (*CachedRepository)(nil)
+
+type NewCachedRepositoryOptions struct {
+	Size int           // estates kept in memory
+	TTL  time.Duration // an estate is read again after this long
+}
+
+func NewCachedRepository(next RepositoryInterface, opts NewCachedRepositoryOptions) *CachedRepository {
+	return &CachedRepository{
+		next:    next,
+		size:    opts.Size,
+		ttl:     opts.TTL,
+		entries: list.New(),
+		byId:    make(map[uuid.UUID]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// Invalidate drop the cached estate, the next read load it from postgres
+func (r *CachedRepository) Invalidate(id uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elem, ok := r.byId[id]
+	if !ok {
+		return
+	}
+	entry := elem.Value.(*cacheEntry)
+	r.seq++
+	entry.generation = r.seq
+	entry.estate = nil
+}
+
+// Len is the number of estates cached or being loaded
+func (r *CachedRepository) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.entries.Len()
+}
+
+// GetEstateWithAllDetails always load every relation, the excluded ones are dropped from the returned copy
+func (r *CachedRepository) GetEstateWithAllDetails(ctx context.Context, id uuid.UUID, exludeRelations ...Relation) (*Estate, error) {
+	estate, entry, generation := r.lookup(id)
+	if estate != nil {
+		telemetry.RepositoryCacheTotal.WithLabelValues("hit").Inc()
+		return cloneEstate(estate, exludeRelations), nil
+	}
+
+	key := id.String() + "/" + strconv.FormatUint(generation, 10)
+	loaded, err, shared := r.loads.Do(key, func() (any, error) {
+		estate, err := r.next.GetEstateWithAllDetails(context.WithoutCancel(ctx), id)
+		if err != nil {
+			return nil, err
+		}
+		r.store(entry, generation, estate)
+		return estate, nil
+	})
+	result := "miss"
+	if shared {
+		result = "shared"
+	}
+	telemetry.RepositoryCacheTotal.WithLabelValues(result).Inc()
+	if err != nil {
+		return nil, err
+	}
+
+	return cloneEstate(loaded.(*Estate), exludeRelations), nil
+}
+
+// lookup return the cached estate, or the entry & generation the loaded estate must be stored with
+func (r *CachedRepository) lookup(id uuid.UUID) (*Estate, *cacheEntry, uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elem, ok := r.byId[id]; ok {
+		r.entries.MoveToFront(elem)
+		entry := elem.Value.(*cacheEntry)
+		if entry.estate != nil && r.now().Before(entry.expiresAt) {
+			return entry.estate, nil, 0
+		}
+		if entry.estate != nil {
+			r.seq++
+			entry.generation = r.seq
+			entry.estate = nil
+		}
+		return nil, entry, entry.generation
+	}
+
+	r.seq++
+	entry := &cacheEntry{id: id, generation: r.seq}
+	r.byId[id] = r.entries.PushFront(entry)
+	for r.entries.Len() > r.size {
+		evicted := r.entries.Remove(r.entries.Back()).(*cacheEntry)
+		delete(r.byId, evicted.id)
+	}
+	return nil, entry, entry.generation
+}
+
+// store keep the loaded estate unless the entry was invalidated or evicted during the load
+func (r *CachedRepository) store(entry *cacheEntry, generation uint64, estate *Estate) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elem, ok := r.byId[entry.id]; !ok || elem.Value != entry || entry.generation != generation {
+		return
+	}
+	entry.estate = estate
+	entry.expiresAt = r.now().Add(r.ttl)
+}
+
+// cloneEstate copy the cached estate, the callers are free to modify what they get
+func cloneEstate(estate *Estate, exludeRelations []Relation) *Estate {
+	clone := *estate
+	clone.Trees = make([]Tree, len(estate.Trees))
+	for i, tree := range estate.Trees {
+		tree.Tags = append([]string(nil), tree.Tags...)
+		if tree.PlantedAt != nil {
+			plantedAt := *tree.PlantedAt
+			tree.PlantedAt = &plantedAt
+		}
+		clone.Trees[i] = tree
+	}
+	if estate.Geo != nil {
+		geo := *estate.Geo
+		clone.Geo = &geo
+	}
+	if estate.Stats != nil {
+		stats := *estate.Stats
+		clone.Stats = &stats
+	}
+	clone.ExclusionZones = make([]ExclusionZone, len(estate.ExclusionZones))
+	for i, zone := range estate.ExclusionZones {
+		zone.Points = append([]Point(nil), zone.Points...)
+		clone.ExclusionZones[i] = zone
+	}
+	clone.Obstacles = make([]Obstacle, len(estate.Obstacles))
+	for i, obstacle := range estate.Obstacles {
+		if obstacle.Height != nil {
+			height := *obstacle.Height
+			obstacle.Height = &height
+		}
+		clone.Obstacles[i] = obstacle
+	}
+
+	for _, relation := range exludeRelations {
+		switch relation {
+		case RELATION_TREES:
+			clone.Trees = nil
+		case RELATION_STATS:
+			clone.Stats = nil
+		case RELATION_EXCLUSIONS:
+			clone.ExclusionZones = nil
+		case RELATION_OBSTACLES:
+			clone.Obstacles = nil
+		}
+	}
+	return &clone
+}
+
+func (r *CachedRepository) CreateTree(ctx context.Context, input CreateTreeInput) error {
+	defer r.Invalidate(input.EstateId)
+	return r.next.CreateTree(ctx, input)
+}
+
+func (r *CachedRepository) CreateTrees(ctx context.Context, estateID uuid.UUID, inputs []CreateTreeInput) error {
+	defer r.Invalidate(estateID)
+	return r.next.CreateTrees(ctx, estateID, inputs)
+}
+
+func (r *CachedRepository) UpdateTree(ctx context.Context, input UpdateTreeInput) error {
+	defer r.Invalidate(input.EstateId)
+	return r.next.UpdateTree(ctx, input)
+}
+
+func (r *CachedRepository) UpsertEstateStats(ctx context.Context, estateID uuid.UUID, stats *EstateStats) error {
+	defer r.Invalidate(estateID)
+	return r.next.UpsertEstateStats(ctx, estateID, stats)
+}
+
+func (r *CachedRepository) ResizeEstate(ctx context.Context, input ResizeEstateInput) (*Estate, []Tree, error) {
+	defer r.Invalidate(input.Id)
+	return r.next.ResizeEstate(ctx, input)
+}
+
+func (r *CachedRepository) DeleteEstate(ctx context.Context, id uuid.UUID) error {
+	defer r.Invalidate(id)
+	return r.next.DeleteEstate(ctx, id)
+}
+
+func (r *CachedRepository) RestoreEstate(ctx context.Context, id uuid.UUID) error {
+	defer r.Invalidate(id)
+	return r.next.RestoreEstate(ctx, id)
+}
+
+func (r *CachedRepository) UpdateEstateGeo(ctx context.Context, id uuid.UUID, geo GeoReference) error {
+	defer r.Invalidate(id)
+	return r.next.UpdateEstateGeo(ctx, id, geo)
+}
+
+func (r *CachedRepository) ImportEstate(ctx context.Context, input ImportEstateInput) error {
+	defer r.Invalidate(input.Bundle.Estate.Id)
+	return r.next.ImportEstate(ctx, input)
+}
+
+func (r *CachedRepository) CreateExclusionZone(ctx context.Context, input CreateExclusionZoneInput) error {
+	defer r.Invalidate(input.EstateId)
+	return r.next.CreateExclusionZone(ctx, input)
+}
+
+func (r *CachedRepository) DeleteExclusionZone(ctx context.Context, estateID, zoneID uuid.UUID) error {
+	defer r.Invalidate(estateID)
+	return r.next.DeleteExclusionZone(ctx, estateID, zoneID)
+}
+
+func (r *CachedRepository) CreateObstacle(ctx context.Context, input CreateObstacleInput) error {
+	defer r.Invalidate(input.EstateId)
+	return r.next.CreateObstacle(ctx, input)
+}
+
+func (r *CachedRepository) DeleteObstacle(ctx context.Context, estateID, obstacleID uuid.UUID) error {
+	defer r.Invalidate(estateID)
+	return r.next.DeleteObstacle(ctx, estateID, obstacleID)
+}
+
+// the methods below don't read nor change the cached estates
+
+func (r *CachedRepository) CreateEstate(ctx context.Context, input CreateEstateInput) error {
+	return r.next.CreateEstate(ctx, input)
+}
+
+func (r *CachedRepository) GetCalculatedEstateStats(ctx context.Context, estateId uuid.UUID) (*EstateStats, error) {
+	return r.next.GetCalculatedEstateStats(ctx, estateId)
+}
+
+func (r *CachedRepository) GetTreeGroupStats(ctx context.Context, estateId uuid.UUID, group TreeGroup) ([]TreeGroupStats, error) {
+	return r.next.GetTreeGroupStats(ctx, estateId, group)
+}
+
+func (r *CachedRepository) CreateHarvest(ctx context.Context, input CreateHarvestInput) error {
+	return r.next.CreateHarvest(ctx, input)
+}
+
+func (r *CachedRepository) GetHarvests(ctx context.Context, input GetHarvestsInput) ([]Harvest, error) {
+	return r.next.GetHarvests(ctx, input)
+}
+
+func (r *CachedRepository) ExportEstate(ctx context.Context, id uuid.UUID) (*EstateBundle, error) {
+	return r.next.ExportEstate(ctx, id)
+}
+
+func (r *CachedRepository) GetAuditEntries(ctx context.Context, input GetAuditEntriesInput) ([]AuditEntry, error) {
+	return r.next.GetAuditEntries(ctx, input)
+}
+
+func (r *CachedRepository) GetTileCells(ctx context.Context, input GetTileCellsInput) ([]TileCell, error) {
+	return r.next.GetTileCells(ctx, input)
+}
+
+func (r *CachedRepository) RebuildTiles(ctx context.Context, estateID uuid.UUID) error {
+	return r.next.RebuildTiles(ctx, estateID)
+}
+
+func (r *CachedRepository) GetOutboxEvent(ctx context.Context, id uuid.UUID) (*OutboxEvent, error) {
+	return r.next.GetOutboxEvent(ctx, id)
+}
+
+func (r *CachedRepository) CreateWebhookSubscriber(ctx context.Context, input CreateWebhookSubscriberInput) error {
+	return r.next.CreateWebhookSubscriber(ctx, input)
+}
+
+func (r *CachedRepository) FanOutOutboxEvents(ctx context.Context) (int64, error) {
+	return r.next.FanOutOutboxEvents(ctx)
+}
+
+func (r *CachedRepository) ClaimWebhookDeliveries(ctx context.Context, input ClaimWebhookDeliveriesInput) ([]WebhookDelivery, error) {
+	return r.next.ClaimWebhookDeliveries(ctx, input)
+}
+
+func (r *CachedRepository) MarkWebhookDelivered(ctx context.Context, id uuid.UUID) error {
+	return r.next.MarkWebhookDelivered(ctx, id)
+}
+
+func (r *CachedRepository) MarkWebhookDeliveryFailed(ctx context.Context, input MarkWebhookDeliveryFailedInput) error {
+	return r.next.MarkWebhookDeliveryFailed(ctx, input)
+}
+
+func (r *CachedRepository) GetWebhookDeliveries(ctx context.Context, input GetWebhookDeliveriesInput) ([]WebhookDelivery, error) {
+	return r.next.GetWebhookDeliveries(ctx, input)
+}
+
+func (r *CachedRepository) CreateJob(ctx context.Context, input CreateJobInput) error {
+	return r.next.CreateJob(ctx, input)
+}
+
+func (r *CachedRepository) GetJob(ctx context.Context, id uuid.UUID) (*Job, error) {
+	return r.next.GetJob(ctx, id)
+}
+
+func (r *CachedRepository) ClaimJob(ctx context.Context, input ClaimJobInput) (*Job, error) {
+	return r.next.ClaimJob(ctx, input)
+}
+
+func (r *CachedRepository) HeartbeatJob(ctx context.Context, input HeartbeatJobInput) (bool, error) {
+	return r.next.HeartbeatJob(ctx, input)
+}
+
+func (r *CachedRepository) FinishJob(ctx context.Context, input FinishJobInput) error {
+	return r.next.FinishJob(ctx, input)
+}
+
+func (r *CachedRepository) CancelJob(ctx context.Context, id uuid.UUID) (*Job, error) {
+	return r.next.CancelJob(ctx, id)
+}
+
+func (r *CachedRepository) GetCachedDronePlan(ctx context.Context, key DronePlanCacheKey) (json.RawMessage, error) {
+	return r.next.GetCachedDronePlan(ctx, key)
+}
+
+func (r *CachedRepository) SaveCachedDronePlan(ctx context.Context, input SaveCachedDronePlanInput) error {
+	return r.next.SaveCachedDronePlan(ctx, input)
+}
+
+func (r *CachedRepository) Ping(ctx context.Context) error {
+	return r.next.Ping(ctx)
+}
+
+func (r *CachedRepository) CheckSchema(ctx context.Context) error {
+	return r.next.CheckSchema(ctx)
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestCachedRepository(t *testing.T) {
+	ctx := context.Background()
+	estateID := uuid.New()
+	height := 5
+	loaded := func() *Estate {
+		return &Estate{
+			Id:             estateID,
+			Width:          10,
+			Length:         10,
+			Trees:          []Tree{{X: 1, Y: 1, Height: 3}},
+			Stats:          &EstateStats{TreeCount: 1},
+			ExclusionZones: []ExclusionZone{{Kind: EXCLUSION_KIND_PLOTS, Points: []Point{{X: 2, Y: 2}}}},
+			Obstacles:      []Obstacle{{X: 3, Y: 3, Height: &height}},
+		}
+	}
+	newRepo := func(t *testing.T) (*CachedRepository, *MockRepositoryInterface) {
+		next := NewMockRepositoryInterface(gomock.NewController(t))
+		return NewCachedRepository(next, NewCachedRepositoryOptions{Size: 10, TTL: time.Minute}), next
+	}
+
+	t.Run("Hit until the TTL expire", func(t *testing.T) {
+		repo, next := newRepo(t)
+		now := time.Now()
+		repo.now = func() time.Time { return now }
+		next.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID).Return(loaded(), nil).Times(2)
+
+		estate, err := repo.GetEstateWithAllDetails(ctx, estateID)
+		assert.NoError(t, err)
+		assert.Equal(t, loaded(), estate)
+
+		estate, err = repo.GetEstateWithAllDetails(ctx, estateID)
+		assert.NoError(t, err)
+		assert.Equal(t, loaded(), estate)
+
+		now = now.Add(time.Minute)
+		_, err = repo.GetEstateWithAllDetails(ctx, estateID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Excluded relations are dropped from the cached estate", func(t *testing.T) {
+		repo, next := newRepo(t)
+		next.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID).Return(loaded(), nil)
+
+		estate, err := repo.GetEstateWithAllDetails(ctx, estateID, RELATION_TREES, RELATION_OBSTACLES)
+		assert.NoError(t, err)
+		assert.Nil(t, estate.Trees)
+		assert.Nil(t, estate.Obstacles)
+		assert.NotNil(t, estate.Stats)
+
+		estate, err = repo.GetEstateWithAllDetails(ctx, estateID, RELATION_STATS)
+		assert.NoError(t, err)
+		assert.Len(t, estate.Trees, 1)
+		assert.Nil(t, estate.Stats)
+	})
+
+	t.Run("Callers can't modify the cached estate", func(t *testing.T) {
+		repo, next := newRepo(t)
+		next.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID).Return(loaded(), nil)
+
+		estate, _ := repo.GetEstateWithAllDetails(ctx, estateID)
+		estate.Width = 20
+		estate.Trees[0].Height = 30
+		estate.Stats.TreeCount = 2
+		estate.ExclusionZones[0].Points[0].X = 9
+		*estate.Obstacles[0].Height = 50
+
+		estate, _ = repo.GetEstateWithAllDetails(ctx, estateID)
+		assert.Equal(t, loaded(), estate)
+	})
+
+	t.Run("Writes invalidate the estate", func(t *testing.T) {
+		writes := map[string]func(repo *CachedRepository, next *MockRepositoryInterface) error{
+			"CreateTree": func(repo *CachedRepository, next *MockRepositoryInterface) error {
+				next.EXPECT().CreateTree(gomock.Any(), gomock.Any()).Return(nil)
+				return repo.CreateTree(ctx, CreateTreeInput{EstateId: estateID})
+			},
+			"CreateTrees": func(repo *CachedRepository, next *MockRepositoryInterface) error {
+				next.EXPECT().CreateTrees(gomock.Any(), estateID, gomock.Any()).Return(nil)
+				return repo.CreateTrees(ctx, estateID, []CreateTreeInput{{X: 1, Y: 1}})
+			},
+			"UpsertEstateStats": func(repo *CachedRepository, next *MockRepositoryInterface) error {
+				next.EXPECT().UpsertEstateStats(gomock.Any(), estateID, gomock.Any()).Return(nil)
+				return repo.UpsertEstateStats(ctx, estateID, &EstateStats{})
+			},
+			"ResizeEstate": func(repo *CachedRepository, next *MockRepositoryInterface) error {
+				next.EXPECT().ResizeEstate(gomock.Any(), gomock.Any()).Return(nil, nil, nil)
+				_, _, err := repo.ResizeEstate(ctx, ResizeEstateInput{Id: estateID})
+				return err
+			},
+			"DeleteEstate": func(repo *CachedRepository, next *MockRepositoryInterface) error {
+				next.EXPECT().DeleteEstate(gomock.Any(), estateID).Return(nil)
+				return repo.DeleteEstate(ctx, estateID)
+			},
+			"CreateExclusionZone": func(repo *CachedRepository, next *MockRepositoryInterface) error {
+				next.EXPECT().CreateExclusionZone(gomock.Any(), gomock.Any()).Return(nil)
+				return repo.CreateExclusionZone(ctx, CreateExclusionZoneInput{EstateId: estateID})
+			},
+			"DeleteObstacle": func(repo *CachedRepository, next *MockRepositoryInterface) error {
+				next.EXPECT().DeleteObstacle(gomock.Any(), estateID, gomock.Any()).Return(errors.New("db error"))
+				return repo.DeleteObstacle(ctx, estateID, uuid.New())
+			},
+			"Other replica": func(repo *CachedRepository, next *MockRepositoryInterface) error {
+				repo.Invalidate(estateID)
+				return nil
+			},
+		}
+
+		for name, write := range writes {
+			t.Run(name, func(t *testing.T) {
+				repo, next := newRepo(t)
+				next.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID).Return(loaded(), nil).Times(2)
+
+				_, err := repo.GetEstateWithAllDetails(ctx, estateID)
+				assert.NoError(t, err)
+				write(repo, next)
+				_, err = repo.GetEstateWithAllDetails(ctx, estateID)
+				assert.NoError(t, err)
+			})
+		}
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		repo, next := newRepo(t)
+		gomock.InOrder(
+			next.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID).Return(nil, errors.New("db error")),
+			next.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID).Return(loaded(), nil),
+		)
+
+		_, err := repo.GetEstateWithAllDetails(ctx, estateID)
+		assert.EqualError(t, err, "db error")
+		estate, err := repo.GetEstateWithAllDetails(ctx, estateID)
+		assert.NoError(t, err)
+		assert.Equal(t, estateID, estate.Id)
+	})
+
+	t.Run("Estate read before a write is not kept", func(t *testing.T) {
+		repo, next := newRepo(t)
+		gomock.InOrder(
+			next.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID).
+				DoAndReturn(func(context.Context, uuid.UUID, ...Relation) (*Estate, error) {
+					// the write commit while the estate is being read
+					repo.Invalidate(estateID)
+					return loaded(), nil
+				}),
+			next.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID).Return(loaded(), nil),
+		)
+
+		_, err := repo.GetEstateWithAllDetails(ctx, estateID)
+		assert.NoError(t, err)
+		_, err = repo.GetEstateWithAllDetails(ctx, estateID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Concurrent misses share one read", func(t *testing.T) {
+		repo, next := newRepo(t)
+		release := make(chan struct{})
+		next.EXPECT().GetEstateWithAllDetails(gomock.Any(), estateID).
+			DoAndReturn(func(context.Context, uuid.UUID, ...Relation) (*Estate, error) {
+				<-release
+				return loaded(), nil
+			})
+
+		var wg sync.WaitGroup
+		estates := make([]*Estate, 5)
+		for i := range estates {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				estates[i], _ = repo.GetEstateWithAllDetails(ctx, estateID)
+			}()
+		}
+		// let the goroutines join the read before it returns
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		for _, estate := range estates {
+			assert.Equal(t, loaded(), estate)
+		}
+		// every caller got its own copy
+		estates[0].Trees[0].Height = 30
+		assert.Equal(t, 3, estates[1].Trees[0].Height)
+	})
+
+	t.Run("Least recently used estate is evicted", func(t *testing.T) {
+		next := NewMockRepositoryInterface(gomock.NewController(t))
+		repo := NewCachedRepository(next, NewCachedRepositoryOptions{Size: 2, TTL: time.Minute})
+		ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+		next.EXPECT().GetEstateWithAllDetails(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, id uuid.UUID, _ ...Relation) (*Estate, error) {
+				return &Estate{Id: id}, nil
+			}).Times(4)
+
+		repo.GetEstateWithAllDetails(ctx, ids[0])
+		repo.GetEstateWithAllDetails(ctx, ids[1])
+		repo.GetEstateWithAllDetails(ctx, ids[0])
+		repo.GetEstateWithAllDetails(ctx, ids[2])
+		assert.Equal(t, 2, repo.Len())
+
+		// ids[0] is still cached, ids[1] is read again
+		repo.GetEstateWithAllDetails(ctx, ids[0])
+		repo.GetEstateWithAllDetails(ctx, ids[1])
+	})
+}
@@ -0,0 +1,227 @@
+// This file contains the geometry of the exclusion zones, shared by tree planting, stats & the drone planner.
+package repository
+
+import "sort"
+
+// Contains report whether the x,y plot is excluded by the zone
+func (z ExclusionZone) Contains(x, y int) bool {
+	if z.Kind == EXCLUSION_KIND_PLOTS {
+		for _, p := range z.Points {
+			if p.X == x && p.Y == y {
+				return true
+			}
+		}
+		return false
+	}
+
+	return polygonContains(z.Points, x, y)
+}
+
+// Bounds return the smallest rectangle holding the zone
+func (z ExclusionZone) Bounds() (lo, hi Point) {
+	if len(z.Points) == 0 {
+		return
+	}
+
+	lo, hi = z.Points[0], z.Points[0]
+	for _, p := range z.Points[1:] {
+		lo = Point{X: min(lo.X, p.X), Y: min(lo.Y, p.Y)}
+		hi = Point{X: max(hi.X, p.X), Y: max(hi.Y, p.Y)}
+	}
+	return
+}
+
+// polygonContains is an even-odd ray casting test, plots on an edge or a vertex are inside
+func polygonContains(vertices []Point, x, y int) bool {
+	if len(vertices) < 3 {
+		return false
+	}
+
+	inside := false
+	for i, j := 0, len(vertices)-1; i < len(vertices); j, i = i, i+1 {
+		a, b := vertices[i], vertices[j]
+		if onSegment(a, b, x, y) {
+			return true
+		}
+
+		// the edge cross the horizontal ray going east from x,y
+		if (a.Y > y) != (b.Y > y) {
+			// x of the crossing compared without division: x < a.X + (y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			lhs := (x - a.X) * (b.Y - a.Y)
+			rhs := (y - a.Y) * (b.X - a.X)
+			if (b.Y-a.Y > 0 && lhs < rhs) || (b.Y-a.Y < 0 && lhs > rhs) {
+				inside = !inside
+			}
+		}
+	}
+
+	return inside
+}
+
+func onSegment(a, b Point, x, y int) bool {
+	cross := (b.X-a.X)*(y-a.Y) - (b.Y-a.Y)*(x-a.X)
+	if cross != 0 {
+		return false
+	}
+	return x >= min(a.X, b.X) && x <= max(a.X, b.X) && y >= min(a.Y, b.Y) && y <= max(a.Y, b.Y)
+}
+
+// IsExcluded report whether trees can't be planted on the x,y plot
+func (e *Estate) IsExcluded(x, y int) bool {
+	for _, zone := range e.ExclusionZones {
+		if zone.Contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExcludedPlotCount count the plots of the estate covered by at least one exclusion zone
+func (e *Estate) ExcludedPlotCount() int64 {
+	return e.ExcludedPlotCountIn(Point{X: 1, Y: 1}, Point{X: e.Length, Y: e.Width})
+}
+
+// ExcludedPlotCountIn count the excluded plots of the estate inside the lo,hi rectangle
+func (e *Estate) ExcludedPlotCountIn(lo, hi Point) int64 {
+	return e.ExcludedPlots(lo, hi).CountIn(lo, hi)
+}
+
+// span is the x range of consecutive plots of a row, both ends included
+type span struct {
+	lo, hi int
+}
+
+// ExcludedPlots is the union of the exclusion zones row by row, so the excluded plots of a rectangle
+// are counted from the zone edges without testing every plot
+type ExcludedPlots struct {
+	lo, hi Point
+	rows   [][]span // sorted & disjoint spans of the row lo.Y+i
+}
+
+// ExcludedPlots build the excluded plots of the estate inside the lo,hi rectangle, only the rows of
+// each zone are walked. Build it once to count many rectangles, like the cells of a map
+func (e *Estate) ExcludedPlots(lo, hi Point) *ExcludedPlots {
+	lo = Point{X: max(lo.X, 1), Y: max(lo.Y, 1)}
+	hi = Point{X: min(hi.X, e.Length), Y: min(hi.Y, e.Width)}
+	res := &ExcludedPlots{lo: lo, hi: hi}
+	if len(e.ExclusionZones) == 0 || lo.X > hi.X || lo.Y > hi.Y {
+		return res
+	}
+
+	res.rows = make([][]span, hi.Y-lo.Y+1)
+	for _, zone := range e.ExclusionZones {
+		zoneLo, zoneHi := zone.Bounds()
+		if zone.Kind == EXCLUSION_KIND_PLOTS {
+			for _, p := range zone.Points {
+				if p.X >= lo.X && p.X <= hi.X && p.Y >= lo.Y && p.Y <= hi.Y {
+					res.rows[p.Y-lo.Y] = append(res.rows[p.Y-lo.Y], span{lo: p.X, hi: p.X})
+				}
+			}
+			continue
+		}
+		for y := max(zoneLo.Y, lo.Y); y <= min(zoneHi.Y, hi.Y); y++ {
+			for _, s := range polygonRowSpans(zone.Points, y) {
+				if s.lo, s.hi = max(s.lo, lo.X), min(s.hi, hi.X); s.lo <= s.hi {
+					res.rows[y-lo.Y] = append(res.rows[y-lo.Y], s)
+				}
+			}
+		}
+	}
+
+	for i, row := range res.rows {
+		res.rows[i] = mergeSpans(row)
+	}
+	return res
+}
+
+// CountIn count the excluded plots inside the lo,hi rectangle, the part outside the built one is not counted
+func (p *ExcludedPlots) CountIn(lo, hi Point) (count int64) {
+	if p.rows == nil {
+		return 0
+	}
+
+	for y := max(lo.Y, p.lo.Y); y <= min(hi.Y, p.hi.Y); y++ {
+		for _, s := range p.rows[y-p.lo.Y] {
+			if s.lo > hi.X {
+				break
+			}
+			if from, to := max(s.lo, lo.X), min(s.hi, hi.X); from <= to {
+				count += int64(to - from + 1)
+			}
+		}
+	}
+	return count
+}
+
+// polygonRowSpans return the plots of the y row inside or on the edges of the polygon, like polygonContains.
+// Spans may overlap, they are merged by the caller
+func polygonRowSpans(vertices []Point, y int) []span {
+	if len(vertices) < 3 {
+		return nil
+	}
+
+	var spans []span
+	var crossings []int
+	for i, j := 0, len(vertices)-1; i < len(vertices); j, i = i, i+1 {
+		a, b := vertices[i], vertices[j]
+		if a.Y == y && b.Y == y {
+			spans = append(spans, span{lo: min(a.X, b.X), hi: max(a.X, b.X)})
+			continue
+		}
+		if y < min(a.Y, b.Y) || y > max(a.Y, b.Y) {
+			continue
+		}
+
+		// x of the edge on the row is num/den, the plot is on the edge when it is a whole number
+		num := int64(a.X)*int64(b.Y-a.Y) + int64(y-a.Y)*int64(b.X-a.X)
+		den := int64(b.Y - a.Y)
+		if num%den == 0 {
+			x := int(num / den)
+			spans = append(spans, span{lo: x, hi: x})
+		}
+		// the same crossings as the ray casting, a plot is east of the crossing from its ceiling
+		if (a.Y > y) != (b.Y > y) {
+			if den < 0 {
+				num, den = -num, -den
+			}
+			ceil := num / den
+			if num%den != 0 && num > 0 {
+				ceil++
+			}
+			crossings = append(crossings, int(ceil))
+		}
+	}
+
+	// a plot is inside when an odd number of crossings is west of it or on it
+	sort.Ints(crossings)
+	for i := 0; i+1 < len(crossings); i += 2 {
+		if crossings[i] <= crossings[i+1]-1 {
+			spans = append(spans, span{lo: crossings[i], hi: crossings[i+1] - 1})
+		}
+	}
+	return spans
+}
+
+// mergeSpans sort the spans & merge the overlapping or adjacent ones
+func mergeSpans(spans []span) []span {
+	if len(spans) < 2 {
+		return spans
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].lo < spans[j].lo })
+	merged := spans[:1]
+	for _, s := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if s.lo <= last.hi+1 {
+			last.hi = max(last.hi, s.hi)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// PlantablePlots is the number of plots a tree can be planted on
+func (e *Estate) PlantablePlots() int64 {
+	return int64(e.Width)*int64(e.Length) - e.ExcludedPlotCount()
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExclusionZoneContains(t *testing.T) {
+	// L shaped building
+	building := ExclusionZone{Kind: EXCLUSION_KIND_POLYGON, Points: []Point{
+		{X: 2, Y: 2}, {X: 6, Y: 2}, {X: 6, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 6}, {X: 2, Y: 6},
+	}}
+	road := ExclusionZone{Kind: EXCLUSION_KIND_PLOTS, Points: []Point{{X: 1, Y: 1}, {X: 2, Y: 1}}}
+	// diagonal river, plots crossed by the edges are excluded
+	river := ExclusionZone{Kind: EXCLUSION_KIND_POLYGON, Points: []Point{{X: 1, Y: 1}, {X: 5, Y: 5}, {X: 5, Y: 1}}}
+
+	tests := []struct {
+		name     string
+		zone     ExclusionZone
+		x, y     int
+		expected bool
+	}{
+		{name: "Polygon - Vertex", zone: building, x: 2, y: 2, expected: true},
+		{name: "Polygon - Edge", zone: building, x: 6, y: 3, expected: true},
+		{name: "Polygon - Inside", zone: building, x: 3, y: 5, expected: true},
+		{name: "Polygon - Inner Corner Edge", zone: building, x: 4, y: 5, expected: true},
+		{name: "Polygon - Outside Concave Part", zone: building, x: 5, y: 5, expected: false},
+		{name: "Polygon - Outside", zone: building, x: 7, y: 3, expected: false},
+		{name: "Triangle - Diagonal Edge", zone: river, x: 3, y: 3, expected: true},
+		{name: "Triangle - Inside", zone: river, x: 4, y: 2, expected: true},
+		{name: "Triangle - Outside", zone: river, x: 2, y: 4, expected: false},
+		{name: "Plots - Listed", zone: road, x: 2, y: 1, expected: true},
+		{name: "Plots - Not Listed", zone: road, x: 3, y: 1, expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.zone.Contains(tc.x, tc.y))
+		})
+	}
+}
+
+func TestEstatePlantablePlots(t *testing.T) {
+	estate := &Estate{Width: 5, Length: 10}
+	assert.Equal(t, int64(50), estate.PlantablePlots())
+
+	estate.ExclusionZones = []ExclusionZone{
+		// 3x2 pond
+		{Kind: EXCLUSION_KIND_POLYGON, Points: []Point{{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 2}, {X: 1, Y: 2}}},
+		// overlap the pond on (3,2), and go past the estate edge
+		{Kind: EXCLUSION_KIND_PLOTS, Points: []Point{{X: 3, Y: 2}, {X: 4, Y: 2}, {X: 11, Y: 2}}},
+	}
+	assert.Equal(t, int64(7), estate.ExcludedPlotCount())
+	assert.Equal(t, int64(43), estate.PlantablePlots())
+	assert.Equal(t, int64(3), estate.ExcludedPlotCountIn(Point{X: 3, Y: 1}, Point{X: 5, Y: 5}))
+	assert.Equal(t, int64(0), estate.ExcludedPlotCountIn(Point{X: 1, Y: 3}, Point{X: 10, Y: 5}))
+	assert.True(t, estate.IsExcluded(4, 2))
+	assert.False(t, estate.IsExcluded(5, 2))
+}
+
+func TestEstateExcludedPlots(t *testing.T) {
+	estate := &Estate{Width: 12, Length: 15, ExclusionZones: []ExclusionZone{
+		// L shaped building
+		{Kind: EXCLUSION_KIND_POLYGON, Points: []Point{
+			{X: 2, Y: 2}, {X: 6, Y: 2}, {X: 6, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 6}, {X: 2, Y: 6},
+		}},
+		// diagonal river
+		{Kind: EXCLUSION_KIND_POLYGON, Points: []Point{{X: 1, Y: 1}, {X: 9, Y: 10}, {X: 12, Y: 3}}},
+		// slanted field, past the estate edge
+		{Kind: EXCLUSION_KIND_POLYGON, Points: []Point{{X: 10, Y: 8}, {X: 17, Y: 9}, {X: 14, Y: 14}, {X: 8, Y: 12}}},
+		{Kind: EXCLUSION_KIND_PLOTS, Points: []Point{{X: 3, Y: 3}, {X: 15, Y: 1}, {X: 1, Y: 12}}},
+	}}
+
+	rects := [][2]Point{
+		{{X: 1, Y: 1}, {X: 15, Y: 12}},
+		{{X: 3, Y: 2}, {X: 7, Y: 9}},
+		{{X: 9, Y: 6}, {X: 20, Y: 20}},
+		{{X: -3, Y: -3}, {X: 2, Y: 2}},
+	}
+	excluded := estate.ExcludedPlots(Point{X: 1, Y: 1}, Point{X: estate.Length, Y: estate.Width})
+	for _, rect := range rects {
+		// same count as testing every plot
+		var expected int64
+		for y := max(rect[0].Y, 1); y <= min(rect[1].Y, estate.Width); y++ {
+			for x := max(rect[0].X, 1); x <= min(rect[1].X, estate.Length); x++ {
+				if estate.IsExcluded(x, y) {
+					expected++
+				}
+			}
+		}
+		assert.Equal(t, expected, estate.ExcludedPlotCountIn(rect[0], rect[1]), "%v", rect)
+		assert.Equal(t, expected, excluded.CountIn(rect[0], rect[1]), "%v", rect)
+	}
+
+	t.Run("Large Estate", func(t *testing.T) {
+		large := &Estate{Width: 50000, Length: 50000, ExclusionZones: []ExclusionZone{
+			{Kind: EXCLUSION_KIND_POLYGON, Points: []Point{{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 2}, {X: 1, Y: 2}}},
+			{Kind: EXCLUSION_KIND_PLOTS, Points: []Point{{X: 50000, Y: 50000}}},
+		}}
+		assert.Equal(t, int64(7), large.ExcludedPlotCount())
+		assert.Equal(t, int64(2_500_000_000-7), large.PlantablePlots())
+	})
+}
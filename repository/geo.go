@@ -0,0 +1,58 @@
+package repository
+
+import "math"
+
+// EARTH_RADIUS is the mean radius of the WGS84 ellipsoid in meters
+const EARTH_RADIUS = 6371008.8
+
+// GeoReference place the plot grid of an estate on the earth. The origin is the WGS84 South-West corner
+// of plot (1,1), the rotation is the bearing of the South-North axis in degrees clockwise from true North
+type GeoReference struct {
+	OriginLat float64 `json:"origin_lat"`
+	OriginLon float64 `json:"origin_lon"`
+	Rotation  float64 `json:"rotation"`
+}
+
+// LatLon is the WGS84 center of the plot, plotSize meters a side. Estates are small enough for
+// a local equirectangular projection around the origin
+func (g GeoReference) LatLon(x, y, plotSize int) (lat, lon float64) {
+	dx := (float64(x) - 0.5) * float64(plotSize)
+	dy := (float64(y) - 0.5) * float64(plotSize)
+
+	sin, cos := math.Sincos(g.Rotation * math.Pi / 180)
+	east := dx*cos + dy*sin
+	north := dy*cos - dx*sin
+
+	lat = g.OriginLat + north/EARTH_RADIUS*180/math.Pi
+	lon = normalizeLon(g.OriginLon + east/(EARTH_RADIUS*math.Cos(g.OriginLat*math.Pi/180))*180/math.Pi)
+	return lat, lon
+}
+
+// PlotAt is the plot holding the WGS84 point, the one whose center is the nearest. It is false when
+// the point is outside the estate or the estate is not geo-referenced
+func (e *Estate) PlotAt(lat, lon float64, plotSize int) (Point, bool) {
+	if e.Geo == nil {
+		return Point{}, false
+	}
+
+	north := (lat - e.Geo.OriginLat) * math.Pi / 180 * EARTH_RADIUS
+	east := normalizeLon(lon-e.Geo.OriginLon) * math.Pi / 180 * EARTH_RADIUS * math.Cos(e.Geo.OriginLat*math.Pi/180)
+
+	sin, cos := math.Sincos(e.Geo.Rotation * math.Pi / 180)
+	dx := east*cos - north*sin
+	dy := east*sin + north*cos
+	if dx < 0 || dy < 0 {
+		return Point{}, false
+	}
+
+	p := Point{X: int(dx/float64(plotSize)) + 1, Y: int(dy/float64(plotSize)) + 1}
+	if p.X > e.Length || p.Y > e.Width {
+		return Point{}, false
+	}
+	return p, true
+}
+
+// normalizeLon wrap the longitude in [-180, 180), estates can lie across the antimeridian
+func normalizeLon(lon float64) float64 {
+	return math.Mod(math.Mod(lon+180, 360)+360, 360) - 180
+}
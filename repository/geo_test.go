@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeoReferenceLatLon(t *testing.T) {
+	// degrees of latitude in a meter, of longitude too on the equator
+	meter := 180 / (math.Pi * EARTH_RADIUS)
+
+	geo := GeoReference{OriginLat: 0, OriginLon: 100}
+	lat, lon := geo.LatLon(1, 1, 10)
+	assert.InDelta(t, 5*meter, lat, 1e-9)
+	assert.InDelta(t, 100+5*meter, lon, 1e-9)
+
+	lat, lon = geo.LatLon(3, 1, 10)
+	assert.InDelta(t, 5*meter, lat, 1e-9)
+	assert.InDelta(t, 100+25*meter, lon, 1e-9)
+
+	// the South-North axis points East, x goes South
+	geo.Rotation = 90
+	lat, lon = geo.LatLon(3, 1, 10)
+	assert.InDelta(t, -25*meter, lat, 1e-9)
+	assert.InDelta(t, 100+5*meter, lon, 1e-9)
+
+	// across the antimeridian
+	lat, lon = GeoReference{OriginLat: 0, OriginLon: 179.9999}.LatLon(3, 1, 10)
+	assert.InDelta(t, 5*meter, lat, 1e-9)
+	assert.InDelta(t, -180+25*meter-0.0001, lon, 1e-9)
+}
+
+func TestEstatePlotAt(t *testing.T) {
+	estate := &Estate{Length: 5, Width: 3, Geo: &GeoReference{OriginLat: 3.5, OriginLon: 101.25, Rotation: 30}}
+
+	for x := 1; x <= estate.Length; x++ {
+		for y := 1; y <= estate.Width; y++ {
+			lat, lon := estate.Geo.LatLon(x, y, 10)
+			p, ok := estate.PlotAt(lat, lon, 10)
+			assert.True(t, ok)
+			assert.Equal(t, Point{X: x, Y: y}, p)
+		}
+	}
+
+	// 4 meters from the center of (2,2) snaps to it
+	lat, lon := estate.Geo.LatLon(2, 2, 10)
+	p, ok := estate.PlotAt(lat+4*180/(math.Pi*EARTH_RADIUS), lon, 10)
+	assert.True(t, ok)
+	assert.Equal(t, Point{X: 2, Y: 2}, p)
+
+	for _, outside := range []Point{{X: 0, Y: 1}, {X: 1, Y: 0}, {X: 6, Y: 1}, {X: 1, Y: 4}} {
+		lat, lon := estate.Geo.LatLon(outside.X, outside.Y, 10)
+		_, ok := estate.PlotAt(lat, lon, 10)
+		assert.False(t, ok, "plot %v", outside)
+	}
+
+	_, ok = (&Estate{Length: 5, Width: 3}).PlotAt(3.5, 101.25, 10)
+	assert.False(t, ok)
+}
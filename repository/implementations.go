@@ -14,21 +14,33 @@ import (
 type Relation string
 
 const (
-	RELATION_STATS Relation = "stats"
-	RELATION_TREES Relation = "trees"
+	RELATION_STATS      Relation = "stats"
+	RELATION_TREES      Relation = "trees"
+	RELATION_EXCLUSIONS Relation = "exclusions"
+	RELATION_OBSTACLES  Relation = "obstacles"
 )
 
 func (r *Repository) CreateEstate(ctx context.Context, input CreateEstateInput) (err error) {
-	err = r.createEstateSql(ctx, input)
+	err = r.withTx(ctx, func(tx *sql.Tx) error {
+		if err := r.createEstateSql(ctx, tx, input); err != nil {
+			return fmt.Errorf("failed to create estate: %w", err)
+		}
+
+		return r.createAuditEntry(ctx, tx, input.Id, AUDIT_ESTATE_CREATED, input.Id, nil, EstateAuditState{
+			Width:  input.Width,
+			Length: input.Length,
+			Geo:    input.Geo,
+		})
+	})
 	if err != nil {
-		return apperror.WrapWithCode(fmt.Errorf("failed to create estate: %w", err), http.StatusInternalServerError)
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
 	}
 
 	return
 }
 
 func (r *Repository) GetEstateWithAllDetails(ctx context.Context, id uuid.UUID, exludeRelations ...Relation) (estate *Estate, err error) {
-	estate, err = r.getEstateByIdSql(ctx, id)
+	estate, err = r.getEstateByIdSql(ctx, r.Db, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			err = apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", id), http.StatusNotFound)
@@ -45,59 +57,182 @@ func (r *Repository) GetEstateWithAllDetails(ctx context.Context, id uuid.UUID,
 	}
 
 	if !excludeMap[RELATION_TREES] {
-		estate.Trees, err = r.getTreesByEstateId(ctx, id)
+		estate.Trees, err = r.getTreesByEstateId(ctx, r.Db, id)
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
 			return nil, apperror.WrapWithCode(fmt.Errorf("failed to get estate tress: %w", err), http.StatusInternalServerError)
 		}
 	}
 
 	if !excludeMap[RELATION_STATS] {
-		estate.Stats, err = r.getEstateStatsSQL(ctx, estate.Id)
+		estate.Stats, err = r.getEstateStatsSQL(ctx, r.Db, estate.Id)
 		if err != nil && !errors.Is(err, sql.ErrNoRows) {
 			return nil, apperror.WrapWithCode(fmt.Errorf("failed to get estate stats: %w", err), http.StatusInternalServerError)
 		}
 	}
 
+	if !excludeMap[RELATION_EXCLUSIONS] {
+		estate.ExclusionZones, err = r.getExclusionZonesByEstateIdSQL(ctx, r.Db, estate.Id)
+		if err != nil {
+			return nil, apperror.WrapWithCode(fmt.Errorf("failed to get estate exclusion zones: %w", err), http.StatusInternalServerError)
+		}
+	}
+
+	if !excludeMap[RELATION_OBSTACLES] {
+		estate.Obstacles, err = r.getObstaclesByEstateIdSQL(ctx, r.Db, estate.Id)
+		if err != nil {
+			return nil, apperror.WrapWithCode(fmt.Errorf("failed to get estate obstacles: %w", err), http.StatusInternalServerError)
+		}
+	}
+
 	return estate, nil
 }
 
+// CreateTree plant a tree in the estate. The plot is checked under the estate lock,
+// so the estate is not resized, deleted or given a new exclusion zone meanwhile
 func (r *Repository) CreateTree(ctx context.Context, input CreateTreeInput) (err error) {
+	if input.Health == "" {
+		input.Health = TREE_HEALTHY
+	}
+	if input.Tags == nil {
+		input.Tags = []string{}
+	}
 
-	estate, err := r.getEstateByIdSql(ctx, input.EstateId)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", input.EstateId), http.StatusNotFound)
+	// the tree, its audit entry & outbox event must be committed together
+	err = r.withTx(ctx, func(tx *sql.Tx) error {
+		// lock the estate first, so concurrent plantings refresh the tile cells one after the other
+		// and the max zoom of the cells is not changed by a resize meanwhile
+		estate, err := r.getEstateForUpdateSQL(ctx, tx, input.EstateId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", input.EstateId), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get estate: %w", err)
 		}
-		return apperror.WrapWithCode(fmt.Errorf("failed to get estate: %w", err), http.StatusInternalServerError)
-	}
 
-	// validate X & Y coordinate
-	if input.X > estate.Length {
-		return apperror.WrapWithCode(fmt.Errorf("coordinate x cannot greater than %d", estate.Length), http.StatusBadRequest)
-	}
-	if input.Y > estate.Width {
-		return apperror.WrapWithCode(fmt.Errorf("coordinate y cannot greater than %d", estate.Width), http.StatusBadRequest)
-	}
+		// validate X & Y coordinate
+		if input.X > estate.Length {
+			return apperror.WrapWithCode(fmt.Errorf("coordinate x cannot greater than %d", estate.Length), http.StatusBadRequest)
+		}
+		if input.Y > estate.Width {
+			return apperror.WrapWithCode(fmt.Errorf("coordinate y cannot greater than %d", estate.Width), http.StatusBadRequest)
+		}
 
-	isExist, err := r.checkExistEstateTree(ctx, CheckExistEstateTreeInput{
-		EstateId: input.EstateId,
-		X:        input.X,
-		Y:        input.Y,
+		estate.ExclusionZones, err = r.getExclusionZonesByEstateIdSQL(ctx, tx, input.EstateId)
+		if err != nil {
+			return fmt.Errorf("failed to get estate exclusion zones: %w", err)
+		}
+		if estate.IsExcluded(input.X, input.Y) {
+			return apperror.WrapWithCode(fmt.Errorf("plot (%d,%d) is excluded from planting", input.X, input.Y), http.StatusUnprocessableEntity)
+		}
+
+		isExist, err := r.checkExistEstateTree(ctx, tx, CheckExistEstateTreeInput{
+			EstateId: input.EstateId,
+			X:        input.X,
+			Y:        input.Y,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check plot tree in database: %w", err)
+		}
+		if isExist {
+			return apperror.WrapWithCode(errors.New("plot already has a tree"), http.StatusUnprocessableEntity)
+		}
+
+		if err := r.createTreeSQL(ctx, tx, input); err != nil {
+			return fmt.Errorf("failed to create tree: %w", err)
+		}
+		if err := r.refreshTileCells(ctx, tx, estate, []Point{{X: input.X, Y: input.Y}}); err != nil {
+			return err
+		}
+		if err := r.bumpEstateVersionSQL(ctx, tx, input.EstateId); err != nil {
+			return fmt.Errorf("failed to bump estate version: %w", err)
+		}
+
+		event := TreeAddedEvent{
+			TreeId:   input.Id,
+			EstateId: input.EstateId,
+			X:        input.X,
+			Y:        input.Y,
+			Height:   input.Height,
+			Species:  input.Species,
+			Health:   input.Health,
+		}
+		if err := r.createAuditEntry(ctx, tx, input.EstateId, AUDIT_TREE_CREATED, input.Id, nil, event); err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, input.EstateId, EVENT_TREE_ADDED, event)
 	})
 	if err != nil {
-		return apperror.WrapWithCode(fmt.Errorf("failed to check plot tree in database: %w", err), http.StatusInternalServerError)
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
 	}
 
-	if isExist {
-		return apperror.WrapWithCode(errors.New("plot already has a tree"), http.StatusUnprocessableEntity)
-	}
+	return
+}
 
-	err = r.createTreeSQL(ctx, input)
+// UpdateTree change the species, health & tags of a tree, audited & published with the tree once updated
+func (r *Repository) UpdateTree(ctx context.Context, input UpdateTreeInput) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		// lock the estate first like every tree change, a deleted estate has no tree to update
+		if _, err := r.getEstateForUpdateSQL(ctx, tx, input.EstateId); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", input.EstateId), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get estate: %w", err)
+		}
+
+		tree, err := r.getTreeForUpdateSQL(ctx, tx, input.EstateId, input.Id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("tree with ID %s not found", input.Id), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get tree: %w", err)
+		}
+
+		before := NewTreeAuditState(*tree)
+		if input.Species != nil {
+			tree.Species = *input.Species
+		}
+		if input.Health != nil {
+			tree.Health = *input.Health
+		}
+		if input.Tags != nil {
+			tree.Tags = input.Tags
+		}
+
+		if err = r.updateTreeAttributesSQL(ctx, tx, tree); err != nil {
+			return fmt.Errorf("failed to update tree: %w", err)
+		}
+		if err = r.bumpEstateVersionSQL(ctx, tx, input.EstateId); err != nil {
+			return fmt.Errorf("failed to bump estate version: %w", err)
+		}
+
+		if err = r.createAuditEntry(ctx, tx, input.EstateId, AUDIT_TREE_UPDATED, tree.Id, before, NewTreeAuditState(*tree)); err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, input.EstateId, EVENT_TREE_UPDATED, TreeUpdatedEvent{
+			TreeId:   tree.Id,
+			EstateId: tree.EstateId,
+			X:        tree.X,
+			Y:        tree.Y,
+			Height:   tree.Height,
+			Species:  tree.Species,
+			Health:   tree.Health,
+		})
+	})
 	if err != nil {
-		return apperror.WrapWithCode(fmt.Errorf("failed to create tree: %w", err), http.StatusInternalServerError)
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
 	}
 
-	return
+	return nil
 }
 
 func (r *Repository) GetCalculatedEstateStats(ctx context.Context, estateId uuid.UUID) (stats *EstateStats, err error) {
@@ -109,11 +244,63 @@ func (r *Repository) GetCalculatedEstateStats(ctx context.Context, estateId uuid
 	return
 }
 
+// treeGroupColumns map every TreeGroup to the column of the trees table it groups by
+var treeGroupColumns = map[TreeGroup]string{
+	TREE_GROUP_SPECIES: "species",
+	TREE_GROUP_HEALTH:  "health",
+}
+
+func (r *Repository) GetTreeGroupStats(ctx context.Context, estateId uuid.UUID, group TreeGroup) ([]TreeGroupStats, error) {
+	column, ok := treeGroupColumns[group]
+	if !ok {
+		return nil, apperror.WrapWithCode(fmt.Errorf("trees can't be grouped by %q", group), http.StatusBadRequest)
+	}
+
+	groups, err := r.getTreeGroupStatsSQL(ctx, estateId, column)
+	if err != nil {
+		return nil, apperror.WrapWithCode(fmt.Errorf("failed to get tree group stats: %w", err), http.StatusInternalServerError)
+	}
+	return groups, nil
+}
+
 func (r *Repository) UpsertEstateStats(ctx context.Context, estateID uuid.UUID, stats *EstateStats) error {
-	err := r.upsertEstateStatsSQL(ctx, estateID, stats)
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		previous, err := r.getEstateStatsSQL(ctx, tx, estateID)
+		if errors.Is(err, sql.ErrNoRows) {
+			previous = nil
+		} else if err != nil {
+			return fmt.Errorf("failed to get estate stats: %w", err)
+		}
+		if err := r.upsertEstateStatsSQL(ctx, tx, estateID, stats); err != nil {
+			return fmt.Errorf("failed to get upsert stats: %w", err)
+		}
+
+		// the stats row keep its ID on update
+		entityID, before := stats.Id, any(nil)
+		if previous != nil {
+			entityID, before = previous.Id, statsChangedEvent(estateID, previous)
+		}
+		event := statsChangedEvent(estateID, stats)
+		if err := r.createAuditEntry(ctx, tx, estateID, AUDIT_ESTATE_STATS_UPSERTED, entityID, before, event); err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, estateID, EVENT_ESTATE_STATS_CHANGED, event)
+	})
 	if err != nil {
-		return apperror.WrapWithCode(fmt.Errorf("failed to get upsert stats: %w", err), http.StatusInternalServerError)
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
 	}
 
 	return nil
 }
+
+func statsChangedEvent(estateID uuid.UUID, stats *EstateStats) EstateStatsChangedEvent {
+	return EstateStatsChangedEvent{
+		EstateId:      estateID,
+		TreeCount:     stats.TreeCount,
+		MaxHeight:     stats.MaxHeight,
+		MinHeight:     stats.MinHeight,
+		MedianHeight:  stats.MedianHeight,
+		DroneDistance: stats.DroneDistance,
+	}
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/telemetry"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+)
+
+// createAuditEntry write the audit entry of a change using the caller transaction, so the entry exists
+// only when the change is committed. The actor & request ID are read from ctx, before & after are
+// marshalled to JSON and a nil one is stored as NULL
+func (r *Repository) createAuditEntry(ctx context.Context, exec dbExecutor, estateID uuid.UUID, action AuditAction, entityID uuid.UUID, before, after any) error {
+	actor := ActorFromContext(ctx)
+	entry := AuditEntry{
+		Id:        uuid.New(),
+		EstateId:  estateID,
+		Action:    action,
+		EntityId:  entityID,
+		Actor:     actor.Name,
+		RequestId: telemetry.RequestIdFromContext(ctx),
+		SourceIp:  actor.SourceIp,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	var err error
+	if entry.Before, err = marshalAuditState(before); err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	if entry.After, err = marshalAuditState(after); err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	if err = r.createAuditEntrySQL(ctx, exec, entry); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// marshalAuditState is nil for a nil state, a nil pointer included
+func marshalAuditState(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil || string(data) == "null" {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetAuditEntries list the audit entries of an estate, the deleted estates included
+func (r *Repository) GetAuditEntries(ctx context.Context, input GetAuditEntriesInput) ([]AuditEntry, error) {
+	entries, err := r.getAuditEntriesSQL(ctx, input)
+	if err != nil {
+		return nil, apperror.WrapWithCode(fmt.Errorf("failed to get audit entries: %w", err), http.StatusInternalServerError)
+	}
+	return entries, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+func (r *Repository) createAuditEntrySQL(ctx context.Context, exec dbExecutor, entry AuditEntry) error {
+	_, err := exec.ExecContext(ctx, `
+		INSERT INTO audit_entries (id, estate_id, action, entity_id, actor, request_id, source_ip, before, after, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);`,
+		entry.Id,
+		entry.EstateId,
+		entry.Action,
+		entry.EntityId,
+		entry.Actor,
+		sql.NullString{String: entry.RequestId, Valid: entry.RequestId != ""},
+		sql.NullString{String: entry.SourceIp, Valid: entry.SourceIp != ""},
+		nullJSON(entry.Before),
+		nullJSON(entry.After),
+		entry.CreatedAt,
+	)
+	return err
+}
+
+// nullJSON pass a nil JSON as NULL, lib/pq would send an empty bytea instead
+func nullJSON(data []byte) any {
+	if data == nil {
+		return nil
+	}
+	return data
+}
+
+func (r *Repository) getAuditEntriesSQL(ctx context.Context, input GetAuditEntriesInput) ([]AuditEntry, error) {
+	rows, err := r.Db.QueryContext(ctx, `
+		SELECT id, estate_id, action, entity_id, actor, request_id, source_ip, before, after, created_at
+		FROM audit_entries
+		WHERE estate_id = $1
+			AND ($2 = '' OR action = $2)
+			AND ($3 = '' OR actor = $3)
+			AND ($4::timestamptz IS NULL OR created_at >= $4)
+			AND ($5::timestamptz IS NULL OR created_at < $5)
+		ORDER BY created_at DESC, id
+		LIMIT $6 OFFSET $7;`,
+		input.EstateId, input.Action, input.Actor, input.From, input.To, input.Limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAuditEntries(rows)
+}
+
+// getAuditEntriesByEstateIdSQL return every audit entry of the estate, oldest first
+func (r *Repository) getAuditEntriesByEstateIdSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID) ([]AuditEntry, error) {
+	rows, err := exec.QueryContext(ctx, `
+		SELECT id, estate_id, action, entity_id, actor, request_id, source_ip, before, after, created_at
+		FROM audit_entries
+		WHERE estate_id = $1
+		ORDER BY created_at, id;`, estateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAuditEntries(rows)
+}
+
+func scanAuditEntries(rows *sql.Rows) ([]AuditEntry, error) {
+	entries := []AuditEntry{}
+	for rows.Next() {
+		var (
+			entry               AuditEntry
+			requestId, sourceIp sql.NullString
+			before, after       []byte
+		)
+		if err := rows.Scan(
+			&entry.Id,
+			&entry.EstateId,
+			&entry.Action,
+			&entry.EntityId,
+			&entry.Actor,
+			&requestId,
+			&sourceIp,
+			&before,
+			&after,
+			&entry.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entry.RequestId, entry.SourceIp = requestId.String, sourceIp.String
+		entry.Before, entry.After = before, after
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
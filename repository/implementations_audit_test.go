@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SawitProRecruitment/UserService/telemetry"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var insertAuditEntry = regexp.QuoteMeta(`INSERT INTO audit_entries (id, estate_id, action, entity_id, actor, request_id, source_ip, before, after, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);`)
+
+// expectAuditEntry expect the audit entry of a change, written in the transaction of the change
+func expectAuditEntry(mock sqlmock.Sqlmock, estateID uuid.UUID, action AuditAction, entityID uuid.UUID) {
+	mock.ExpectExec(insertAuditEntry).
+		WithArgs(sqlmock.AnyArg(), estateID, action, entityID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestCreateAuditEntry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+
+	t.Run("Actor & Request Of The Context", func(t *testing.T) {
+		ctx := telemetry.WithRequestId(context.Background(), "req-1")
+		ctx = WithActor(ctx, Actor{Name: "alice", SourceIp: "10.0.0.7"})
+
+		mock.ExpectExec(insertAuditEntry).
+			WithArgs(sqlmock.AnyArg(), estateID, AUDIT_ESTATE_RESIZED, estateID, "alice", "req-1", "10.0.0.7",
+				[]byte(`{"width":10,"length":10}`), []byte(`{"width":20,"length":10}`), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.createAuditEntry(ctx, db, estateID, AUDIT_ESTATE_RESIZED, estateID,
+			EstateAuditState{Width: 10, Length: 10}, EstateAuditState{Width: 20, Length: 10})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("System Change Without Previous State", func(t *testing.T) {
+		var previous *GeoReference
+		mock.ExpectExec(insertAuditEntry).
+			WithArgs(sqlmock.AnyArg(), estateID, AUDIT_ESTATE_GEO_UPDATED, estateID, ACTOR_SYSTEM, nil, nil,
+				nil, []byte(`{"origin_lat":2.1,"origin_lon":101.5,"rotation":0}`), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.createAuditEntry(context.Background(), db, estateID, AUDIT_ESTATE_GEO_UPDATED, estateID,
+			previous, GeoReference{OriginLat: 2.1, OriginLon: 101.5})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Deletion", func(t *testing.T) {
+		mock.ExpectExec(insertAuditEntry).
+			WithArgs(sqlmock.AnyArg(), estateID, AUDIT_ESTATE_DELETED, estateID, ACTOR_SYSTEM, nil, nil,
+				[]byte(`{"deleted":false}`), []byte(`{"deleted":true}`), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.createAuditEntry(context.Background(), db, estateID, AUDIT_ESTATE_DELETED, estateID,
+			EstateAuditState{Deleted: ptr.ToPointer(false)}, EstateAuditState{Deleted: ptr.ToPointer(true)})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetAuditEntries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	entryID := uuid.New()
+	treeID := uuid.New()
+	createdAt := time.Now()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	query := regexp.QuoteMeta(`SELECT id, estate_id, action, entity_id, actor, request_id, source_ip, before, after, created_at FROM audit_entries WHERE estate_id = $1 AND ($2 = '' OR action = $2) AND ($3 = '' OR actor = $3) AND ($4::timestamptz IS NULL OR created_at >= $4) AND ($5::timestamptz IS NULL OR created_at < $5) ORDER BY created_at DESC, id LIMIT $6 OFFSET $7;`)
+	columns := []string{"id", "estate_id", "action", "entity_id", "actor", "request_id", "source_ip", "before", "after", "created_at"}
+
+	tests := []struct {
+		name            string
+		input           GetAuditEntriesInput
+		mockSetup       func()
+		expectedEntries []AuditEntry
+		expectedError   error
+	}{
+		{
+			name:  "Success",
+			input: GetAuditEntriesInput{EstateId: estateID, Action: AUDIT_TREE_CREATED, Actor: "alice", From: &from, Limit: 20},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(estateID, AUDIT_TREE_CREATED, "alice", &from, nil, 20, 0).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(entryID, estateID, "tree.created", treeID, "alice", "req-1", "10.0.0.7", nil, []byte(`{"tree_id":"x"}`), createdAt))
+			},
+			expectedEntries: []AuditEntry{{
+				Id:        entryID,
+				EstateId:  estateID,
+				Action:    AUDIT_TREE_CREATED,
+				EntityId:  treeID,
+				Actor:     "alice",
+				RequestId: "req-1",
+				SourceIp:  "10.0.0.7",
+				After:     json.RawMessage(`{"tree_id":"x"}`),
+				CreatedAt: createdAt,
+			}},
+		},
+		{
+			name:  "No Entries",
+			input: GetAuditEntriesInput{EstateId: estateID, Limit: 20, Offset: 40},
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(estateID, AuditAction(""), "", nil, nil, 20, 40).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedEntries: []AuditEntry{},
+		},
+		{
+			name:  "Database Error",
+			input: GetAuditEntriesInput{EstateId: estateID, Limit: 20},
+			mockSetup: func() {
+				mock.ExpectQuery(query).WillReturnError(errors.New("db error"))
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to get audit entries: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			entries, err := repo.GetAuditEntries(context.Background(), tc.input)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedEntries, entries)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+)
+
+// ExportEstate read the whole estate & its history in a single snapshot, so the bundle is consistent
+// even when the estate is changed meanwhile
+func (r *Repository) ExportEstate(ctx context.Context, id uuid.UUID) (*EstateBundle, error) {
+	bundle := &EstateBundle{}
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := r.withTxOptions(ctx, opts, func(tx *sql.Tx) error {
+		estate, err := r.getEstateByIdSql(ctx, tx, id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", id), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get estate: %w", err)
+		}
+
+		if estate.Trees, err = r.getTreesByEstateId(ctx, tx, id); err != nil {
+			return fmt.Errorf("failed to get estate trees: %w", err)
+		}
+		if estate.Stats, err = r.getEstateStatsSQL(ctx, tx, id); err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("failed to get estate stats: %w", err)
+			}
+			estate.Stats = nil
+		}
+		if estate.ExclusionZones, err = r.getExclusionZonesByEstateIdSQL(ctx, tx, id); err != nil {
+			return fmt.Errorf("failed to get estate exclusion zones: %w", err)
+		}
+		if estate.Obstacles, err = r.getObstaclesByEstateIdSQL(ctx, tx, id); err != nil {
+			return fmt.Errorf("failed to get estate obstacles: %w", err)
+		}
+		bundle.Estate = *estate
+
+		if bundle.Harvests, err = r.getHarvestsSQL(ctx, tx, GetHarvestsInput{EstateId: id}); err != nil {
+			return fmt.Errorf("failed to get estate harvests: %w", err)
+		}
+		if bundle.AuditEntries, err = r.getAuditEntriesByEstateIdSQL(ctx, tx, id); err != nil {
+			return fmt.Errorf("failed to get estate audit entries: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return bundle, nil
+}
+
+// ImportEstate restore an estate bundle as it was exported, IDs, versions & timestamps included, or nothing of it.
+// The tile cells are aggregated again and the import itself is audited. An estate with the same ID,
+// a deleted one included, is a conflict
+func (r *Repository) ImportEstate(ctx context.Context, input ImportEstateInput) error {
+	estate := input.Bundle.Estate
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		created, err := r.createImportedEstateSQL(ctx, tx, &estate)
+		if err != nil {
+			return fmt.Errorf("failed to create estate: %w", err)
+		}
+		if !created {
+			return apperror.WrapWithCode(fmt.Errorf("estate with ID %s already exists", estate.Id), http.StatusConflict)
+		}
+
+		for _, tree := range estate.Trees {
+			if err := r.createImportedTreeSQL(ctx, tx, tree); err != nil {
+				return fmt.Errorf("failed to create tree: %w", err)
+			}
+		}
+		if estate.Stats != nil {
+			if err := r.createImportedEstateStatsSQL(ctx, tx, estate.Id, estate.Stats); err != nil {
+				return fmt.Errorf("failed to create estate stats: %w", err)
+			}
+		}
+		for _, zone := range estate.ExclusionZones {
+			if err := r.createImportedExclusionZoneSQL(ctx, tx, zone); err != nil {
+				return fmt.Errorf("failed to create exclusion zone: %w", err)
+			}
+		}
+		for _, obstacle := range estate.Obstacles {
+			if err := r.createImportedObstacleSQL(ctx, tx, obstacle); err != nil {
+				return fmt.Errorf("failed to create obstacle: %w", err)
+			}
+		}
+		for _, harvest := range input.Bundle.Harvests {
+			if err := r.createImportedHarvestSQL(ctx, tx, harvest); err != nil {
+				return fmt.Errorf("failed to create harvest: %w", err)
+			}
+		}
+		for _, entry := range input.Bundle.AuditEntries {
+			if err := r.createAuditEntrySQL(ctx, tx, entry); err != nil {
+				return fmt.Errorf("failed to create audit entry: %w", err)
+			}
+		}
+
+		if estate.MaxTileZoom() > 0 {
+			if err := r.rebuildTileCells(ctx, tx, &estate); err != nil {
+				return err
+			}
+		}
+
+		if err := r.createAuditEntry(ctx, tx, estate.Id, AUDIT_ESTATE_IMPORTED, estate.Id, nil, EstateAuditState{
+			Width:        estate.Width,
+			Length:       estate.Length,
+			Geo:          estate.Geo,
+			ImportedFrom: input.ImportedFrom,
+		}); err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, estate.Id, EVENT_ESTATE_IMPORTED, EstateImportedEvent{
+			EstateId:     estate.Id,
+			Width:        estate.Width,
+			Length:       estate.Length,
+			Trees:        len(estate.Trees),
+			ImportedFrom: input.ImportedFrom,
+		})
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// createImportedEstateSQL return false when an estate with the same ID exists, a deleted one included
+func (r *Repository) createImportedEstateSQL(ctx context.Context, exec dbExecutor, estate *Estate) (bool, error) {
+	var originLat, originLon sql.NullFloat64
+	var rotation float64
+	if estate.Geo != nil {
+		originLat = sql.NullFloat64{Float64: estate.Geo.OriginLat, Valid: true}
+		originLon = sql.NullFloat64{Float64: estate.Geo.OriginLon, Valid: true}
+		rotation = estate.Geo.Rotation
+	}
+
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO estates (id, width, length, version, origin_lat, origin_lon, rotation, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO NOTHING;`,
+		estate.Id, estate.Width, estate.Length, estate.Version, originLat, originLon, rotation, estate.CreatedAt, estate.UpdatedAt)
+	if err != nil {
+		return false, err
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowAffected > 0, nil
+}
+
+func (r *Repository) createImportedTreeSQL(ctx context.Context, exec dbExecutor, tree Tree) error {
+	tags := tree.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO trees (`+treeColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);`,
+		tree.Id, tree.EstateId, tree.X, tree.Y, tree.Height, tree.Species, tree.PlantedAt, tree.Health, pq.Array(tags),
+		tree.CreatedAt, tree.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	return expectRowAffected(res)
+}
+
+func (r *Repository) createImportedEstateStatsSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, stats *EstateStats) error {
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO estate_stats (id, estate_id, tree_count, max_height, min_height, median_height, drone_distance, drone_profile, drone_duration, drone_energy, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);`,
+		stats.Id,
+		estateID,
+		stats.TreeCount,
+		stats.MaxHeight,
+		stats.MinHeight,
+		stats.MedianHeight,
+		stats.DroneDistance,
+		stats.DroneProfile,
+		stats.DroneDuration,
+		stats.DroneEnergy,
+		stats.CreatedAt,
+		stats.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return expectRowAffected(res)
+}
+
+func (r *Repository) createImportedExclusionZoneSQL(ctx context.Context, exec dbExecutor, zone ExclusionZone) error {
+	points, err := json.Marshal(zone.Points)
+	if err != nil {
+		return fmt.Errorf("failed to encode exclusion zone points: %w", err)
+	}
+
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO exclusion_zones (id, estate_id, label, kind, points, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6);`,
+		zone.Id, zone.EstateId, zone.Label, zone.Kind, points, zone.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return expectRowAffected(res)
+}
+
+func (r *Repository) createImportedObstacleSQL(ctx context.Context, exec dbExecutor, obstacle Obstacle) error {
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO obstacles (id, estate_id, x, y, height, label, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`,
+		obstacle.Id, obstacle.EstateId, obstacle.X, obstacle.Y, obstacle.Height, obstacle.Label, obstacle.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return expectRowAffected(res)
+}
+
+func (r *Repository) createImportedHarvestSQL(ctx context.Context, exec dbExecutor, harvest Harvest) error {
+	plots, err := json.Marshal(harvest.Plots)
+	if err != nil {
+		return fmt.Errorf("failed to encode harvest plots: %w", err)
+	}
+
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO harvests (id, estate_id, tree_id, plots, harvested_at, weight_kg, bunch_count, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);`,
+		harvest.Id, harvest.EstateId, harvest.TreeId, plots, harvest.HarvestedAt, harvest.WeightKg, harvest.BunchCount, harvest.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return expectRowAffected(res)
+}
+
+func expectRowAffected(res sql.Result) error {
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowAffected < 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportEstate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID, treeID, entryID := uuid.New(), uuid.New(), uuid.New()
+	createdAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	selectEstate := regexp.QuoteMeta(`SELECT id, width, length, version, origin_lat, origin_lon, rotation, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL;`)
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectedBundle *EstateBundle
+		expectedError  error
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "width", "length", "version", "origin_lat", "origin_lon", "rotation", "created_at", "updated_at"}).
+						AddRow(estateID, 10, 20, 3, nil, nil, 0, createdAt, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT ` + treeColumns + ` FROM trees WHERE estate_id = $1;`)).WithArgs(estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "x", "y", "height", "species", "planted_at", "health", "tags", "created_at", "updated_at"}).
+						AddRow(treeID, estateID, 3, 4, 12, "", nil, TREE_HEALTHY, "{}", createdAt, nil))
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM estate_stats WHERE estate_id = $1;`)).WithArgs(estateID).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM exclusion_zones WHERE estate_id = $1`)).WithArgs(estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "label", "kind", "points", "created_at"}))
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM obstacles WHERE estate_id = $1`)).WithArgs(estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "x", "y", "height", "label", "created_at"}))
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM harvests WHERE estate_id = $1`)).WithArgs(estateID, nil, nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "tree_id", "plots", "harvested_at", "weight_kg", "bunch_count", "created_at"}))
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM audit_entries WHERE estate_id = $1 ORDER BY created_at, id;`)).WithArgs(estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "action", "entity_id", "actor", "request_id", "source_ip", "before", "after", "created_at"}).
+						AddRow(entryID, estateID, AUDIT_TREE_CREATED, treeID, "alice", "req-1", nil, nil, []byte(`{"x":3}`), createdAt))
+				mock.ExpectCommit()
+			},
+			expectedBundle: &EstateBundle{
+				Estate: Estate{
+					Id: estateID, Width: 10, Length: 20, Version: 3, CreatedAt: createdAt,
+					Trees: []Tree{{Id: treeID, EstateId: estateID, X: 3, Y: 4, Height: 12, Health: TREE_HEALTHY, Tags: []string{}, CreatedAt: createdAt}},
+				},
+				AuditEntries: []AuditEntry{{
+					Id: entryID, EstateId: estateID, Action: AUDIT_TREE_CREATED, EntityId: treeID, Actor: "alice", RequestId: "req-1",
+					After: json.RawMessage(`{"x":3}`), CreatedAt: createdAt,
+				}},
+			},
+		},
+		{
+			name: "Estate Not Found Or Deleted",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound),
+		},
+		{
+			name: "Database Error",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to get estate: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			bundle, err := repo.ExportEstate(context.Background(), estateID)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+				assert.Nil(t, bundle)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedBundle, bundle)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestImportEstate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID, sourceID, treeID := uuid.New(), uuid.New(), uuid.New()
+	createdAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	height := 40
+	bundle := EstateBundle{
+		Estate: Estate{
+			Id: estateID, Width: 10, Length: 20, Version: 3, CreatedAt: createdAt,
+			Trees: []Tree{{Id: treeID, EstateId: estateID, X: 3, Y: 4, Height: 12, Health: TREE_HEALTHY, CreatedAt: createdAt}},
+			Stats: &EstateStats{Id: uuid.New(), EstateID: estateID.String(), TreeCount: 1, CreatedAt: createdAt},
+			ExclusionZones: []ExclusionZone{{
+				Id: uuid.New(), EstateId: estateID, Kind: EXCLUSION_KIND_PLOTS, Points: []Point{{X: 1, Y: 1}}, CreatedAt: createdAt,
+			}},
+			Obstacles: []Obstacle{{Id: uuid.New(), EstateId: estateID, X: 5, Y: 5, Height: &height, CreatedAt: createdAt}},
+		},
+		Harvests: []Harvest{{
+			Id: uuid.New(), EstateId: estateID, TreeId: &treeID, Plots: []Point{{X: 3, Y: 4}}, HarvestedAt: createdAt, WeightKg: 20, CreatedAt: createdAt,
+		}},
+		AuditEntries: []AuditEntry{{
+			Id: uuid.New(), EstateId: estateID, Action: AUDIT_TREE_CREATED, EntityId: treeID, Actor: "alice",
+			After: json.RawMessage(`{"x":3}`), CreatedAt: createdAt,
+		}},
+	}
+	insertEstate := regexp.QuoteMeta(`INSERT INTO estates (id, width, length, version, origin_lat, origin_lon, rotation, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (id) DO NOTHING;`)
+
+	expectImportedEvent := func(payload string) {
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+			WithArgs(sqlmock.AnyArg(), estateID, EVENT_ESTATE_IMPORTED, jsonArg(payload), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+			WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	tests := []struct {
+		name          string
+		input         ImportEstateInput
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:  "Success",
+			input: ImportEstateInput{Bundle: bundle, ImportedFrom: &sourceID},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(insertEstate).
+					WithArgs(estateID, 10, 20, int64(3), nil, nil, float64(0), createdAt, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO trees (`+treeColumns+`)`)).
+					WithArgs(treeID, estateID, 3, 4, 12, "", nil, TREE_HEALTHY, pq.Array([]string{}), createdAt, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO estate_stats`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO exclusion_zones (id, estate_id, label, kind, points, created_at)`)).
+					WithArgs(bundle.Estate.ExclusionZones[0].Id, estateID, "", EXCLUSION_KIND_PLOTS, []byte(`[{"x":1,"y":1}]`), createdAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO obstacles (id, estate_id, x, y, height, label, created_at)`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO harvests (id, estate_id, tree_id, plots, harvested_at, weight_kg, bunch_count, created_at)`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(insertAuditEntry).
+					WithArgs(bundle.AuditEntries[0].Id, estateID, AUDIT_TREE_CREATED, treeID, "alice", nil, nil, nil, []byte(`{"x":3}`), createdAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(insertAuditEntry).
+					WithArgs(sqlmock.AnyArg(), estateID, AUDIT_ESTATE_IMPORTED, estateID, ACTOR_SYSTEM, nil, nil,
+						nil, []byte(`{"width":10,"length":20,"imported_from":"`+sourceID.String()+`"}`), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectImportedEvent(`{"estate_id":"` + estateID.String() + `","width":10,"length":20,"trees":1,"imported_from":"` + sourceID.String() + `"}`)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Tiles Of A Large Estate Are Rebuilt",
+			input: ImportEstateInput{Bundle: EstateBundle{Estate: Estate{Id: estateID, Width: 300, Length: 300, Version: 1, CreatedAt: createdAt}}},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(insertEstate).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM tile_cells WHERE estate_id = $1;`)).WithArgs(estateID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO tile_cells`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				expectAuditEntry(mock, estateID, AUDIT_ESTATE_IMPORTED, estateID)
+				expectImportedEvent(`{"estate_id":"` + estateID.String() + `","width":300,"length":300,"trees":0}`)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Estate Already Exists",
+			input: ImportEstateInput{Bundle: bundle},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(insertEstate).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s already exists", estateID), http.StatusConflict),
+		},
+		{
+			name:  "Nothing Is Kept When A Row Fails",
+			input: ImportEstateInput{Bundle: bundle},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(insertEstate).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO trees`)).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to create tree: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.ImportEstate(context.Background(), tc.input)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	"github.com/google/uuid"
+)
+
+// ResizeEstate change the estate size, trees outside the new boundary make the resize fail with
+// EstateResizeConflictError unless input.Force is set, in which case they are removed
+func (r *Repository) ResizeEstate(ctx context.Context, input ResizeEstateInput) (estate *Estate, removedTrees []Tree, err error) {
+	err = r.withTx(ctx, func(tx *sql.Tx) error {
+		// lock the estate so no tree is planted outside the new boundary while resizing
+		estate, err = r.getEstateForUpdateSQL(ctx, tx, input.Id)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", input.Id), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get estate: %w", err)
+		}
+
+		oldZoom := estate.MaxTileZoom()
+		before := EstateAuditState{Width: estate.Width, Length: estate.Length}
+		if input.Width != nil {
+			estate.Width = *input.Width
+		}
+		if input.Length != nil {
+			estate.Length = *input.Length
+		}
+
+		outside, err := r.getTreesOutsideBoundarySQL(ctx, tx, estate.Id, estate.Width, estate.Length)
+		if err != nil {
+			return fmt.Errorf("failed to get trees outside the new boundary: %w", err)
+		}
+
+		if len(outside) > 0 {
+			if !input.Force {
+				return apperror.WrapWithCode(&EstateResizeConflictError{Trees: outside}, http.StatusConflict)
+			}
+			if err = r.deleteTreesOutsideBoundarySQL(ctx, tx, estate.Id, estate.Width, estate.Length); err != nil {
+				return fmt.Errorf("failed to remove trees outside the new boundary: %w", err)
+			}
+		}
+
+		if err = r.updateEstateSizeSQL(ctx, tx, estate); err != nil {
+			return fmt.Errorf("failed to update estate: %w", err)
+		}
+		estate.Version++
+
+		// the cells of every zoom change with the max zoom, and the cells of the removed trees lose them
+		if newZoom := estate.MaxTileZoom(); (len(outside) > 0 || newZoom != oldZoom) && max(oldZoom, newZoom) > 0 {
+			if err = r.rebuildTileCells(ctx, tx, estate); err != nil {
+				return err
+			}
+		}
+
+		removedTrees = outside
+
+		err = r.createAuditEntry(ctx, tx, estate.Id, AUDIT_ESTATE_RESIZED, estate.Id, before, EstateAuditState{
+			Width:        estate.Width,
+			Length:       estate.Length,
+			RemovedTrees: len(outside),
+		})
+		if err != nil {
+			return err
+		}
+		// the removed trees are gone from the database, their audit entries keep what they were
+		for _, tree := range outside {
+			if err = r.createAuditEntry(ctx, tx, estate.Id, AUDIT_TREE_DELETED, tree.Id, NewTreeAuditState(tree), nil); err != nil {
+				return err
+			}
+		}
+
+		return r.createOutboxEvent(ctx, tx, estate.Id, EVENT_ESTATE_RESIZED, EstateResizedEvent{
+			EstateId:         estate.Id,
+			Width:            estate.Width,
+			Length:           estate.Length,
+			RemovedTreeCount: len(outside),
+		})
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return nil, nil, appErr
+		}
+		return nil, nil, apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return estate, removedTrees, nil
+}
+
+// DeleteEstate soft delete the estate, it is hidden from every read until restored
+func (r *Repository) DeleteEstate(ctx context.Context, id uuid.UUID) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		deleted, err := r.softDeleteEstateSQL(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete estate: %w", err)
+		}
+		if !deleted {
+			return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", id), http.StatusNotFound)
+		}
+
+		err = r.createAuditEntry(ctx, tx, id, AUDIT_ESTATE_DELETED, id, EstateAuditState{Deleted: ptr.ToPointer(false)}, EstateAuditState{Deleted: ptr.ToPointer(true)})
+		if err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, id, EVENT_ESTATE_DELETED, EstateLifecycleEvent{EstateId: id})
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// RestoreEstate bring back a soft deleted estate with its trees & stats
+func (r *Repository) RestoreEstate(ctx context.Context, id uuid.UUID) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		restored, err := r.restoreEstateSQL(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to restore estate: %w", err)
+		}
+		if !restored {
+			return apperror.WrapWithCode(fmt.Errorf("deleted estate with ID %s not found", id), http.StatusNotFound)
+		}
+
+		err = r.createAuditEntry(ctx, tx, id, AUDIT_ESTATE_RESTORED, id, EstateAuditState{Deleted: ptr.ToPointer(true)}, EstateAuditState{Deleted: ptr.ToPointer(false)})
+		if err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, id, EVENT_ESTATE_RESTORED, EstateLifecycleEvent{EstateId: id})
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// UpdateEstateGeo place the estate on the earth, replacing its previous geo-reference
+func (r *Repository) UpdateEstateGeo(ctx context.Context, id uuid.UUID, geo GeoReference) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		previous, found, err := r.updateEstateGeoSQL(ctx, tx, id, geo)
+		if err != nil {
+			return fmt.Errorf("failed to update estate geo-reference: %w", err)
+		}
+		if !found {
+			return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", id), http.StatusNotFound)
+		}
+
+		// before is null when the estate was not geo-referenced
+		if err = r.createAuditEntry(ctx, tx, id, AUDIT_ESTATE_GEO_UPDATED, id, previous, geo); err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, id, EVENT_ESTATE_GEO_UPDATED, EstateGeoUpdatedEvent{
+			EstateId:  id,
+			OriginLat: geo.OriginLat,
+			OriginLon: geo.OriginLon,
+			Rotation:  geo.Rotation,
+		})
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+func (r *Repository) getEstateForUpdateSQL(ctx context.Context, exec dbExecutor, id uuid.UUID) (estate *Estate, err error) {
+	estate = &Estate{}
+	err = exec.QueryRowContext(ctx, `
+		SELECT id, width, length, version, created_at, updated_at
+		FROM estates
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE;`, id).Scan(
+		&estate.Id,
+		&estate.Width,
+		&estate.Length,
+		&estate.Version,
+		&estate.CreatedAt,
+		&estate.UpdatedAt,
+	)
+	return
+}
+
+// bumpEstateVersionSQL mark a change of the trees, exclusion zones or obstacles of the estate,
+// the drone plans cached for the previous version are not served anymore
+func (r *Repository) bumpEstateVersionSQL(ctx context.Context, exec dbExecutor, id uuid.UUID) error {
+	_, err := exec.ExecContext(ctx, `
+		UPDATE estates
+		SET version = version + 1
+		WHERE id = $1;`, id)
+	return err
+}
+
+// getTreesOutsideBoundarySQL list the trees that would not fit in an estate of the given size
+func (r *Repository) getTreesOutsideBoundarySQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, width, length int) ([]Tree, error) {
+	rows, err := exec.QueryContext(ctx, `
+		SELECT `+treeColumns+`
+		FROM trees
+		WHERE estate_id = $1 AND (x > $2 OR y > $3)
+		ORDER BY y, x;`,
+		estateID, length, width)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTrees(rows)
+}
+
+func (r *Repository) deleteTreesOutsideBoundarySQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, width, length int) error {
+	_, err := exec.ExecContext(ctx, `
+		DELETE FROM trees
+		WHERE estate_id = $1 AND (x > $2 OR y > $3);`,
+		estateID, length, width)
+	return err
+}
+
+func (r *Repository) updateEstateSizeSQL(ctx context.Context, exec dbExecutor, estate *Estate) error {
+	res, err := exec.ExecContext(ctx, `
+		UPDATE estates
+		SET width = $2, length = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1;`,
+		estate.Id, estate.Width, estate.Length)
+	if err != nil {
+		return err
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowAffected < 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// softDeleteEstateSQL return false when the estate does not exist or is already deleted
+func (r *Repository) softDeleteEstateSQL(ctx context.Context, exec dbExecutor, id uuid.UUID) (bool, error) {
+	res, err := exec.ExecContext(ctx, `
+		UPDATE estates
+		SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+		WHERE id = $1 AND deleted_at IS NULL;`, id)
+	if err != nil {
+		return false, err
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowAffected > 0, nil
+}
+
+// restoreEstateSQL return false when the estate does not exist or is not deleted
+func (r *Repository) restoreEstateSQL(ctx context.Context, exec dbExecutor, id uuid.UUID) (bool, error) {
+	res, err := exec.ExecContext(ctx, `
+		UPDATE estates
+		SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deleted_at IS NOT NULL;`, id)
+	if err != nil {
+		return false, err
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowAffected > 0, nil
+}
+
+// updateEstateGeoSQL return the geo-reference the estate had before, found is false when the estate
+// does not exist or is deleted
+func (r *Repository) updateEstateGeoSQL(ctx context.Context, exec dbExecutor, id uuid.UUID, geo GeoReference) (previous *GeoReference, found bool, err error) {
+	var (
+		originLat, originLon sql.NullFloat64
+		rotation             float64
+	)
+	err = exec.QueryRowContext(ctx, `
+		UPDATE estates e
+		SET origin_lat = $2, origin_lon = $3, rotation = $4, version = e.version + 1, updated_at = CURRENT_TIMESTAMP
+		FROM (SELECT id, origin_lat, origin_lon, rotation FROM estates WHERE id = $1 AND deleted_at IS NULL FOR UPDATE) old
+		WHERE e.id = old.id
+		RETURNING old.origin_lat, old.origin_lon, old.rotation;`,
+		id, geo.OriginLat, geo.OriginLon, geo.Rotation).Scan(&originLat, &originLon, &rotation)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	if originLat.Valid && originLon.Valid {
+		previous = &GeoReference{OriginLat: originLat.Float64, OriginLon: originLon.Float64, Rotation: rotation}
+	}
+	return previous, true, nil
+}
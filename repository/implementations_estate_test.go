@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResizeEstate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	treeID := uuid.New()
+	createdAt := time.Now()
+	plantedAt := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	selectEstate := regexp.QuoteMeta(`SELECT id, width, length, version, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`)
+	selectOutside := regexp.QuoteMeta(`SELECT id, estate_id, x, y, height, species, planted_at, health, tags, created_at, updated_at FROM trees WHERE estate_id = $1 AND (x > $2 OR y > $3) ORDER BY y, x;`)
+	deleteOutside := regexp.QuoteMeta(`DELETE FROM trees WHERE estate_id = $1 AND (x > $2 OR y > $3);`)
+	updateEstate := regexp.QuoteMeta(`UPDATE estates SET width = $2, length = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = $1;`)
+
+	estateRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "width", "length", "version", "created_at", "updated_at"}).
+			AddRow(estateID, 10, 10, 1, createdAt, nil)
+	}
+	treeColumns := []string{"id", "estate_id", "x", "y", "height", "species", "planted_at", "health", "tags", "created_at", "updated_at"}
+	// removedTrees is the before state of the tree.deleted audit entries
+	expectEvent := func(payload string, removedTrees ...string) {
+		expectAuditEntry(mock, estateID, AUDIT_ESTATE_RESIZED, estateID)
+		for _, before := range removedTrees {
+			mock.ExpectExec(insertAuditEntry).
+				WithArgs(sqlmock.AnyArg(), estateID, AUDIT_TREE_DELETED, treeID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), jsonArg(before), sqlmock.AnyArg(), sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+			WithArgs(sqlmock.AnyArg(), estateID, EVENT_ESTATE_RESIZED, jsonArg(payload), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+			WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	tests := []struct {
+		name            string
+		input           ResizeEstateInput
+		mockSetup       func()
+		expectedEstate  *Estate
+		expectedRemoved []Tree
+		expectedError   error
+	}{
+		{
+			name:  "Success - Grow",
+			input: ResizeEstateInput{Id: estateID, Width: ptr.ToPointer(20)},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectOutside).WithArgs(estateID, 10, 20).WillReturnRows(sqlmock.NewRows(treeColumns))
+				mock.ExpectExec(updateEstate).WithArgs(estateID, 20, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectEvent(`{"estate_id":"` + estateID.String() + `","width":20,"length":10,"removed_tree_count":0}`)
+				mock.ExpectCommit()
+			},
+			expectedEstate: &Estate{Id: estateID, Width: 20, Length: 10, Version: 2, CreatedAt: createdAt},
+		},
+		{
+			name:  "Success - Force Shrink",
+			input: ResizeEstateInput{Id: estateID, Length: ptr.ToPointer(4), Force: true},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectOutside).WithArgs(estateID, 4, 10).
+					WillReturnRows(sqlmock.NewRows(treeColumns).AddRow(treeID, estateID, 5, 1, 7, "tenera", plantedAt, "diseased", "{north}", createdAt, nil))
+				mock.ExpectExec(deleteOutside).WithArgs(estateID, 4, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(updateEstate).WithArgs(estateID, 10, 4).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectEvent(`{"estate_id":"`+estateID.String()+`","width":10,"length":4,"removed_tree_count":1}`,
+					`{"tree_id":"`+treeID.String()+`","estate_id":"`+estateID.String()+`","x":5,"y":1,"height":7,"species":"tenera",`+
+						`"planted_at":"2023-06-01","health":"diseased","tags":["north"]}`)
+				mock.ExpectCommit()
+			},
+			expectedEstate: &Estate{Id: estateID, Width: 10, Length: 4, Version: 2, CreatedAt: createdAt},
+			expectedRemoved: []Tree{{
+				Id: treeID, EstateId: estateID, X: 5, Y: 1, Height: 7, Species: "tenera", PlantedAt: &plantedAt,
+				Health: TREE_DISEASED, Tags: []string{"north"}, CreatedAt: createdAt,
+			}},
+		},
+		{
+			name:  "Shrink Conflict Without Force",
+			input: ResizeEstateInput{Id: estateID, Length: ptr.ToPointer(4)},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectOutside).WithArgs(estateID, 4, 10).
+					WillReturnRows(sqlmock.NewRows(treeColumns).AddRow(treeID, estateID, 5, 1, 7, "", nil, "healthy", "{}", createdAt, nil))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(&EstateResizeConflictError{Trees: []Tree{{Id: treeID}}}, http.StatusConflict),
+		},
+		{
+			name:  "Estate Not Found",
+			input: ResizeEstateInput{Id: estateID, Width: ptr.ToPointer(20)},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound),
+		},
+		{
+			name:  "Update Error",
+			input: ResizeEstateInput{Id: estateID, Width: ptr.ToPointer(20)},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectOutside).WithArgs(estateID, 10, 20).WillReturnRows(sqlmock.NewRows(treeColumns))
+				mock.ExpectExec(updateEstate).WithArgs(estateID, 20, 10).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to update estate: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			estate, removed, err := repo.ResizeEstate(context.Background(), tc.input)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedEstate, estate)
+				assert.Equal(t, tc.expectedRemoved, removed)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("Conflict Error List Trees", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+		mock.ExpectQuery(selectOutside).WithArgs(estateID, 10, 2).
+			WillReturnRows(sqlmock.NewRows(treeColumns).AddRow(treeID, estateID, 1, 3, 7, "", nil, "healthy", "{}", createdAt, nil))
+		mock.ExpectRollback()
+
+		_, _, err := repo.ResizeEstate(context.Background(), ResizeEstateInput{Id: estateID, Width: ptr.ToPointer(2)})
+
+		var conflict *EstateResizeConflictError
+		assert.True(t, errors.As(err, &conflict))
+		assert.Equal(t, []Tree{{Id: treeID, EstateId: estateID, X: 1, Y: 3, Height: 7, Health: TREE_HEALTHY, Tags: []string{}, CreatedAt: createdAt}}, conflict.Trees)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteEstate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	softDelete := regexp.QuoteMeta(`UPDATE estates SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = $1 AND deleted_at IS NULL;`)
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(softDelete).WithArgs(estateID).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mock, estateID, AUDIT_ESTATE_DELETED, estateID)
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+					WithArgs(sqlmock.AnyArg(), estateID, EVENT_ESTATE_DELETED, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+					WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Estate Not Found Or Already Deleted",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(softDelete).WithArgs(estateID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound),
+		},
+		{
+			name: "Database Error",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(softDelete).WithArgs(estateID).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to delete estate: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.DeleteEstate(context.Background(), estateID)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRestoreEstate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	restore := regexp.QuoteMeta(`UPDATE estates SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NOT NULL;`)
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(restore).WithArgs(estateID).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mock, estateID, AUDIT_ESTATE_RESTORED, estateID)
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+					WithArgs(sqlmock.AnyArg(), estateID, EVENT_ESTATE_RESTORED, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+					WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Deleted Estate Not Found",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(restore).WithArgs(estateID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("deleted estate with ID %s not found", estateID), http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.RestoreEstate(context.Background(), estateID)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUpdateEstateGeo(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	geo := GeoReference{OriginLat: 2.1, OriginLon: 101.5, Rotation: 30}
+	update := regexp.QuoteMeta(`UPDATE estates e SET origin_lat = $2, origin_lon = $3, rotation = $4, version = e.version + 1, updated_at = CURRENT_TIMESTAMP FROM (SELECT id, origin_lat, origin_lon, rotation FROM estates WHERE id = $1 AND deleted_at IS NULL FOR UPDATE) old WHERE e.id = old.id RETURNING old.origin_lat, old.origin_lon, old.rotation;`)
+	previousRow := func(lat, lon any, rotation float64) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"origin_lat", "origin_lon", "rotation"}).AddRow(lat, lon, rotation)
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(update).WithArgs(estateID, 2.1, 101.5, 30.0).WillReturnRows(previousRow(nil, nil, 0))
+				expectAuditEntry(mock, estateID, AUDIT_ESTATE_GEO_UPDATED, estateID)
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+					WithArgs(sqlmock.AnyArg(), estateID, EVENT_ESTATE_GEO_UPDATED, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+					WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Estate Not Found",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(update).WithArgs(estateID, 2.1, 101.5, 30.0).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound),
+		},
+		{
+			name: "Database Error",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(update).WithArgs(estateID, 2.1, 101.5, 30.0).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to update estate geo-reference: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.UpdateEstateGeo(context.Background(), estateID, geo)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
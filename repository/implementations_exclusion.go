@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+)
+
+// CreateExclusionZone add an area where trees can't be planted, it fails with
+// ExclusionZoneConflictError when trees are already planted inside it
+func (r *Repository) CreateExclusionZone(ctx context.Context, input CreateExclusionZoneInput) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		// lock the estate so it is not resized while the zone is checked
+		estate, err := r.getEstateForUpdateSQL(ctx, tx, input.EstateId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", input.EstateId), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get estate: %w", err)
+		}
+
+		for _, p := range input.Points {
+			if p.X > estate.Length || p.Y > estate.Width {
+				return apperror.WrapWithCode(fmt.Errorf("point (%d,%d) is outside the %dx%d estate", p.X, p.Y, estate.Length, estate.Width), http.StatusBadRequest)
+			}
+		}
+
+		zone := ExclusionZone{Kind: input.Kind, Points: input.Points}
+		lo, hi := zone.Bounds()
+		candidates, err := r.getTreesInRectangleSQL(ctx, tx, input.EstateId, lo, hi)
+		if err != nil {
+			return fmt.Errorf("failed to get trees inside the exclusion zone: %w", err)
+		}
+
+		var inside []Tree
+		for _, tree := range candidates {
+			if zone.Contains(tree.X, tree.Y) {
+				inside = append(inside, tree)
+			}
+		}
+		if len(inside) > 0 {
+			return apperror.WrapWithCode(&ExclusionZoneConflictError{Trees: inside}, http.StatusConflict)
+		}
+
+		if err = r.createExclusionZoneSQL(ctx, tx, input); err != nil {
+			return fmt.Errorf("failed to create exclusion zone: %w", err)
+		}
+		if err = r.bumpEstateVersionSQL(ctx, tx, input.EstateId); err != nil {
+			return fmt.Errorf("failed to bump estate version: %w", err)
+		}
+
+		after := ExclusionZoneAuditState{
+			ZoneId:   input.Id,
+			EstateId: input.EstateId,
+			Label:    input.Label,
+			Kind:     input.Kind,
+			Points:   input.Points,
+		}
+		if err = r.createAuditEntry(ctx, tx, input.EstateId, AUDIT_EXCLUSION_CREATED, input.Id, nil, after); err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, input.EstateId, EVENT_EXCLUSION_ADDED, ExclusionZoneEvent{
+			ZoneId:     input.Id,
+			EstateId:   input.EstateId,
+			Label:      input.Label,
+			Kind:       input.Kind,
+			PointCount: len(input.Points),
+		})
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteExclusionZone(ctx context.Context, estateID, zoneID uuid.UUID) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		zone, err := r.deleteExclusionZoneSQL(ctx, tx, estateID, zoneID)
+		if err != nil {
+			return fmt.Errorf("failed to delete exclusion zone: %w", err)
+		}
+		if zone == nil {
+			return apperror.WrapWithCode(fmt.Errorf("exclusion zone with ID %s not found", zoneID), http.StatusNotFound)
+		}
+		if err = r.bumpEstateVersionSQL(ctx, tx, estateID); err != nil {
+			return fmt.Errorf("failed to bump estate version: %w", err)
+		}
+
+		before := ExclusionZoneAuditState{
+			ZoneId:   zoneID,
+			EstateId: estateID,
+			Label:    zone.Label,
+			Kind:     zone.Kind,
+			Points:   zone.Points,
+		}
+		if err = r.createAuditEntry(ctx, tx, estateID, AUDIT_EXCLUSION_DELETED, zoneID, before, nil); err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, estateID, EVENT_EXCLUSION_REMOVED, ExclusionZoneEvent{
+			ZoneId:   zoneID,
+			EstateId: estateID,
+		})
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
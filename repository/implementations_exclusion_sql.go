@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+func (r *Repository) getExclusionZonesByEstateIdSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID) ([]ExclusionZone, error) {
+	rows, err := exec.QueryContext(ctx, `
+		SELECT id, estate_id, label, kind, points, created_at
+		FROM exclusion_zones
+		WHERE estate_id = $1
+		ORDER BY created_at, id;`, estateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var zones []ExclusionZone
+	for rows.Next() {
+		var (
+			zone   ExclusionZone
+			points []byte
+		)
+		if err := rows.Scan(
+			&zone.Id,
+			&zone.EstateId,
+			&zone.Label,
+			&zone.Kind,
+			&points,
+			&zone.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan exclusion zone data: %w", err)
+		}
+		if err := json.Unmarshal(points, &zone.Points); err != nil {
+			return nil, fmt.Errorf("failed to decode exclusion zone points: %w", err)
+		}
+		zones = append(zones, zone)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return zones, nil
+}
+
+func (r *Repository) createExclusionZoneSQL(ctx context.Context, exec dbExecutor, input CreateExclusionZoneInput) error {
+	points, err := json.Marshal(input.Points)
+	if err != nil {
+		return fmt.Errorf("failed to encode exclusion zone points: %w", err)
+	}
+
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO exclusion_zones (id, estate_id, label, kind, points)
+		VALUES ($1, $2, $3, $4, $5);`,
+		input.Id, input.EstateId, input.Label, input.Kind, points)
+	if err != nil {
+		return err
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowAffected < 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// deleteExclusionZoneSQL return the deleted zone, nil when the zone does not exist in the estate
+func (r *Repository) deleteExclusionZoneSQL(ctx context.Context, exec dbExecutor, estateID, zoneID uuid.UUID) (*ExclusionZone, error) {
+	var (
+		zone   ExclusionZone
+		points []byte
+	)
+	err := exec.QueryRowContext(ctx, `
+		DELETE FROM exclusion_zones z
+		USING estates e
+		WHERE z.id = $1 AND z.estate_id = $2 AND e.id = z.estate_id AND e.deleted_at IS NULL
+		RETURNING z.id, z.estate_id, z.label, z.kind, z.points, z.created_at;`, zoneID, estateID).Scan(
+		&zone.Id,
+		&zone.EstateId,
+		&zone.Label,
+		&zone.Kind,
+		&points,
+		&zone.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(points, &zone.Points); err != nil {
+		return nil, fmt.Errorf("failed to decode exclusion zone points: %w", err)
+	}
+
+	return &zone, nil
+}
+
+// getTreesInRectangleSQL list the trees planted between lo & hi plots, both included
+func (r *Repository) getTreesInRectangleSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, lo, hi Point) ([]Tree, error) {
+	rows, err := exec.QueryContext(ctx, `
+		SELECT `+treeColumns+`
+		FROM trees
+		WHERE estate_id = $1 AND x BETWEEN $2 AND $3 AND y BETWEEN $4 AND $5
+		ORDER BY y, x;`,
+		estateID, lo.X, hi.X, lo.Y, hi.Y)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTrees(rows)
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateExclusionZone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	zoneID := uuid.New()
+	createdAt := time.Now()
+
+	selectEstate := regexp.QuoteMeta(`SELECT id, width, length, version, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`)
+	selectTrees := regexp.QuoteMeta(`SELECT id, estate_id, x, y, height, species, planted_at, health, tags, created_at, updated_at FROM trees WHERE estate_id = $1 AND x BETWEEN $2 AND $3 AND y BETWEEN $4 AND $5 ORDER BY y, x;`)
+	insertZone := regexp.QuoteMeta(`INSERT INTO exclusion_zones (id, estate_id, label, kind, points) VALUES ($1, $2, $3, $4, $5);`)
+	treeColumns := []string{"id", "estate_id", "x", "y", "height", "species", "planted_at", "health", "tags", "created_at", "updated_at"}
+
+	estateRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "width", "length", "version", "created_at", "updated_at"}).
+			AddRow(estateID, 10, 10, 1, createdAt, nil)
+	}
+	// triangle, (1,3) is in its bounding box but outside of it
+	input := CreateExclusionZoneInput{
+		Id:       zoneID,
+		EstateId: estateID,
+		Label:    "river",
+		Kind:     EXCLUSION_KIND_POLYGON,
+		Points:   []Point{{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 3}},
+	}
+
+	tests := []struct {
+		name          string
+		input         CreateExclusionZoneInput
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:  "Success",
+			input: input,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectTrees).WithArgs(estateID, 1, 3, 1, 3).
+					WillReturnRows(sqlmock.NewRows(treeColumns).AddRow(uuid.New(), estateID, 1, 3, 5, "", nil, "healthy", "{}", createdAt, nil))
+				mock.ExpectExec(insertZone).
+					WithArgs(zoneID, estateID, "river", EXCLUSION_KIND_POLYGON, []byte(`[{"x":1,"y":1},{"x":3,"y":1},{"x":3,"y":3}]`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE estates SET version = version + 1 WHERE id = $1;`)).
+					WithArgs(estateID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mock, estateID, AUDIT_EXCLUSION_CREATED, zoneID)
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+					WithArgs(sqlmock.AnyArg(), estateID, EVENT_EXCLUSION_ADDED,
+						jsonArg(`{"zone_id":"`+zoneID.String()+`","estate_id":"`+estateID.String()+`","label":"river","kind":"polygon","point_count":3}`), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+					WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Trees Inside Zone",
+			input: input,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectTrees).WithArgs(estateID, 1, 3, 1, 3).
+					WillReturnRows(sqlmock.NewRows(treeColumns).AddRow(uuid.New(), estateID, 2, 2, 5, "", nil, "healthy", "{}", createdAt, nil))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(&ExclusionZoneConflictError{Trees: make([]Tree, 1)}, http.StatusConflict),
+		},
+		{
+			name: "Point Outside Estate",
+			input: CreateExclusionZoneInput{
+				Id:       zoneID,
+				EstateId: estateID,
+				Kind:     EXCLUSION_KIND_PLOTS,
+				Points:   []Point{{X: 11, Y: 1}},
+			},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(errors.New("point (11,1) is outside the 10x10 estate"), http.StatusBadRequest),
+		},
+		{
+			name:  "Estate Not Found",
+			input: input,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound),
+		},
+		{
+			name:  "Insert Error",
+			input: input,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectTrees).WithArgs(estateID, 1, 3, 1, 3).WillReturnRows(sqlmock.NewRows(treeColumns))
+				mock.ExpectExec(insertZone).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to create exclusion zone: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.CreateExclusionZone(context.Background(), tc.input)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteExclusionZone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	zoneID := uuid.New()
+	deleteZone := regexp.QuoteMeta(`DELETE FROM exclusion_zones z USING estates e WHERE z.id = $1 AND z.estate_id = $2 AND e.id = z.estate_id AND e.deleted_at IS NULL RETURNING z.id, z.estate_id, z.label, z.kind, z.points, z.created_at;`)
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(deleteZone).WithArgs(zoneID, estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "label", "kind", "points", "created_at"}).
+						AddRow(zoneID, estateID, "river", EXCLUSION_KIND_PLOTS, []byte(`[{"x":1,"y":1}]`), time.Now()))
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE estates SET version = version + 1 WHERE id = $1;`)).
+					WithArgs(estateID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mock, estateID, AUDIT_EXCLUSION_DELETED, zoneID)
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+					WithArgs(sqlmock.AnyArg(), estateID, EVENT_EXCLUSION_REMOVED, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+					WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Zone Not Found",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(deleteZone).WithArgs(zoneID, estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("exclusion zone with ID %s not found", zoneID), http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.DeleteExclusionZone(context.Background(), estateID, zoneID)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
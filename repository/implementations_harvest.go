@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+)
+
+// CreateHarvest record the fruit bunches harvested from a tree, or from a region of plots when input.TreeId is nil
+func (r *Repository) CreateHarvest(ctx context.Context, input CreateHarvestInput) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		// lock the estate so it is not resized while the plots are checked
+		estate, err := r.getEstateForUpdateSQL(ctx, tx, input.EstateId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", input.EstateId), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get estate: %w", err)
+		}
+
+		if input.TreeId != nil {
+			plot, err := r.getTreePlotSQL(ctx, tx, input.EstateId, *input.TreeId)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return apperror.WrapWithCode(fmt.Errorf("tree with ID %s not found", *input.TreeId), http.StatusNotFound)
+				}
+				return fmt.Errorf("failed to get tree: %w", err)
+			}
+			input.Plots = []Point{plot}
+		}
+		for _, p := range input.Plots {
+			if p.X > estate.Length || p.Y > estate.Width {
+				return apperror.WrapWithCode(fmt.Errorf("plot (%d,%d) is outside the %dx%d estate", p.X, p.Y, estate.Length, estate.Width), http.StatusBadRequest)
+			}
+		}
+
+		// the drone plan doesn't depend on the harvests, the estate version is kept
+		if err = r.createHarvestSQL(ctx, tx, input); err != nil {
+			return fmt.Errorf("failed to create harvest: %w", err)
+		}
+
+		after := HarvestAuditState{
+			HarvestId:   input.Id,
+			EstateId:    input.EstateId,
+			TreeId:      input.TreeId,
+			Plots:       input.Plots,
+			HarvestedAt: input.HarvestedAt.Format(time.DateOnly),
+			WeightKg:    input.WeightKg,
+			BunchCount:  input.BunchCount,
+		}
+		if err = r.createAuditEntry(ctx, tx, input.EstateId, AUDIT_HARVEST_CREATED, input.Id, nil, after); err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, input.EstateId, EVENT_HARVEST_RECORDED, HarvestRecordedEvent{
+			HarvestId:   input.Id,
+			EstateId:    input.EstateId,
+			TreeId:      input.TreeId,
+			PlotCount:   len(input.Plots),
+			HarvestedAt: after.HarvestedAt,
+			WeightKg:    input.WeightKg,
+			BunchCount:  input.BunchCount,
+		})
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// GetHarvests list the harvests of an estate by date, the estate existence is not checked
+func (r *Repository) GetHarvests(ctx context.Context, input GetHarvestsInput) ([]Harvest, error) {
+	harvests, err := r.getHarvestsSQL(ctx, r.Db, input)
+	if err != nil {
+		return nil, apperror.WrapWithCode(fmt.Errorf("failed to get harvests: %w", err), http.StatusInternalServerError)
+	}
+
+	return harvests, nil
+}
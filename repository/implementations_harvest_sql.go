@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+func (r *Repository) getTreePlotSQL(ctx context.Context, exec dbExecutor, estateID, treeID uuid.UUID) (plot Point, err error) {
+	err = exec.QueryRowContext(ctx, `
+		SELECT x, y
+		FROM trees
+		WHERE id = $1 AND estate_id = $2;`, treeID, estateID).Scan(&plot.X, &plot.Y)
+	return
+}
+
+func (r *Repository) createHarvestSQL(ctx context.Context, exec dbExecutor, input CreateHarvestInput) error {
+	plots, err := json.Marshal(input.Plots)
+	if err != nil {
+		return fmt.Errorf("failed to encode harvest plots: %w", err)
+	}
+
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO harvests (id, estate_id, tree_id, plots, harvested_at, weight_kg, bunch_count)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`,
+		input.Id, input.EstateId, input.TreeId, plots, input.HarvestedAt, input.WeightKg, input.BunchCount)
+	if err != nil {
+		return err
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowAffected < 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (r *Repository) getHarvestsSQL(ctx context.Context, exec dbExecutor, input GetHarvestsInput) ([]Harvest, error) {
+	rows, err := exec.QueryContext(ctx, `
+		SELECT id, estate_id, tree_id, plots, harvested_at, weight_kg, bunch_count, created_at
+		FROM harvests
+		WHERE estate_id = $1
+			AND ($2::date IS NULL OR harvested_at >= $2)
+			AND ($3::date IS NULL OR harvested_at <= $3)
+		ORDER BY harvested_at, created_at, id;`,
+		input.EstateId, input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var harvests []Harvest
+	for rows.Next() {
+		var (
+			harvest Harvest
+			plots   []byte
+		)
+		if err := rows.Scan(
+			&harvest.Id,
+			&harvest.EstateId,
+			&harvest.TreeId,
+			&plots,
+			&harvest.HarvestedAt,
+			&harvest.WeightKg,
+			&harvest.BunchCount,
+			&harvest.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan harvest data: %w", err)
+		}
+		if err := json.Unmarshal(plots, &harvest.Plots); err != nil {
+			return nil, fmt.Errorf("failed to decode harvest plots: %w", err)
+		}
+		harvests = append(harvests, harvest)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return harvests, nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateHarvest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	harvestID := uuid.New()
+	treeID := uuid.New()
+	createdAt := time.Now()
+	harvestedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	selectEstate := regexp.QuoteMeta(`SELECT id, width, length, version, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`)
+	selectTree := regexp.QuoteMeta(`SELECT x, y FROM trees WHERE id = $1 AND estate_id = $2;`)
+	insertHarvest := regexp.QuoteMeta(`INSERT INTO harvests (id, estate_id, tree_id, plots, harvested_at, weight_kg, bunch_count) VALUES ($1, $2, $3, $4, $5, $6, $7);`)
+
+	estateRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "width", "length", "version", "created_at", "updated_at"}).
+			AddRow(estateID, 10, 10, 1, createdAt, nil)
+	}
+	expectEvent := func(payload string) {
+		expectAuditEntry(mock, estateID, AUDIT_HARVEST_CREATED, harvestID)
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+			WithArgs(sqlmock.AnyArg(), estateID, EVENT_HARVEST_RECORDED, jsonArg(payload), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+			WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	regionInput := CreateHarvestInput{
+		Id:          harvestID,
+		EstateId:    estateID,
+		Plots:       []Point{{X: 1, Y: 1}, {X: 2, Y: 1}},
+		HarvestedAt: harvestedAt,
+		WeightKg:    120.5,
+		BunchCount:  6,
+	}
+
+	tests := []struct {
+		name          string
+		input         CreateHarvestInput
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:  "Region Harvest",
+			input: regionInput,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectExec(insertHarvest).
+					WithArgs(harvestID, estateID, nil, []byte(`[{"x":1,"y":1},{"x":2,"y":1}]`), harvestedAt, 120.5, 6).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectEvent(`{"harvest_id":"` + harvestID.String() + `","estate_id":"` + estateID.String() + `","plot_count":2,"harvested_at":"2024-03-01","weight_kg":120.5,"bunch_count":6}`)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Tree Harvest",
+			input: CreateHarvestInput{Id: harvestID, EstateId: estateID, TreeId: &treeID, HarvestedAt: harvestedAt, WeightKg: 25, BunchCount: 1},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectTree).WithArgs(treeID, estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"x", "y"}).AddRow(4, 5))
+				mock.ExpectExec(insertHarvest).
+					WithArgs(harvestID, estateID, &treeID, []byte(`[{"x":4,"y":5}]`), harvestedAt, 25.0, 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectEvent(`{"harvest_id":"` + harvestID.String() + `","estate_id":"` + estateID.String() + `","tree_id":"` + treeID.String() + `","plot_count":1,"harvested_at":"2024-03-01","weight_kg":25,"bunch_count":1}`)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Tree Not Found",
+			input: CreateHarvestInput{Id: harvestID, EstateId: estateID, TreeId: &treeID, HarvestedAt: harvestedAt, WeightKg: 25},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectTree).WithArgs(treeID, estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("tree with ID %s not found", treeID), http.StatusNotFound),
+		},
+		{
+			name:  "Plot Outside Estate",
+			input: CreateHarvestInput{Id: harvestID, EstateId: estateID, Plots: []Point{{X: 1, Y: 1}, {X: 11, Y: 2}}, WeightKg: 25},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(errors.New("plot (11,2) is outside the 10x10 estate"), http.StatusBadRequest),
+		},
+		{
+			name:  "Estate Not Found",
+			input: regionInput,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound),
+		},
+		{
+			name:  "Insert Error",
+			input: regionInput,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectExec(insertHarvest).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to create harvest: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.CreateHarvest(context.Background(), tc.input)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetHarvests(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	harvestID := uuid.New()
+	treeID := uuid.New()
+	createdAt := time.Now()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	harvestedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	selectHarvests := regexp.QuoteMeta(`SELECT id, estate_id, tree_id, plots, harvested_at, weight_kg, bunch_count, created_at FROM harvests WHERE estate_id = $1 AND ($2::date IS NULL OR harvested_at >= $2) AND ($3::date IS NULL OR harvested_at <= $3) ORDER BY harvested_at, created_at, id;`)
+	columns := []string{"id", "estate_id", "tree_id", "plots", "harvested_at", "weight_kg", "bunch_count", "created_at"}
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expected      []Harvest
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mock.ExpectQuery(selectHarvests).WithArgs(estateID, &from, nil).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(harvestID, estateID, treeID, []byte(`[{"x":4,"y":5}]`), harvestedAt, 25.5, 2, createdAt).
+						AddRow(harvestID, estateID, nil, []byte(`[{"x":1,"y":1},{"x":2,"y":1}]`), harvestedAt, 100.0, 5, createdAt))
+			},
+			expected: []Harvest{
+				{Id: harvestID, EstateId: estateID, TreeId: &treeID, Plots: []Point{{X: 4, Y: 5}}, HarvestedAt: harvestedAt, WeightKg: 25.5, BunchCount: 2, CreatedAt: createdAt},
+				{Id: harvestID, EstateId: estateID, Plots: []Point{{X: 1, Y: 1}, {X: 2, Y: 1}}, HarvestedAt: harvestedAt, WeightKg: 100, BunchCount: 5, CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "Query Error",
+			mockSetup: func() {
+				mock.ExpectQuery(selectHarvests).WithArgs(estateID, &from, nil).WillReturnError(errors.New("db error"))
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to get harvests: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			harvests, err := repo.GetHarvests(context.Background(), GetHarvestsInput{EstateId: estateID, From: &from})
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, harvests)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
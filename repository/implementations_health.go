@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+)
+
+// requiredTables is every table of database.sql, the schema is applied when all of them exist.
+// Keep it in sync when a table is added
+var requiredTables = []string{
+	"estates",
+	"trees",
+	"estate_stats",
+	"outbox_events",
+	"webhook_subscribers",
+	"webhook_deliveries",
+	"exclusion_zones",
+	"obstacles",
+	"harvests",
+	"jobs",
+	"drone_plan_cache",
+	"tile_cells",
+	"audit_entries",
+}
+
+// requiredColumns is every column database.sql adds with ALTER TABLE, by table. A database created before
+// them still has every table, so the schema is only applied when these columns exist too.
+// Keep it in sync when a column is added
+var requiredColumns = map[string][]string{
+	"estates":      {"deleted_at", "version", "origin_lat", "origin_lon", "rotation"},
+	"trees":        {"species", "planted_at", "health", "tags"},
+	"estate_stats": {"drone_profile", "drone_duration", "drone_energy"},
+}
+
+func (r *Repository) Ping(ctx context.Context) error {
+	if err := r.Db.PingContext(ctx); err != nil {
+		return apperror.WrapWithCode(fmt.Errorf("failed to ping database: %w", err), http.StatusServiceUnavailable)
+	}
+	return nil
+}
+
+// CheckSchema return an error when a table or a column of database.sql is missing
+func (r *Repository) CheckSchema(ctx context.Context) error {
+	missing, err := r.getMissingTablesSQL(ctx, requiredTables)
+	if err != nil {
+		return apperror.WrapWithCode(fmt.Errorf("failed to check schema: %w", err), http.StatusServiceUnavailable)
+	}
+
+	if len(missing) > 0 {
+		return apperror.WrapWithCode(fmt.Errorf("missing tables: %s", strings.Join(missing, ", ")), http.StatusServiceUnavailable)
+	}
+
+	missing, err = r.getMissingColumnsSQL(ctx, requiredColumns)
+	if err != nil {
+		return apperror.WrapWithCode(fmt.Errorf("failed to check schema: %w", err), http.StatusServiceUnavailable)
+	}
+
+	if len(missing) > 0 {
+		return apperror.WrapWithCode(fmt.Errorf("missing columns: %s", strings.Join(missing, ", ")), http.StatusServiceUnavailable)
+	}
+
+	return nil
+}
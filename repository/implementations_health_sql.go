@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/lib/pq"
+)
+
+func (r *Repository) getMissingTablesSQL(ctx context.Context, tables []string) (missing []string, err error) {
+	rows, err := r.Db.QueryContext(ctx, `
+		SELECT t.name
+		FROM unnest($1::text[]) AS t(name)
+		WHERE to_regclass(t.name) IS NULL
+		ORDER BY t.name;`,
+		pq.Array(tables))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		missing = append(missing, name)
+	}
+
+	return missing, rows.Err()
+}
+
+// getMissingColumnsSQL return the columns absent from the tables of the current schema, as table.column
+func (r *Repository) getMissingColumnsSQL(ctx context.Context, columns map[string][]string) (missing []string, err error) {
+	sorted := make([]string, 0, len(columns))
+	for table := range columns {
+		sorted = append(sorted, table)
+	}
+	sort.Strings(sorted)
+
+	var tables, names []string
+	for _, table := range sorted {
+		for _, column := range columns[table] {
+			tables = append(tables, table)
+			names = append(names, column)
+		}
+	}
+
+	rows, err := r.Db.QueryContext(ctx, `
+		SELECT c.table_name || '.' || c.column_name
+		FROM unnest($1::text[], $2::text[]) AS c(table_name, column_name)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM information_schema.columns ic
+			WHERE ic.table_schema = current_schema() AND ic.table_name = c.table_name AND ic.column_name = c.column_name
+		)
+		ORDER BY 1;`,
+		pq.Array(tables), pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		missing = append(missing, name)
+	}
+
+	return missing, rows.Err()
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSchema(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	query := regexp.QuoteMeta(`SELECT t.name FROM unnest($1::text[]) AS t(name) WHERE to_regclass(t.name) IS NULL ORDER BY t.name;`)
+	columnsQuery := `SELECT c.table_name \|\| '.' \|\| c.column_name FROM unnest\(\$1::text\[\], \$2::text\[\]\) AS c\(table_name, column_name\) WHERE NOT EXISTS .+ information_schema.columns`
+	tables := []string{
+		"estate_stats", "estate_stats", "estate_stats",
+		"estates", "estates", "estates", "estates", "estates",
+		"trees", "trees", "trees", "trees",
+	}
+	columns := []string{
+		"drone_profile", "drone_duration", "drone_energy",
+		"deleted_at", "version", "origin_lat", "origin_lon", "rotation",
+		"species", "planted_at", "health", "tags",
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "Every Table Exist",
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(pq.Array(requiredTables)).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+				mock.ExpectQuery(columnsQuery).
+					WithArgs(pq.Array(tables), pq.Array(columns)).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+			},
+		},
+		{
+			name: "Missing Columns",
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(pq.Array(requiredTables)).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+				mock.ExpectQuery(columnsQuery).
+					WithArgs(pq.Array(tables), pq.Array(columns)).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("estates.deleted_at").AddRow("trees.tags"))
+			},
+			expectedError: apperror.WrapWithCode(errors.New("missing columns: estates.deleted_at, trees.tags"), http.StatusServiceUnavailable),
+		},
+		{
+			name: "Missing Tables",
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(pq.Array(requiredTables)).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("webhook_deliveries").AddRow("webhook_subscribers"))
+			},
+			expectedError: apperror.WrapWithCode(errors.New("missing tables: webhook_deliveries, webhook_subscribers"), http.StatusServiceUnavailable),
+		},
+		{
+			name: "Columns Query Error",
+			mockSetup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(pq.Array(requiredTables)).
+					WillReturnRows(sqlmock.NewRows([]string{"name"}))
+				mock.ExpectQuery(columnsQuery).WillReturnError(errors.New("connection refused"))
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to check schema: %w", errors.New("connection refused")), http.StatusServiceUnavailable),
+		},
+		{
+			name: "Query Error",
+			mockSetup: func() {
+				mock.ExpectQuery(query).WillReturnError(errors.New("connection refused"))
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to check schema: %w", errors.New("connection refused")), http.StatusServiceUnavailable),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.CheckSchema(context.Background())
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+)
+
+func (r *Repository) CreateJob(ctx context.Context, input CreateJobInput) error {
+	if err := r.createJobSQL(ctx, input); err != nil {
+		return apperror.WrapWithCode(fmt.Errorf("failed to create job: %w", err), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetJob(ctx context.Context, id uuid.UUID) (*Job, error) {
+	job, err := r.getJobSQL(ctx, r.Db, id, false)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.WrapWithCode(fmt.Errorf("job with ID %s not found", id), http.StatusNotFound)
+		}
+		return nil, apperror.WrapWithCode(fmt.Errorf("failed to get job: %w", err), http.StatusInternalServerError)
+	}
+
+	return job, nil
+}
+
+// ClaimJob pick the oldest due job, nil when there is none. A running job whose lease expired,
+// because its worker died, is picked again
+func (r *Repository) ClaimJob(ctx context.Context, input ClaimJobInput) (*Job, error) {
+	job, err := r.claimJobSQL(ctx, input)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperror.WrapWithCode(fmt.Errorf("failed to claim job: %w", err), http.StatusInternalServerError)
+	}
+
+	return job, nil
+}
+
+// HeartbeatJob save the progress of a running job and extend its lease. cancelRequested is also true
+// when the job is no longer running or its lease was lost, so the worker stop it
+func (r *Repository) HeartbeatJob(ctx context.Context, input HeartbeatJobInput) (cancelRequested bool, err error) {
+	cancelRequested, err = r.heartbeatJobSQL(ctx, input)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return true, nil
+		}
+		return false, apperror.WrapWithCode(fmt.Errorf("failed to heartbeat job: %w", err), http.StatusInternalServerError)
+	}
+
+	return cancelRequested, nil
+}
+
+func (r *Repository) FinishJob(ctx context.Context, input FinishJobInput) error {
+	if err := r.finishJobSQL(ctx, input); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.WrapWithCode(fmt.Errorf("job %s lease was lost, its outcome is discarded", input.Id), http.StatusConflict)
+		}
+		return apperror.WrapWithCode(fmt.Errorf("failed to finish job: %w", err), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// CancelJob cancel a queued job right away, a running job is cancelled by its worker on the next heartbeat
+func (r *Repository) CancelJob(ctx context.Context, id uuid.UUID) (job *Job, err error) {
+	err = r.withTx(ctx, func(tx *sql.Tx) error {
+		job, err = r.getJobSQL(ctx, tx, id, true)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("job with ID %s not found", id), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get job: %w", err)
+		}
+
+		if job.Status.IsFinished() {
+			return apperror.WrapWithCode(fmt.Errorf("job %s is already %s", id, job.Status), http.StatusConflict)
+		}
+
+		if job, err = r.cancelJobSQL(ctx, tx, id); err != nil {
+			return fmt.Errorf("failed to cancel job: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return job, nil
+}
@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const jobColumns = `id, job_type, estate_id, params, status, progress, result, attempts, max_attempts, last_error,
+	cancel_requested, run_at, created_at, started_at, finished_at, updated_at`
+
+// rowScanner is *sql.Row or *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanJob(row rowScanner) (*Job, error) {
+	var (
+		job    Job
+		params []byte
+		result []byte
+	)
+	err := row.Scan(
+		&job.Id,
+		&job.Type,
+		&job.EstateId,
+		&params,
+		&job.Status,
+		&job.Progress,
+		&result,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.LastError,
+		&job.CancelRequested,
+		&job.RunAt,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.Params = params
+	job.Result = result
+	return &job, nil
+}
+
+func (r *Repository) createJobSQL(ctx context.Context, input CreateJobInput) error {
+	res, err := r.Db.ExecContext(ctx, `
+		INSERT INTO jobs (id, job_type, estate_id, params, max_attempts)
+		VALUES ($1, $2, $3, $4, $5);`,
+		input.Id, input.Type, input.EstateId, []byte(input.Params), input.MaxAttempts)
+	if err != nil {
+		return err
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowAffected < 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// getJobSQL lock the job row until the transaction ends when forUpdate
+func (r *Repository) getJobSQL(ctx context.Context, exec dbExecutor, id uuid.UUID, forUpdate bool) (*Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+	if forUpdate {
+		query += ` FOR UPDATE`
+	}
+	return scanJob(exec.QueryRowContext(ctx, query+`;`, id))
+}
+
+// claimJobSQL mark the oldest due job running and push its lease, jobs locked by another worker are skipped
+func (r *Repository) claimJobSQL(ctx context.Context, input ClaimJobInput) (*Job, error) {
+	query := `
+		UPDATE jobs
+		SET status = 'running',
+			attempts = attempts + 1,
+			locked_until = CURRENT_TIMESTAMP + ($1 * INTERVAL '1 millisecond'),
+			started_at = COALESCE(started_at, CURRENT_TIMESTAMP),
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id
+			FROM jobs
+			WHERE (status = 'queued' AND run_at <= CURRENT_TIMESTAMP)
+				OR (status = 'running' AND locked_until < CURRENT_TIMESTAMP)
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns + `;`
+	return scanJob(r.Db.QueryRowContext(ctx, query, input.Lease.Milliseconds()))
+}
+
+// heartbeatJobSQL return sql.ErrNoRows when the job is not running anymore or was claimed by another worker
+func (r *Repository) heartbeatJobSQL(ctx context.Context, input HeartbeatJobInput) (cancelRequested bool, err error) {
+	err = r.Db.QueryRowContext(ctx, `
+		UPDATE jobs
+		SET progress = $2, locked_until = CURRENT_TIMESTAMP + ($3 * INTERVAL '1 millisecond'), updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'running' AND attempts = $4
+		RETURNING cancel_requested;`,
+		input.Id, input.Progress, input.Lease.Milliseconds(), input.Attempts).Scan(&cancelRequested)
+	return
+}
+
+// finishJobSQL return sql.ErrNoRows when the lease was lost, the job then belongs to another worker
+func (r *Repository) finishJobSQL(ctx context.Context, input FinishJobInput) error {
+	// jsonb column, a nil result must be sent as NULL and not as an empty value
+	var result any
+	if len(input.Result) > 0 {
+		result = []byte(input.Result)
+	}
+
+	res, err := r.Db.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = $2,
+			progress = $3,
+			result = $4,
+			last_error = $5,
+			run_at = $6,
+			locked_until = NULL,
+			finished_at = CASE WHEN $2 IN ('succeeded', 'failed', 'cancelled') THEN CURRENT_TIMESTAMP END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = 'running' AND attempts = $7;`,
+		input.Id, input.Status, input.Progress, result, input.LastError, input.RunAt, input.Attempts)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// cancelJobSQL cancel a queued job, a running one is only flagged for its worker
+func (r *Repository) cancelJobSQL(ctx context.Context, exec dbExecutor, id uuid.UUID) (*Job, error) {
+	return scanJob(exec.QueryRowContext(ctx, `
+		UPDATE jobs
+		SET status = CASE WHEN status = 'queued' THEN 'cancelled' ELSE status END,
+			finished_at = CASE WHEN status = 'queued' THEN CURRENT_TIMESTAMP ELSE finished_at END,
+			cancel_requested = TRUE,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING `+jobColumns+`;`, id))
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var jobRowColumns = []string{"id", "job_type", "estate_id", "params", "status", "progress", "result", "attempts", "max_attempts", "last_error",
+	"cancel_requested", "run_at", "created_at", "started_at", "finished_at", "updated_at"}
+
+func TestCreateJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	input := CreateJobInput{
+		Id:          uuid.New(),
+		Type:        JOB_DRONE_PLAN,
+		EstateId:    uuid.New(),
+		Params:      []byte(`{"max_distance":100}`),
+		MaxAttempts: 3,
+	}
+	insertJob := regexp.QuoteMeta(`INSERT INTO jobs (id, job_type, estate_id, params, max_attempts) VALUES ($1, $2, $3, $4, $5);`)
+
+	mock.ExpectExec(insertJob).
+		WithArgs(input.Id, input.Type, input.EstateId, []byte(`{"max_distance":100}`), 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	assert.NoError(t, repo.CreateJob(context.Background(), input))
+
+	mock.ExpectExec(insertJob).WillReturnError(errors.New("db error"))
+	assert.Equal(t,
+		apperror.WrapWithCode(fmt.Errorf("failed to create job: %w", errors.New("db error")), http.StatusInternalServerError),
+		repo.CreateJob(context.Background(), input))
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	jobID := uuid.New()
+	estateID := uuid.New()
+	createdAt := time.Now()
+	selectJob := regexp.QuoteMeta(`FROM jobs WHERE id = $1;`)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectQuery(selectJob).WithArgs(jobID).WillReturnRows(sqlmock.NewRows(jobRowColumns).
+			AddRow(jobID, "export", estateID, []byte(`{}`), "succeeded", 1.0, []byte(`{"width":10}`), 1, 3, nil,
+				false, createdAt, createdAt, createdAt, createdAt, createdAt))
+
+		job, err := repo.GetJob(context.Background(), jobID)
+		assert.NoError(t, err)
+		assert.Equal(t, JOB_EXPORT, job.Type)
+		assert.Equal(t, JOB_SUCCEEDED, job.Status)
+		assert.JSONEq(t, `{"width":10}`, string(job.Result))
+	})
+
+	t.Run("Job Not Found", func(t *testing.T) {
+		mock.ExpectQuery(selectJob).WithArgs(jobID).WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetJob(context.Background(), jobID)
+		assert.Equal(t, apperror.WrapWithCode(fmt.Errorf("job with ID %s not found", jobID), http.StatusNotFound), err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	jobID := uuid.New()
+	createdAt := time.Now()
+	claimJob := regexp.QuoteMeta(`UPDATE jobs SET status = 'running', attempts = attempts + 1`)
+
+	t.Run("Claimed", func(t *testing.T) {
+		mock.ExpectQuery(claimJob).WithArgs(int64(30000)).WillReturnRows(sqlmock.NewRows(jobRowColumns).
+			AddRow(jobID, "recompute_stats", uuid.New(), []byte(`{}`), "running", 0.0, nil, 1, 3, nil,
+				false, createdAt, createdAt, createdAt, nil, createdAt))
+
+		job, err := repo.ClaimJob(context.Background(), ClaimJobInput{Lease: 30 * time.Second})
+		assert.NoError(t, err)
+		assert.Equal(t, jobID, job.Id)
+		assert.Equal(t, JOB_RUNNING, job.Status)
+		assert.Nil(t, job.Result)
+	})
+
+	t.Run("No Due Job", func(t *testing.T) {
+		mock.ExpectQuery(claimJob).WithArgs(int64(30000)).WillReturnError(sql.ErrNoRows)
+
+		job, err := repo.ClaimJob(context.Background(), ClaimJobInput{Lease: 30 * time.Second})
+		assert.NoError(t, err)
+		assert.Nil(t, job)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHeartbeatJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	input := HeartbeatJobInput{Id: uuid.New(), Attempts: 2, Progress: 0.5, Lease: time.Minute}
+	heartbeat := regexp.QuoteMeta(`UPDATE jobs SET progress = $2`)
+
+	mock.ExpectQuery(heartbeat).WithArgs(input.Id, 0.5, int64(60000), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"cancel_requested"}).AddRow(false))
+	cancelRequested, err := repo.HeartbeatJob(context.Background(), input)
+	assert.NoError(t, err)
+	assert.False(t, cancelRequested)
+
+	// the job was finished or picked by another worker
+	mock.ExpectQuery(heartbeat).WithArgs(input.Id, 0.5, int64(60000), 2).WillReturnError(sql.ErrNoRows)
+	cancelRequested, err = repo.HeartbeatJob(context.Background(), input)
+	assert.NoError(t, err)
+	assert.True(t, cancelRequested)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFinishJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	runAt := time.Now()
+	lastError := "estate not found"
+	finishJob := regexp.QuoteMeta(`UPDATE jobs SET status = $2, progress = $3, result = $4, last_error = $5, run_at = $6`)
+
+	succeeded := FinishJobInput{Id: uuid.New(), Attempts: 1, Status: JOB_SUCCEEDED, Progress: 1, Result: []byte(`{"distance":54}`), RunAt: runAt}
+	mock.ExpectExec(finishJob).
+		WithArgs(succeeded.Id, JOB_SUCCEEDED, 1.0, []byte(`{"distance":54}`), nil, runAt, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.FinishJob(context.Background(), succeeded))
+
+	// without result the column is NULL
+	failed := FinishJobInput{Id: uuid.New(), Attempts: 3, Status: JOB_FAILED, LastError: &lastError, RunAt: runAt}
+	mock.ExpectExec(finishJob).
+		WithArgs(failed.Id, JOB_FAILED, 0.0, nil, &lastError, runAt, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.FinishJob(context.Background(), failed))
+
+	// the lease expired and another worker claimed the job, nothing is written
+	lost := FinishJobInput{Id: uuid.New(), Attempts: 1, Status: JOB_SUCCEEDED, Progress: 1, RunAt: runAt}
+	mock.ExpectExec(regexp.QuoteMeta(`WHERE id = $1 AND status = 'running' AND attempts = $7;`)).
+		WithArgs(lost.Id, JOB_SUCCEEDED, 1.0, nil, nil, runAt, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = repo.FinishJob(context.Background(), lost)
+	var appErr *apperror.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	jobID := uuid.New()
+	estateID := uuid.New()
+	createdAt := time.Now()
+	selectJob := regexp.QuoteMeta(`FROM jobs WHERE id = $1 FOR UPDATE;`)
+	cancelJob := regexp.QuoteMeta(`UPDATE jobs SET status = CASE WHEN status = 'queued' THEN 'cancelled' ELSE status END`)
+
+	jobRow := func(status JobStatus, cancelRequested bool) *sqlmock.Rows {
+		return sqlmock.NewRows(jobRowColumns).
+			AddRow(jobID, "drone_plan", estateID, []byte(`{}`), string(status), 0.0, nil, 0, 3, nil,
+				cancelRequested, createdAt, createdAt, nil, nil, nil)
+	}
+
+	tests := []struct {
+		name           string
+		mockSetup      func()
+		expectedStatus JobStatus
+		expectedError  error
+	}{
+		{
+			name: "Queued Job Is Cancelled",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectJob).WithArgs(jobID).WillReturnRows(jobRow(JOB_QUEUED, false))
+				mock.ExpectQuery(cancelJob).WithArgs(jobID).WillReturnRows(jobRow(JOB_CANCELLED, true))
+				mock.ExpectCommit()
+			},
+			expectedStatus: JOB_CANCELLED,
+		},
+		{
+			name: "Running Job Is Flagged",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectJob).WithArgs(jobID).WillReturnRows(jobRow(JOB_RUNNING, false))
+				mock.ExpectQuery(cancelJob).WithArgs(jobID).WillReturnRows(jobRow(JOB_RUNNING, true))
+				mock.ExpectCommit()
+			},
+			expectedStatus: JOB_RUNNING,
+		},
+		{
+			name: "Job Already Finished",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectJob).WithArgs(jobID).WillReturnRows(jobRow(JOB_SUCCEEDED, false))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("job %s is already succeeded", jobID), http.StatusConflict),
+		},
+		{
+			name: "Job Not Found",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectJob).WithArgs(jobID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("job with ID %s not found", jobID), http.StatusNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			job, err := repo.CancelJob(context.Background(), jobID)
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, job)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, job.Status)
+				assert.True(t, job.CancelRequested)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+)
+
+// CreateObstacle record a structure the drone must clear, or a no-fly plot when input.Height is nil.
+// A plot holds at most one obstacle
+func (r *Repository) CreateObstacle(ctx context.Context, input CreateObstacleInput) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		// lock the estate so it is not resized while the plot is checked
+		estate, err := r.getEstateForUpdateSQL(ctx, tx, input.EstateId)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", input.EstateId), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get estate: %w", err)
+		}
+
+		if input.X > estate.Length || input.Y > estate.Width {
+			return apperror.WrapWithCode(fmt.Errorf("plot (%d,%d) is outside the %dx%d estate", input.X, input.Y, estate.Length, estate.Width), http.StatusBadRequest)
+		}
+
+		isExist, err := r.checkExistObstacleSQL(ctx, tx, input.EstateId, input.X, input.Y)
+		if err != nil {
+			return fmt.Errorf("failed to check plot obstacle: %w", err)
+		}
+		if isExist {
+			return apperror.WrapWithCode(fmt.Errorf("plot (%d,%d) already has an obstacle", input.X, input.Y), http.StatusConflict)
+		}
+
+		if err = r.createObstacleSQL(ctx, tx, input); err != nil {
+			return fmt.Errorf("failed to create obstacle: %w", err)
+		}
+		if err = r.bumpEstateVersionSQL(ctx, tx, input.EstateId); err != nil {
+			return fmt.Errorf("failed to bump estate version: %w", err)
+		}
+
+		event := ObstacleEvent{
+			ObstacleId: input.Id,
+			EstateId:   input.EstateId,
+			X:          input.X,
+			Y:          input.Y,
+			Height:     input.Height,
+			NoFly:      input.Height == nil,
+			Label:      input.Label,
+		}
+		if err = r.createAuditEntry(ctx, tx, input.EstateId, AUDIT_OBSTACLE_CREATED, input.Id, nil, event); err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, input.EstateId, EVENT_OBSTACLE_ADDED, event)
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteObstacle(ctx context.Context, estateID, obstacleID uuid.UUID) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		obstacle, err := r.deleteObstacleSQL(ctx, tx, estateID, obstacleID)
+		if err != nil {
+			return fmt.Errorf("failed to delete obstacle: %w", err)
+		}
+		if obstacle == nil {
+			return apperror.WrapWithCode(fmt.Errorf("obstacle with ID %s not found", obstacleID), http.StatusNotFound)
+		}
+		if err = r.bumpEstateVersionSQL(ctx, tx, estateID); err != nil {
+			return fmt.Errorf("failed to bump estate version: %w", err)
+		}
+
+		before := ObstacleEvent{
+			ObstacleId: obstacleID,
+			EstateId:   estateID,
+			X:          obstacle.X,
+			Y:          obstacle.Y,
+			Height:     obstacle.Height,
+			NoFly:      obstacle.IsNoFly(),
+			Label:      obstacle.Label,
+		}
+		if err = r.createAuditEntry(ctx, tx, estateID, AUDIT_OBSTACLE_DELETED, obstacleID, before, nil); err != nil {
+			return err
+		}
+
+		return r.createOutboxEvent(ctx, tx, estateID, EVENT_OBSTACLE_REMOVED, ObstacleEvent{
+			ObstacleId: obstacleID,
+			EstateId:   estateID,
+		})
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+func (r *Repository) getObstaclesByEstateIdSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID) ([]Obstacle, error) {
+	rows, err := exec.QueryContext(ctx, `
+		SELECT id, estate_id, x, y, height, label, created_at
+		FROM obstacles
+		WHERE estate_id = $1
+		ORDER BY y, x;`, estateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var obstacles []Obstacle
+	for rows.Next() {
+		var obstacle Obstacle
+		if err := rows.Scan(
+			&obstacle.Id,
+			&obstacle.EstateId,
+			&obstacle.X,
+			&obstacle.Y,
+			&obstacle.Height,
+			&obstacle.Label,
+			&obstacle.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan obstacle data: %w", err)
+		}
+		obstacles = append(obstacles, obstacle)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return obstacles, nil
+}
+
+func (r *Repository) checkExistObstacleSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, x, y int) (bool, error) {
+	var exists bool
+	err := exec.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM obstacles
+			WHERE estate_id = $1 AND x = $2 AND y = $3
+		);`, estateID, x, y).Scan(&exists)
+	return exists, err
+}
+
+func (r *Repository) createObstacleSQL(ctx context.Context, exec dbExecutor, input CreateObstacleInput) error {
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO obstacles (id, estate_id, x, y, height, label)
+		VALUES ($1, $2, $3, $4, $5, $6);`,
+		input.Id, input.EstateId, input.X, input.Y, input.Height, input.Label)
+	if err != nil {
+		return err
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowAffected < 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// deleteObstacleSQL return the deleted obstacle, nil when the obstacle does not exist in the estate
+func (r *Repository) deleteObstacleSQL(ctx context.Context, exec dbExecutor, estateID, obstacleID uuid.UUID) (*Obstacle, error) {
+	var obstacle Obstacle
+	err := exec.QueryRowContext(ctx, `
+		DELETE FROM obstacles o
+		USING estates e
+		WHERE o.id = $1 AND o.estate_id = $2 AND e.id = o.estate_id AND e.deleted_at IS NULL
+		RETURNING o.id, o.estate_id, o.x, o.y, o.height, o.label, o.created_at;`, obstacleID, estateID).Scan(
+		&obstacle.Id,
+		&obstacle.EstateId,
+		&obstacle.X,
+		&obstacle.Y,
+		&obstacle.Height,
+		&obstacle.Label,
+		&obstacle.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &obstacle, nil
+}
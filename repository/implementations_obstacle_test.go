@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateObstacle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	obstacleID := uuid.New()
+	createdAt := time.Now()
+
+	selectEstate := regexp.QuoteMeta(`SELECT id, width, length, version, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`)
+	checkObstacle := regexp.QuoteMeta(`SELECT EXISTS ( SELECT 1 FROM obstacles WHERE estate_id = $1 AND x = $2 AND y = $3 );`)
+	insertObstacle := regexp.QuoteMeta(`INSERT INTO obstacles (id, estate_id, x, y, height, label) VALUES ($1, $2, $3, $4, $5, $6);`)
+
+	estateRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "width", "length", "version", "created_at", "updated_at"}).
+			AddRow(estateID, 10, 10, 1, createdAt, nil)
+	}
+	input := CreateObstacleInput{
+		Id:       obstacleID,
+		EstateId: estateID,
+		X:        3,
+		Y:        4,
+		Height:   ptr.ToPointer(30),
+		Label:    "tower",
+	}
+
+	tests := []struct {
+		name          string
+		input         CreateObstacleInput
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:  "Success",
+			input: input,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(checkObstacle).WithArgs(estateID, 3, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec(insertObstacle).
+					WithArgs(obstacleID, estateID, 3, 4, ptr.ToPointer(30), "tower").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE estates SET version = version + 1 WHERE id = $1;`)).
+					WithArgs(estateID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mock, estateID, AUDIT_OBSTACLE_CREATED, obstacleID)
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+					WithArgs(sqlmock.AnyArg(), estateID, EVENT_OBSTACLE_ADDED, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+					WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Plot Already Has Obstacle",
+			input: input,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(checkObstacle).WithArgs(estateID, 3, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(errors.New("plot (3,4) already has an obstacle"), http.StatusConflict),
+		},
+		{
+			name:  "Plot Outside Estate",
+			input: CreateObstacleInput{Id: obstacleID, EstateId: estateID, X: 3, Y: 11},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(errors.New("plot (3,11) is outside the 10x10 estate"), http.StatusBadRequest),
+		},
+		{
+			name:  "Estate Not Found",
+			input: input,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound),
+		},
+		{
+			name:  "Insert Error",
+			input: input,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(checkObstacle).WithArgs(estateID, 3, 4).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec(insertObstacle).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to create obstacle: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.CreateObstacle(context.Background(), tc.input)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteObstacle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	obstacleID := uuid.New()
+	deleteObstacle := regexp.QuoteMeta(`DELETE FROM obstacles o USING estates e WHERE o.id = $1 AND o.estate_id = $2 AND e.id = o.estate_id AND e.deleted_at IS NULL RETURNING o.id, o.estate_id, o.x, o.y, o.height, o.label, o.created_at;`)
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(deleteObstacle).WithArgs(obstacleID, estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "x", "y", "height", "label", "created_at"}).
+						AddRow(obstacleID, estateID, 3, 4, nil, "", time.Now()))
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE estates SET version = version + 1 WHERE id = $1;`)).
+					WithArgs(estateID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mock, estateID, AUDIT_OBSTACLE_DELETED, obstacleID)
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+					WithArgs(sqlmock.AnyArg(), estateID, EVENT_OBSTACLE_REMOVED, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+					WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Obstacle Not Found",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(deleteObstacle).WithArgs(obstacleID, estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("obstacle with ID %s not found", obstacleID), http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.DeleteObstacle(context.Background(), estateID, obstacleID)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
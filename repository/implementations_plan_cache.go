@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+)
+
+// GetCachedDronePlan return nil when no plan was saved for this version of the estate
+func (r *Repository) GetCachedDronePlan(ctx context.Context, key DronePlanCacheKey) (json.RawMessage, error) {
+	plan, err := r.getCachedDronePlanSQL(ctx, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, apperror.WrapWithCode(fmt.Errorf("failed to get cached drone plan: %w", err), http.StatusInternalServerError)
+	}
+
+	return plan, nil
+}
+
+// SaveCachedDronePlan replace the plan saved for the same parameters, and drop the plans of the
+// older versions of the estate
+func (r *Repository) SaveCachedDronePlan(ctx context.Context, input SaveCachedDronePlanInput) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		if err := r.upsertCachedDronePlanSQL(ctx, tx, input); err != nil {
+			return fmt.Errorf("failed to save cached drone plan: %w", err)
+		}
+		if err := r.deleteStaleDronePlansSQL(ctx, tx, input.Key); err != nil {
+			return fmt.Errorf("failed to delete stale drone plans: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+)
+
+func (r *Repository) getCachedDronePlanSQL(ctx context.Context, key DronePlanCacheKey) (json.RawMessage, error) {
+	var plan []byte
+	err := r.Db.QueryRowContext(ctx, `
+		SELECT plan
+		FROM drone_plan_cache
+		WHERE estate_id = $1 AND params = $2 AND estate_version = $3;`,
+		key.EstateId, key.Params, key.Version).Scan(&plan)
+	return plan, err
+}
+
+// upsertCachedDronePlanSQL never replace a plan of a newer version, saved by a request which read the estate later
+func (r *Repository) upsertCachedDronePlanSQL(ctx context.Context, exec dbExecutor, input SaveCachedDronePlanInput) error {
+	_, err := exec.ExecContext(ctx, `
+		INSERT INTO drone_plan_cache (estate_id, params, estate_version, plan)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (estate_id, params) DO UPDATE
+		SET estate_version = EXCLUDED.estate_version, plan = EXCLUDED.plan, created_at = CURRENT_TIMESTAMP
+		WHERE drone_plan_cache.estate_version <= EXCLUDED.estate_version;`,
+		input.Key.EstateId, input.Key.Params, input.Key.Version, []byte(input.Plan))
+	return err
+}
+
+func (r *Repository) deleteStaleDronePlansSQL(ctx context.Context, exec dbExecutor, key DronePlanCacheKey) error {
+	_, err := exec.ExecContext(ctx, `
+		DELETE FROM drone_plan_cache
+		WHERE estate_id = $1 AND estate_version < $2;`,
+		key.EstateId, key.Version)
+	return err
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCachedDronePlan(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	key := DronePlanCacheKey{EstateId: uuid.New(), Version: 3, Params: "plot=10,pattern=rows"}
+	selectPlan := regexp.QuoteMeta(`SELECT plan FROM drone_plan_cache WHERE estate_id = $1 AND params = $2 AND estate_version = $3;`)
+
+	t.Run("Hit", func(t *testing.T) {
+		mock.ExpectQuery(selectPlan).WithArgs(key.EstateId, key.Params, int64(3)).
+			WillReturnRows(sqlmock.NewRows([]string{"plan"}).AddRow([]byte(`{"distance":54}`)))
+
+		plan, err := repo.GetCachedDronePlan(context.Background(), key)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"distance":54}`, string(plan))
+	})
+
+	t.Run("Miss", func(t *testing.T) {
+		mock.ExpectQuery(selectPlan).WithArgs(key.EstateId, key.Params, int64(3)).WillReturnError(sql.ErrNoRows)
+
+		plan, err := repo.GetCachedDronePlan(context.Background(), key)
+		assert.NoError(t, err)
+		assert.Nil(t, plan)
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock.ExpectQuery(selectPlan).WillReturnError(errors.New("db error"))
+
+		_, err := repo.GetCachedDronePlan(context.Background(), key)
+		assert.Equal(t, apperror.WrapWithCode(fmt.Errorf("failed to get cached drone plan: %w", errors.New("db error")), http.StatusInternalServerError), err)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveCachedDronePlan(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	input := SaveCachedDronePlanInput{
+		Key:  DronePlanCacheKey{EstateId: uuid.New(), Version: 3, Params: "plot=10,pattern=rows"},
+		Plan: []byte(`{"distance":54}`),
+	}
+	upsertPlan := regexp.QuoteMeta(`INSERT INTO drone_plan_cache (estate_id, params, estate_version, plan) VALUES ($1, $2, $3, $4) ON CONFLICT (estate_id, params) DO UPDATE`)
+	deleteStale := regexp.QuoteMeta(`DELETE FROM drone_plan_cache WHERE estate_id = $1 AND estate_version < $2;`)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(upsertPlan).
+			WithArgs(input.Key.EstateId, input.Key.Params, int64(3), []byte(`{"distance":54}`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteStale).WithArgs(input.Key.EstateId, int64(3)).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		assert.NoError(t, repo.SaveCachedDronePlan(context.Background(), input))
+	})
+
+	t.Run("Database Error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(upsertPlan).WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err := repo.SaveCachedDronePlan(context.Background(), input)
+		assert.EqualError(t, err, "failed to save cached drone plan: db error")
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+)
+
+// CreateTrees plant every tree of a planting plan in the estate, or none of them.
+// The plots are checked under the estate lock, so a tree planted meanwhile fail the whole plan
+func (r *Repository) CreateTrees(ctx context.Context, estateID uuid.UUID, inputs []CreateTreeInput) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		estate, err := r.getEstateForUpdateSQL(ctx, tx, estateID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get estate: %w", err)
+		}
+
+		estate.ExclusionZones, err = r.getExclusionZonesByEstateIdSQL(ctx, tx, estateID)
+		if err != nil {
+			return fmt.Errorf("failed to get estate exclusion zones: %w", err)
+		}
+
+		plots := make([]Point, 0, len(inputs))
+		seen := make(map[Point]bool, len(inputs))
+		for _, input := range inputs {
+			p := Point{X: input.X, Y: input.Y}
+			if p.X > estate.Length || p.Y > estate.Width {
+				return apperror.WrapWithCode(fmt.Errorf("plot (%d,%d) is outside the %dx%d estate", p.X, p.Y, estate.Length, estate.Width), http.StatusBadRequest)
+			}
+			if seen[p] {
+				return apperror.WrapWithCode(fmt.Errorf("plot (%d,%d) is planted twice", p.X, p.Y), http.StatusBadRequest)
+			}
+			if estate.IsExcluded(p.X, p.Y) {
+				return apperror.WrapWithCode(fmt.Errorf("plot (%d,%d) is excluded from planting", p.X, p.Y), http.StatusUnprocessableEntity)
+			}
+			seen[p] = true
+			plots = append(plots, p)
+		}
+
+		occupied, err := r.getOccupiedPlotsSQL(ctx, tx, estateID, plots)
+		if err != nil {
+			return fmt.Errorf("failed to check plot trees: %w", err)
+		}
+		if len(occupied) > 0 {
+			return apperror.WrapWithCode(fmt.Errorf("plot (%d,%d) already has a tree", occupied[0].X, occupied[0].Y), http.StatusUnprocessableEntity)
+		}
+
+		for _, input := range inputs {
+			input.EstateId = estateID
+			if input.Health == "" {
+				input.Health = TREE_HEALTHY
+			}
+			if input.Tags == nil {
+				input.Tags = []string{}
+			}
+
+			if err := r.createTreeSQL(ctx, tx, input); err != nil {
+				return fmt.Errorf("failed to create tree: %w", err)
+			}
+			event := TreeAddedEvent{
+				TreeId:   input.Id,
+				EstateId: estateID,
+				X:        input.X,
+				Y:        input.Y,
+				Height:   input.Height,
+				Species:  input.Species,
+				Health:   input.Health,
+			}
+			if err := r.createAuditEntry(ctx, tx, estateID, AUDIT_TREE_CREATED, input.Id, nil, event); err != nil {
+				return err
+			}
+			if err := r.createOutboxEvent(ctx, tx, estateID, EVENT_TREE_ADDED, event); err != nil {
+				return err
+			}
+		}
+
+		if err := r.refreshTileCells(ctx, tx, estate, plots); err != nil {
+			return err
+		}
+		return r.bumpEstateVersionSQL(ctx, tx, estateID)
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
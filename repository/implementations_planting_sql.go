@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// getOccupiedPlotsSQL list the plots holding a tree among the given plots, like checkExistEstateTree does for one plot
+func (r *Repository) getOccupiedPlotsSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, plots []Point) ([]Point, error) {
+	xs := make([]int64, 0, len(plots))
+	ys := make([]int64, 0, len(plots))
+	for _, p := range plots {
+		xs = append(xs, int64(p.X))
+		ys = append(ys, int64(p.Y))
+	}
+
+	rows, err := exec.QueryContext(ctx, `
+		SELECT t.x, t.y
+		FROM trees t
+		JOIN unnest($2::int[], $3::int[]) AS p(x, y) ON t.x = p.x AND t.y = p.y
+		WHERE t.estate_id = $1
+		ORDER BY t.y, t.x;`,
+		estateID, pq.Array(xs), pq.Array(ys))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var occupied []Point
+	for rows.Next() {
+		var p Point
+		if err := rows.Scan(&p.X, &p.Y); err != nil {
+			return nil, err
+		}
+		occupied = append(occupied, p)
+	}
+
+	return occupied, rows.Err()
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTrees(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	firstID, secondID := uuid.New(), uuid.New()
+	createdAt := time.Now()
+
+	selectEstate := regexp.QuoteMeta(`SELECT id, width, length, version, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`)
+	selectZones := regexp.QuoteMeta(`SELECT id, estate_id, label, kind, points, created_at FROM exclusion_zones WHERE estate_id = $1 ORDER BY created_at, id;`)
+	selectOccupied := regexp.QuoteMeta(`SELECT t.x, t.y FROM trees t JOIN unnest($2::int[], $3::int[]) AS p(x, y) ON t.x = p.x AND t.y = p.y WHERE t.estate_id = $1 ORDER BY t.y, t.x;`)
+	insertTree := regexp.QuoteMeta(`INSERT INTO trees (id, estate_id, x, y, height, species, planted_at, health, tags) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`)
+	insertEvent := regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)
+
+	estateRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "width", "length", "version", "created_at", "updated_at"}).
+			AddRow(estateID, 10, 10, 1, createdAt, nil)
+	}
+	zoneRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "estate_id", "label", "kind", "points", "created_at"}).
+			AddRow(uuid.New(), estateID, "river", EXCLUSION_KIND_PLOTS, []byte(`[{"x":5,"y":5}]`), createdAt)
+	}
+	inputs := []CreateTreeInput{
+		{Id: firstID, X: 1, Y: 1, Height: 1},
+		{Id: secondID, X: 3, Y: 1, Height: 1, Species: "Tenera"},
+	}
+
+	tests := []struct {
+		name          string
+		inputs        []CreateTreeInput
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:   "Success",
+			inputs: inputs,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectZones).WithArgs(estateID).WillReturnRows(zoneRows())
+				mock.ExpectQuery(selectOccupied).WithArgs(estateID, pq.Array([]int64{1, 3}), pq.Array([]int64{1, 1})).
+					WillReturnRows(sqlmock.NewRows([]string{"x", "y"}))
+				for _, input := range inputs {
+					mock.ExpectExec(insertTree).
+						WithArgs(input.Id, estateID, input.X, input.Y, 1, input.Species, nil, TREE_HEALTHY, pq.Array([]string{})).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					expectAuditEntry(mock, estateID, AUDIT_TREE_CREATED, input.Id)
+					mock.ExpectExec(insertEvent).
+						WithArgs(sqlmock.AnyArg(), estateID, EVENT_TREE_ADDED, sqlmock.AnyArg(), sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(1, 1))
+					mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+						WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+						WillReturnResult(sqlmock.NewResult(0, 0))
+				}
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE estates SET version = version + 1 WHERE id = $1;`)).
+					WithArgs(estateID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Plot Planted Meanwhile",
+			inputs: inputs,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectZones).WithArgs(estateID).WillReturnRows(zoneRows())
+				mock.ExpectQuery(selectOccupied).WithArgs(estateID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"x", "y"}).AddRow(3, 1))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(errors.New("plot (3,1) already has a tree"), http.StatusUnprocessableEntity),
+		},
+		{
+			name:   "Excluded Plot",
+			inputs: []CreateTreeInput{{Id: firstID, X: 5, Y: 5, Height: 1}},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectZones).WithArgs(estateID).WillReturnRows(zoneRows())
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(errors.New("plot (5,5) is excluded from planting"), http.StatusUnprocessableEntity),
+		},
+		{
+			name:   "Plot Planted Twice",
+			inputs: []CreateTreeInput{{Id: firstID, X: 1, Y: 1, Height: 1}, {Id: secondID, X: 1, Y: 1, Height: 1}},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectZones).WithArgs(estateID).WillReturnRows(zoneRows())
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(errors.New("plot (1,1) is planted twice"), http.StatusBadRequest),
+		},
+		{
+			name:   "Plot Outside Estate",
+			inputs: []CreateTreeInput{{Id: firstID, X: 11, Y: 1, Height: 1}},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnRows(estateRow())
+				mock.ExpectQuery(selectZones).WithArgs(estateID).WillReturnRows(zoneRows())
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(errors.New("plot (11,1) is outside the 10x10 estate"), http.StatusBadRequest),
+		},
+		{
+			name:   "Estate Not Found",
+			inputs: inputs,
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.CreateTrees(context.Background(), estateID, tc.inputs)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
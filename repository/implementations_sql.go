@@ -7,13 +7,14 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
-func (r *Repository) createTreeSQL(ctx context.Context, input CreateTreeInput) (err error) {
-	res, err := r.Db.ExecContext(ctx, `
-		INSERT INTO trees (id, estate_id, x, y, height) 
-		VALUES ($1, $2, $3, $4, $5);`,
-		input.Id, input.EstateId, input.X, input.Y, input.Height)
+func (r *Repository) createTreeSQL(ctx context.Context, exec dbExecutor, input CreateTreeInput) (err error) {
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO trees (id, estate_id, x, y, height, species, planted_at, health, tags)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`,
+		input.Id, input.EstateId, input.X, input.Y, input.Height, input.Species, input.PlantedAt, input.Health, pq.Array(input.Tags))
 	if err != nil {
 		return err
 	}
@@ -30,24 +31,97 @@ func (r *Repository) createTreeSQL(ctx context.Context, input CreateTreeInput) (
 	return
 }
 
-func (r *Repository) getEstateByIdSql(ctx context.Context, id uuid.UUID) (estate *Estate, err error) {
+// getTreeForUpdateSQL lock the tree of the estate until the end of the transaction
+func (r *Repository) getTreeForUpdateSQL(ctx context.Context, exec dbExecutor, estateID, treeID uuid.UUID) (*Tree, error) {
+	rows, err := exec.QueryContext(ctx, `
+		SELECT `+treeColumns+`
+		FROM trees
+		WHERE id = $1 AND estate_id = $2
+		FOR UPDATE;`,
+		treeID, estateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	trees, err := scanTrees(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(trees) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &trees[0], nil
+}
+
+func (r *Repository) updateTreeAttributesSQL(ctx context.Context, exec dbExecutor, tree *Tree) error {
+	return exec.QueryRowContext(ctx, `
+		UPDATE trees
+		SET species = $2, health = $3, tags = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING updated_at;`,
+		tree.Id, tree.Species, tree.Health, pq.Array(tree.Tags)).Scan(&tree.UpdatedAt)
+}
+
+// treeColumns is selected by every tree query, in the order scanTrees read them
+const treeColumns = `id, estate_id, x, y, height, species, planted_at, health, tags, created_at, updated_at`
+
+func scanTrees(rows *sql.Rows) ([]Tree, error) {
+	var trees []Tree
+	for rows.Next() {
+		var tree Tree
+		if err := rows.Scan(
+			&tree.Id,
+			&tree.EstateId,
+			&tree.X,
+			&tree.Y,
+			&tree.Height,
+			&tree.Species,
+			&tree.PlantedAt,
+			&tree.Health,
+			pq.Array(&tree.Tags),
+			&tree.CreatedAt,
+			&tree.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan tree data: %w", err)
+		}
+		trees = append(trees, tree)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error during rows iteration: %w", err)
+	}
+
+	return trees, nil
+}
+
+func (r *Repository) getEstateByIdSql(ctx context.Context, exec dbExecutor, id uuid.UUID) (estate *Estate, err error) {
 	estate = &Estate{}
+	var originLat, originLon sql.NullFloat64
+	var rotation float64
 	query := `
-		SELECT id, width, length, created_at, updated_at
+		SELECT id, width, length, version, origin_lat, origin_lon, rotation, created_at, updated_at
 		FROM estates
-		WHERE id = $1;`
-	err = r.Db.QueryRowContext(ctx, query, id).Scan(
+		WHERE id = $1 AND deleted_at IS NULL;`
+	err = exec.QueryRowContext(ctx, query, id).Scan(
 		&estate.Id,
 		&estate.Width,
 		&estate.Length,
+		&estate.Version,
+		&originLat,
+		&originLon,
+		&rotation,
 		&estate.CreatedAt,
 		&estate.UpdatedAt,
 	)
+	if err == nil && originLat.Valid && originLon.Valid {
+		estate.Geo = &GeoReference{OriginLat: originLat.Float64, OriginLon: originLon.Float64, Rotation: rotation}
+	}
 	return
 }
 
-func (r *Repository) checkExistEstateTree(ctx context.Context, input CheckExistEstateTreeInput) (isExist bool, err error) {
-	err = r.Db.QueryRowContext(ctx, `
+func (r *Repository) checkExistEstateTree(ctx context.Context, exec dbExecutor, input CheckExistEstateTreeInput) (isExist bool, err error) {
+	err = exec.QueryRowContext(ctx, `
 		SELECT EXISTS (
 			SELECT 1 FROM trees 
 			WHERE estate_id = $1 AND x = $2 AND y = $3
@@ -59,12 +133,20 @@ func (r *Repository) checkExistEstateTree(ctx context.Context, input CheckExistE
 	return
 }
 
-func (r *Repository) createEstateSql(ctx context.Context, input CreateEstateInput) (err error) {
+func (r *Repository) createEstateSql(ctx context.Context, exec dbExecutor, input CreateEstateInput) (err error) {
+	var originLat, originLon sql.NullFloat64
+	var rotation float64
+	if input.Geo != nil {
+		originLat = sql.NullFloat64{Float64: input.Geo.OriginLat, Valid: true}
+		originLon = sql.NullFloat64{Float64: input.Geo.OriginLon, Valid: true}
+		rotation = input.Geo.Rotation
+	}
+
 	var res sql.Result
-	res, err = r.Db.ExecContext(ctx, `
-		INSERT INTO estates (id, width, length)  
-			VALUES ($1, $2, $3);`,
-		input.Id, input.Width, input.Length)
+	res, err = exec.ExecContext(ctx, `
+		INSERT INTO estates (id, width, length, origin_lat, origin_lon, rotation)  
+			VALUES ($1, $2, $3, $4, $5, $6);`,
+		input.Id, input.Width, input.Length, originLat, originLon, rotation)
 	if err != nil {
 		return
 	}
@@ -110,41 +192,20 @@ func (r *Repository) getAllTreeHeightEstateSql(ctx context.Context, estateID uui
 	return
 }
 
-func (r *Repository) getTreesByEstateId(ctx context.Context, estateID uuid.UUID) ([]Tree, error) {
+func (r *Repository) getTreesByEstateId(ctx context.Context, exec dbExecutor, estateID uuid.UUID) ([]Tree, error) {
 	// Query untuk mendapatkan semua pohon di estate
 	queryTrees := `
-		SELECT id, estate_id, x, y, height, created_at, updated_at
+		SELECT ` + treeColumns + `
 		FROM trees
 		WHERE estate_id = $1;`
-	rows, err := r.Db.QueryContext(ctx, queryTrees, estateID)
+	rows, err := exec.QueryContext(ctx, queryTrees, estateID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	// Simpan semua pohon dalam slice
-	var trees []Tree
-	for rows.Next() {
-		var tree Tree
-		if err := rows.Scan(
-			&tree.Id,
-			&tree.EstateId,
-			&tree.X,
-			&tree.Y,
-			&tree.Height,
-			&tree.CreatedAt,
-			&tree.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan tree data: %w", err)
-		}
-		trees = append(trees, tree)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error during rows iteration: %w", err)
-	}
-
-	return trees, nil
+	return scanTrees(rows)
 }
 
 func (r *Repository) getCalculatedEstateStatsSQL(ctx context.Context, estateId uuid.UUID) (stats *EstateStats, err error) {
@@ -166,13 +227,43 @@ func (r *Repository) getCalculatedEstateStatsSQL(ctx context.Context, estateId u
 	return
 }
 
-func (r *Repository) getEstateStatsSQL(ctx context.Context, estateId uuid.UUID) (stats *EstateStats, err error) {
+// getTreeGroupStatsSQL calculate the stats of the trees grouped by column, which must be a trusted column name
+func (r *Repository) getTreeGroupStatsSQL(ctx context.Context, estateId uuid.UUID, column string) ([]TreeGroupStats, error) {
+	rows, err := r.Db.QueryContext(ctx, `
+		SELECT
+			`+column+`,
+			COUNT(*) AS tree_count,
+			MAX(height) AS max_height,
+			MIN(height) AS min_height,
+			ROUND(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY height)) AS median_height
+		FROM trees
+		WHERE estate_id = $1
+		GROUP BY `+column+`
+		ORDER BY `+column+`;`, estateId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []TreeGroupStats
+	for rows.Next() {
+		var group TreeGroupStats
+		if err := rows.Scan(&group.Value, &group.TreeCount, &group.MaxHeight, &group.MinHeight, &group.MedianHeight); err != nil {
+			return nil, fmt.Errorf("failed to scan tree group stats: %w", err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, rows.Err()
+}
+
+func (r *Repository) getEstateStatsSQL(ctx context.Context, exec dbExecutor, estateId uuid.UUID) (stats *EstateStats, err error) {
 	stats = &EstateStats{}
 	query := `
-		SELECT id, estate_id, tree_count, max_height, min_height, median_height,drone_distance, created_at, updated_at
+		SELECT id, estate_id, tree_count, max_height, min_height, median_height,drone_distance, drone_profile, drone_duration, drone_energy, created_at, updated_at
 		FROM estate_stats
 		WHERE estate_id = $1;`
-	err = r.Db.QueryRowContext(ctx, query, estateId).Scan(
+	err = exec.QueryRowContext(ctx, query, estateId).Scan(
 		&stats.Id,
 		&stats.EstateID,
 		&stats.TreeCount,
@@ -180,6 +271,9 @@ func (r *Repository) getEstateStatsSQL(ctx context.Context, estateId uuid.UUID)
 		&stats.MinHeight,
 		&stats.MedianHeight,
 		&stats.DroneDistance,
+		&stats.DroneProfile,
+		&stats.DroneDuration,
+		&stats.DroneEnergy,
 		&stats.CreatedAt,
 		&stats.UpdatedAt,
 	)
@@ -190,11 +284,11 @@ func (r *Repository) getEstateStatsSQL(ctx context.Context, estateId uuid.UUID)
 	return
 }
 
-func (r *Repository) upsertEstateStatsSQL(ctx context.Context, estateID uuid.UUID, stats *EstateStats) error {
+func (r *Repository) upsertEstateStatsSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, stats *EstateStats) error {
 	// Query untuk menyimpan atau memperbarui statistik
 	query := `
-		INSERT INTO estate_stats (id, estate_id, tree_count, max_height, min_height, median_height, drone_distance)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO estate_stats (id, estate_id, tree_count, max_height, min_height, median_height, drone_distance, drone_profile, drone_duration, drone_energy)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		ON CONFLICT (estate_id) DO UPDATE
 		SET
 			tree_count = EXCLUDED.tree_count,
@@ -202,8 +296,11 @@ func (r *Repository) upsertEstateStatsSQL(ctx context.Context, estateID uuid.UUI
 			min_height = EXCLUDED.min_height,
 			median_height = EXCLUDED.median_height,
 			drone_distance = EXCLUDED.drone_distance,
+			drone_profile = EXCLUDED.drone_profile,
+			drone_duration = EXCLUDED.drone_duration,
+			drone_energy = EXCLUDED.drone_energy,
 			updated_at = CURRENT_TIMESTAMP;`
-	_, err := r.Db.ExecContext(ctx, query,
+	_, err := exec.ExecContext(ctx, query,
 		stats.Id,
 		estateID,
 		stats.TreeCount,
@@ -211,6 +308,9 @@ func (r *Repository) upsertEstateStatsSQL(ctx context.Context, estateID uuid.UUI
 		stats.MinHeight,
 		stats.MedianHeight,
 		stats.DroneDistance,
+		stats.DroneProfile,
+		stats.DroneDuration,
+		stats.DroneEnergy,
 	)
 	if err != nil {
 		return err
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"net/http"
@@ -37,27 +38,34 @@ func TestCreateEstate(t *testing.T) {
 		{
 			name: "Success",
 			mockSetup: func() {
+				mock.ExpectBegin()
 				mock.ExpectExec(`INSERT INTO estate`).
-					WithArgs(testInput.Id, testInput.Width, testInput.Length).
+					WithArgs(testInput.Id, testInput.Width, testInput.Length, nil, nil, 0.0).
 					WillReturnResult(sqlmock.NewResult(1, 1)) // 1 row affected
+				expectAuditEntry(mock, testInput.Id, AUDIT_ESTATE_CREATED, testInput.Id)
+				mock.ExpectCommit()
 			},
 			expectedError: nil,
 		},
 		{
 			name: "Database Error",
 			mockSetup: func() {
+				mock.ExpectBegin()
 				mock.ExpectExec(`INSERT INTO estate`).
-					WithArgs(testInput.Id, testInput.Width, testInput.Length).
+					WithArgs(testInput.Id, testInput.Width, testInput.Length, nil, nil, 0.0).
 					WillReturnError(errors.New("database error"))
+				mock.ExpectRollback()
 			},
 			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to create estate: %w", errors.New("database error")), http.StatusInternalServerError),
 		},
 		{
 			name: "No Rows Affected",
 			mockSetup: func() {
+				mock.ExpectBegin()
 				mock.ExpectExec(`INSERT INTO estate`).
-					WithArgs(testInput.Id, testInput.Width, testInput.Length).
+					WithArgs(testInput.Id, testInput.Width, testInput.Length, nil, nil, 0.0).
 					WillReturnResult(sqlmock.NewResult(1, 0)) // 0 rows affected
+				mock.ExpectRollback()
 			},
 			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to create estate: %w", errors.New("no rows affected")), http.StatusInternalServerError),
 		},
@@ -92,16 +100,17 @@ func TestGetEstateWithAllDetails(t *testing.T) {
 	updatedAt := time.Now()
 
 	// Mock estate data
-	estateRow := mock.NewRows([]string{"id", "width", "length", "created_at", "updated_at"}).
-		AddRow(estateID, 100, 200, createdAt, updatedAt)
+	estateRow := mock.NewRows([]string{"id", "width", "length", "version", "origin_lat", "origin_lon", "rotation", "created_at", "updated_at"}).
+		AddRow(estateID, 100, 200, 1, 2.1, 101.5, 30.0, createdAt, updatedAt)
 
 	// Mock tree data
-	treeRow := mock.NewRows([]string{"id", "estate_id", "x", "y", "height", "created_at", "updated_at"}).
-		AddRow(uuid.New(), estateID, 10, 20, 5, createdAt, updatedAt)
+	plantedAt := time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)
+	treeRow := mock.NewRows([]string{"id", "estate_id", "x", "y", "height", "species", "planted_at", "health", "tags", "created_at", "updated_at"}).
+		AddRow(uuid.New(), estateID, 10, 20, 5, "Tenera", plantedAt, "diseased", "{young,irrigated}", createdAt, updatedAt)
 
 	// // Mock estate stats data
-	statsRow := mock.NewRows([]string{"id", "estate_id", "tree_count", "max_height", "min_height", "median_height", "drone_distance", "created_at", "updated_at"}).
-		AddRow(uuid.New(), estateID, 5, 10, 2, 6, 100, createdAt, updatedAt)
+	statsRow := mock.NewRows([]string{"id", "estate_id", "tree_count", "max_height", "min_height", "median_height", "drone_distance", "drone_profile", "drone_duration", "drone_energy", "created_at", "updated_at"}).
+		AddRow(uuid.New(), estateID, 5, 10, 2, 6, 100, "plot=10,clearance=1,takeoff=1,cruise=1,ascent=1,descent=1,speed=10/3/2,power=200/400/150", 108, 0.94, createdAt, updatedAt)
 
 	tests := []struct {
 		name             string
@@ -112,19 +121,29 @@ func TestGetEstateWithAllDetails(t *testing.T) {
 		{
 			name: "Success - All Details",
 			mockSetup: func() {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, version, origin_lat, origin_lon, rotation, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL;`)).
 					WithArgs(estateID).
 					WillReturnRows(estateRow)
 
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, x, y, height, created_at, updated_at FROM trees WHERE estate_id = $1;`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, x, y, height, species, planted_at, health, tags, created_at, updated_at FROM trees WHERE estate_id = $1;`)).
 					WithArgs(estateID).
 					WillReturnRows(treeRow)
 
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, tree_count, max_height, min_height, median_height,drone_distance, created_at, updated_at
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, tree_count, max_height, min_height, median_height,drone_distance, drone_profile, drone_duration, drone_energy, created_at, updated_at
 		    	            			FROM estate_stats
 		    	            			WHERE estate_id = $1;`)).
 					WithArgs(estateID).
 					WillReturnRows(statsRow)
+
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, label, kind, points, created_at FROM exclusion_zones WHERE estate_id = $1 ORDER BY created_at, id;`)).
+					WithArgs(estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "label", "kind", "points", "created_at"}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, x, y, height, label, created_at FROM obstacles WHERE estate_id = $1 ORDER BY y, x;`)).
+					WithArgs(estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "x", "y", "height", "label", "created_at"}).
+						AddRow(uuid.New(), estateID, 3, 4, 30, "tower", createdAt).
+						AddRow(uuid.New(), estateID, 5, 4, nil, "substation", createdAt))
 			},
 			excludeRelations: []Relation{},
 			expectedError:    nil,
@@ -132,7 +151,7 @@ func TestGetEstateWithAllDetails(t *testing.T) {
 		{
 			name: "Estate Not Found",
 			mockSetup: func() {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, version, origin_lat, origin_lon, rotation, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL;`)).
 					WithArgs(estateID).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -142,7 +161,7 @@ func TestGetEstateWithAllDetails(t *testing.T) {
 		{
 			name: "Error Fetching Estate",
 			mockSetup: func() {
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, version, origin_lat, origin_lon, rotation, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL;`)).
 					WithArgs(estateID).
 					WillReturnError(errors.New("db error"))
 			},
@@ -152,13 +171,13 @@ func TestGetEstateWithAllDetails(t *testing.T) {
 		{
 			name: "Error fetching trees",
 			mockSetup: func() {
-				estateRow := mock.NewRows([]string{"id", "width", "length", "created_at", "updated_at"}).
-					AddRow(estateID, 100, 200, createdAt, updatedAt)
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
+				estateRow := mock.NewRows([]string{"id", "width", "length", "version", "origin_lat", "origin_lon", "rotation", "created_at", "updated_at"}).
+					AddRow(estateID, 100, 200, 1, nil, nil, 0.0, createdAt, updatedAt)
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, version, origin_lat, origin_lon, rotation, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL;`)).
 					WithArgs(estateID).
 					WillReturnRows(estateRow)
 
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, x, y, height, created_at, updated_at FROM trees WHERE estate_id = $1;`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, x, y, height, species, planted_at, health, tags, created_at, updated_at FROM trees WHERE estate_id = $1;`)).
 					WithArgs(estateID).
 					WillReturnError(errors.New("db error"))
 
@@ -169,18 +188,18 @@ func TestGetEstateWithAllDetails(t *testing.T) {
 		{
 			name: "Error Fetching Stats",
 			mockSetup: func() {
-				estateRow := mock.NewRows([]string{"id", "width", "length", "created_at", "updated_at"}).
-					AddRow(estateID, 100, 200, createdAt, updatedAt)
+				estateRow := mock.NewRows([]string{"id", "width", "length", "version", "origin_lat", "origin_lon", "rotation", "created_at", "updated_at"}).
+					AddRow(estateID, 100, 200, 1, nil, nil, 0.0, createdAt, updatedAt)
 
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, version, origin_lat, origin_lon, rotation, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL;`)).
 					WithArgs(estateID).
 					WillReturnRows(estateRow)
 
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, x, y, height, created_at, updated_at FROM trees WHERE estate_id = $1;`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, x, y, height, species, planted_at, health, tags, created_at, updated_at FROM trees WHERE estate_id = $1;`)).
 					WithArgs(estateID).
 					WillReturnRows(treeRow)
 
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, tree_count, max_height, min_height, median_height,drone_distance, created_at, updated_at
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, estate_id, tree_count, max_height, min_height, median_height,drone_distance, drone_profile, drone_duration, drone_energy, created_at, updated_at
 		    	            			FROM estate_stats
 		    	            			WHERE estate_id = $1;`)).
 					WithArgs(estateID).
@@ -191,7 +210,7 @@ func TestGetEstateWithAllDetails(t *testing.T) {
 		},
 		// 	name: "Exclude Trees and Stats",
 		// 	mockSetup: func() {
-		// 		mock.ExpectQuery(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = \$1`).
+		// 		mock.ExpectQuery(`SELECT id, width, length, version, created_at, updated_at FROM estates WHERE id = \$1 AND deleted_at IS NULL`).
 		// 			WithArgs(estateID).
 		// 			WillReturnRows(estateRow)
 		// 	},
@@ -212,6 +231,11 @@ func TestGetEstateWithAllDetails(t *testing.T) {
 			} else {
 				assert.NotNil(t, estate)
 				assert.NoError(t, err)
+				assert.Equal(t, "Tenera", estate.Trees[0].Species)
+				assert.Equal(t, &plantedAt, estate.Trees[0].PlantedAt)
+				assert.Equal(t, TREE_DISEASED, estate.Trees[0].Health)
+				assert.Equal(t, []string{"young", "irrigated"}, estate.Trees[0].Tags)
+				assert.Equal(t, &GeoReference{OriginLat: 2.1, OriginLon: 101.5, Rotation: 30}, estate.Geo)
 			}
 
 			assert.NoError(t, mock.ExpectationsWereMet()) // Ensure all expectations were met
@@ -231,9 +255,31 @@ func TestCreateTree(t *testing.T) {
 	createdAt := time.Now()
 	updatedAt := time.Now()
 
-	// Mock estate data
-	estateRow := mock.NewRows([]string{"id", "width", "length", "created_at", "updated_at"}).
-		AddRow(estateID, 100, 200, createdAt, updatedAt)
+	input := CreateTreeInput{
+		Id:       treeID,
+		EstateId: estateID,
+		X:        10,
+		Y:        20,
+		Height:   15,
+	}
+	selectEstate := regexp.QuoteMeta(`SELECT id, width, length, version, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`)
+	selectZones := regexp.QuoteMeta(`SELECT id, estate_id, label, kind, points, created_at FROM exclusion_zones WHERE estate_id = $1 ORDER BY created_at, id;`)
+	selectExist := regexp.QuoteMeta(`SELECT EXISTS ( SELECT 1 FROM trees WHERE estate_id = $1 AND x = $2 AND y = $3 );`)
+	insertTree := regexp.QuoteMeta(`INSERT INTO trees (id, estate_id, x, y, height, species, planted_at, health, tags) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`)
+	zoneColumns := []string{"id", "estate_id", "label", "kind", "points", "created_at"}
+
+	// the estate is locked before the plot is checked, every check runs in the transaction
+	expectLockedEstate := func() {
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectEstate).
+			WithArgs(estateID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "width", "length", "version", "created_at", "updated_at"}).AddRow(estateID, 100, 200, 1, createdAt, updatedAt))
+	}
+	expectFreePlot := func() {
+		expectLockedEstate()
+		mock.ExpectQuery(selectZones).WithArgs(estateID).WillReturnRows(sqlmock.NewRows(zoneColumns))
+		mock.ExpectQuery(selectExist).WithArgs(estateID, 10, 20).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	}
 
 	tests := []struct {
 		name          string
@@ -244,57 +290,39 @@ func TestCreateTree(t *testing.T) {
 		{
 			name: "Success - Create Tree",
 			mockSetup: func() {
-				// Mock getEstateByIdSql
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
+				expectFreePlot()
+				mock.ExpectExec(insertTree).
+					WithArgs(treeID, estateID, 10, 20, 15, "", nil, TREE_HEALTHY, "{}").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE estates SET version = version + 1 WHERE id = $1;`)).
 					WithArgs(estateID).
-					WillReturnRows(estateRow)
-
-				// Mock checkExistEstateTree
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS ( SELECT 1 FROM trees WHERE estate_id = $1 AND x = $2 AND y = $3 );`)).
-					WithArgs(estateID, 10, 20).
-					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
-
-				// Mock createTreeSQL
-				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO trees (id, estate_id, x, y, height) VALUES ($1, $2, $3, $4, $5);`)).
-					WithArgs(treeID, estateID, 10, 20, 15).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mock, estateID, AUDIT_TREE_CREATED, treeID)
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+					WithArgs(sqlmock.AnyArg(), estateID, EVENT_TREE_ADDED, sqlmock.AnyArg(), sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+					WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
 			},
-			input: CreateTreeInput{
-				Id:       treeID,
-				EstateId: estateID,
-				X:        10,
-				Y:        20,
-				Height:   15,
-			},
-			expectedError: nil,
+			input: input,
 		},
 		{
-			name: "Estate Not Found",
+			name: "Estate Not Found Or Deleted",
 			mockSetup: func() {
-				// Mock getEstateByIdSql
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
-					WithArgs(estateID).
-					WillReturnError(sql.ErrNoRows)
-			},
-			input: CreateTreeInput{
-				Id:       treeID,
-				EstateId: estateID,
-				X:        10,
-				Y:        20,
-				Height:   15,
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
 			},
+			input:         input,
 			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound),
 		},
 		{
 			name: "Invalid X Coordinate",
 			mockSetup: func() {
-				estateRow := mock.NewRows([]string{"id", "width", "length", "created_at", "updated_at"}).
-					AddRow(estateID, 100, 200, createdAt, updatedAt)
-
-				// Mock getEstateByIdSql
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
-					WithArgs(estateID).
-					WillReturnRows(estateRow)
+				expectLockedEstate()
+				mock.ExpectRollback()
 			},
 			input: CreateTreeInput{
 				Id:       treeID,
@@ -308,12 +336,8 @@ func TestCreateTree(t *testing.T) {
 		{
 			name: "Invalid Y Coordinate",
 			mockSetup: func() {
-				estateRow := mock.NewRows([]string{"id", "width", "length", "created_at", "updated_at"}).
-					AddRow(estateID, 100, 200, createdAt, updatedAt)
-				// Mock getEstateByIdSql
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
-					WithArgs(estateID).
-					WillReturnRows(estateRow)
+				expectLockedEstate()
+				mock.ExpectRollback()
 			},
 			input: CreateTreeInput{
 				Id:       treeID,
@@ -325,84 +349,73 @@ func TestCreateTree(t *testing.T) {
 			expectedError: apperror.WrapWithCode(fmt.Errorf("coordinate y cannot greater than %d", 100), http.StatusBadRequest),
 		},
 		{
-			name: "Tree Already Exists",
+			name: "Plot Is Excluded",
 			mockSetup: func() {
-				estateRow := mock.NewRows([]string{"id", "width", "length", "created_at", "updated_at"}).
-					AddRow(estateID, 100, 200, createdAt, updatedAt)
-				// Mock getEstateByIdSql
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
+				expectLockedEstate()
+				// a river crossing the estate from (5,20) to (15,20)
+				mock.ExpectQuery(selectZones).
 					WithArgs(estateID).
-					WillReturnRows(estateRow)
-
-				// Mock checkExistEstateTree
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS ( SELECT 1 FROM trees WHERE estate_id = $1 AND x = $2 AND y = $3 );`)).
-					WithArgs(estateID, 10, 20).
-					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+					WillReturnRows(sqlmock.NewRows(zoneColumns).
+						AddRow(uuid.New(), estateID, "river", EXCLUSION_KIND_POLYGON, []byte(`[{"x":5,"y":20},{"x":15,"y":20},{"x":15,"y":21},{"x":5,"y":21}]`), createdAt))
+				mock.ExpectRollback()
 			},
-			input: CreateTreeInput{
-				Id:       treeID,
-				EstateId: estateID,
-				X:        10,
-				Y:        20,
-				Height:   15,
+			input:         input,
+			expectedError: apperror.WrapWithCode(errors.New("plot (10,20) is excluded from planting"), http.StatusUnprocessableEntity),
+		},
+		{
+			name: "Tree Already Exists",
+			mockSetup: func() {
+				expectLockedEstate()
+				mock.ExpectQuery(selectZones).WithArgs(estateID).WillReturnRows(sqlmock.NewRows(zoneColumns))
+				mock.ExpectQuery(selectExist).WithArgs(estateID, 10, 20).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
 			},
+			input:         input,
 			expectedError: apperror.WrapWithCode(errors.New("plot already has a tree"), http.StatusUnprocessableEntity),
 		},
 		{
 			name: "Database Error - Check Tree Existence",
 			mockSetup: func() {
-				estateRow := mock.NewRows([]string{"id", "width", "length", "created_at", "updated_at"}).
-					AddRow(estateID, 100, 200, createdAt, updatedAt)
-
-				// Mock getEstateByIdSql
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
-					WithArgs(estateID).
-					WillReturnRows(estateRow)
-
-				// Mock checkExistEstateTree
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS ( SELECT 1 FROM trees WHERE estate_id = $1 AND x = $2 AND y = $3 );`)).
-					WithArgs(estateID, 10, 20).
-					WillReturnError(errors.New("db error"))
-			},
-			input: CreateTreeInput{
-				Id:       treeID,
-				EstateId: estateID,
-				X:        10,
-				Y:        20,
-				Height:   15,
+				expectLockedEstate()
+				mock.ExpectQuery(selectZones).WithArgs(estateID).WillReturnRows(sqlmock.NewRows(zoneColumns))
+				mock.ExpectQuery(selectExist).WithArgs(estateID, 10, 20).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
 			},
+			input:         input,
 			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to check plot tree in database: %w", errors.New("db error")), http.StatusInternalServerError),
 		},
 		{
 			name: "Database Error - Create Tree",
 			mockSetup: func() {
-				estateRow := mock.NewRows([]string{"id", "width", "length", "created_at", "updated_at"}).
-					AddRow(estateID, 100, 200, createdAt, updatedAt)
-
-				// Mock getEstateByIdSql
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, width, length, created_at, updated_at FROM estates WHERE id = $1;`)).
-					WithArgs(estateID).
-					WillReturnRows(estateRow)
-
-				// Mock checkExistEstateTree
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS ( SELECT 1 FROM trees WHERE estate_id = $1 AND x = $2 AND y = $3 );`)).
-					WithArgs(estateID, 10, 20).
-					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
-
-				// Mock createTreeSQL
-				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO trees (id, estate_id, x, y, height) VALUES ($1, $2, $3, $4, $5);`)).
-					WithArgs(treeID, estateID, 10, 20, 15).
+				expectFreePlot()
+				mock.ExpectExec(insertTree).
+					WithArgs(treeID, estateID, 10, 20, 15, "", nil, TREE_HEALTHY, "{}").
 					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
 			},
-			input: CreateTreeInput{
-				Id:       treeID,
-				EstateId: estateID,
-				X:        10,
-				Y:        20,
-				Height:   15,
-			},
+			input:         input,
 			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to create tree: %w", errors.New("db error")), http.StatusInternalServerError),
 		},
+		{
+			name: "Database Error - Create Outbox Event",
+			mockSetup: func() {
+				// tree insert must be rolled back when the outbox event fails
+				expectFreePlot()
+				mock.ExpectExec(insertTree).
+					WithArgs(treeID, estateID, 10, 20, 15, "", nil, TREE_HEALTHY, "{}").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE estates SET version = version + 1 WHERE id = $1;`)).
+					WithArgs(estateID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectAuditEntry(mock, estateID, AUDIT_TREE_CREATED, treeID)
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+					WithArgs(sqlmock.AnyArg(), estateID, EVENT_TREE_ADDED, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			input:         input,
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to write outbox event: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
 	}
 
 	for _, tc := range tests {
@@ -422,6 +435,114 @@ func TestCreateTree(t *testing.T) {
 	}
 }
 
+func TestUpdateTree(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID, treeID := uuid.New(), uuid.New()
+	createdAt := time.Now()
+	updatedAt := createdAt.Add(time.Hour)
+	species, dead := "dura", TREE_DEAD
+
+	selectEstate := regexp.QuoteMeta(`SELECT id, width, length, version, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`)
+	selectTree := regexp.QuoteMeta(`SELECT id, estate_id, x, y, height, species, planted_at, health, tags, created_at, updated_at FROM trees WHERE id = $1 AND estate_id = $2 FOR UPDATE;`)
+	updateTree := regexp.QuoteMeta(`UPDATE trees SET species = $2, health = $3, tags = $4, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING updated_at;`)
+	treeColumns := []string{"id", "estate_id", "x", "y", "height", "species", "planted_at", "health", "tags", "created_at", "updated_at"}
+	expectLockedEstate := func() {
+		mock.ExpectBegin()
+		mock.ExpectQuery(selectEstate).
+			WithArgs(estateID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "width", "length", "version", "created_at", "updated_at"}).AddRow(estateID, 10, 10, 1, createdAt, nil))
+	}
+
+	tests := []struct {
+		name          string
+		input         UpdateTreeInput
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name:  "Success - Health & Species, Tags Kept",
+			input: UpdateTreeInput{Id: treeID, EstateId: estateID, Species: &species, Health: &dead},
+			mockSetup: func() {
+				expectLockedEstate()
+				mock.ExpectQuery(selectTree).WithArgs(treeID, estateID).
+					WillReturnRows(sqlmock.NewRows(treeColumns).AddRow(treeID, estateID, 3, 4, 12, "tenera", nil, "healthy", "{north}", createdAt, nil))
+				mock.ExpectQuery(updateTree).WithArgs(treeID, "dura", TREE_DEAD, "{\"north\"}").
+					WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(updatedAt))
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE estates SET version = version + 1 WHERE id = $1;`)).
+					WithArgs(estateID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(insertAuditEntry).
+					WithArgs(sqlmock.AnyArg(), estateID, AUDIT_TREE_UPDATED, treeID, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						jsonArg(`{"tree_id":"`+treeID.String()+`","estate_id":"`+estateID.String()+`","x":3,"y":4,"height":12,"species":"tenera","health":"healthy","tags":["north"]}`),
+						jsonArg(`{"tree_id":"`+treeID.String()+`","estate_id":"`+estateID.String()+`","x":3,"y":4,"height":12,"species":"dura","health":"dead","tags":["north"]}`),
+						sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+					WithArgs(sqlmock.AnyArg(), estateID, EVENT_TREE_UPDATED,
+						jsonArg(`{"tree_id":"`+treeID.String()+`","estate_id":"`+estateID.String()+`","x":3,"y":4,"height":12,"species":"dura","health":"dead"}`),
+						sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+					WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "Estate Not Found",
+			input: UpdateTreeInput{Id: treeID, EstateId: estateID, Health: &dead},
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound),
+		},
+		{
+			name:  "Tree Not Found",
+			input: UpdateTreeInput{Id: treeID, EstateId: estateID, Health: &dead},
+			mockSetup: func() {
+				expectLockedEstate()
+				mock.ExpectQuery(selectTree).WithArgs(treeID, estateID).WillReturnRows(sqlmock.NewRows(treeColumns))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("tree with ID %s not found", treeID), http.StatusNotFound),
+		},
+		{
+			name:  "Update Error",
+			input: UpdateTreeInput{Id: treeID, EstateId: estateID, Tags: []string{}},
+			mockSetup: func() {
+				expectLockedEstate()
+				mock.ExpectQuery(selectTree).WithArgs(treeID, estateID).
+					WillReturnRows(sqlmock.NewRows(treeColumns).AddRow(treeID, estateID, 3, 4, 12, "", nil, "healthy", "{north}", createdAt, nil))
+				mock.ExpectQuery(updateTree).WithArgs(treeID, "", TREE_HEALTHY, "{}").WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to update tree: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.UpdateTree(context.Background(), tc.input)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestGetCalculatedEstateStats(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -531,6 +652,45 @@ func TestGetCalculatedEstateStats(t *testing.T) {
 	}
 }
 
+func TestGetTreeGroupStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	columns := []string{"species", "tree_count", "max_height", "min_height", "median_height"}
+
+	t.Run("Group By Species", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT species, COUNT(*) AS tree_count, MAX(height) AS max_height, MIN(height) AS min_height, ROUND(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY height)) AS median_height FROM trees WHERE estate_id = $1 GROUP BY species ORDER BY species;`)).
+			WithArgs(estateID).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("", 1, 4, 4, 4).AddRow("Tenera", 3, 20, 5, 12))
+
+		groups, err := repo.GetTreeGroupStats(context.Background(), estateID, TREE_GROUP_SPECIES)
+		assert.NoError(t, err)
+		assert.Equal(t, []TreeGroupStats{
+			{Value: "", TreeCount: 1, MaxHeight: 4, MinHeight: 4, MedianHeight: 4},
+			{Value: "Tenera", TreeCount: 3, MaxHeight: 20, MinHeight: 5, MedianHeight: 12},
+		}, groups)
+	})
+
+	t.Run("Group By Health", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(`GROUP BY health ORDER BY health;`)).
+			WithArgs(estateID).
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.GetTreeGroupStats(context.Background(), estateID, TREE_GROUP_HEALTH)
+		assert.Equal(t, apperror.WrapWithCode(fmt.Errorf("failed to get tree group stats: %w", errors.New("db error")), http.StatusInternalServerError), err)
+	})
+
+	t.Run("Unknown Group", func(t *testing.T) {
+		_, err := repo.GetTreeGroupStats(context.Background(), estateID, TreeGroup("height; DROP TABLE trees"))
+		assert.Equal(t, http.StatusBadRequest, err.(*apperror.AppError).Code)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUpsertEstateStats(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -547,6 +707,46 @@ func TestUpsertEstateStats(t *testing.T) {
 		MinHeight:     5,
 		MedianHeight:  12,
 		DroneDistance: 100,
+		DroneProfile:  "plot=10,clearance=1,takeoff=1,cruise=1,ascent=1,descent=1,speed=10/3/2,power=200/400/150",
+		DroneDuration: 108,
+		DroneEnergy:   0.94,
+	}
+
+	previousID := uuid.New()
+	selectPrevious := regexp.QuoteMeta(`SELECT id, estate_id, tree_count, max_height, min_height, median_height,drone_distance, drone_profile, drone_duration, drone_energy, created_at, updated_at FROM estate_stats WHERE estate_id = $1;`)
+	upsert := regexp.QuoteMeta(`
+		INSERT INTO estate_stats (id, estate_id, tree_count, max_height, min_height, median_height, drone_distance, drone_profile, drone_duration, drone_energy)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (estate_id) DO UPDATE
+		SET
+			tree_count = EXCLUDED.tree_count,
+			max_height = EXCLUDED.max_height,
+			min_height = EXCLUDED.min_height,
+			median_height = EXCLUDED.median_height,
+			drone_distance = EXCLUDED.drone_distance,
+			drone_profile = EXCLUDED.drone_profile,
+			drone_duration = EXCLUDED.drone_duration,
+			drone_energy = EXCLUDED.drone_energy,
+			updated_at = CURRENT_TIMESTAMP;`)
+	upsertArgs := []driver.Value{
+		stats.Id,
+		estateID,
+		stats.TreeCount,
+		stats.MaxHeight,
+		stats.MinHeight,
+		stats.MedianHeight,
+		stats.DroneDistance,
+		stats.DroneProfile,
+		stats.DroneDuration,
+		stats.DroneEnergy,
+	}
+	expectEvent := func() {
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+			WithArgs(sqlmock.AnyArg(), estateID, EVENT_ESTATE_STATS_CHANGED, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+			WithArgs(ESTATE_EVENTS_CHANNEL, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
 	}
 
 	tests := []struct {
@@ -559,28 +759,29 @@ func TestUpsertEstateStats(t *testing.T) {
 		{
 			name: "Success - Upsert Estate Stats",
 			mockSetup: func() {
-				// Mock upsertEstateStatsSQL
-				mock.ExpectExec(regexp.QuoteMeta(`
-					INSERT INTO estate_stats (id, estate_id, tree_count, max_height, min_height, median_height, drone_distance)
-					VALUES ($1, $2, $3, $4, $5, $6, $7)
-					ON CONFLICT (estate_id) DO UPDATE
-					SET
-						tree_count = EXCLUDED.tree_count,
-						max_height = EXCLUDED.max_height,
-						min_height = EXCLUDED.min_height,
-						median_height = EXCLUDED.median_height,
-						drone_distance = EXCLUDED.drone_distance,
-						updated_at = CURRENT_TIMESTAMP;`)).
-					WithArgs(
-						stats.Id,
-						estateID,
-						stats.TreeCount,
-						stats.MaxHeight,
-						stats.MinHeight,
-						stats.MedianHeight,
-						stats.DroneDistance,
-					).
-					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectPrevious).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectExec(upsert).WithArgs(upsertArgs...).WillReturnResult(sqlmock.NewResult(1, 1))
+				expectAuditEntry(mock, estateID, AUDIT_ESTATE_STATS_UPSERTED, stats.Id)
+				expectEvent()
+				mock.ExpectCommit()
+			},
+			estateId:      estateID,
+			stats:         stats,
+			expectedError: nil,
+		},
+		{
+			name: "Success - Replace Previous Stats",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectPrevious).WithArgs(estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "tree_count", "max_height", "min_height", "median_height", "drone_distance", "drone_profile", "drone_duration", "drone_energy", "created_at", "updated_at"}).
+						AddRow(previousID, estateID.String(), 8, 18, 5, 10, 80, "", 90, 0.8, time.Now(), nil))
+				mock.ExpectExec(upsert).WithArgs(upsertArgs...).WillReturnResult(sqlmock.NewResult(1, 1))
+				// the stats row keep its ID
+				expectAuditEntry(mock, estateID, AUDIT_ESTATE_STATS_UPSERTED, previousID)
+				expectEvent()
+				mock.ExpectCommit()
 			},
 			estateId:      estateID,
 			stats:         stats,
@@ -589,28 +790,10 @@ func TestUpsertEstateStats(t *testing.T) {
 		{
 			name: "Database Error",
 			mockSetup: func() {
-				// Mock upsertEstateStatsSQL
-				mock.ExpectExec(regexp.QuoteMeta(`
-					INSERT INTO estate_stats (id, estate_id, tree_count, max_height, min_height, median_height, drone_distance)
-					VALUES ($1, $2, $3, $4, $5, $6, $7)
-					ON CONFLICT (estate_id) DO UPDATE
-					SET
-						tree_count = EXCLUDED.tree_count,
-						max_height = EXCLUDED.max_height,
-						min_height = EXCLUDED.min_height,
-						median_height = EXCLUDED.median_height,
-						drone_distance = EXCLUDED.drone_distance,
-						updated_at = CURRENT_TIMESTAMP;`)).
-					WithArgs(
-						stats.Id,
-						estateID,
-						stats.TreeCount,
-						stats.MaxHeight,
-						stats.MinHeight,
-						stats.MedianHeight,
-						stats.DroneDistance,
-					).
-					WillReturnError(errors.New("db error"))
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectPrevious).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectExec(upsert).WithArgs(upsertArgs...).WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
 			},
 			estateId:      estateID,
 			stats:         stats,
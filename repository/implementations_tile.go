@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+)
+
+// GetTileCells list the cells of a map tile holding trees, ordered by row then column
+func (r *Repository) GetTileCells(ctx context.Context, input GetTileCellsInput) ([]TileCell, error) {
+	lo := Point{X: input.X * TILE_SIZE, Y: input.Y * TILE_SIZE}
+	hi := Point{X: lo.X + TILE_SIZE - 1, Y: lo.Y + TILE_SIZE - 1}
+
+	var (
+		cells []TileCell
+		err   error
+	)
+	if input.Zoom >= input.MaxZoom {
+		cells, err = r.getTileTreesSQL(ctx, input.EstateId, lo, hi)
+	} else {
+		cells, err = r.getTileCellsSQL(ctx, input.EstateId, input.Zoom, lo, hi)
+	}
+	if err != nil {
+		return nil, apperror.WrapWithCode(fmt.Errorf("failed to get tile cells: %w", err), http.StatusInternalServerError)
+	}
+
+	return cells, nil
+}
+
+// RebuildTiles aggregate the tile cells of every zoom again from the trees of the estate,
+// for the estates planted before the tiles were kept
+func (r *Repository) RebuildTiles(ctx context.Context, estateID uuid.UUID) error {
+	err := r.withTx(ctx, func(tx *sql.Tx) error {
+		estate, err := r.getEstateForUpdateSQL(ctx, tx, estateID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.WrapWithCode(fmt.Errorf("estate with ID %s not found", estateID), http.StatusNotFound)
+			}
+			return fmt.Errorf("failed to get estate: %w", err)
+		}
+
+		return r.rebuildTileCells(ctx, tx, estate)
+	})
+	if err != nil {
+		var appErr *apperror.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return apperror.WrapWithCode(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+// refreshTileCells recompute the stored cells holding the newly planted plots, from the finest zoom out to zoom 0.
+// Nothing is stored for an estate fitting in a single tile, its only tile is read from the trees
+func (r *Repository) refreshTileCells(ctx context.Context, exec dbExecutor, estate *Estate, plots []Point) error {
+	maxZoom := estate.MaxTileZoom()
+	cells := make([]Point, 0, len(plots))
+	for _, p := range plots {
+		cells = append(cells, Point{X: p.X - 1, Y: p.Y - 1})
+	}
+
+	for zoom := maxZoom - 1; zoom >= 0; zoom-- {
+		cells = parentTileCells(cells)
+		var err error
+		if zoom == maxZoom-1 {
+			err = r.refreshTileCellsFromTreesSQL(ctx, exec, estate.Id, zoom, estate.TileCellSize(zoom), cells)
+		} else {
+			err = r.refreshTileCellsFromChildrenSQL(ctx, exec, estate.Id, zoom, cells)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to refresh tile cells of zoom %d: %w", zoom, err)
+		}
+	}
+
+	return nil
+}
+
+// rebuildTileCells drop the tile cells of the estate and aggregate every zoom again
+func (r *Repository) rebuildTileCells(ctx context.Context, exec dbExecutor, estate *Estate) error {
+	if err := r.deleteTileCellsSQL(ctx, exec, estate.Id); err != nil {
+		return fmt.Errorf("failed to delete tile cells: %w", err)
+	}
+
+	maxZoom := estate.MaxTileZoom()
+	for zoom := maxZoom - 1; zoom >= 0; zoom-- {
+		var err error
+		if zoom == maxZoom-1 {
+			err = r.buildTileLevelFromTreesSQL(ctx, exec, estate.Id, zoom, estate.TileCellSize(zoom))
+		} else {
+			err = r.buildTileLevelFromChildrenSQL(ctx, exec, estate.Id, zoom)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to build tile cells of zoom %d: %w", zoom, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// refreshTileCellsFromTreesSQL recompute the given cells of the finest stored zoom from the trees they hold
+func (r *Repository) refreshTileCellsFromTreesSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, zoom, cellSize int, cells []Point) error {
+	xs, ys := tileCellArrays(cells)
+	_, err := exec.ExecContext(ctx, `
+		INSERT INTO tile_cells (estate_id, zoom, x, y, tree_count, sum_height, max_height)
+		SELECT $1, $2, c.x, c.y, COUNT(*), SUM(t.height), MAX(t.height)
+		FROM unnest($4::int[], $5::int[]) AS c(x, y)
+		JOIN trees t ON t.estate_id = $1
+			AND t.x BETWEEN c.x * $3 + 1 AND (c.x + 1) * $3
+			AND t.y BETWEEN c.y * $3 + 1 AND (c.y + 1) * $3
+		GROUP BY c.x, c.y
+		ON CONFLICT (estate_id, zoom, x, y) DO UPDATE
+		SET tree_count = EXCLUDED.tree_count, sum_height = EXCLUDED.sum_height, max_height = EXCLUDED.max_height;`,
+		estateID, zoom, cellSize, pq.Array(xs), pq.Array(ys))
+	return err
+}
+
+// refreshTileCellsFromChildrenSQL recompute the given cells from their 4 cells one zoom in
+func (r *Repository) refreshTileCellsFromChildrenSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, zoom int, cells []Point) error {
+	xs, ys := tileCellArrays(cells)
+	_, err := exec.ExecContext(ctx, `
+		INSERT INTO tile_cells (estate_id, zoom, x, y, tree_count, sum_height, max_height)
+		SELECT $1, $2, c.x, c.y, SUM(t.tree_count), SUM(t.sum_height), MAX(t.max_height)
+		FROM unnest($3::int[], $4::int[]) AS c(x, y)
+		JOIN tile_cells t ON t.estate_id = $1 AND t.zoom = $2 + 1
+			AND t.x BETWEEN c.x * 2 AND c.x * 2 + 1
+			AND t.y BETWEEN c.y * 2 AND c.y * 2 + 1
+		GROUP BY c.x, c.y
+		ON CONFLICT (estate_id, zoom, x, y) DO UPDATE
+		SET tree_count = EXCLUDED.tree_count, sum_height = EXCLUDED.sum_height, max_height = EXCLUDED.max_height;`,
+		estateID, zoom, pq.Array(xs), pq.Array(ys))
+	return err
+}
+
+func (r *Repository) deleteTileCellsSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID) error {
+	_, err := exec.ExecContext(ctx, `DELETE FROM tile_cells WHERE estate_id = $1;`, estateID)
+	return err
+}
+
+// buildTileLevelFromTreesSQL aggregate every tree of the estate into the cells of the finest stored zoom
+func (r *Repository) buildTileLevelFromTreesSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, zoom, cellSize int) error {
+	_, err := exec.ExecContext(ctx, `
+		INSERT INTO tile_cells (estate_id, zoom, x, y, tree_count, sum_height, max_height)
+		SELECT $1, $2, (x - 1) / $3, (y - 1) / $3, COUNT(*), SUM(height), MAX(height)
+		FROM trees
+		WHERE estate_id = $1
+		GROUP BY (x - 1) / $3, (y - 1) / $3;`,
+		estateID, zoom, cellSize)
+	return err
+}
+
+// buildTileLevelFromChildrenSQL aggregate every cell of the zoom one zoom in
+func (r *Repository) buildTileLevelFromChildrenSQL(ctx context.Context, exec dbExecutor, estateID uuid.UUID, zoom int) error {
+	_, err := exec.ExecContext(ctx, `
+		INSERT INTO tile_cells (estate_id, zoom, x, y, tree_count, sum_height, max_height)
+		SELECT $1, $2, x / 2, y / 2, SUM(tree_count), SUM(sum_height), MAX(max_height)
+		FROM tile_cells
+		WHERE estate_id = $1 AND zoom = $2 + 1
+		GROUP BY x / 2, y / 2;`,
+		estateID, zoom)
+	return err
+}
+
+// getTileCellsSQL list the stored cells of the zoom inside the lo,hi cell rectangle
+func (r *Repository) getTileCellsSQL(ctx context.Context, estateID uuid.UUID, zoom int, lo, hi Point) ([]TileCell, error) {
+	rows, err := r.Db.QueryContext(ctx, `
+		SELECT x, y, tree_count, sum_height, max_height
+		FROM tile_cells
+		WHERE estate_id = $1 AND zoom = $2 AND x BETWEEN $3 AND $4 AND y BETWEEN $5 AND $6
+		ORDER BY y, x;`,
+		estateID, zoom, lo.X, hi.X, lo.Y, hi.Y)
+	if err != nil {
+		return nil, err
+	}
+	return scanTileCells(rows)
+}
+
+// getTileTreesSQL list the trees inside the lo,hi cell rectangle of the max zoom as cells
+func (r *Repository) getTileTreesSQL(ctx context.Context, estateID uuid.UUID, lo, hi Point) ([]TileCell, error) {
+	rows, err := r.Db.QueryContext(ctx, `
+		SELECT x - 1, y - 1, 1, height, height
+		FROM trees
+		WHERE estate_id = $1 AND x BETWEEN $2 AND $3 AND y BETWEEN $4 AND $5
+		ORDER BY y, x;`,
+		estateID, lo.X+1, hi.X+1, lo.Y+1, hi.Y+1)
+	if err != nil {
+		return nil, err
+	}
+	return scanTileCells(rows)
+}
+
+func scanTileCells(rows *sql.Rows) ([]TileCell, error) {
+	defer rows.Close()
+
+	cells := []TileCell{}
+	for rows.Next() {
+		var c TileCell
+		if err := rows.Scan(&c.X, &c.Y, &c.TreeCount, &c.SumHeight, &c.MaxHeight); err != nil {
+			return nil, err
+		}
+		cells = append(cells, c)
+	}
+
+	return cells, rows.Err()
+}
+
+func tileCellArrays(cells []Point) (xs, ys []int64) {
+	xs = make([]int64, 0, len(cells))
+	ys = make([]int64, 0, len(cells))
+	for _, c := range cells {
+		xs = append(xs, int64(c.X))
+		ys = append(ys, int64(c.Y))
+	}
+	return xs, ys
+}
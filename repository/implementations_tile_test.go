@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTileCells(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	selectTrees := regexp.QuoteMeta(`SELECT x - 1, y - 1, 1, height, height FROM trees WHERE estate_id = $1 AND x BETWEEN $2 AND $3 AND y BETWEEN $4 AND $5 ORDER BY y, x;`)
+	selectCells := regexp.QuoteMeta(`SELECT x, y, tree_count, sum_height, max_height FROM tile_cells WHERE estate_id = $1 AND zoom = $2 AND x BETWEEN $3 AND $4 AND y BETWEEN $5 AND $6 ORDER BY y, x;`)
+	columns := []string{"x", "y", "tree_count", "sum_height", "max_height"}
+
+	tests := []struct {
+		name          string
+		input         GetTileCellsInput
+		mockSetup     func()
+		expectedCells []TileCell
+		expectedError error
+	}{
+		{
+			name:  "Max Zoom Read From Trees",
+			input: GetTileCellsInput{EstateId: estateID, Zoom: 2, MaxZoom: 2, X: 1, Y: 0},
+			mockSetup: func() {
+				mock.ExpectQuery(selectTrees).WithArgs(estateID, 257, 512, 1, 256).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(300, 4, 1, 12, 12))
+			},
+			expectedCells: []TileCell{{X: 300, Y: 4, TreeCount: 1, SumHeight: 12, MaxHeight: 12}},
+		},
+		{
+			name:  "Stored Zoom",
+			input: GetTileCellsInput{EstateId: estateID, Zoom: 0, MaxZoom: 2},
+			mockSetup: func() {
+				mock.ExpectQuery(selectCells).WithArgs(estateID, 0, 0, 255, 0, 255).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(0, 0, 3, 20, 9).AddRow(149, 0, 1, 5, 5))
+			},
+			expectedCells: []TileCell{
+				{X: 0, Y: 0, TreeCount: 3, SumHeight: 20, MaxHeight: 9},
+				{X: 149, Y: 0, TreeCount: 1, SumHeight: 5, MaxHeight: 5},
+			},
+		},
+		{
+			name:  "Empty Tile",
+			input: GetTileCellsInput{EstateId: estateID, Zoom: 1, MaxZoom: 2, X: 1, Y: 1},
+			mockSetup: func() {
+				mock.ExpectQuery(selectCells).WithArgs(estateID, 1, 256, 511, 256, 511).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedCells: []TileCell{},
+		},
+		{
+			name:  "Database Error",
+			input: GetTileCellsInput{EstateId: estateID, Zoom: 0, MaxZoom: 0},
+			mockSetup: func() {
+				mock.ExpectQuery(selectTrees).WillReturnError(errors.New("db error"))
+			},
+			expectedError: apperror.WrapWithCode(errors.New("failed to get tile cells: db error"), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			cells, err := repo.GetTileCells(context.Background(), tc.input)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCells, cells)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRebuildTiles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	selectEstate := regexp.QuoteMeta(`SELECT id, width, length, version, created_at, updated_at FROM estates WHERE id = $1 AND deleted_at IS NULL FOR UPDATE;`)
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "width", "length", "version", "created_at", "updated_at"}).
+						AddRow(estateID, 600, 1000, 1, time.Now(), nil))
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM tile_cells WHERE estate_id = $1;`)).WithArgs(estateID).
+					WillReturnResult(sqlmock.NewResult(0, 10))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT $1, $2, (x - 1) / $3, (y - 1) / $3, COUNT(*), SUM(height), MAX(height) FROM trees`)).
+					WithArgs(estateID, 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 5))
+				mock.ExpectExec(regexp.QuoteMeta(`SELECT $1, $2, x / 2, y / 2, SUM(tree_count), SUM(sum_height), MAX(max_height) FROM tile_cells`)).
+					WithArgs(estateID, 0).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Estate Not Found",
+			mockSetup: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectEstate).WithArgs(estateID).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedError: apperror.WrapWithCode(errors.New("estate with ID "+estateID.String()+" not found"), http.StatusNotFound),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.RebuildTiles(context.Background(), estateID)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+				assert.Equal(t, tc.expectedError.(*apperror.AppError).Code, err.(*apperror.AppError).Code)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRefreshTileCells(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estate := &Estate{Id: uuid.New(), Width: 10, Length: 600}
+
+	// zoom 1 is aggregated from the trees of 2x2 plots, zoom 0 from the cells of zoom 1
+	mock.ExpectExec(regexp.QuoteMeta(`JOIN trees t ON t.estate_id = $1`)).
+		WithArgs(estate.Id, 1, 2, pq.Array([]int64{0, 299}), pq.Array([]int64{0, 0})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`JOIN tile_cells t ON t.estate_id = $1 AND t.zoom = $2 + 1`)).
+		WithArgs(estate.Id, 0, pq.Array([]int64{0, 149}), pq.Array([]int64{0, 0})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.refreshTileCells(context.Background(), db, estate, []Point{{X: 1, Y: 1}, {X: 600, Y: 1}, {X: 2, Y: 2}})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// an estate fitting in a tile keep no cells
+	err = repo.refreshTileCells(context.Background(), db, &Estate{Id: uuid.New(), Width: 10, Length: 10}, []Point{{X: 1, Y: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
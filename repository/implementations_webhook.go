@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+)
+
+// createOutboxEvent write the event into outbox table using the caller transaction,
+// so the event only exist when the change it describes is committed.
+// The event ID is also sent with NOTIFY, which postgres only deliver to listeners on commit
+func (r *Repository) createOutboxEvent(ctx context.Context, exec dbExecutor, estateID uuid.UUID, eventType EventType, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox event: %w", err)
+	}
+
+	event := OutboxEvent{
+		Id:        uuid.New(),
+		EstateId:  estateID,
+		EventType: eventType,
+		Payload:   payload,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err = r.createOutboxEventSQL(ctx, exec, event); err != nil {
+		return fmt.Errorf("failed to write outbox event: %w", err)
+	}
+
+	message, err := json.Marshal(EventNotification{Id: event.Id, Type: event.EventType, EstateId: event.EstateId})
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox event: %w", err)
+	}
+
+	if err = r.notifyEstateEventSQL(ctx, exec, message); err != nil {
+		return fmt.Errorf("failed to notify outbox event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetOutboxEvent(ctx context.Context, id uuid.UUID) (*OutboxEvent, error) {
+	event, err := r.getOutboxEventSQL(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.WrapWithCode(fmt.Errorf("outbox event with ID %s not found", id), http.StatusNotFound)
+		}
+		return nil, apperror.WrapWithCode(fmt.Errorf("failed to get outbox event: %w", err), http.StatusInternalServerError)
+	}
+
+	return event, nil
+}
+
+func (r *Repository) CreateWebhookSubscriber(ctx context.Context, input CreateWebhookSubscriberInput) error {
+	err := r.createWebhookSubscriberSQL(ctx, input)
+	if err != nil {
+		return apperror.WrapWithCode(fmt.Errorf("failed to create webhook subscriber: %w", err), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (r *Repository) FanOutOutboxEvents(ctx context.Context) (total int64, err error) {
+	total, err = r.fanOutOutboxEventsSQL(ctx)
+	if err != nil {
+		return 0, apperror.WrapWithCode(fmt.Errorf("failed to fan out outbox events: %w", err), http.StatusInternalServerError)
+	}
+
+	return total, nil
+}
+
+func (r *Repository) ClaimWebhookDeliveries(ctx context.Context, input ClaimWebhookDeliveriesInput) (deliveries []WebhookDelivery, err error) {
+	deliveries, err = r.claimWebhookDeliveriesSQL(ctx, input)
+	if err != nil {
+		return nil, apperror.WrapWithCode(fmt.Errorf("failed to claim webhook deliveries: %w", err), http.StatusInternalServerError)
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) MarkWebhookDelivered(ctx context.Context, id uuid.UUID) error {
+	err := r.markWebhookDeliveredSQL(ctx, id)
+	if err != nil {
+		return apperror.WrapWithCode(fmt.Errorf("failed to mark webhook delivered: %w", err), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkWebhookDeliveryFailed(ctx context.Context, input MarkWebhookDeliveryFailedInput) error {
+	err := r.markWebhookDeliveryFailedSQL(ctx, input)
+	if err != nil {
+		return apperror.WrapWithCode(fmt.Errorf("failed to mark webhook delivery failed: %w", err), http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetWebhookDeliveries(ctx context.Context, input GetWebhookDeliveriesInput) (deliveries []WebhookDelivery, err error) {
+	deliveries, err = r.getWebhookDeliveriesSQL(ctx, input)
+	if err != nil {
+		return nil, apperror.WrapWithCode(fmt.Errorf("failed to get webhook deliveries: %w", err), http.StatusInternalServerError)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+func (r *Repository) createOutboxEventSQL(ctx context.Context, exec dbExecutor, event OutboxEvent) error {
+	res, err := exec.ExecContext(ctx, `
+		INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5);`,
+		event.Id, event.EstateId, event.EventType, event.Payload, event.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowAffected < 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+func (r *Repository) notifyEstateEventSQL(ctx context.Context, exec dbExecutor, message []byte) error {
+	_, err := exec.ExecContext(ctx, `SELECT pg_notify($1, $2);`, ESTATE_EVENTS_CHANNEL, string(message))
+	return err
+}
+
+func (r *Repository) getOutboxEventSQL(ctx context.Context, id uuid.UUID) (*OutboxEvent, error) {
+	var event OutboxEvent
+	err := r.Db.QueryRowContext(ctx, `
+		SELECT id, estate_id, event_type, payload, created_at, dispatched_at
+		FROM outbox_events
+		WHERE id = $1;`,
+		id).Scan(&event.Id, &event.EstateId, &event.EventType, &event.Payload, &event.CreatedAt, &event.DispatchedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
+
+func (r *Repository) createWebhookSubscriberSQL(ctx context.Context, input CreateWebhookSubscriberInput) error {
+	res, err := r.Db.ExecContext(ctx, `
+		INSERT INTO webhook_subscribers (id, url, secret)
+		VALUES ($1, $2, $3);`,
+		input.Id, input.Url, input.Secret)
+	if err != nil {
+		return err
+	}
+
+	rowAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowAffected < 1 {
+		return errors.New("no rows affected")
+	}
+
+	return nil
+}
+
+// fanOutOutboxEventsSQL create one delivery per subscriber for every undispatched event,
+// events locked by another dispatcher are skipped
+func (r *Repository) fanOutOutboxEventsSQL(ctx context.Context) (total int64, err error) {
+	query := `
+		WITH pending AS (
+			SELECT id
+			FROM outbox_events
+			WHERE dispatched_at IS NULL
+			ORDER BY created_at
+			FOR UPDATE SKIP LOCKED
+		), fanned AS (
+			INSERT INTO webhook_deliveries (id, event_id, subscriber_id)
+			SELECT gen_random_uuid(), p.id, s.id
+			FROM pending p CROSS JOIN webhook_subscribers s
+			ON CONFLICT (event_id, subscriber_id) DO NOTHING
+			RETURNING id
+		), dispatched AS (
+			UPDATE outbox_events
+			SET dispatched_at = CURRENT_TIMESTAMP
+			WHERE id IN (SELECT id FROM pending)
+		)
+		SELECT COUNT(*) FROM fanned;`
+	err = r.Db.QueryRowContext(ctx, query).Scan(&total)
+	return
+}
+
+// claimWebhookDeliveriesSQL pick due deliveries and push their next_attempt_at by the lease,
+// so other dispatchers will not pick the same deliveries while they are being sent
+func (r *Repository) claimWebhookDeliveriesSQL(ctx context.Context, input ClaimWebhookDeliveriesInput) ([]WebhookDelivery, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE webhook_deliveries
+			SET next_attempt_at = CURRENT_TIMESTAMP + ($2 * INTERVAL '1 millisecond'),
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id IN (
+				SELECT id
+				FROM webhook_deliveries
+				WHERE status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP
+				ORDER BY next_attempt_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, event_id, subscriber_id, status, attempts, next_attempt_at, last_error, created_at, updated_at
+		)
+		SELECT c.id, c.event_id, c.subscriber_id, c.status, c.attempts, c.next_attempt_at, c.last_error, c.created_at, c.updated_at,
+			e.estate_id, e.event_type, e.payload, e.created_at,
+			s.url, s.secret
+		FROM claimed c
+		JOIN outbox_events e ON e.id = c.event_id
+		JOIN webhook_subscribers s ON s.id = c.subscriber_id;`
+	rows, err := r.Db.QueryContext(ctx, query, input.Limit, input.Lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		delivery := WebhookDelivery{Event: &OutboxEvent{}, Subscriber: &WebhookSubscriber{}}
+		if err := rows.Scan(
+			&delivery.Id,
+			&delivery.EventId,
+			&delivery.SubscriberId,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+			&delivery.Event.EstateId,
+			&delivery.Event.EventType,
+			&delivery.Event.Payload,
+			&delivery.Event.CreatedAt,
+			&delivery.Subscriber.Url,
+			&delivery.Subscriber.Secret,
+		); err != nil {
+			return nil, err
+		}
+		delivery.Event.Id = delivery.EventId
+		delivery.Subscriber.Id = delivery.SubscriberId
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) markWebhookDeliveredSQL(ctx context.Context, id uuid.UUID) error {
+	_, err := r.Db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'delivered', attempts = attempts + 1, last_error = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1;`,
+		id)
+	return err
+}
+
+func (r *Repository) markWebhookDeliveryFailedSQL(ctx context.Context, input MarkWebhookDeliveryFailedInput) error {
+	status := WEBHOOK_DELIVERY_PENDING
+	if input.Dead {
+		status = WEBHOOK_DELIVERY_DEAD
+	}
+
+	_, err := r.Db.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1;`,
+		input.Id, status, input.LastError, input.NextAttemptAt)
+	return err
+}
+
+func (r *Repository) getWebhookDeliveriesSQL(ctx context.Context, input GetWebhookDeliveriesInput) ([]WebhookDelivery, error) {
+	query := `
+		SELECT d.id, d.event_id, d.subscriber_id, d.status, d.attempts, d.next_attempt_at, d.last_error, d.created_at, d.updated_at,
+			e.estate_id, e.event_type, e.payload, e.created_at,
+			s.url
+		FROM webhook_deliveries d
+		JOIN outbox_events e ON e.id = d.event_id
+		JOIN webhook_subscribers s ON s.id = d.subscriber_id
+		WHERE d.status = $1
+		ORDER BY d.updated_at DESC NULLS LAST, d.created_at DESC
+		LIMIT $2 OFFSET $3;`
+	rows, err := r.Db.QueryContext(ctx, query, input.Status, input.Limit, input.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		delivery := WebhookDelivery{Event: &OutboxEvent{}, Subscriber: &WebhookSubscriber{}}
+		if err := rows.Scan(
+			&delivery.Id,
+			&delivery.EventId,
+			&delivery.SubscriberId,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+			&delivery.Event.EstateId,
+			&delivery.Event.EventType,
+			&delivery.Event.Payload,
+			&delivery.Event.CreatedAt,
+			&delivery.Subscriber.Url,
+		); err != nil {
+			return nil, err
+		}
+		delivery.Event.Id = delivery.EventId
+		delivery.Subscriber.Id = delivery.SubscriberId
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
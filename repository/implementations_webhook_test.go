@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWebhookSubscriber(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	input := CreateWebhookSubscriberInput{
+		Id:     uuid.New(),
+		Url:    "http://finance.local/hooks",
+		Secret: "0123456789abcdef",
+	}
+
+	tests := []struct {
+		name          string
+		mockSetup     func()
+		expectedError error
+	}{
+		{
+			name: "Success",
+			mockSetup: func() {
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO webhook_subscribers (id, url, secret) VALUES ($1, $2, $3);`)).
+					WithArgs(input.Id, input.Url, input.Secret).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "Database Error",
+			mockSetup: func() {
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO webhook_subscribers (id, url, secret) VALUES ($1, $2, $3);`)).
+					WithArgs(input.Id, input.Url, input.Secret).
+					WillReturnError(errors.New("db error"))
+			},
+			expectedError: apperror.WrapWithCode(fmt.Errorf("failed to create webhook subscriber: %w", errors.New("db error")), http.StatusInternalServerError),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockSetup()
+
+			err := repo.CreateWebhookSubscriber(context.Background(), input)
+
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFanOutOutboxEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+
+	mock.ExpectQuery(`WITH pending AS \(.*FOR UPDATE SKIP LOCKED.*INSERT INTO webhook_deliveries.*UPDATE outbox_events.*SELECT COUNT\(\*\) FROM fanned;`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	total, err := repo.FanOutOutboxEvents(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	mock.ExpectQuery(`WITH pending AS`).WillReturnError(errors.New("db error"))
+
+	_, err = repo.FanOutOutboxEvents(context.Background())
+	assert.EqualError(t, err, "failed to fan out outbox events: db error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimWebhookDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	now := time.Now()
+	deliveryID, eventID, subscriberID, estateID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	columns := []string{"id", "event_id", "subscriber_id", "status", "attempts", "next_attempt_at", "last_error", "created_at", "updated_at",
+		"estate_id", "event_type", "payload", "event_created_at", "url", "secret"}
+
+	mock.ExpectQuery(`WITH claimed AS \(.*FOR UPDATE SKIP LOCKED.*\)`).
+		WithArgs(10, int64(30000)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(
+			deliveryID, eventID, subscriberID, "pending", 1, now, nil, now, nil,
+			estateID, "tree.added", []byte(`{"x":1}`), now, "http://finance.local/hooks", "secret"))
+
+	deliveries, err := repo.ClaimWebhookDeliveries(context.Background(), ClaimWebhookDeliveriesInput{Limit: 10, Lease: 30 * time.Second})
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, deliveryID, deliveries[0].Id)
+	assert.Equal(t, eventID, deliveries[0].Event.Id)
+	assert.Equal(t, EVENT_TREE_ADDED, deliveries[0].Event.EventType)
+	assert.Equal(t, estateID, deliveries[0].Event.EstateId)
+	assert.Equal(t, "secret", deliveries[0].Subscriber.Secret)
+
+	mock.ExpectQuery(`WITH claimed AS`).WillReturnError(errors.New("db error"))
+
+	_, err = repo.ClaimWebhookDeliveries(context.Background(), ClaimWebhookDeliveriesInput{Limit: 10, Lease: 30 * time.Second})
+	assert.EqualError(t, err, "failed to claim webhook deliveries: db error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkWebhookDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	id := uuid.New()
+	nextAttempt := time.Now().Add(time.Minute)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE webhook_deliveries SET status = 'delivered'`)).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.MarkWebhookDelivered(context.Background(), id))
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE webhook_deliveries SET status = $2`)).
+		WithArgs(id, WEBHOOK_DELIVERY_PENDING, "timeout", nextAttempt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.MarkWebhookDeliveryFailed(context.Background(), MarkWebhookDeliveryFailedInput{
+		Id: id, LastError: "timeout", NextAttemptAt: nextAttempt,
+	}))
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE webhook_deliveries SET status = $2`)).
+		WithArgs(id, WEBHOOK_DELIVERY_DEAD, "timeout", nextAttempt).
+		WillReturnError(errors.New("db error"))
+	err = repo.MarkWebhookDeliveryFailed(context.Background(), MarkWebhookDeliveryFailedInput{
+		Id: id, LastError: "timeout", NextAttemptAt: nextAttempt, Dead: true,
+	})
+	assert.EqualError(t, err, "failed to mark webhook delivery failed: db error")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	now := time.Now()
+	lastError := "subscriber responded with status 500"
+
+	columns := []string{"id", "event_id", "subscriber_id", "status", "attempts", "next_attempt_at", "last_error", "created_at", "updated_at",
+		"estate_id", "event_type", "payload", "event_created_at", "url"}
+
+	mock.ExpectQuery(`SELECT d.id, d.event_id.*FROM webhook_deliveries d.*WHERE d.status = \$1`).
+		WithArgs(WEBHOOK_DELIVERY_DEAD, 20, 0).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(
+			uuid.New(), uuid.New(), uuid.New(), "dead", 8, now, lastError, now, now,
+			uuid.New(), "estate.stats_changed", []byte(`{}`), now, "http://finance.local/hooks"))
+
+	deliveries, err := repo.GetWebhookDeliveries(context.Background(), GetWebhookDeliveriesInput{Status: WEBHOOK_DELIVERY_DEAD, Limit: 20})
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, WEBHOOK_DELIVERY_DEAD, deliveries[0].Status)
+	assert.Equal(t, &lastError, deliveries[0].LastError)
+	assert.Equal(t, "http://finance.local/hooks", deliveries[0].Subscriber.Url)
+
+	mock.ExpectQuery(`SELECT d.id, d.event_id`).WillReturnError(errors.New("db error"))
+
+	_, err = repo.GetWebhookDeliveries(context.Background(), GetWebhookDeliveriesInput{Status: WEBHOOK_DELIVERY_DEAD, Limit: 20})
+	assert.EqualError(t, err, "failed to get webhook deliveries: db error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateOutboxEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	estateID := uuid.New()
+	// a payload far above the 8000 bytes postgres accept for NOTIFY
+	data := map[string]string{"label": strings.Repeat("x", 10000)}
+
+	var eventID uuid.UUID
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox_events (id, estate_id, event_type, payload, created_at) VALUES ($1, $2, $3, $4, $5);`)).
+		WithArgs(sqlmock.AnyArg(), estateID, EVENT_EXCLUSION_ADDED, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2);`)).
+		WithArgs(ESTATE_EVENTS_CHANNEL, notificationArg{estateID: estateID, eventID: &eventID}).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, repo.createOutboxEvent(context.Background(), db, estateID, EVENT_EXCLUSION_ADDED, data))
+	assert.NotEqual(t, uuid.Nil, eventID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// notificationArg match a NOTIFY payload holding only the event ID, type & estate ID
+type notificationArg struct {
+	estateID uuid.UUID
+	eventID  *uuid.UUID
+}
+
+func (a notificationArg) Match(v driver.Value) bool {
+	message, ok := v.(string)
+	if !ok || len(message) >= 8000 {
+		return false
+	}
+	var notification EventNotification
+	if err := json.Unmarshal([]byte(message), &notification); err != nil {
+		return false
+	}
+	*a.eventID = notification.Id
+	return notification.EstateId == a.estateID && notification.Type == EVENT_EXCLUSION_ADDED
+}
+
+func TestGetOutboxEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := &Repository{Db: db}
+	eventID, estateID := uuid.New(), uuid.New()
+	now := time.Now()
+	query := regexp.QuoteMeta(`SELECT id, estate_id, event_type, payload, created_at, dispatched_at FROM outbox_events WHERE id = $1;`)
+
+	mock.ExpectQuery(query).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "event_type", "payload", "created_at", "dispatched_at"}).
+			AddRow(eventID, estateID, "tree.added", []byte(`{"x":1}`), now, nil))
+
+	event, err := repo.GetOutboxEvent(context.Background(), eventID)
+	assert.NoError(t, err)
+	assert.Equal(t, &OutboxEvent{Id: eventID, EstateId: estateID, EventType: EVENT_TREE_ADDED, Payload: []byte(`{"x":1}`), CreatedAt: now}, event)
+
+	mock.ExpectQuery(query).WithArgs(eventID).WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.GetOutboxEvent(context.Background(), eventID)
+	assert.EqualError(t, err, fmt.Sprintf("outbox event with ID %s not found", eventID))
+
+	mock.ExpectQuery(query).WithArgs(eventID).WillReturnError(errors.New("db error"))
+
+	_, err = repo.GetOutboxEvent(context.Background(), eventID)
+	assert.EqualError(t, err, "failed to get outbox event: db error")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// jsonArg match a JSON argument equal to the expected JSON, whatever the key order
+type jsonArg string
+
+func (a jsonArg) Match(v driver.Value) bool {
+	var actual []byte
+	switch value := v.(type) {
+	case []byte:
+		actual = value
+	case string:
+		actual = []byte(value)
+	default:
+		return false
+	}
+	var expected, got any
+	if json.Unmarshal([]byte(a), &expected) != nil || json.Unmarshal(actual, &got) != nil {
+		return false
+	}
+	return reflect.DeepEqual(expected, got)
+}
@@ -0,0 +1,289 @@
+// This file contains the repository decorator recording span & duration of every repository method.
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/telemetry"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentedRepository wrap a RepositoryInterface, every method is traced as a child span
+// of the request span and its duration is recorded in telemetry.DbQueryDuration
+type InstrumentedRepository struct {
+	next RepositoryInterface
+}
+
+var _ RepositoryInterface = (*InstrumentedRepository)(nil)
+
+func NewInstrumentedRepository(next RepositoryInterface) *InstrumentedRepository {
+	return &InstrumentedRepository{next: next}
+}
+
+// observe start the span of the method, the returned func must be called with the method error
+func (r *InstrumentedRepository) observe(ctx context.Context, method string) (context.Context, func(err error)) {
+	start := time.Now()
+	ctx, span := telemetry.Tracer().Start(ctx, "repository."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("request.id", telemetry.RequestIdFromContext(ctx)),
+		),
+	)
+
+	return ctx, func(err error) {
+		result := "ok"
+		if err != nil {
+			result = "error"
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+		telemetry.DbQueryDuration.WithLabelValues(method, result).Observe(time.Since(start).Seconds())
+	}
+}
+
+func (r *InstrumentedRepository) CreateEstate(ctx context.Context, input CreateEstateInput) (err error) {
+	ctx, done := r.observe(ctx, "CreateEstate")
+	defer func() { done(err) }()
+	return r.next.CreateEstate(ctx, input)
+}
+
+func (r *InstrumentedRepository) CreateTree(ctx context.Context, input CreateTreeInput) (err error) {
+	ctx, done := r.observe(ctx, "CreateTree")
+	defer func() { done(err) }()
+	return r.next.CreateTree(ctx, input)
+}
+
+func (r *InstrumentedRepository) CreateTrees(ctx context.Context, estateID uuid.UUID, inputs []CreateTreeInput) (err error) {
+	ctx, done := r.observe(ctx, "CreateTrees")
+	defer func() { done(err) }()
+	return r.next.CreateTrees(ctx, estateID, inputs)
+}
+
+func (r *InstrumentedRepository) UpdateTree(ctx context.Context, input UpdateTreeInput) (err error) {
+	ctx, done := r.observe(ctx, "UpdateTree")
+	defer func() { done(err) }()
+	return r.next.UpdateTree(ctx, input)
+}
+
+func (r *InstrumentedRepository) GetEstateWithAllDetails(ctx context.Context, id uuid.UUID, exludeRelations ...Relation) (estate *Estate, err error) {
+	ctx, done := r.observe(ctx, "GetEstateWithAllDetails")
+	defer func() { done(err) }()
+	return r.next.GetEstateWithAllDetails(ctx, id, exludeRelations...)
+}
+
+func (r *InstrumentedRepository) GetCalculatedEstateStats(ctx context.Context, estateId uuid.UUID) (stats *EstateStats, err error) {
+	ctx, done := r.observe(ctx, "GetCalculatedEstateStats")
+	defer func() { done(err) }()
+	return r.next.GetCalculatedEstateStats(ctx, estateId)
+}
+
+func (r *InstrumentedRepository) GetTreeGroupStats(ctx context.Context, estateId uuid.UUID, group TreeGroup) (groups []TreeGroupStats, err error) {
+	ctx, done := r.observe(ctx, "GetTreeGroupStats")
+	defer func() { done(err) }()
+	return r.next.GetTreeGroupStats(ctx, estateId, group)
+}
+
+func (r *InstrumentedRepository) UpsertEstateStats(ctx context.Context, estateID uuid.UUID, stats *EstateStats) (err error) {
+	ctx, done := r.observe(ctx, "UpsertEstateStats")
+	defer func() { done(err) }()
+	return r.next.UpsertEstateStats(ctx, estateID, stats)
+}
+
+func (r *InstrumentedRepository) ResizeEstate(ctx context.Context, input ResizeEstateInput) (estate *Estate, removedTrees []Tree, err error) {
+	ctx, done := r.observe(ctx, "ResizeEstate")
+	defer func() { done(err) }()
+	return r.next.ResizeEstate(ctx, input)
+}
+
+func (r *InstrumentedRepository) DeleteEstate(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, done := r.observe(ctx, "DeleteEstate")
+	defer func() { done(err) }()
+	return r.next.DeleteEstate(ctx, id)
+}
+
+func (r *InstrumentedRepository) RestoreEstate(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, done := r.observe(ctx, "RestoreEstate")
+	defer func() { done(err) }()
+	return r.next.RestoreEstate(ctx, id)
+}
+
+func (r *InstrumentedRepository) UpdateEstateGeo(ctx context.Context, id uuid.UUID, geo GeoReference) (err error) {
+	ctx, done := r.observe(ctx, "UpdateEstateGeo")
+	defer func() { done(err) }()
+	return r.next.UpdateEstateGeo(ctx, id, geo)
+}
+
+func (r *InstrumentedRepository) ExportEstate(ctx context.Context, id uuid.UUID) (bundle *EstateBundle, err error) {
+	ctx, done := r.observe(ctx, "ExportEstate")
+	defer func() { done(err) }()
+	return r.next.ExportEstate(ctx, id)
+}
+
+func (r *InstrumentedRepository) ImportEstate(ctx context.Context, input ImportEstateInput) (err error) {
+	ctx, done := r.observe(ctx, "ImportEstate")
+	defer func() { done(err) }()
+	return r.next.ImportEstate(ctx, input)
+}
+
+func (r *InstrumentedRepository) CreateExclusionZone(ctx context.Context, input CreateExclusionZoneInput) (err error) {
+	ctx, done := r.observe(ctx, "CreateExclusionZone")
+	defer func() { done(err) }()
+	return r.next.CreateExclusionZone(ctx, input)
+}
+
+func (r *InstrumentedRepository) DeleteExclusionZone(ctx context.Context, estateID, zoneID uuid.UUID) (err error) {
+	ctx, done := r.observe(ctx, "DeleteExclusionZone")
+	defer func() { done(err) }()
+	return r.next.DeleteExclusionZone(ctx, estateID, zoneID)
+}
+
+func (r *InstrumentedRepository) CreateObstacle(ctx context.Context, input CreateObstacleInput) (err error) {
+	ctx, done := r.observe(ctx, "CreateObstacle")
+	defer func() { done(err) }()
+	return r.next.CreateObstacle(ctx, input)
+}
+
+func (r *InstrumentedRepository) DeleteObstacle(ctx context.Context, estateID, obstacleID uuid.UUID) (err error) {
+	ctx, done := r.observe(ctx, "DeleteObstacle")
+	defer func() { done(err) }()
+	return r.next.DeleteObstacle(ctx, estateID, obstacleID)
+}
+
+func (r *InstrumentedRepository) CreateHarvest(ctx context.Context, input CreateHarvestInput) (err error) {
+	ctx, done := r.observe(ctx, "CreateHarvest")
+	defer func() { done(err) }()
+	return r.next.CreateHarvest(ctx, input)
+}
+
+func (r *InstrumentedRepository) GetHarvests(ctx context.Context, input GetHarvestsInput) (harvests []Harvest, err error) {
+	ctx, done := r.observe(ctx, "GetHarvests")
+	defer func() { done(err) }()
+	return r.next.GetHarvests(ctx, input)
+}
+
+func (r *InstrumentedRepository) GetAuditEntries(ctx context.Context, input GetAuditEntriesInput) (entries []AuditEntry, err error) {
+	ctx, done := r.observe(ctx, "GetAuditEntries")
+	defer func() { done(err) }()
+	return r.next.GetAuditEntries(ctx, input)
+}
+
+func (r *InstrumentedRepository) GetTileCells(ctx context.Context, input GetTileCellsInput) (cells []TileCell, err error) {
+	ctx, done := r.observe(ctx, "GetTileCells")
+	defer func() { done(err) }()
+	return r.next.GetTileCells(ctx, input)
+}
+
+func (r *InstrumentedRepository) RebuildTiles(ctx context.Context, estateID uuid.UUID) (err error) {
+	ctx, done := r.observe(ctx, "RebuildTiles")
+	defer func() { done(err) }()
+	return r.next.RebuildTiles(ctx, estateID)
+}
+
+func (r *InstrumentedRepository) GetOutboxEvent(ctx context.Context, id uuid.UUID) (event *OutboxEvent, err error) {
+	ctx, done := r.observe(ctx, "GetOutboxEvent")
+	defer func() { done(err) }()
+	return r.next.GetOutboxEvent(ctx, id)
+}
+
+func (r *InstrumentedRepository) CreateWebhookSubscriber(ctx context.Context, input CreateWebhookSubscriberInput) (err error) {
+	ctx, done := r.observe(ctx, "CreateWebhookSubscriber")
+	defer func() { done(err) }()
+	return r.next.CreateWebhookSubscriber(ctx, input)
+}
+
+func (r *InstrumentedRepository) FanOutOutboxEvents(ctx context.Context) (total int64, err error) {
+	ctx, done := r.observe(ctx, "FanOutOutboxEvents")
+	defer func() { done(err) }()
+	return r.next.FanOutOutboxEvents(ctx)
+}
+
+func (r *InstrumentedRepository) ClaimWebhookDeliveries(ctx context.Context, input ClaimWebhookDeliveriesInput) (deliveries []WebhookDelivery, err error) {
+	ctx, done := r.observe(ctx, "ClaimWebhookDeliveries")
+	defer func() { done(err) }()
+	return r.next.ClaimWebhookDeliveries(ctx, input)
+}
+
+func (r *InstrumentedRepository) MarkWebhookDelivered(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, done := r.observe(ctx, "MarkWebhookDelivered")
+	defer func() { done(err) }()
+	return r.next.MarkWebhookDelivered(ctx, id)
+}
+
+func (r *InstrumentedRepository) MarkWebhookDeliveryFailed(ctx context.Context, input MarkWebhookDeliveryFailedInput) (err error) {
+	ctx, done := r.observe(ctx, "MarkWebhookDeliveryFailed")
+	defer func() { done(err) }()
+	return r.next.MarkWebhookDeliveryFailed(ctx, input)
+}
+
+func (r *InstrumentedRepository) GetWebhookDeliveries(ctx context.Context, input GetWebhookDeliveriesInput) (deliveries []WebhookDelivery, err error) {
+	ctx, done := r.observe(ctx, "GetWebhookDeliveries")
+	defer func() { done(err) }()
+	return r.next.GetWebhookDeliveries(ctx, input)
+}
+
+func (r *InstrumentedRepository) CreateJob(ctx context.Context, input CreateJobInput) (err error) {
+	ctx, done := r.observe(ctx, "CreateJob")
+	defer func() { done(err) }()
+	return r.next.CreateJob(ctx, input)
+}
+
+func (r *InstrumentedRepository) GetJob(ctx context.Context, id uuid.UUID) (job *Job, err error) {
+	ctx, done := r.observe(ctx, "GetJob")
+	defer func() { done(err) }()
+	return r.next.GetJob(ctx, id)
+}
+
+func (r *InstrumentedRepository) ClaimJob(ctx context.Context, input ClaimJobInput) (job *Job, err error) {
+	ctx, done := r.observe(ctx, "ClaimJob")
+	defer func() { done(err) }()
+	return r.next.ClaimJob(ctx, input)
+}
+
+func (r *InstrumentedRepository) HeartbeatJob(ctx context.Context, input HeartbeatJobInput) (cancelRequested bool, err error) {
+	ctx, done := r.observe(ctx, "HeartbeatJob")
+	defer func() { done(err) }()
+	return r.next.HeartbeatJob(ctx, input)
+}
+
+func (r *InstrumentedRepository) FinishJob(ctx context.Context, input FinishJobInput) (err error) {
+	ctx, done := r.observe(ctx, "FinishJob")
+	defer func() { done(err) }()
+	return r.next.FinishJob(ctx, input)
+}
+
+func (r *InstrumentedRepository) CancelJob(ctx context.Context, id uuid.UUID) (job *Job, err error) {
+	ctx, done := r.observe(ctx, "CancelJob")
+	defer func() { done(err) }()
+	return r.next.CancelJob(ctx, id)
+}
+
+func (r *InstrumentedRepository) GetCachedDronePlan(ctx context.Context, key DronePlanCacheKey) (plan json.RawMessage, err error) {
+	ctx, done := r.observe(ctx, "GetCachedDronePlan")
+	defer func() { done(err) }()
+	return r.next.GetCachedDronePlan(ctx, key)
+}
+
+func (r *InstrumentedRepository) SaveCachedDronePlan(ctx context.Context, input SaveCachedDronePlanInput) (err error) {
+	ctx, done := r.observe(ctx, "SaveCachedDronePlan")
+	defer func() { done(err) }()
+	return r.next.SaveCachedDronePlan(ctx, input)
+}
+
+func (r *InstrumentedRepository) Ping(ctx context.Context) (err error) {
+	ctx, done := r.observe(ctx, "Ping")
+	defer func() { done(err) }()
+	return r.next.Ping(ctx)
+}
+
+func (r *InstrumentedRepository) CheckSchema(ctx context.Context) (err error) {
+	ctx, done := r.observe(ctx, "CheckSchema")
+	defer func() { done(err) }()
+	return r.next.CheckSchema(ctx)
+}
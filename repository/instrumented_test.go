@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/telemetry"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestInstrumentedRepository(t *testing.T) {
+	t.Run("Pass the request context to the wrapped repository", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := NewMockRepositoryInterface(ctrl)
+		repo := NewInstrumentedRepository(next)
+
+		ctx := telemetry.WithRequestId(context.Background(), "req-1")
+		expected := &Estate{Id: uuid.New()}
+		next.EXPECT().GetEstateWithAllDetails(gomock.Any(), expected.Id, RELATION_TREES).
+			DoAndReturn(func(ctx context.Context, id uuid.UUID, _ ...Relation) (*Estate, error) {
+				assert.Equal(t, "req-1", telemetry.RequestIdFromContext(ctx))
+				return expected, nil
+			})
+
+		estate, err := repo.GetEstateWithAllDetails(ctx, expected.Id, RELATION_TREES)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, estate)
+	})
+
+	t.Run("Record error result", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		next := NewMockRepositoryInterface(ctrl)
+		repo := NewInstrumentedRepository(next)
+
+		before := testutil.CollectAndCount(telemetry.DbQueryDuration, "plantation_db_query_duration_seconds")
+		next.EXPECT().CreateEstate(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+		err := repo.CreateEstate(context.Background(), CreateEstateInput{})
+		assert.EqualError(t, err, "db error")
+		assert.Equal(t, before+1, testutil.CollectAndCount(telemetry.DbQueryDuration, "plantation_db_query_duration_seconds"))
+	})
+}
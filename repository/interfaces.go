@@ -6,6 +6,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/google/uuid"
 )
@@ -13,7 +14,46 @@ import (
 type RepositoryInterface interface {
 	CreateEstate(ctx context.Context, input CreateEstateInput) (err error)
 	CreateTree(ctx context.Context, input CreateTreeInput) (err error)
+	CreateTrees(ctx context.Context, estateID uuid.UUID, inputs []CreateTreeInput) error
+	UpdateTree(ctx context.Context, input UpdateTreeInput) error
 	GetEstateWithAllDetails(ctx context.Context, id uuid.UUID, exludeRelations ...Relation) (estate *Estate, err error)
 	GetCalculatedEstateStats(ctx context.Context, estateId uuid.UUID) (stats *EstateStats, err error)
 	UpsertEstateStats(ctx context.Context, estateID uuid.UUID, stats *EstateStats) error
+	GetTreeGroupStats(ctx context.Context, estateId uuid.UUID, group TreeGroup) (groups []TreeGroupStats, err error)
+	ResizeEstate(ctx context.Context, input ResizeEstateInput) (estate *Estate, removedTrees []Tree, err error)
+	DeleteEstate(ctx context.Context, id uuid.UUID) error
+	RestoreEstate(ctx context.Context, id uuid.UUID) error
+	UpdateEstateGeo(ctx context.Context, id uuid.UUID, geo GeoReference) error
+	ExportEstate(ctx context.Context, id uuid.UUID) (bundle *EstateBundle, err error)
+	ImportEstate(ctx context.Context, input ImportEstateInput) error
+	CreateExclusionZone(ctx context.Context, input CreateExclusionZoneInput) error
+	DeleteExclusionZone(ctx context.Context, estateID, zoneID uuid.UUID) error
+	CreateObstacle(ctx context.Context, input CreateObstacleInput) error
+	DeleteObstacle(ctx context.Context, estateID, obstacleID uuid.UUID) error
+	CreateHarvest(ctx context.Context, input CreateHarvestInput) error
+	GetHarvests(ctx context.Context, input GetHarvestsInput) (harvests []Harvest, err error)
+	GetAuditEntries(ctx context.Context, input GetAuditEntriesInput) (entries []AuditEntry, err error)
+	GetTileCells(ctx context.Context, input GetTileCellsInput) (cells []TileCell, err error)
+	RebuildTiles(ctx context.Context, estateID uuid.UUID) error
+
+	GetOutboxEvent(ctx context.Context, id uuid.UUID) (*OutboxEvent, error)
+	CreateWebhookSubscriber(ctx context.Context, input CreateWebhookSubscriberInput) error
+	FanOutOutboxEvents(ctx context.Context) (total int64, err error)
+	ClaimWebhookDeliveries(ctx context.Context, input ClaimWebhookDeliveriesInput) (deliveries []WebhookDelivery, err error)
+	MarkWebhookDelivered(ctx context.Context, id uuid.UUID) error
+	MarkWebhookDeliveryFailed(ctx context.Context, input MarkWebhookDeliveryFailedInput) error
+	GetWebhookDeliveries(ctx context.Context, input GetWebhookDeliveriesInput) (deliveries []WebhookDelivery, err error)
+
+	CreateJob(ctx context.Context, input CreateJobInput) error
+	GetJob(ctx context.Context, id uuid.UUID) (*Job, error)
+	ClaimJob(ctx context.Context, input ClaimJobInput) (*Job, error)
+	HeartbeatJob(ctx context.Context, input HeartbeatJobInput) (cancelRequested bool, err error)
+	FinishJob(ctx context.Context, input FinishJobInput) error
+	CancelJob(ctx context.Context, id uuid.UUID) (*Job, error)
+
+	GetCachedDronePlan(ctx context.Context, key DronePlanCacheKey) (plan json.RawMessage, err error)
+	SaveCachedDronePlan(ctx context.Context, input SaveCachedDronePlanInput) error
+
+	Ping(ctx context.Context) error
+	CheckSchema(ctx context.Context) error
 }
@@ -11,6 +11,7 @@ package repository
 
 import (
 	context "context"
+	json "encoding/json"
 	reflect "reflect"
 
 	uuid "github.com/google/uuid"
@@ -41,6 +42,65 @@ func (m *MockRepositoryInterface) EXPECT() *MockRepositoryInterfaceMockRecorder
 	return m.recorder
 }
 
+// CancelJob mocks base method.
+func (m *MockRepositoryInterface) CancelJob(ctx context.Context, id uuid.UUID) (*Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelJob", ctx, id)
+	ret0, _ := ret[0].(*Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelJob indicates an expected call of CancelJob.
+func (mr *MockRepositoryInterfaceMockRecorder) CancelJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockRepositoryInterface)(nil).CancelJob), ctx, id)
+}
+
+// CheckSchema mocks base method.
+func (m *MockRepositoryInterface) CheckSchema(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSchema", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSchema indicates an expected call of CheckSchema.
+func (mr *MockRepositoryInterfaceMockRecorder) CheckSchema(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSchema", reflect.TypeOf((*MockRepositoryInterface)(nil).CheckSchema), ctx)
+}
+
+// ClaimJob mocks base method.
+func (m *MockRepositoryInterface) ClaimJob(ctx context.Context, input ClaimJobInput) (*Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJob", ctx, input)
+	ret0, _ := ret[0].(*Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJob indicates an expected call of ClaimJob.
+func (mr *MockRepositoryInterfaceMockRecorder) ClaimJob(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJob", reflect.TypeOf((*MockRepositoryInterface)(nil).ClaimJob), ctx, input)
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockRepositoryInterface) ClaimWebhookDeliveries(ctx context.Context, input ClaimWebhookDeliveriesInput) ([]WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", ctx, input)
+	ret0, _ := ret[0].([]WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockRepositoryInterfaceMockRecorder) ClaimWebhookDeliveries(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockRepositoryInterface)(nil).ClaimWebhookDeliveries), ctx, input)
+}
+
 // CreateEstate mocks base method.
 func (m *MockRepositoryInterface) CreateEstate(ctx context.Context, input CreateEstateInput) error {
 	m.ctrl.T.Helper()
@@ -55,6 +115,62 @@ func (mr *MockRepositoryInterfaceMockRecorder) CreateEstate(ctx, input any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateEstate), ctx, input)
 }
 
+// CreateExclusionZone mocks base method.
+func (m *MockRepositoryInterface) CreateExclusionZone(ctx context.Context, input CreateExclusionZoneInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExclusionZone", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateExclusionZone indicates an expected call of CreateExclusionZone.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateExclusionZone(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExclusionZone", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateExclusionZone), ctx, input)
+}
+
+// CreateHarvest mocks base method.
+func (m *MockRepositoryInterface) CreateHarvest(ctx context.Context, input CreateHarvestInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHarvest", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateHarvest indicates an expected call of CreateHarvest.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateHarvest(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHarvest", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateHarvest), ctx, input)
+}
+
+// CreateJob mocks base method.
+func (m *MockRepositoryInterface) CreateJob(ctx context.Context, input CreateJobInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateJob(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateJob), ctx, input)
+}
+
+// CreateObstacle mocks base method.
+func (m *MockRepositoryInterface) CreateObstacle(ctx context.Context, input CreateObstacleInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateObstacle", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateObstacle indicates an expected call of CreateObstacle.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateObstacle(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateObstacle", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateObstacle), ctx, input)
+}
+
 // CreateTree mocks base method.
 func (m *MockRepositoryInterface) CreateTree(ctx context.Context, input CreateTreeInput) error {
 	m.ctrl.T.Helper()
@@ -69,6 +185,150 @@ func (mr *MockRepositoryInterfaceMockRecorder) CreateTree(ctx, input any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTree", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateTree), ctx, input)
 }
 
+// CreateTrees mocks base method.
+func (m *MockRepositoryInterface) CreateTrees(ctx context.Context, estateID uuid.UUID, inputs []CreateTreeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTrees", ctx, estateID, inputs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTrees indicates an expected call of CreateTrees.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateTrees(ctx, estateID, inputs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTrees", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateTrees), ctx, estateID, inputs)
+}
+
+// CreateWebhookSubscriber mocks base method.
+func (m *MockRepositoryInterface) CreateWebhookSubscriber(ctx context.Context, input CreateWebhookSubscriberInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscriber", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhookSubscriber indicates an expected call of CreateWebhookSubscriber.
+func (mr *MockRepositoryInterfaceMockRecorder) CreateWebhookSubscriber(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscriber", reflect.TypeOf((*MockRepositoryInterface)(nil).CreateWebhookSubscriber), ctx, input)
+}
+
+// DeleteEstate mocks base method.
+func (m *MockRepositoryInterface) DeleteEstate(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEstate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEstate indicates an expected call of DeleteEstate.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteEstate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteEstate), ctx, id)
+}
+
+// DeleteExclusionZone mocks base method.
+func (m *MockRepositoryInterface) DeleteExclusionZone(ctx context.Context, estateID, zoneID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExclusionZone", ctx, estateID, zoneID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExclusionZone indicates an expected call of DeleteExclusionZone.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteExclusionZone(ctx, estateID, zoneID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExclusionZone", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteExclusionZone), ctx, estateID, zoneID)
+}
+
+// DeleteObstacle mocks base method.
+func (m *MockRepositoryInterface) DeleteObstacle(ctx context.Context, estateID, obstacleID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteObstacle", ctx, estateID, obstacleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteObstacle indicates an expected call of DeleteObstacle.
+func (mr *MockRepositoryInterfaceMockRecorder) DeleteObstacle(ctx, estateID, obstacleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteObstacle", reflect.TypeOf((*MockRepositoryInterface)(nil).DeleteObstacle), ctx, estateID, obstacleID)
+}
+
+// ExportEstate mocks base method.
+func (m *MockRepositoryInterface) ExportEstate(ctx context.Context, id uuid.UUID) (*EstateBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportEstate", ctx, id)
+	ret0, _ := ret[0].(*EstateBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportEstate indicates an expected call of ExportEstate.
+func (mr *MockRepositoryInterfaceMockRecorder) ExportEstate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).ExportEstate), ctx, id)
+}
+
+// FanOutOutboxEvents mocks base method.
+func (m *MockRepositoryInterface) FanOutOutboxEvents(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FanOutOutboxEvents", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FanOutOutboxEvents indicates an expected call of FanOutOutboxEvents.
+func (mr *MockRepositoryInterfaceMockRecorder) FanOutOutboxEvents(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FanOutOutboxEvents", reflect.TypeOf((*MockRepositoryInterface)(nil).FanOutOutboxEvents), ctx)
+}
+
+// FinishJob mocks base method.
+func (m *MockRepositoryInterface) FinishJob(ctx context.Context, input FinishJobInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishJob", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishJob indicates an expected call of FinishJob.
+func (mr *MockRepositoryInterfaceMockRecorder) FinishJob(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishJob", reflect.TypeOf((*MockRepositoryInterface)(nil).FinishJob), ctx, input)
+}
+
+// GetAuditEntries mocks base method.
+func (m *MockRepositoryInterface) GetAuditEntries(ctx context.Context, input GetAuditEntriesInput) ([]AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEntries", ctx, input)
+	ret0, _ := ret[0].([]AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEntries indicates an expected call of GetAuditEntries.
+func (mr *MockRepositoryInterfaceMockRecorder) GetAuditEntries(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEntries", reflect.TypeOf((*MockRepositoryInterface)(nil).GetAuditEntries), ctx, input)
+}
+
+// GetCachedDronePlan mocks base method.
+func (m *MockRepositoryInterface) GetCachedDronePlan(ctx context.Context, key DronePlanCacheKey) (json.RawMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCachedDronePlan", ctx, key)
+	ret0, _ := ret[0].(json.RawMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCachedDronePlan indicates an expected call of GetCachedDronePlan.
+func (mr *MockRepositoryInterfaceMockRecorder) GetCachedDronePlan(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCachedDronePlan", reflect.TypeOf((*MockRepositoryInterface)(nil).GetCachedDronePlan), ctx, key)
+}
+
 // GetCalculatedEstateStats mocks base method.
 func (m *MockRepositoryInterface) GetCalculatedEstateStats(ctx context.Context, estateId uuid.UUID) (*EstateStats, error) {
 	m.ctrl.T.Helper()
@@ -104,6 +364,253 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetEstateWithAllDetails(ctx, id a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateWithAllDetails", reflect.TypeOf((*MockRepositoryInterface)(nil).GetEstateWithAllDetails), varargs...)
 }
 
+// GetHarvests mocks base method.
+func (m *MockRepositoryInterface) GetHarvests(ctx context.Context, input GetHarvestsInput) ([]Harvest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHarvests", ctx, input)
+	ret0, _ := ret[0].([]Harvest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHarvests indicates an expected call of GetHarvests.
+func (mr *MockRepositoryInterfaceMockRecorder) GetHarvests(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHarvests", reflect.TypeOf((*MockRepositoryInterface)(nil).GetHarvests), ctx, input)
+}
+
+// GetJob mocks base method.
+func (m *MockRepositoryInterface) GetJob(ctx context.Context, id uuid.UUID) (*Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", ctx, id)
+	ret0, _ := ret[0].(*Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockRepositoryInterfaceMockRecorder) GetJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockRepositoryInterface)(nil).GetJob), ctx, id)
+}
+
+// GetOutboxEvent mocks base method.
+func (m *MockRepositoryInterface) GetOutboxEvent(ctx context.Context, id uuid.UUID) (*OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboxEvent", ctx, id)
+	ret0, _ := ret[0].(*OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOutboxEvent indicates an expected call of GetOutboxEvent.
+func (mr *MockRepositoryInterfaceMockRecorder) GetOutboxEvent(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboxEvent", reflect.TypeOf((*MockRepositoryInterface)(nil).GetOutboxEvent), ctx, id)
+}
+
+// GetTileCells mocks base method.
+func (m *MockRepositoryInterface) GetTileCells(ctx context.Context, input GetTileCellsInput) ([]TileCell, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTileCells", ctx, input)
+	ret0, _ := ret[0].([]TileCell)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTileCells indicates an expected call of GetTileCells.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTileCells(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTileCells", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTileCells), ctx, input)
+}
+
+// GetTreeGroupStats mocks base method.
+func (m *MockRepositoryInterface) GetTreeGroupStats(ctx context.Context, estateId uuid.UUID, group TreeGroup) ([]TreeGroupStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeGroupStats", ctx, estateId, group)
+	ret0, _ := ret[0].([]TreeGroupStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeGroupStats indicates an expected call of GetTreeGroupStats.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeGroupStats(ctx, estateId, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeGroupStats", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeGroupStats), ctx, estateId, group)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockRepositoryInterface) GetWebhookDeliveries(ctx context.Context, input GetWebhookDeliveriesInput) ([]WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", ctx, input)
+	ret0, _ := ret[0].([]WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockRepositoryInterfaceMockRecorder) GetWebhookDeliveries(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockRepositoryInterface)(nil).GetWebhookDeliveries), ctx, input)
+}
+
+// HeartbeatJob mocks base method.
+func (m *MockRepositoryInterface) HeartbeatJob(ctx context.Context, input HeartbeatJobInput) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeartbeatJob", ctx, input)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeartbeatJob indicates an expected call of HeartbeatJob.
+func (mr *MockRepositoryInterfaceMockRecorder) HeartbeatJob(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeartbeatJob", reflect.TypeOf((*MockRepositoryInterface)(nil).HeartbeatJob), ctx, input)
+}
+
+// ImportEstate mocks base method.
+func (m *MockRepositoryInterface) ImportEstate(ctx context.Context, input ImportEstateInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportEstate", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportEstate indicates an expected call of ImportEstate.
+func (mr *MockRepositoryInterfaceMockRecorder) ImportEstate(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).ImportEstate), ctx, input)
+}
+
+// MarkWebhookDelivered mocks base method.
+func (m *MockRepositoryInterface) MarkWebhookDelivered(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWebhookDelivered", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWebhookDelivered indicates an expected call of MarkWebhookDelivered.
+func (mr *MockRepositoryInterfaceMockRecorder) MarkWebhookDelivered(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWebhookDelivered", reflect.TypeOf((*MockRepositoryInterface)(nil).MarkWebhookDelivered), ctx, id)
+}
+
+// MarkWebhookDeliveryFailed mocks base method.
+func (m *MockRepositoryInterface) MarkWebhookDeliveryFailed(ctx context.Context, input MarkWebhookDeliveryFailedInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkWebhookDeliveryFailed", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkWebhookDeliveryFailed indicates an expected call of MarkWebhookDeliveryFailed.
+func (mr *MockRepositoryInterfaceMockRecorder) MarkWebhookDeliveryFailed(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkWebhookDeliveryFailed", reflect.TypeOf((*MockRepositoryInterface)(nil).MarkWebhookDeliveryFailed), ctx, input)
+}
+
+// Ping mocks base method.
+func (m *MockRepositoryInterface) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRepositoryInterfaceMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRepositoryInterface)(nil).Ping), ctx)
+}
+
+// RebuildTiles mocks base method.
+func (m *MockRepositoryInterface) RebuildTiles(ctx context.Context, estateID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildTiles", ctx, estateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RebuildTiles indicates an expected call of RebuildTiles.
+func (mr *MockRepositoryInterfaceMockRecorder) RebuildTiles(ctx, estateID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildTiles", reflect.TypeOf((*MockRepositoryInterface)(nil).RebuildTiles), ctx, estateID)
+}
+
+// ResizeEstate mocks base method.
+func (m *MockRepositoryInterface) ResizeEstate(ctx context.Context, input ResizeEstateInput) (*Estate, []Tree, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResizeEstate", ctx, input)
+	ret0, _ := ret[0].(*Estate)
+	ret1, _ := ret[1].([]Tree)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResizeEstate indicates an expected call of ResizeEstate.
+func (mr *MockRepositoryInterfaceMockRecorder) ResizeEstate(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResizeEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).ResizeEstate), ctx, input)
+}
+
+// RestoreEstate mocks base method.
+func (m *MockRepositoryInterface) RestoreEstate(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreEstate", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreEstate indicates an expected call of RestoreEstate.
+func (mr *MockRepositoryInterfaceMockRecorder) RestoreEstate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).RestoreEstate), ctx, id)
+}
+
+// SaveCachedDronePlan mocks base method.
+func (m *MockRepositoryInterface) SaveCachedDronePlan(ctx context.Context, input SaveCachedDronePlanInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveCachedDronePlan", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveCachedDronePlan indicates an expected call of SaveCachedDronePlan.
+func (mr *MockRepositoryInterfaceMockRecorder) SaveCachedDronePlan(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCachedDronePlan", reflect.TypeOf((*MockRepositoryInterface)(nil).SaveCachedDronePlan), ctx, input)
+}
+
+// UpdateEstateGeo mocks base method.
+func (m *MockRepositoryInterface) UpdateEstateGeo(ctx context.Context, id uuid.UUID, geo GeoReference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEstateGeo", ctx, id, geo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEstateGeo indicates an expected call of UpdateEstateGeo.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateEstateGeo(ctx, id, geo any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEstateGeo", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateEstateGeo), ctx, id, geo)
+}
+
+// UpdateTree mocks base method.
+func (m *MockRepositoryInterface) UpdateTree(ctx context.Context, input UpdateTreeInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTree", ctx, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTree indicates an expected call of UpdateTree.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateTree(ctx, input any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTree", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateTree), ctx, input)
+}
+
 // UpsertEstateStats mocks base method.
 func (m *MockRepositoryInterface) UpsertEstateStats(ctx context.Context, estateID uuid.UUID, stats *EstateStats) error {
 	m.ctrl.T.Helper()
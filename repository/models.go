@@ -2,20 +2,25 @@
 package repository
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Estate struct {
-	Id     uuid.UUID
-	Width  int
-	Length int
+	Id      uuid.UUID
+	Width   int
+	Length  int
+	Version int64         // bumped by every change of the estate, its trees, exclusion zones or obstacles
+	Geo     *GeoReference // nil when the estate is not geo-referenced
 
-	CreatedAt time.Time
-	UpdatedAt *time.Time
-	Trees     []Tree
-	Stats     *EstateStats
+	CreatedAt      time.Time
+	UpdatedAt      *time.Time
+	Trees          []Tree
+	Stats          *EstateStats
+	ExclusionZones []ExclusionZone
+	Obstacles      []Obstacle
 }
 
 type EstateStats struct {
@@ -26,6 +31,9 @@ type EstateStats struct {
 	MinHeight     int
 	MedianHeight  int
 	DroneDistance int64
+	DroneProfile  string  // config.DroneProfile the distance was calculated with
+	DroneDuration int64   // flight time of the drone in seconds
+	DroneEnergy   float64 // energy drawn by the drone in watt-hours
 	CreatedAt     time.Time
 	UpdatedAt     *time.Time
 }
@@ -36,6 +44,159 @@ type Tree struct {
 	X         int
 	Y         int
 	Height    int
+	Species   string     // oil palm variety, empty when unknown
+	PlantedAt *time.Time // date only, nil when unknown
+	Health    TreeHealth
+	Tags      []string
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 }
+
+// HasTag tell whether the tree is tagged with tag
+func (t Tree) HasTag(tag string) bool {
+	for _, tt := range t.Tags {
+		if tt == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ExclusionZone is an area of the estate where trees can't be planted (river, road, building),
+// given either as a list of plots or as a polygon whose vertices are plot coordinates
+type ExclusionZone struct {
+	Id        uuid.UUID
+	EstateId  uuid.UUID
+	Label     string
+	Kind      ExclusionKind
+	Points    []Point
+	CreatedAt time.Time
+}
+
+// Obstacle is a structure over a plot the drone must clear (tower, power line),
+// a nil Height marks a no-fly plot the drone must route around
+type Obstacle struct {
+	Id        uuid.UUID
+	EstateId  uuid.UUID
+	X         int
+	Y         int
+	Height    *int
+	Label     string
+	CreatedAt time.Time
+}
+
+func (o Obstacle) IsNoFly() bool {
+	return o.Height == nil
+}
+
+// HasNoFlyPlots tell whether the drone may not be able to cover the whole estate
+func (e *Estate) HasNoFlyPlots() bool {
+	for _, obstacle := range e.Obstacles {
+		if obstacle.IsNoFly() {
+			return true
+		}
+	}
+	return false
+}
+
+// Harvest is the fresh fruit bunches harvested on a day from a tree or from a region of plots.
+// A tree harvest keep the plot of the tree, and become a one plot region if the tree is removed
+type Harvest struct {
+	Id          uuid.UUID
+	EstateId    uuid.UUID
+	TreeId      *uuid.UUID
+	Plots       []Point
+	HarvestedAt time.Time // date only
+	WeightKg    float64
+	BunchCount  int
+	CreatedAt   time.Time
+}
+
+// TileCell aggregate the trees of a square of plots, at a zoom level of the map tiles
+type TileCell struct {
+	X         int // column of the cell from the West, starting at 0
+	Y         int // row of the cell from the South, starting at 0
+	TreeCount int
+	SumHeight int64
+	MaxHeight int
+}
+
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type OutboxEvent struct {
+	Id           uuid.UUID
+	EstateId     uuid.UUID
+	EventType    EventType
+	Payload      []byte
+	CreatedAt    time.Time
+	DispatchedAt *time.Time
+}
+
+func (e OutboxEvent) Envelope() EventEnvelope {
+	return EventEnvelope{
+		Id:        e.Id,
+		Type:      e.EventType,
+		EstateId:  e.EstateId,
+		CreatedAt: e.CreatedAt,
+		Data:      e.Payload,
+	}
+}
+
+// AuditEntry record a change of the estate data, who made it & from where, with the changed entity
+// before & after the change as JSON. Before is nil for a creation and After for a deletion
+type AuditEntry struct {
+	Id        uuid.UUID
+	EstateId  uuid.UUID
+	Action    AuditAction
+	EntityId  uuid.UUID // the estate itself or its tree, exclusion zone, obstacle, harvest or stats
+	Actor     string
+	RequestId string // empty when the change is not from an HTTP request
+	SourceIp  string // empty when the change is not from an HTTP request
+	Before    json.RawMessage
+	After     json.RawMessage
+	CreatedAt time.Time
+}
+
+type WebhookSubscriber struct {
+	Id        uuid.UUID
+	Url       string
+	Secret    string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+type Job struct {
+	Id              uuid.UUID
+	Type            JobType
+	EstateId        uuid.UUID
+	Params          json.RawMessage
+	Status          JobStatus
+	Progress        float64 // 0 to 1
+	Result          json.RawMessage
+	Attempts        int
+	MaxAttempts     int
+	LastError       *string
+	CancelRequested bool
+	RunAt           time.Time
+	CreatedAt       time.Time
+	StartedAt       *time.Time
+	FinishedAt      *time.Time
+	UpdatedAt       *time.Time
+}
+
+type WebhookDelivery struct {
+	Id            uuid.UUID
+	EventId       uuid.UUID
+	SubscriberId  uuid.UUID
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     *string
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+	Event         *OutboxEvent
+	Subscriber    *WebhookSubscriber
+}
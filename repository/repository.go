@@ -2,17 +2,27 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
 
 	_ "github.com/lib/pq"
 )
 
 type Repository struct {
-	Db *sql.DB
+	Db     *sql.DB
+	Logger *slog.Logger
 }
 
 type NewRepositoryOptions struct {
-	Dsn string
+	Dsn             string
+	MaxOpenConns    int           // 0 means unlimited
+	MaxIdleConns    int           // 0 means database/sql default (2)
+	ConnMaxLifetime time.Duration // 0 means connections are reused forever
+	ConnMaxIdleTime time.Duration
+	Logger          *slog.Logger // optional, default to slog.Default()
 }
 
 func NewRepository(opts NewRepositoryOptions) *Repository {
@@ -20,7 +30,66 @@ func NewRepository(opts NewRepositoryOptions) *Repository {
 	if err != nil {
 		panic(err)
 	}
+	db.SetMaxOpenConns(opts.MaxOpenConns)
+	if opts.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(opts.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
 	return &Repository{
-		Db: db,
+		Db:     db,
+		Logger: logger,
 	}
 }
+
+// Close the connection pool, waiting for the running queries to finish
+func (r *Repository) Close() error {
+	return r.Db.Close()
+}
+
+// logger return the repository logger, tests build Repository without one
+func (r *Repository) logger() *slog.Logger {
+	if r.Logger != nil {
+		return r.Logger
+	}
+	return slog.Default()
+}
+
+// dbExecutor is satisfied by both *sql.DB and *sql.Tx,
+// so the SQL helpers can run inside or outside a transaction
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// withTx run fn inside a transaction, commit when fn succeed and rollback otherwise
+func (r *Repository) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return r.withTxOptions(ctx, nil, fn)
+}
+
+// withTxOptions is withTx with the isolation level & read-only mode of opts, nil for the defaults
+func (r *Repository) withTxOptions(ctx context.Context, opts *sql.TxOptions, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := r.Db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err = fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			r.logger().WarnContext(ctx, "failed to rollback transaction", slog.String("error", rollbackErr.Error()))
+		}
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+// TILE_SIZE is the cells a side of a map tile
+const TILE_SIZE = 256
+
+// MaxTileZoom is the zoom where a cell is a single plot, a single tile cover the estate at zoom 0
+func (e *Estate) MaxTileZoom() int {
+	return maxTileZoom(e.Length, e.Width)
+}
+
+// TileCellSize is the plots a side of a cell at the zoom
+func (e *Estate) TileCellSize(zoom int) int {
+	return 1 << (e.MaxTileZoom() - zoom)
+}
+
+func maxTileZoom(length, width int) (zoom int) {
+	for TILE_SIZE<<zoom < max(length, width) {
+		zoom++
+	}
+	return zoom
+}
+
+// parentTileCells is the distinct cells one zoom out holding the cells
+func parentTileCells(cells []Point) []Point {
+	seen := make(map[Point]bool, len(cells))
+	parents := make([]Point, 0, len(cells))
+	for _, c := range cells {
+		parent := Point{X: c.X / 2, Y: c.Y / 2}
+		if !seen[parent] {
+			seen[parent] = true
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
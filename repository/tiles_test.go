@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxTileZoom(t *testing.T) {
+	assert.Equal(t, 0, (&Estate{Length: 1, Width: 1}).MaxTileZoom())
+	assert.Equal(t, 0, (&Estate{Length: 256, Width: 10}).MaxTileZoom())
+	assert.Equal(t, 1, (&Estate{Length: 10, Width: 257}).MaxTileZoom())
+	assert.Equal(t, 8, (&Estate{Length: 50000, Width: 50000}).MaxTileZoom())
+
+	estate := &Estate{Length: 1000, Width: 600}
+	assert.Equal(t, 2, estate.MaxTileZoom())
+	assert.Equal(t, 4, estate.TileCellSize(0))
+	assert.Equal(t, 1, estate.TileCellSize(2))
+}
+
+func TestParentTileCells(t *testing.T) {
+	assert.Equal(t,
+		[]Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 3}},
+		parentTileCells([]Point{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 3, Y: 0}, {X: 2, Y: 0}, {X: 5, Y: 7}}))
+}
@@ -2,6 +2,10 @@
 package repository
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -16,14 +20,53 @@ type CreateEstateInput struct {
 	Id     uuid.UUID
 	Width  int
 	Length int
+	Geo    *GeoReference // nil leave the estate without geo-reference
 }
 
 type CreateTreeInput struct {
+	Id        uuid.UUID
+	EstateId  uuid.UUID
+	X         int
+	Y         int
+	Height    int
+	Species   string
+	PlantedAt *time.Time
+	Health    TreeHealth // TREE_HEALTHY when empty
+	Tags      []string
+}
+
+// UpdateTreeInput change the attributes of a tree, a nil field is kept
+type UpdateTreeInput struct {
 	Id       uuid.UUID
 	EstateId uuid.UUID
-	X        int
-	Y        int
-	Height   int
+	Species  *string
+	Health   *TreeHealth
+	Tags     []string // an empty, non nil slice clears the tags
+}
+
+type TreeHealth string
+
+const (
+	TREE_HEALTHY  TreeHealth = "healthy"
+	TREE_DISEASED TreeHealth = "diseased"
+	TREE_DEAD     TreeHealth = "dead"
+)
+
+// TreeGroup is the tree attribute the stats are grouped by
+type TreeGroup string
+
+const (
+	TREE_GROUP_SPECIES TreeGroup = "species"
+	TREE_GROUP_HEALTH  TreeGroup = "health"
+)
+
+// TreeGroupStats is the height stats of the trees sharing the same species or health
+type TreeGroupStats struct {
+	Value        string // the species or the health, empty species for the trees without one
+	TreeCount    int64
+	MaxHeight    int
+	MinHeight    int
+	MedianHeight int
 }
 
 type CheckExistEstateTreeInput struct {
@@ -36,3 +79,411 @@ type UpdateEstateInput struct {
 	Id            uuid.UUID
 	DroneDistance int
 }
+
+type EventType string
+
+const (
+	EVENT_TREE_ADDED           EventType = "tree.added"
+	EVENT_TREE_UPDATED         EventType = "tree.updated"
+	EVENT_ESTATE_STATS_CHANGED EventType = "estate.stats_changed"
+	EVENT_ESTATE_RESIZED       EventType = "estate.resized"
+	EVENT_ESTATE_DELETED       EventType = "estate.deleted"
+	EVENT_ESTATE_RESTORED      EventType = "estate.restored"
+	EVENT_ESTATE_IMPORTED      EventType = "estate.imported"
+	EVENT_ESTATE_GEO_UPDATED   EventType = "estate.geo_updated"
+	EVENT_EXCLUSION_ADDED      EventType = "estate.exclusion_added"
+	EVENT_EXCLUSION_REMOVED    EventType = "estate.exclusion_removed"
+	EVENT_OBSTACLE_ADDED       EventType = "estate.obstacle_added"
+	EVENT_OBSTACLE_REMOVED     EventType = "estate.obstacle_removed"
+	EVENT_HARVEST_RECORDED     EventType = "harvest.recorded"
+)
+
+// ESTATE_EVENTS_CHANNEL is the Postgres NOTIFY channel every committed outbox event is published to
+const ESTATE_EVENTS_CHANNEL = "estate_events"
+
+// EventEnvelope is the JSON representation of an outbox event,
+// shared by webhook deliveries and the SSE stream
+type EventEnvelope struct {
+	Id        uuid.UUID       `json:"id"`
+	Type      EventType       `json:"type"`
+	EstateId  uuid.UUID       `json:"estate_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// EventNotification is the NOTIFY payload of an outbox event. Postgres reject payloads of 8000 bytes
+// or more, so the data is left out & the listeners load the event from the outbox by its ID
+type EventNotification struct {
+	Id       uuid.UUID `json:"id"`
+	Type     EventType `json:"type"`
+	EstateId uuid.UUID `json:"estate_id"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WEBHOOK_DELIVERY_PENDING   WebhookDeliveryStatus = "pending"
+	WEBHOOK_DELIVERY_DELIVERED WebhookDeliveryStatus = "delivered"
+	WEBHOOK_DELIVERY_DEAD      WebhookDeliveryStatus = "dead"
+)
+
+type JobType string
+
+const (
+	JOB_DRONE_PLAN      JobType = "drone_plan"
+	JOB_RECOMPUTE_STATS JobType = "recompute_stats"
+	JOB_IMPORT          JobType = "import"
+	JOB_EXPORT          JobType = "export"
+	JOB_REBUILD_TILES   JobType = "rebuild_tiles"
+)
+
+type JobStatus string
+
+const (
+	JOB_QUEUED    JobStatus = "queued"
+	JOB_RUNNING   JobStatus = "running"
+	JOB_SUCCEEDED JobStatus = "succeeded"
+	JOB_FAILED    JobStatus = "failed"
+	JOB_CANCELLED JobStatus = "cancelled"
+)
+
+// IsFinished is true when the job will not run again
+func (s JobStatus) IsFinished() bool {
+	return s == JOB_SUCCEEDED || s == JOB_FAILED || s == JOB_CANCELLED
+}
+
+// TreeAddedEvent is the outbox payload of EVENT_TREE_ADDED
+type TreeAddedEvent struct {
+	TreeId   uuid.UUID  `json:"tree_id"`
+	EstateId uuid.UUID  `json:"estate_id"`
+	X        int        `json:"x"`
+	Y        int        `json:"y"`
+	Height   int        `json:"height"`
+	Species  string     `json:"species,omitempty"`
+	Health   TreeHealth `json:"health"`
+}
+
+// TreeUpdatedEvent is the outbox payload of EVENT_TREE_UPDATED, the tree once updated
+type TreeUpdatedEvent struct {
+	TreeId   uuid.UUID  `json:"tree_id"`
+	EstateId uuid.UUID  `json:"estate_id"`
+	X        int        `json:"x"`
+	Y        int        `json:"y"`
+	Height   int        `json:"height"`
+	Species  string     `json:"species,omitempty"`
+	Health   TreeHealth `json:"health"`
+}
+
+// TreeAuditState is the audited state of a tree
+type TreeAuditState struct {
+	TreeId    uuid.UUID  `json:"tree_id"`
+	EstateId  uuid.UUID  `json:"estate_id"`
+	X         int        `json:"x"`
+	Y         int        `json:"y"`
+	Height    int        `json:"height"`
+	Species   string     `json:"species,omitempty"`
+	PlantedAt string     `json:"planted_at,omitempty"`
+	Health    TreeHealth `json:"health"`
+	Tags      []string   `json:"tags,omitempty"`
+}
+
+// NewTreeAuditState return the audited state of the tree
+func NewTreeAuditState(tree Tree) TreeAuditState {
+	state := TreeAuditState{
+		TreeId:   tree.Id,
+		EstateId: tree.EstateId,
+		X:        tree.X,
+		Y:        tree.Y,
+		Height:   tree.Height,
+		Species:  tree.Species,
+		Health:   tree.Health,
+		Tags:     tree.Tags,
+	}
+	if tree.PlantedAt != nil {
+		state.PlantedAt = tree.PlantedAt.Format(time.DateOnly)
+	}
+	return state
+}
+
+// EstateStatsChangedEvent is the outbox payload of EVENT_ESTATE_STATS_CHANGED
+type EstateStatsChangedEvent struct {
+	EstateId      uuid.UUID `json:"estate_id"`
+	TreeCount     int64     `json:"tree_count"`
+	MaxHeight     int       `json:"max_height"`
+	MinHeight     int       `json:"min_height"`
+	MedianHeight  int       `json:"median_height"`
+	DroneDistance int64     `json:"drone_distance"`
+}
+
+// EstateResizedEvent is the outbox payload of EVENT_ESTATE_RESIZED,
+// the IDs of the removed trees are left to the audit log to keep the payload bounded
+type EstateResizedEvent struct {
+	EstateId         uuid.UUID `json:"estate_id"`
+	Width            int       `json:"width"`
+	Length           int       `json:"length"`
+	RemovedTreeCount int       `json:"removed_tree_count"`
+}
+
+// EstateGeoUpdatedEvent is the outbox payload of EVENT_ESTATE_GEO_UPDATED
+type EstateGeoUpdatedEvent struct {
+	EstateId  uuid.UUID `json:"estate_id"`
+	OriginLat float64   `json:"origin_lat"`
+	OriginLon float64   `json:"origin_lon"`
+	Rotation  float64   `json:"rotation"`
+}
+
+// EstateLifecycleEvent is the outbox payload of EVENT_ESTATE_DELETED & EVENT_ESTATE_RESTORED
+type EstateLifecycleEvent struct {
+	EstateId uuid.UUID `json:"estate_id"`
+}
+
+// EstateImportedEvent is the outbox payload of EVENT_ESTATE_IMPORTED
+type EstateImportedEvent struct {
+	EstateId     uuid.UUID  `json:"estate_id"`
+	Width        int        `json:"width"`
+	Length       int        `json:"length"`
+	Trees        int        `json:"trees"`
+	ImportedFrom *uuid.UUID `json:"imported_from,omitempty"`
+}
+
+type ResizeEstateInput struct {
+	Id     uuid.UUID
+	Width  *int // nil keep the current width
+	Length *int // nil keep the current length
+	Force  bool // remove the trees outside the new boundary instead of rejecting the resize
+}
+
+// EstateResizeConflictError is returned when a resize without force would leave trees outside the estate
+type EstateResizeConflictError struct {
+	Trees []Tree
+}
+
+func (e *EstateResizeConflictError) Error() string {
+	return fmt.Sprintf("%d trees are outside the new estate boundary", len(e.Trees))
+}
+
+type ExclusionKind string
+
+const (
+	EXCLUSION_KIND_PLOTS   ExclusionKind = "plots"   // every point is an excluded plot
+	EXCLUSION_KIND_POLYGON ExclusionKind = "polygon" // points are the vertices, plots inside or on the edges are excluded
+)
+
+type CreateExclusionZoneInput struct {
+	Id       uuid.UUID
+	EstateId uuid.UUID
+	Label    string
+	Kind     ExclusionKind
+	Points   []Point
+}
+
+// ExclusionZoneEvent is the outbox payload of EVENT_EXCLUSION_ADDED & EVENT_EXCLUSION_REMOVED,
+// a zone holds up to 1000 points so only their count is sent
+type ExclusionZoneEvent struct {
+	ZoneId     uuid.UUID     `json:"zone_id"`
+	EstateId   uuid.UUID     `json:"estate_id"`
+	Label      string        `json:"label,omitempty"`
+	Kind       ExclusionKind `json:"kind,omitempty"`
+	PointCount int           `json:"point_count,omitempty"`
+}
+
+// ExclusionZoneAuditState is the audited state of an exclusion zone
+type ExclusionZoneAuditState struct {
+	ZoneId   uuid.UUID     `json:"zone_id"`
+	EstateId uuid.UUID     `json:"estate_id"`
+	Label    string        `json:"label,omitempty"`
+	Kind     ExclusionKind `json:"kind,omitempty"`
+	Points   []Point       `json:"points,omitempty"`
+}
+
+// ExclusionZoneConflictError is returned when trees are already planted inside a new exclusion zone
+type ExclusionZoneConflictError struct {
+	Trees []Tree
+}
+
+func (e *ExclusionZoneConflictError) Error() string {
+	return fmt.Sprintf("%d trees are planted inside the exclusion zone", len(e.Trees))
+}
+
+type CreateObstacleInput struct {
+	Id       uuid.UUID
+	EstateId uuid.UUID
+	X        int
+	Y        int
+	Height   *int // nil is a no-fly plot
+	Label    string
+}
+
+// ObstacleEvent is the outbox payload of EVENT_OBSTACLE_ADDED & EVENT_OBSTACLE_REMOVED
+type ObstacleEvent struct {
+	ObstacleId uuid.UUID `json:"obstacle_id"`
+	EstateId   uuid.UUID `json:"estate_id"`
+	X          int       `json:"x,omitempty"`
+	Y          int       `json:"y,omitempty"`
+	Height     *int      `json:"height,omitempty"`
+	NoFly      bool      `json:"no_fly,omitempty"`
+	Label      string    `json:"label,omitempty"`
+}
+
+type CreateHarvestInput struct {
+	Id          uuid.UUID
+	EstateId    uuid.UUID
+	TreeId      *uuid.UUID // the harvest is recorded on the plot of the tree, Plots is ignored
+	Plots       []Point
+	HarvestedAt time.Time
+	WeightKg    float64
+	BunchCount  int
+}
+
+// GetHarvestsInput select the harvests of an estate, From & To are inclusive dates
+type GetHarvestsInput struct {
+	EstateId uuid.UUID
+	From     *time.Time
+	To       *time.Time
+}
+
+// GetTileCellsInput select the cells of a map tile, X & Y are the tile column & row from the South-West corner
+type GetTileCellsInput struct {
+	EstateId uuid.UUID
+	Zoom     int
+	MaxZoom  int // the cells of the max zoom are the trees
+	X        int
+	Y        int
+}
+
+// HarvestRecordedEvent is the outbox payload of EVENT_HARVEST_RECORDED,
+// a region holds up to 2500 plots so only their count is sent
+type HarvestRecordedEvent struct {
+	HarvestId   uuid.UUID  `json:"harvest_id"`
+	EstateId    uuid.UUID  `json:"estate_id"`
+	TreeId      *uuid.UUID `json:"tree_id,omitempty"`
+	PlotCount   int        `json:"plot_count"`
+	HarvestedAt string     `json:"harvested_at"`
+	WeightKg    float64    `json:"weight_kg"`
+	BunchCount  int        `json:"bunch_count"`
+}
+
+// HarvestAuditState is the audited state of a harvest
+type HarvestAuditState struct {
+	HarvestId   uuid.UUID  `json:"harvest_id"`
+	EstateId    uuid.UUID  `json:"estate_id"`
+	TreeId      *uuid.UUID `json:"tree_id,omitempty"`
+	Plots       []Point    `json:"plots"`
+	HarvestedAt string     `json:"harvested_at"`
+	WeightKg    float64    `json:"weight_kg"`
+	BunchCount  int        `json:"bunch_count"`
+}
+
+type CreateWebhookSubscriberInput struct {
+	Id     uuid.UUID
+	Url    string
+	Secret string
+}
+
+type ClaimWebhookDeliveriesInput struct {
+	Limit int
+	Lease time.Duration // how long the claimed deliveries are hidden from other dispatchers
+}
+
+type MarkWebhookDeliveryFailedInput struct {
+	Id            uuid.UUID
+	LastError     string
+	NextAttemptAt time.Time
+	Dead          bool // no more retry, move to dead-letter
+}
+
+type GetWebhookDeliveriesInput struct {
+	Status WebhookDeliveryStatus
+	Limit  int
+	Offset int
+}
+
+type CreateJobInput struct {
+	Id          uuid.UUID
+	Type        JobType
+	EstateId    uuid.UUID
+	Params      json.RawMessage
+	MaxAttempts int
+}
+
+type ClaimJobInput struct {
+	Lease time.Duration // how long the claimed job is hidden from other workers without heartbeat
+}
+
+type HeartbeatJobInput struct {
+	Id       uuid.UUID
+	Attempts int // attempt of the claim, the lease is lost once another worker claimed the job again
+	Progress float64
+	Lease    time.Duration
+}
+
+type FinishJobInput struct {
+	Id        uuid.UUID
+	Attempts  int       // attempt of the claim, the lease is lost once another worker claimed the job again
+	Status    JobStatus // JOB_QUEUED to retry the job at RunAt
+	Progress  float64
+	Result    json.RawMessage
+	LastError *string
+	RunAt     time.Time
+}
+
+// DronePlanCacheKey identify a drone plan computed for a version of the estate
+type DronePlanCacheKey struct {
+	EstateId uuid.UUID
+	Version  int64  // Estate.Version the plan was computed for
+	Params   string // the resolved plan parameters, drone profile included
+}
+
+type SaveCachedDronePlanInput struct {
+	Key  DronePlanCacheKey
+	Plan json.RawMessage
+}
+
+// AuditAction is the change of the estate data an audit entry records
+type AuditAction string
+
+const (
+	AUDIT_ESTATE_CREATED        AuditAction = "estate.created"
+	AUDIT_ESTATE_RESIZED        AuditAction = "estate.resized"
+	AUDIT_ESTATE_DELETED        AuditAction = "estate.deleted"
+	AUDIT_ESTATE_RESTORED       AuditAction = "estate.restored"
+	AUDIT_ESTATE_GEO_UPDATED    AuditAction = "estate.geo_updated"
+	AUDIT_ESTATE_STATS_UPSERTED AuditAction = "estate.stats_upserted"
+	AUDIT_ESTATE_IMPORTED       AuditAction = "estate.imported"
+	AUDIT_TREE_CREATED          AuditAction = "tree.created"
+	AUDIT_TREE_UPDATED          AuditAction = "tree.updated"
+	AUDIT_TREE_DELETED          AuditAction = "tree.deleted"
+	AUDIT_EXCLUSION_CREATED     AuditAction = "exclusion_zone.created"
+	AUDIT_EXCLUSION_DELETED     AuditAction = "exclusion_zone.deleted"
+	AUDIT_OBSTACLE_CREATED      AuditAction = "obstacle.created"
+	AUDIT_OBSTACLE_DELETED      AuditAction = "obstacle.deleted"
+	AUDIT_HARVEST_CREATED       AuditAction = "harvest.created"
+)
+
+// EstateAuditState is the audited state of an estate, holding only the fields the change touched
+type EstateAuditState struct {
+	Width        int           `json:"width,omitempty"`
+	Length       int           `json:"length,omitempty"`
+	Geo          *GeoReference `json:"geo,omitempty"`
+	Deleted      *bool         `json:"deleted,omitempty"`
+	RemovedTrees int           `json:"removed_trees,omitempty"` // each one is audited by a tree.deleted entry
+	ImportedFrom *uuid.UUID    `json:"imported_from,omitempty"` // the estate ID in the bundle, when imported under another ID
+}
+
+// GetAuditEntriesInput select the audit entries of an estate, newest first. Empty Action & Actor match
+// every entry, From is inclusive & To exclusive
+type GetAuditEntriesInput struct {
+	EstateId uuid.UUID
+	Action   AuditAction
+	Actor    string
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+	Offset   int
+}
+
+// ImportEstateInput restore an estate bundle, Bundle.Estate.Id is the ID of the restored estate
+type ImportEstateInput struct {
+	Bundle       EstateBundle
+	ImportedFrom *uuid.UUID // the estate ID in the bundle, nil when restored under the same ID
+}
@@ -0,0 +1,72 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogger(t *testing.T) {
+	t.Run("Invalid level", func(t *testing.T) {
+		_, err := NewLogger(config.Log{Level: "verbose", Format: "json"}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, `invalid log level "verbose"`)
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		_, err := NewLogger(config.Log{Level: "info", Format: "xml"}, &bytes.Buffer{})
+		assert.EqualError(t, err, `invalid log format "xml"`)
+	})
+
+	t.Run("Context fields are added to every line", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := NewLogger(config.Log{Level: "debug", Format: "json"}, &buf)
+		assert.NoError(t, err)
+
+		ctx := WithRequestId(context.Background(), "req-1")
+		ctx = WithLogAttrs(ctx, slog.String("route", "/estate/:id/tree"))
+		ctx = WithLogAttrs(ctx, slog.String("estate_id", "abc"))
+		logger.With("component", "test").DebugContext(ctx, "hello")
+
+		var line map[string]any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+		assert.Equal(t, "hello", line["msg"])
+		assert.Equal(t, "req-1", line["request_id"])
+		assert.Equal(t, "/estate/:id/tree", line["route"])
+		assert.Equal(t, "abc", line["estate_id"])
+		assert.Equal(t, "test", line["component"])
+	})
+}
+
+func TestRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(config.Log{Level: "info", Format: "json"}, &buf)
+	assert.NoError(t, err)
+
+	e := echo.New()
+	e.Use(RequestLogger(logger))
+	e.Use(Middleware())
+	e.GET("/estate/:id/stats", func(c echo.Context) error {
+		assert.Equal(t, logger, LoggerFromContext(c.Request().Context()))
+		return c.NoContent(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/estate/abc/stats", nil)
+	req.Header.Set(HeaderRequestId, "req-2")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	var line map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "request completed", line["msg"])
+	assert.Equal(t, float64(http.StatusNotFound), line["status"])
+	assert.Equal(t, "req-2", line["request_id"])
+	assert.Equal(t, "/estate/:id/stats", line["route"])
+	assert.Equal(t, "abc", line["estate_id"])
+}
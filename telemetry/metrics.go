@@ -0,0 +1,98 @@
+// This file contains the prometheus collectors exposed on /metrics.
+package telemetry
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "plantation"
+
+var (
+	// Registry hold every collector of the service, including go runtime & process collectors
+	Registry = prometheus.NewRegistry()
+
+	HttpRequestDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests per route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	HttpRequestsTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests per route and status code.",
+	}, []string{"method", "route", "status"})
+
+	DbQueryDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of repository methods.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "result"})
+
+	DroneDistanceDuration = promauto.With(Registry).NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "drone_distance_calculation_duration_seconds",
+		Help:      "Duration of the drone distance calculation.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 12),
+	})
+
+	DronePlanCacheTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "drone_plan_cache_total",
+		Help:      "Drone plan cache lookups per layer (memory or postgres) and result (hit or miss).",
+	}, []string{"layer", "result"})
+
+	RepositoryCacheTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "repository_cache_total",
+		Help:      "Estate lookups in the repository cache per result (hit, miss or shared, a miss joining a running load).",
+	}, []string{"result"})
+
+	JobsTotal = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jobs_total",
+		Help:      "Number of job runs per type and outcome (succeeded, failed, retried, cancelled or requeued).",
+	}, []string{"type", "outcome"})
+
+	JobDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_duration_seconds",
+		Help:      "Duration of a job run.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+	}, []string{"type"})
+
+	// the estate size & tree count are observed on every change of the estate, not kept per estate:
+	// a series per estate would never end, and each replica only knows the estates it changed
+	EstatePlots = promauto.With(Registry).NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "estate_plots",
+		Help:      "Number of plots (width x length) of the estates, observed when an estate is created, imported or its stats change.",
+		Buckets:   prometheus.ExponentialBuckets(1, 10, 10),
+	})
+
+	EstateTrees = promauto.With(Registry).NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "estate_trees",
+		Help:      "Number of trees planted in the estates, observed when an estate is imported or its stats change.",
+		Buckets:   prometheus.ExponentialBuckets(1, 10, 10),
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// MetricsHandler serve the registry in prometheus exposition format
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
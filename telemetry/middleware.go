@@ -0,0 +1,103 @@
+package telemetry
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware assign the request ID, start the server span and record the route metrics.
+// The request ID & span are put into the request context, so every repository call carry them
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			start := time.Now()
+
+			// an over-long or unprintable ID is replaced, the audit log could not store it
+			requestId := req.Header.Get(HeaderRequestId)
+			if !validRequestId(requestId) {
+				requestId = uuid.NewString()
+			}
+			c.Response().Header().Set(HeaderRequestId, requestId)
+
+			// route template instead of raw path to keep metric cardinality low
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+			ctx, span := Tracer().Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", req.Method),
+					attribute.String("http.route", route),
+					attribute.String("request.id", requestId),
+				),
+			)
+			defer span.End()
+
+			ctx = WithRequestId(ctx, requestId)
+			logAttrs := []slog.Attr{slog.String("route", route)}
+			if estateId := c.Param("id"); estateId != "" && strings.HasPrefix(route, "/estate/") {
+				logAttrs = append(logAttrs, slog.String("estate_id", estateId))
+			}
+			ctx = WithLogAttrs(ctx, logAttrs...)
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err != nil {
+				// let echo write the error response so the recorded status is the real one
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(attribute.Int("http.response.status_code", status))
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+
+			labels := []string{req.Method, route, strconv.Itoa(status)}
+			HttpRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+			HttpRequestsTotal.WithLabelValues(labels...).Inc()
+
+			return nil
+		}
+	}
+}
+
+// RequestLogger put the logger into the request context and log every completed request.
+// It must be registered before Middleware, so the line carry the request ID & route
+func RequestLogger(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			c.SetRequest(c.Request().WithContext(WithLogger(c.Request().Context(), logger)))
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			req := c.Request()
+			logger.InfoContext(req.Context(), "request completed",
+				slog.String("method", req.Method),
+				slog.String("uri", req.RequestURI),
+				slog.Int("status", c.Response().Status),
+				slog.Duration("latency", time.Since(start)),
+			)
+			return nil
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package telemetry
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware())
+
+	var ctxRequestId string
+	e.GET("/estate/:id", func(c echo.Context) error {
+		ctxRequestId = RequestIdFromContext(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	})
+	e.POST("/estate/:id/tree", func(c echo.Context) error {
+		ctxRequestId = RequestIdFromContext(c.Request().Context())
+		return c.NoContent(http.StatusCreated)
+	})
+	e.GET("/fail", func(c echo.Context) error {
+		return errors.New("unexpected")
+	})
+
+	t.Run("Propagate incoming request ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/estate/abc", nil)
+		req.Header.Set(HeaderRequestId, "req-123")
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "req-123", rec.Header().Get(HeaderRequestId))
+		assert.Equal(t, "req-123", ctxRequestId)
+	})
+
+	t.Run("Generate request ID when missing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/estate/abc", nil)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		assert.NotEmpty(t, rec.Header().Get(HeaderRequestId))
+		assert.Equal(t, rec.Header().Get(HeaderRequestId), ctxRequestId)
+	})
+
+	t.Run("Replace invalid request ID", func(t *testing.T) {
+		for _, requestId := range []string{strings.Repeat("a", 300), "req\n123", "req 123", "req-é"} {
+			req := httptest.NewRequest(http.MethodPost, "/estate/abc/tree", nil)
+			req.Header.Set(HeaderRequestId, requestId)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.NotEqual(t, requestId, ctxRequestId)
+			_, err := uuid.Parse(ctxRequestId)
+			assert.NoError(t, err)
+			assert.Equal(t, ctxRequestId, rec.Header().Get(HeaderRequestId))
+		}
+	})
+
+	t.Run("Record route template and error status", func(t *testing.T) {
+		before := testutil.ToFloat64(HttpRequestsTotal.WithLabelValues(http.MethodGet, "/fail", "500"))
+
+		req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, before+1, testutil.ToFloat64(HttpRequestsTotal.WithLabelValues(http.MethodGet, "/fail", "500")))
+		assert.Equal(t, float64(2), testutil.ToFloat64(HttpRequestsTotal.WithLabelValues(http.MethodGet, "/estate/:id", "200")))
+	})
+}
+
+func TestMetricsHandler(t *testing.T) {
+	HttpRequestsTotal.WithLabelValues(http.MethodGet, "/metrics-test", "200").Inc()
+
+	rec := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `plantation_http_requests_total{method="GET",route="/metrics-test",status="200"} 1`)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+}
@@ -0,0 +1,35 @@
+package telemetry
+
+import "context"
+
+const HeaderRequestId = "X-Request-ID"
+
+// requestIdMaxLength is the size of audit_entries.request_id
+const requestIdMaxLength = 255
+
+// validRequestId report whether an incoming request ID can be kept: printable ASCII without spaces,
+// short enough for the audit log
+func validRequestId(requestId string) bool {
+	if requestId == "" || len(requestId) > requestIdMaxLength {
+		return false
+	}
+	for i := 0; i < len(requestId); i++ {
+		if requestId[i] <= ' ' || requestId[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+type requestIdKey struct{}
+
+// WithRequestId return a copy of ctx carrying the request ID
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestIdFromContext return the request ID of ctx, empty when ctx is not from an HTTP request
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
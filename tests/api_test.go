@@ -2,14 +2,11 @@
 package tests
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 	"testing"
 
+	"github.com/SawitProRecruitment/UserService/client"
+	"github.com/SawitProRecruitment/UserService/utils/ptr"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -23,26 +20,13 @@ func TestApi(t *testing.T) {
 
 	testcases := getTestCases()
 	ctx := context.Background()
-	client := &http.Client{}
+	api, err := client.NewPlantationClient(ApiUrl, client.NewPlantationClientOptions{})
+	require.NoError(t, err)
 
 	for _, tc := range testcases {
 		t.Run(tc.Name, func(t *testing.T) {
-			for idx := range tc.Steps {
-				step := &tc.Steps[idx]
-				request, err := step.Request(t, ctx, &tc)
-				request.Header.Set("Content-Type", "application/json")
-				request.Header.Set("Accept", "application/json")
-				require.NoError(t, err)
-
-				// Send request
-				response, err := client.Do(request)
-
-				require.NoError(t, err)
-				defer response.Body.Close()
-
-				// Check response
-				ReadJsonResult(t, response, step)
-				step.Expect(t, ctx, &tc, response, step.Result)
+			for _, step := range tc.Steps {
+				step(t, ctx, api, &tc)
 			}
 		})
 	}
@@ -53,34 +37,24 @@ func getTestCases() []TestCase {
 		{
 			Name: "Test Error 1",
 			Steps: []TestCaseStep{
-				{
-					Request: func(t *testing.T, ctx context.Context, tc *TestCase) (*http.Request, error) {
-						return http.NewRequest("POST", ApiUrl+"/estate", nil)
-					},
-					Expect: ExpectBadRequest(),
+				func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+					resp, err := api.API().PostEstateWithBodyWithResponse(ctx, "application/json", nil)
+					require.NoError(t, err)
+					require.Equal(t, 400, resp.StatusCode())
 				},
 			},
 		},
 		{
 			Name: "Test Error 2: Invalid Format",
 			Steps: []TestCaseStep{
-				{
-					Request: SendRequestNewEstate(-1, -5),
-					Expect:  ExpectBadRequest(),
-				},
+				ExpectNewEstateBadRequest(-1, -5),
 			},
 		},
 		{
 			Name: "Test Error: Create Tree Out of Bound",
 			Steps: []TestCaseStep{
-				{
-					Request: SendRequestNewEstate(10, 20),
-					Expect:  ExpectNewEstateOk(),
-				},
-				{
-					Request: SendRequestNewTree(5, 0, 0),
-					Expect:  ExpectBadRequest(),
-				},
+				ExpectNewEstateOk(10, 20),
+				ExpectNewTreeBadRequest(5, 0, 0),
 			},
 		},
 		CreateNormalTestCase("Normal 1", []any{
@@ -96,41 +70,94 @@ func getTestCases() []TestCase {
 			[]any{GetStats, 3, 10, 20, 10},
 			[]any{GetDronePlan, 0, 82},
 		}),
+		{
+			Name: "Resize, Delete and Restore",
+			Steps: []TestCaseStep{
+				ExpectNewEstateOk(5, 1),
+				ExpectNewTreeOk(10, 2, 1),
+				ExpectNewTreeOk(20, 3, 1),
+				ExpectNewTreeOk(10, 4, 1),
+				func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+					length := 3
+					_, err := api.ResizeEstate(ctx, tc.EstateId, nil, &length, false)
+					var conflict *client.ResizeConflictError
+					require.ErrorAs(t, err, &conflict)
+					require.Len(t, conflict.Trees, 1)
+					require.Equal(t, 4, conflict.Trees[0].X)
+
+					resized, err := api.ResizeEstate(ctx, tc.EstateId, nil, &length, true)
+					require.NoError(t, err)
+					require.Equal(t, 3, resized.Length)
+					require.Len(t, resized.RemovedTrees, 1)
+				},
+				ExpectGetDronePlanOk(0, 62),
+				func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+					require.NoError(t, api.DeleteEstate(ctx, tc.EstateId))
+					_, err := api.GetStats(ctx, tc.EstateId)
+					require.ErrorIs(t, err, client.ErrNotFound)
+
+					require.NoError(t, api.RestoreEstate(ctx, tc.EstateId))
+				},
+				ExpectGetStatsOk(2, 10, 20, 15),
+			},
+		},
+		{
+			Name: "Exclusion Zone",
+			Steps: []TestCaseStep{
+				ExpectNewEstateOk(5, 1),
+				ExpectNewTreeOk(10, 2, 1),
+				func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+					resp, err := api.API().PostEstateIdExclusionsWithResponse(ctx, tc.EstateId, client.CreateExclusionZoneRequest{
+						Plots: &[]client.PlotPoint{{X: 4, Y: 1}},
+					})
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode())
+
+					_, err = api.AddTree(ctx, tc.EstateId, 4, 1, 10)
+					var apiErr *client.APIError
+					require.ErrorAs(t, err, &apiErr)
+					require.Equal(t, 422, apiErr.StatusCode)
+
+					stats, err := api.GetStats(ctx, tc.EstateId)
+					require.NoError(t, err)
+					require.Equal(t, int64(4), *stats.PlantablePlots)
+					require.Equal(t, 0.25, *stats.Density)
+				},
+			},
+		},
+		{
+			Name: "Obstacle",
+			Steps: []TestCaseStep{
+				ExpectNewEstateOk(3, 2),
+				ExpectNewTreeOk(9, 2, 2),
+				func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+					resp, err := api.API().PostEstateIdObstaclesWithResponse(ctx, tc.EstateId, client.CreateObstacleRequest{
+						X:     2,
+						Y:     1,
+						NoFly: ptr.ToPointer(true),
+					})
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode())
+
+					plan, err := api.GetDronePlan(ctx, tc.EstateId, nil)
+					require.NoError(t, err)
+					require.False(t, plan.Coverage.FullyCovered)
+					require.Equal(t, int64(1), plan.Coverage.UncoveredPlots)
+				},
+				// routed around (2,1) over the tree at (2,2)
+				ExpectGetDronePlanOk(0, 108),
+			},
+		},
 	}
 }
 
 type TestCase struct {
-	Name  string
-	Steps []TestCaseStep
-}
-
-type RequestFunc func(*testing.T, context.Context, *TestCase) (*http.Request, error)
-type ExpectFunc func(*testing.T, context.Context, *TestCase, *http.Response, map[string]any)
-
-type TestCaseStep struct {
-	Request RequestFunc
-	Expect  ExpectFunc
-	Result  map[string]any
+	Name     string
+	Steps    []TestCaseStep
+	EstateId uuid.UUID // set by the create estate step
 }
 
-func ResponseContains(t *testing.T, resp *http.Response, text string) {
-	body, err := io.ReadAll(resp.Body)
-	bodyStr := string(body)
-	require.NoError(t, err)
-	require.Contains(t, bodyStr, text)
-}
-
-func ReadJsonResult(t *testing.T, resp *http.Response, step *TestCaseStep) {
-	var result map[string]any
-	err := json.NewDecoder(resp.Body).Decode(&result)
-	step.Result = result
-	require.NoError(t, err)
-}
-
-func RequireIsUUID(t *testing.T, value string) {
-	_, err := uuid.Parse(value)
-	require.NoError(t, err)
-}
+type TestCaseStep func(*testing.T, context.Context, *client.PlantationClient, *TestCase)
 
 const (
 	CreateEstate = iota
@@ -146,122 +173,71 @@ func CreateNormalTestCase(name string, a []any) TestCase {
 	for _, step := range a {
 		switch step.([]any)[0].(int) {
 		case CreateEstate:
-			tc.Steps = append(tc.Steps, TestCaseStep{
-				Request: SendRequestNewEstate(step.([]any)[1].(int), step.([]any)[2].(int)),
-				Expect:  ExpectNewEstateOk(),
-			})
+			tc.Steps = append(tc.Steps, ExpectNewEstateOk(step.([]any)[1].(int), step.([]any)[2].(int)))
 		case CreateTree:
-			tc.Steps = append(tc.Steps, TestCaseStep{
-				Request: SendRequestNewTree(step.([]any)[1].(int), step.([]any)[2].(int), step.([]any)[3].(int)),
-				Expect:  ExpectNewTreeOk(),
-			})
+			tc.Steps = append(tc.Steps, ExpectNewTreeOk(step.([]any)[1].(int), step.([]any)[2].(int), step.([]any)[3].(int)))
 		case GetStats:
-			tc.Steps = append(tc.Steps, TestCaseStep{
-				Request: SendRequestGetStats(),
-				Expect:  ExpectGetStatsOk(step.([]any)[1].(int), step.([]any)[2].(int), step.([]any)[3].(int), step.([]any)[4].(int)),
-			})
+			tc.Steps = append(tc.Steps, ExpectGetStatsOk(step.([]any)[1].(int), step.([]any)[2].(int), step.([]any)[3].(int), step.([]any)[4].(int)))
 		case GetDronePlan:
-			tc.Steps = append(tc.Steps, TestCaseStep{
-				Request: SendRequestGetDronePlan(step.([]any)[1].(int)),
-				Expect:  ExpectGetDronePlanOk(step.([]any)[2].(int)),
-			})
+			tc.Steps = append(tc.Steps, ExpectGetDronePlanOk(step.([]any)[1].(int), step.([]any)[2].(int)))
 		}
 
 	}
 	return tc
 }
 
-func SendRequestNewEstate(length, width int) RequestFunc {
-	return func(t *testing.T, ctx context.Context, tc *TestCase) (*http.Request, error) {
-		req := map[string]int{
-			"length": length,
-			"width":  width,
-		}
-		body, err := json.Marshal(req)
+func ExpectNewEstateOk(length, width int) TestCaseStep {
+	return func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+		id, err := api.CreateEstate(ctx, width, length)
 		require.NoError(t, err)
-		return http.NewRequest("POST", ApiUrl+"/estate", bytes.NewReader(body))
+		require.NotEqual(t, uuid.Nil, id)
+		tc.EstateId = id
 	}
 }
 
-func ExpectNewEstateOk() ExpectFunc {
-	return func(t *testing.T, ctx context.Context, tc *TestCase, resp *http.Response, data map[string]any) {
-		RequireReturnIsUUID(t, resp, data)
+func ExpectNewEstateBadRequest(length, width int) TestCaseStep {
+	return func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+		_, err := api.CreateEstate(ctx, width, length)
+		require.ErrorIs(t, err, client.ErrValidation)
 	}
 }
 
-func SendRequestNewTree(height, x, y int) RequestFunc {
-	return func(t *testing.T, ctx context.Context, tc *TestCase) (*http.Request, error) {
-		req := map[string]int{
-			"height": height,
-			"x":      x,
-			"y":      y,
-		}
-		id := tc.Steps[0].Result["id"].(string)
-		body, err := json.Marshal(req)
+func ExpectNewTreeOk(height, x, y int) TestCaseStep {
+	return func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+		id, err := api.AddTree(ctx, tc.EstateId, x, y, height)
 		require.NoError(t, err)
-		return http.NewRequest("POST", ApiUrl+"/estate/"+id+"/tree", bytes.NewReader(body))
+		require.NotEqual(t, uuid.Nil, id)
 	}
 }
 
-func ExpectNewTreeOk() ExpectFunc {
-	return func(t *testing.T, ctx context.Context, tc *TestCase, resp *http.Response, data map[string]any) {
-		RequireReturnIsUUID(t, resp, data)
+func ExpectNewTreeBadRequest(height, x, y int) TestCaseStep {
+	return func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+		_, err := api.AddTree(ctx, tc.EstateId, x, y, height)
+		require.ErrorIs(t, err, client.ErrValidation)
 	}
 }
 
-func SendRequestGetStats() RequestFunc {
-	return func(t *testing.T, ctx context.Context, tc *TestCase) (*http.Request, error) {
-		id := tc.Steps[0].Result["id"].(string)
-		return http.NewRequest("GET", ApiUrl+"/estate/"+id+"/stats", nil)
-	}
-}
-
-func ExpectGetStatsOk(count, min, max, median int) ExpectFunc {
-	return func(t *testing.T, ctx context.Context, tc *TestCase, resp *http.Response, data map[string]any) {
-		RequireStats(t, resp, data, count, min, max, median)
+func ExpectGetStatsOk(count, min, max, median int) TestCaseStep {
+	return func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+		stats, err := api.GetStats(ctx, tc.EstateId)
+		require.NoError(t, err)
+		require.Equal(t, int64(count), *stats.Count)
+		require.Equal(t, min, *stats.Min)
+		require.Equal(t, max, *stats.Max)
+		require.Equal(t, median, *stats.Median)
 	}
 }
 
-func SendRequestGetDronePlan(distance int) RequestFunc {
-	return func(t *testing.T, ctx context.Context, tc *TestCase) (*http.Request, error) {
-		id := tc.Steps[0].Result["id"].(string)
-		var url string
-
-		if distance == 0 {
-			url = fmt.Sprintf("%s/estate/%s/drone-plan", ApiUrl, id)
-		} else {
-			url = fmt.Sprintf("%s/estate/%s/drone-plan?distance=%d", ApiUrl, id, distance)
+// maxDistance 0 request the plan without battery limit
+func ExpectGetDronePlanOk(maxDistance, distance int) TestCaseStep {
+	return func(t *testing.T, ctx context.Context, api *client.PlantationClient, tc *TestCase) {
+		var maxDistanceParam *int
+		if maxDistance != 0 {
+			maxDistanceParam = &maxDistance
 		}
-		return http.NewRequest("GET", url, nil)
-	}
-}
 
-func ExpectGetDronePlanOk(distance int) ExpectFunc {
-	return func(t *testing.T, ctx context.Context, tc *TestCase, resp *http.Response, data map[string]any) {
-		RequireDistance(t, resp, data, distance)
-	}
-}
-
-func RequireReturnIsUUID(t *testing.T, resp *http.Response, data map[string]any) {
-	require.Equal(t, http.StatusCreated, resp.StatusCode)
-	RequireIsUUID(t, data["id"].(string))
-}
-
-func RequireStats(t *testing.T, resp *http.Response, data map[string]any, count, min, max, median int) {
-	require.Equal(t, http.StatusOK, resp.StatusCode)
-	require.Equal(t, count, int(data["count"].(float64)))
-	require.Equal(t, min, int(data["min"].(float64)))
-	require.Equal(t, max, int(data["max"].(float64)))
-	require.Equal(t, median, int(data["median"].(float64)))
-}
-
-func RequireDistance(t *testing.T, resp *http.Response, data map[string]any, distance int) {
-	require.Equal(t, http.StatusOK, resp.StatusCode)
-	require.Equal(t, distance, int(data["distance"].(float64)))
-}
-
-func ExpectBadRequest() ExpectFunc {
-	return func(t *testing.T, ctx context.Context, tc *TestCase, resp *http.Response, data map[string]any) {
-		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		plan, err := api.GetDronePlan(ctx, tc.EstateId, maxDistanceParam)
+		require.NoError(t, err)
+		require.Equal(t, int64(distance), *plan.Distance)
 	}
 }
@@ -10,6 +10,11 @@ func (e *AppError) Error() string {
 	return e.err.Error()
 }
 
+// Unwrap let errors.Is & errors.As reach the wrapped error
+func (e *AppError) Unwrap() error {
+	return e.err
+}
+
 // Helper functions to wrap http code
 func WrapWithCode(err error, code int) *AppError {
 	return &AppError{Code: code, err: err}
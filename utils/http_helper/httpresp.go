@@ -1,8 +1,11 @@
 package httphelper
 
 import (
+	"errors"
+	"log/slog"
 	"net/http"
 
+	"github.com/SawitProRecruitment/UserService/telemetry"
 	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
 	"github.com/labstack/echo/v4"
 )
@@ -13,15 +16,31 @@ type ErrorResponse struct {
 }
 
 func HttpRespError(c echo.Context, srcErr error) (err error) {
+	code := http.StatusInternalServerError
 	// Check if it's an APIError
 	if apiErr, ok := srcErr.(*apperror.AppError); ok {
-		err = c.JSON(apiErr.Code, ErrorResponse{
-			Message: srcErr.Error(),
-		})
-	} else {
-		err = c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Message: srcErr.Error(),
-		})
+		code = apiErr.Code
+	}
+
+	// server errors are not the client fault, keep the whole chain for debugging
+	if code >= http.StatusInternalServerError {
+		ctx := c.Request().Context()
+		telemetry.LoggerFromContext(ctx).ErrorContext(ctx, "request failed",
+			slog.Int("status", code),
+			slog.String("error", srcErr.Error()),
+			slog.Any("error_chain", errorChain(srcErr)),
+		)
+	}
+
+	return c.JSON(code, ErrorResponse{
+		Message: srcErr.Error(),
+	})
+}
+
+// errorChain list the message of every wrapped error, from the outermost
+func errorChain(err error) (chain []string) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		chain = append(chain, err.Error())
 	}
 	return
 }
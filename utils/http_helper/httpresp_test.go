@@ -1,12 +1,16 @@
 package httphelper_test
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/telemetry"
 	apperror "github.com/SawitProRecruitment/UserService/utils/app_error"
 	httphelper "github.com/SawitProRecruitment/UserService/utils/http_helper"
 	"github.com/labstack/echo/v4"
@@ -51,3 +55,25 @@ func TestHttpRespError(t *testing.T) {
 		})
 	}
 }
+
+func TestHttpRespError_LogServerError(t *testing.T) {
+	e := echo.New()
+	var buf bytes.Buffer
+	logger, err := telemetry.NewLogger(config.Log{Level: "info", Format: "json"}, &buf)
+	assert.NoError(t, err)
+
+	newContext := func() echo.Context {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx := telemetry.WithLogger(telemetry.WithRequestId(req.Context(), "req-1"), logger)
+		return e.NewContext(req.WithContext(ctx), httptest.NewRecorder())
+	}
+
+	// client error is not logged
+	_ = httphelper.HttpRespError(newContext(), apperror.WrapWithCode(errors.New("not found"), http.StatusNotFound))
+	assert.Empty(t, buf.String())
+
+	cause := errors.New("connection refused")
+	_ = httphelper.HttpRespError(newContext(), fmt.Errorf("failed to create tree: %w", cause))
+	assert.Contains(t, buf.String(), `"request_id":"req-1"`)
+	assert.Contains(t, buf.String(), `"error_chain":["failed to create tree: connection refused","connection refused"]`)
+}
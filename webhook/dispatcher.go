@@ -0,0 +1,150 @@
+// This file contains the dispatcher that deliver outbox events to webhook subscribers.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+)
+
+type Dispatcher struct {
+	Repository repository.RepositoryInterface
+	Client     *http.Client
+	Config     config.Webhook
+	Logger     *slog.Logger
+	now        func() time.Time
+}
+
+type NewDispatcherOptions struct {
+	Repository repository.RepositoryInterface
+	Client     *http.Client // optional, default client use Config.RequestTimeout
+	Config     config.Webhook
+	Logger     *slog.Logger // optional, default to slog.Default()
+}
+
+func NewDispatcher(opts NewDispatcherOptions) *Dispatcher {
+	client := opts.Client
+	if client == nil {
+		client = &http.Client{Timeout: opts.Config.RequestTimeout}
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Dispatcher{
+		Repository: opts.Repository,
+		Client:     client,
+		Config:     opts.Config,
+		Logger:     logger,
+		now:        time.Now,
+	}
+}
+
+// Run poll the outbox every DispatchInterval until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.Config.DispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DispatchOnce(ctx); err != nil {
+			d.Logger.ErrorContext(ctx, "webhook dispatch failed", slog.String("error", err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce fan out new outbox events and send every due delivery once
+func (d *Dispatcher) DispatchOnce(ctx context.Context) error {
+	if _, err := d.Repository.FanOutOutboxEvents(ctx); err != nil {
+		return err
+	}
+
+	deliveries, err := d.Repository.ClaimWebhookDeliveries(ctx, repository.ClaimWebhookDeliveriesInput{
+		Limit: d.Config.BatchSize,
+		Lease: d.Config.RequestTimeout * 2,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		if errSend := d.send(ctx, delivery); errSend != nil {
+			attempts := delivery.Attempts + 1
+			d.Logger.WarnContext(ctx, "webhook delivery failed",
+				slog.String("delivery_id", delivery.Id.String()),
+				slog.String("estate_id", delivery.Event.EstateId.String()),
+				slog.Int("attempts", attempts),
+				slog.String("error", errSend.Error()),
+			)
+			err = d.Repository.MarkWebhookDeliveryFailed(ctx, repository.MarkWebhookDeliveryFailedInput{
+				Id:            delivery.Id,
+				LastError:     errSend.Error(),
+				NextAttemptAt: d.now().Add(d.backoff(attempts)),
+				Dead:          attempts >= d.Config.MaxAttempts,
+			})
+		} else {
+			err = d.Repository.MarkWebhookDelivered(ctx, delivery.Id)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send post the signed event to the subscriber, any non 2xx response is a failure
+func (d *Dispatcher) send(ctx context.Context, delivery repository.WebhookDelivery) error {
+	body, err := json.Marshal(delivery.Event.Envelope())
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Subscriber.Url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderSignature, Sign(delivery.Subscriber.Secret, body))
+	req.Header.Set(HeaderEventId, delivery.Event.Id.String())
+	req.Header.Set(HeaderEventType, string(delivery.Event.EventType))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// backoff return BaseBackoff doubled for every previous attempt, capped at MaxBackoff
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.Config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if d.Config.MaxBackoff > 0 && delay >= d.Config.MaxBackoff {
+			return d.Config.MaxBackoff
+		}
+	}
+	return delay
+}
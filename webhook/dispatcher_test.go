@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SawitProRecruitment/UserService/config"
+	"github.com/SawitProRecruitment/UserService/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"type":"tree.added"}`)
+	signature := Sign("secret", body)
+
+	assert.Equal(t, "sha256=", signature[:7])
+	assert.True(t, Verify("secret", body, signature))
+	assert.False(t, Verify("other-secret", body, signature))
+	assert.False(t, Verify("secret", []byte(`{"type":"tampered"}`), signature))
+}
+
+func TestBackoff(t *testing.T) {
+	d := &Dispatcher{Config: config.Webhook{BaseBackoff: time.Second, MaxBackoff: 10 * time.Second}}
+
+	assert.Equal(t, time.Second, d.backoff(1))
+	assert.Equal(t, 2*time.Second, d.backoff(2))
+	assert.Equal(t, 8*time.Second, d.backoff(4))
+	assert.Equal(t, 10*time.Second, d.backoff(5))
+	assert.Equal(t, 10*time.Second, d.backoff(50))
+}
+
+func TestDispatchOnce(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := config.Webhook{
+		BatchSize:      10,
+		MaxAttempts:    3,
+		BaseBackoff:    time.Second,
+		MaxBackoff:     time.Minute,
+		RequestTimeout: time.Second,
+	}
+
+	newDelivery := func(url string, attempts int) repository.WebhookDelivery {
+		eventID := uuid.New()
+		return repository.WebhookDelivery{
+			Id:       uuid.New(),
+			EventId:  eventID,
+			Attempts: attempts,
+			Event: &repository.OutboxEvent{
+				Id:        eventID,
+				EstateId:  uuid.New(),
+				EventType: repository.EVENT_TREE_ADDED,
+				Payload:   []byte(`{"x":1,"y":2,"height":5}`),
+				CreatedAt: now,
+			},
+			Subscriber: &repository.WebhookSubscriber{Url: url, Secret: "subscriber-secret"},
+		}
+	}
+
+	t.Run("Delivered with valid signature", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		var received repository.EventEnvelope
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			assert.True(t, Verify("subscriber-secret", body, r.Header.Get(HeaderSignature)))
+			assert.Equal(t, string(repository.EVENT_TREE_ADDED), r.Header.Get(HeaderEventType))
+			assert.NoError(t, json.Unmarshal(body, &received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer receiver.Close()
+
+		delivery := newDelivery(receiver.URL, 0)
+		mockRepo.EXPECT().FanOutOutboxEvents(gomock.Any()).Return(int64(1), nil)
+		mockRepo.EXPECT().ClaimWebhookDeliveries(gomock.Any(), repository.ClaimWebhookDeliveriesInput{Limit: 10, Lease: 2 * time.Second}).
+			Return([]repository.WebhookDelivery{delivery}, nil)
+		mockRepo.EXPECT().MarkWebhookDelivered(gomock.Any(), delivery.Id).Return(nil)
+
+		d := NewDispatcher(NewDispatcherOptions{Repository: mockRepo, Config: cfg})
+		assert.NoError(t, d.DispatchOnce(context.Background()))
+		assert.Equal(t, delivery.Event.Id, received.Id)
+		assert.JSONEq(t, `{"x":1,"y":2,"height":5}`, string(received.Data))
+	})
+
+	t.Run("Failed delivery is retried with backoff", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer receiver.Close()
+
+		delivery := newDelivery(receiver.URL, 1)
+		mockRepo.EXPECT().FanOutOutboxEvents(gomock.Any()).Return(int64(0), nil)
+		mockRepo.EXPECT().ClaimWebhookDeliveries(gomock.Any(), gomock.Any()).Return([]repository.WebhookDelivery{delivery}, nil)
+		mockRepo.EXPECT().MarkWebhookDeliveryFailed(gomock.Any(), repository.MarkWebhookDeliveryFailedInput{
+			Id:            delivery.Id,
+			LastError:     "subscriber responded with status 500",
+			NextAttemptAt: now.Add(2 * time.Second),
+			Dead:          false,
+		}).Return(nil)
+
+		d := NewDispatcher(NewDispatcherOptions{Repository: mockRepo, Config: cfg})
+		d.now = func() time.Time { return now }
+		assert.NoError(t, d.DispatchOnce(context.Background()))
+	})
+
+	t.Run("Last attempt move delivery to dead-letter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer receiver.Close()
+
+		delivery := newDelivery(receiver.URL, 2)
+		mockRepo.EXPECT().FanOutOutboxEvents(gomock.Any()).Return(int64(0), nil)
+		mockRepo.EXPECT().ClaimWebhookDeliveries(gomock.Any(), gomock.Any()).Return([]repository.WebhookDelivery{delivery}, nil)
+		mockRepo.EXPECT().MarkWebhookDeliveryFailed(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, input repository.MarkWebhookDeliveryFailedInput) error {
+				assert.True(t, input.Dead)
+				return nil
+			})
+
+		d := NewDispatcher(NewDispatcherOptions{Repository: mockRepo, Config: cfg})
+		assert.NoError(t, d.DispatchOnce(context.Background()))
+	})
+
+	t.Run("Fan out error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		mockRepo.EXPECT().FanOutOutboxEvents(gomock.Any()).Return(int64(0), errors.New("db error"))
+
+		d := NewDispatcher(NewDispatcherOptions{Repository: mockRepo, Config: cfg})
+		assert.EqualError(t, d.DispatchOnce(context.Background()), "db error")
+	})
+}